@@ -26,11 +26,13 @@ import (
 	"time"
 
 	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/sigstore/rekor/cmd/rekor-cli/app/format"
 	"github.com/sigstore/rekor/pkg/client"
+	rclient "github.com/sigstore/rekor/pkg/generated/client"
 	"github.com/sigstore/rekor/pkg/generated/client/entries"
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/log"
@@ -91,12 +93,6 @@ var getCmd = &cobra.Command{
 		if logIndex == "" && uuid == "" {
 			return nil, errors.New("either --uuid or --log-index must be specified")
 		}
-		// retrieve rekor pubkey for verification
-		verifier, err := loadVerifier(rekorClient)
-		if err != nil {
-			return nil, fmt.Errorf("retrieving rekor public key")
-		}
-
 		if logIndex != "" {
 			params := entries.NewGetLogEntryByIndexParams()
 			params.SetTimeout(viper.GetDuration("timeout"))
@@ -114,7 +110,7 @@ var getCmd = &cobra.Command{
 			for ix, entry := range resp.Payload {
 				// verify log entry
 				e = entry
-				if err := verify.VerifyLogEntry(ctx, &e, verifier); err != nil {
+				if err := verifyLogEntry(ctx, rekorClient, &e); err != nil {
 					return nil, fmt.Errorf("unable to verify entry was added to log: %w", err)
 				}
 
@@ -150,7 +146,7 @@ var getCmd = &cobra.Command{
 
 				// verify log entry
 				e = entry
-				if err := verify.VerifyLogEntry(ctx, &e, verifier); err != nil {
+				if err := verifyLogEntry(ctx, rekorClient, &e); err != nil {
 					return nil, fmt.Errorf("unable to verify entry was added to log: %w", err)
 				}
 
@@ -162,6 +158,15 @@ var getCmd = &cobra.Command{
 	}),
 }
 
+// verifyLogEntry verifies the entry with the key of the tree it was logged in
+func verifyLogEntry(ctx context.Context, rekorClient *rclient.Rekor, e *models.LogEntryAnon) error {
+	verifier, err := loadEntryVerifier(rekorClient, swag.StringValue(e.LogID))
+	if err != nil {
+		return fmt.Errorf("retrieving rekor public key: %w", err)
+	}
+	return verify.VerifyLogEntry(ctx, e, verifier)
+}
+
 func parseEntry(uuid string, e models.LogEntryAnon) (interface{}, error) {
 	b, err := base64.StdEncoding.DecodeString(e.Body.(string))
 	if err != nil {
@@ -0,0 +1,72 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/faketrillian"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/signer"
+)
+
+func TestVerifyLogEntry(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	trees := shardedLog(t, f, 2, 1)
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the inactive shard is signed by its own key
+	config := filepath.Join(t.TempDir(), "sharding-config.yaml")
+	if err := os.WriteFile(config, []byte(fmt.Sprintf("- treeID: %d\n  signer: memory\n", trees[0])), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("trillian_log_server.sharding_config", config)
+	defer viper.Set("trillian_log_server.sharding_config", "")
+	_, rekorClient := testServer(t, f, trees[1], s)
+
+	// the entries of both shards are verified with the key of their shard
+	for logIndex := int64(0); logIndex < 3; logIndex++ {
+		params := entries.NewGetLogEntryByIndexParams()
+		params.LogIndex = logIndex
+		resp, err := rekorClient.Entries.GetLogEntryByIndex(params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range resp.Payload {
+			e := e
+			if err := verifyLogEntry(ctx, rekorClient, &e); err != nil {
+				t.Errorf("verifying entry %d: %v", logIndex, err)
+			}
+
+			// an entry that claims to be signed by a key of none of the
+			// trees of the log is not verified
+			logID := "0000"
+			e.LogID = &logID
+			if err := verifyLogEntry(ctx, rekorClient, &e); err == nil {
+				t.Errorf("expected entry %d with unknown log ID to fail", logIndex)
+			}
+		}
+	}
+}
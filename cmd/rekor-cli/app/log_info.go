@@ -16,12 +16,16 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"crypto"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-openapi/swag"
@@ -35,6 +39,8 @@ import (
 	"github.com/sigstore/rekor/cmd/rekor-cli/app/format"
 	"github.com/sigstore/rekor/cmd/rekor-cli/app/state"
 	"github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/generated/client/pubkey"
 	"github.com/sigstore/rekor/pkg/generated/client/tlog"
 	"github.com/sigstore/rekor/pkg/log"
 	"github.com/sigstore/rekor/pkg/util"
@@ -47,26 +53,63 @@ type logInfoCmdOutput struct {
 	RootHash       string
 	TimestampNanos uint64
 	TreeID         string
+	// InactiveShards are the frozen trees before the active tree, in the
+	// order of their virtual log indexes
+	InactiveShards []logInfoShard `json:",omitempty"`
+}
+
+// logInfoShard describes a verified inactive shard
+type logInfoShard struct {
+	TreeID   string
+	TreeSize int64
+	RootHash string
+	// FirstIndex is the virtual log index of the first entry in the shard
+	FirstIndex int64
 }
 
 func (l *logInfoCmdOutput) String() string {
 	// Verification is always successful if we return an object.
 	ts := time.Unix(0, int64(l.TimestampNanos)).UTC().Format(time.RFC3339)
 
-	return fmt.Sprintf(`Verification Successful!
+	out := fmt.Sprintf(`Verification Successful!
 Active Tree Size:       %v
 Total Tree Size:        %v
 Root Hash:              %s
 Timestamp:              %s
 TreeID:                 %s
 `, l.ActiveTreeSize, l.TotalTreeSize, l.RootHash, ts, l.TreeID)
+	if len(l.InactiveShards) == 0 {
+		return out
+	}
+	out += "Inactive Shards:\n"
+	terms := []string{}
+	for _, shard := range l.InactiveShards {
+		out += fmt.Sprintf("  TreeID %s: size %d, root hash %s, first log index %d\n", shard.TreeID, shard.TreeSize, shard.RootHash, shard.FirstIndex)
+		terms = append(terms, fmt.Sprintf("%d (tree %s)", shard.TreeSize, shard.TreeID))
+	}
+	terms = append(terms, fmt.Sprintf("%d (tree %s, active)", l.ActiveTreeSize, l.TreeID))
+	return out + fmt.Sprintf("Total Tree Size = %s = %d\n", strings.Join(terms, " + "), l.TotalTreeSize)
 }
 
 // logInfoCmd represents the current information about the transparency log
 var logInfoCmd = &cobra.Command{
 	Use:   "loginfo",
 	Short: "Rekor loginfo command",
-	Long:  `Prints info about the transparency log`,
+	Long: `Prints info about the transparency log
+
+The tree heads of the active tree and of each inactive shard are verified with the
+public key of the server, which is fetched from the server unless it's pinned with
+rekor_server_public_key in the config file. A pinned key is used for the inactive
+shards as well, so a shard signed with its own key must have that key pinned too,
+in rekor_server_shard_public_keys:
+
+  rekor_server_public_key: |
+    -----BEGIN PUBLIC KEY-----
+    ...
+  rekor_server_shard_public_keys:
+    "<tree ID>": |
+      -----BEGIN PUBLIC KEY-----
+      ...`,
 	Run: format.WrapCmd(func(args []string) (interface{}, error) {
 		serverURL := viper.GetString("rekor_server")
 		ctx := context.Background()
@@ -82,72 +125,173 @@ var logInfoCmd = &cobra.Command{
 			return nil, err
 		}
 
-		logInfo := result.GetPayload()
-
-		// Verify inactive shards
-		if err := verifyInactiveTrees(ctx, rekorClient, serverURL, logInfo.InactiveShards); err != nil {
-			return nil, err
-		}
+		return verifyLogInfo(ctx, rekorClient, serverURL, result.GetPayload())
+	}),
+}
 
-		// Verify the active tree
-		sth := util.SignedCheckpoint{}
-		signedTreeHead := swag.StringValue(logInfo.SignedTreeHead)
-		if err := sth.UnmarshalText([]byte(signedTreeHead)); err != nil {
-			return nil, err
-		}
-		treeID := swag.StringValue(logInfo.TreeID)
+// verifyLogInfo verifies the active tree and the inactive shards of the log
+func verifyLogInfo(ctx context.Context, rekorClient *rclient.Rekor, serverURL string, logInfo *models.LogInfo) (*logInfoCmdOutput, error) {
+	// Verify inactive shards
+	shards, err := verifyInactiveTrees(ctx, rekorClient, serverURL, logInfo)
+	if err != nil {
+		return nil, err
+	}
 
-		if err := verifyTree(ctx, rekorClient, signedTreeHead, serverURL, treeID); err != nil {
-			return nil, err
-		}
+	// Verify the active tree
+	signedTreeHead := swag.StringValue(logInfo.SignedTreeHead)
+	treeID := swag.StringValue(logInfo.TreeID)
+	verifier, err := loadVerifier(rekorClient, "")
+	if err != nil {
+		return nil, err
+	}
+	sth, err := verifyTree(ctx, rekorClient, verifier, signedTreeHead, serverURL, treeID)
+	if err != nil {
+		return nil, err
+	}
 
-		cmdOutput := &logInfoCmdOutput{
-			ActiveTreeSize: swag.Int64Value(logInfo.TreeSize),
-			TotalTreeSize:  totalTreeSize(logInfo, logInfo.InactiveShards),
-			RootHash:       swag.StringValue(logInfo.RootHash),
-			TimestampNanos: sth.GetTimestamp(),
-			TreeID:         swag.StringValue(logInfo.TreeID),
-		}
-		return cmdOutput, nil
-	}),
+	return &logInfoCmdOutput{
+		ActiveTreeSize: swag.Int64Value(logInfo.TreeSize),
+		TotalTreeSize:  totalTreeSize(logInfo, logInfo.InactiveShards),
+		RootHash:       swag.StringValue(logInfo.RootHash),
+		TimestampNanos: sth.GetTimestamp(),
+		TreeID:         treeID,
+		InactiveShards: shards,
+	}, nil
 }
 
-func verifyInactiveTrees(ctx context.Context, rekorClient *rclient.Rekor, serverURL string, inactiveShards []*models.InactiveShardLogInfo) error {
-	if inactiveShards == nil {
-		return nil
+// verifyInactiveTrees verifies the checkpoint of each inactive shard with the
+// public key of the shard. As the shards are frozen, their roots must match
+// any previously stored state exactly. The server must resolve virtual log
+// indexes using the size of each shard, as otherwise entries would move
+// between shards.
+func verifyInactiveTrees(ctx context.Context, rekorClient *rclient.Rekor, serverURL string, logInfo *models.LogInfo) ([]logInfoShard, error) {
+	if logInfo.InactiveShards == nil {
+		return nil, nil
 	}
 	log.CliLogger.Infof("Validating inactive shards...")
-	for _, shard := range inactiveShards {
-		signedTreeHead := swag.StringValue(shard.SignedTreeHead)
+	total := totalTreeSize(logInfo, logInfo.InactiveShards)
+	var shards []logInfoShard
+	var offset int64
+	for _, shard := range logInfo.InactiveShards {
 		treeID := swag.StringValue(shard.TreeID)
-		if err := verifyTree(ctx, rekorClient, signedTreeHead, serverURL, treeID); err != nil {
-			return fmt.Errorf("verifying inactive shard with ID %s: %w", treeID, err)
+		sth, err := verifyInactiveTree(ctx, rekorClient, serverURL, shard)
+		if err != nil {
+			return nil, fmt.Errorf("verifying inactive shard with ID %s: %w", treeID, err)
 		}
+		if err := verifyShardBoundary(ctx, rekorClient, offset, total, sth); err != nil {
+			return nil, fmt.Errorf("verifying log indexes of inactive shard with ID %s: %w", treeID, err)
+		}
+		shards = append(shards, logInfoShard{
+			TreeID:     treeID,
+			TreeSize:   int64(sth.Size),
+			RootHash:   hex.EncodeToString(sth.Hash),
+			FirstIndex: offset,
+		})
+		offset += int64(sth.Size)
 	}
 	log.CliLogger.Infof("Successfully validated inactive shards")
+	return shards, nil
+}
+
+func verifyInactiveTree(ctx context.Context, rekorClient *rclient.Rekor, serverURL string, shard *models.InactiveShardLogInfo) (*util.SignedCheckpoint, error) {
+	treeID := swag.StringValue(shard.TreeID)
+	verifier, err := loadVerifier(rekorClient, treeID)
+	if err != nil {
+		return nil, err
+	}
+	sth, err := verifyTree(ctx, rekorClient, verifier, swag.StringValue(shard.SignedTreeHead), serverURL, treeID)
+	if err != nil {
+		return nil, err
+	}
+	if int64(sth.Size) != swag.Int64Value(shard.TreeSize) || hex.EncodeToString(sth.Hash) != swag.StringValue(shard.RootHash) {
+		return nil, errors.New("tree size or root hash doesn't match the signed tree head")
+	}
+	frozen := frozenStateKey(treeID)
+	if prev := state.Load(frozen); prev != nil {
+		if prev.Size != sth.Size || !bytes.Equal(prev.Hash, sth.Hash) {
+			return nil, fmt.Errorf("%w: frozen tree changed from size %d to %d", verify.ErrInconsistentCheckpoints, prev.Size, sth.Size)
+		}
+	}
+	if viper.GetBool("store_tree_state") {
+		if err := state.Dump(frozen, sth); err != nil {
+			log.CliLogger.Infof("Unable to store previous state: %v", err)
+		}
+	}
+	return sth, nil
+}
+
+// frozenStateKey is the key of the stored state of an inactive shard, which
+// is kept apart from the state stored while the tree was active
+func frozenStateKey(treeID string) string {
+	return treeID + "/frozen"
+}
+
+// verifyShardBoundary checks that the virtual log index of the last entry in
+// the shard resolves to that entry, and that the next virtual log index
+// resolves to the first entry of a later tree
+func verifyShardBoundary(ctx context.Context, rekorClient *rclient.Rekor, offset, total int64, sth *util.SignedCheckpoint) error {
+	size := int64(sth.Size)
+	if size > 0 {
+		e, err := entryAtIndex(ctx, rekorClient, offset+size-1)
+		if err != nil {
+			return err
+		}
+		p := e.Verification.InclusionProof
+		if swag.Int64Value(p.LogIndex) != size-1 || swag.Int64Value(p.TreeSize) != size || swag.StringValue(p.RootHash) != hex.EncodeToString(sth.Hash) {
+			return fmt.Errorf("log index %d resolves to index %d of a tree of size %d rather than the last entry of the shard", offset+size-1, swag.Int64Value(p.LogIndex), swag.Int64Value(p.TreeSize))
+		}
+		if err := verify.VerifyInclusion(ctx, e); err != nil {
+			return err
+		}
+	}
+	// the following trees are empty
+	if offset+size == total {
+		return nil
+	}
+	e, err := entryAtIndex(ctx, rekorClient, offset+size)
+	if err != nil {
+		return err
+	}
+	if p := e.Verification.InclusionProof; swag.Int64Value(p.LogIndex) != 0 {
+		return fmt.Errorf("log index %d resolves to index %d rather than the first entry of the next tree", offset+size, swag.Int64Value(p.LogIndex))
+	}
 	return nil
 }
 
-func verifyTree(ctx context.Context, rekorClient *rclient.Rekor, signedTreeHead, serverURL, treeID string) error {
+func entryAtIndex(ctx context.Context, rekorClient *rclient.Rekor, index int64) (*models.LogEntryAnon, error) {
+	params := entries.NewGetLogEntryByIndexParamsWithContext(ctx)
+	params.SetTimeout(viper.GetDuration("timeout"))
+	params.SetLogIndex(index)
+	resp, err := rekorClient.Entries.GetLogEntryByIndex(params)
+	if err != nil {
+		return nil, fmt.Errorf("getting entry at log index %d: %w", index, err)
+	}
+	for _, e := range resp.Payload {
+		e := e
+		if e.Verification == nil || e.Verification.InclusionProof == nil {
+			return nil, fmt.Errorf("entry at log index %d has no inclusion proof", index)
+		}
+		return &e, nil
+	}
+	return nil, fmt.Errorf("no entry at log index %d", index)
+}
+
+func verifyTree(ctx context.Context, rekorClient *rclient.Rekor, verifier signature.Verifier, signedTreeHead, serverURL, treeID string) (*util.SignedCheckpoint, error) {
 	oldState := state.Load(serverURL)
 	if treeID != "" {
 		oldState = state.Load(treeID)
 	}
 	sth := util.SignedCheckpoint{}
 	if err := sth.UnmarshalText([]byte(signedTreeHead)); err != nil {
-		return err
-	}
-	verifier, err := loadVerifier(rekorClient)
-	if err != nil {
-		return err
+		return nil, err
 	}
 	if !sth.Verify(verifier) {
-		return errors.New("signature on tree head did not verify")
+		return nil, errors.New("signature on tree head did not verify")
 	}
 
 	if oldState != nil {
 		if err := verify.ProveConsistency(ctx, rekorClient, oldState, &sth, treeID); err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		log.CliLogger.Infof("No previous log state stored, unable to prove consistency")
@@ -163,14 +307,28 @@ func verifyTree(ctx context.Context, rekorClient *rclient.Rekor, signedTreeHead,
 			log.CliLogger.Infof("Unable to store previous state: %v", err)
 		}
 	}
-	return nil
+	return &sth, nil
 }
 
-func loadVerifier(rekorClient *rclient.Rekor) (signature.Verifier, error) {
+// loadVerifier returns a verifier for the public key of the tree, or of the
+// active tree if treeID is empty. A key pinned by rekor_server_public_key is
+// used for every tree, unless the tree is an inactive shard with its own key
+// pinned in rekor_server_shard_public_keys, which maps tree IDs to keys. The
+// key is only fetched from the server if none is pinned.
+func loadVerifier(rekorClient *rclient.Rekor, treeID string) (signature.Verifier, error) {
 	publicKey := viper.GetString("rekor_server_public_key")
+	if treeID != "" {
+		if shardKey, ok := viper.GetStringMapString("rekor_server_shard_public_keys")[treeID]; ok {
+			publicKey = shardKey
+		}
+	}
 	if publicKey == "" {
 		// fetch key from server
-		keyResp, err := rekorClient.Pubkey.GetPublicKey(nil)
+		params := pubkey.NewGetPublicKeyParams()
+		if treeID != "" {
+			params.SetTreeID(&treeID)
+		}
+		keyResp, err := rekorClient.Pubkey.GetPublicKey(params)
 		if err != nil {
 			return nil, err
 		}
@@ -190,6 +348,49 @@ func loadVerifier(rekorClient *rclient.Rekor) (signature.Verifier, error) {
 	return signature.LoadVerifier(pub, crypto.SHA256)
 }
 
+// loadEntryVerifier returns a verifier for the public key of the tree with
+// the log ID of an entry, as the entries of each shard are signed with the
+// key of the shard. The keys are loaded as by loadVerifier.
+func loadEntryVerifier(rekorClient *rclient.Rekor, logID string) (signature.Verifier, error) {
+	verifier, err := loadVerifier(rekorClient, "")
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := hasLogID(verifier, logID); err != nil || ok {
+		return verifier, err
+	}
+
+	result, err := rekorClient.Tlog.GetLogInfo(tlog.NewGetLogInfoParams())
+	if err != nil {
+		return nil, err
+	}
+	for _, shard := range result.Payload.InactiveShards {
+		verifier, err := loadVerifier(rekorClient, swag.StringValue(shard.TreeID))
+		if err != nil {
+			return nil, err
+		}
+		if ok, err := hasLogID(verifier, logID); err != nil || ok {
+			return verifier, err
+		}
+	}
+	return nil, fmt.Errorf("no tree of the log is signed with the key of log ID %s", logID)
+}
+
+// hasLogID returns whether the log ID is the SHA256 hash of the DER-encoded
+// public key of the verifier
+func hasLogID(verifier signature.Verifier, logID string) (bool, error) {
+	pub, err := verifier.PublicKey()
+	if err != nil {
+		return false, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return false, err
+	}
+	hash := sha256.Sum256(der)
+	return hex.EncodeToString(hash[:]) == logID, nil
+}
+
 func totalTreeSize(activeShard *models.LogInfo, inactiveShards []*models.InactiveShardLogInfo) int64 {
 	total := swag.Int64Value(activeShard.TreeSize)
 	for _, i := range inactiveShards {
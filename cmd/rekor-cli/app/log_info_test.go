@@ -0,0 +1,217 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/loads"
+	"github.com/google/trillian"
+	"github.com/spf13/viper"
+	"google.golang.org/genproto/protobuf/field_mask"
+
+	"github.com/sigstore/rekor/cmd/rekor-cli/app/state"
+	"github.com/sigstore/rekor/pkg/api"
+	"github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/rekor/pkg/faketrillian"
+	rclient "github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/pubkey"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/generated/restapi"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations"
+	"github.com/sigstore/rekor/pkg/signer"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/rekor/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// shardedLog returns the trees of a fake Trillian with the given number of
+// leaves, all but the last of which are frozen
+func shardedLog(t *testing.T, f *faketrillian.Trillian, sizes ...int) []int64 {
+	t.Helper()
+	ctx := context.Background()
+	var trees []int64
+	for i, size := range sizes {
+		treeID, err := f.CreateLog(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < size; j++ {
+			if _, err := f.QueueLeaf(ctx, &trillian.QueueLeafRequest{
+				LogId: treeID,
+				Leaf:  &trillian.LogLeaf{LeafValue: []byte(fmt.Sprintf("leaf %d of tree %d", j, treeID))},
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if i < len(sizes)-1 {
+			if _, err := f.UpdateTree(ctx, &trillian.UpdateTreeRequest{
+				Tree:       &trillian.Tree{TreeId: treeID, TreeState: trillian.TreeState_FROZEN},
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"tree_state"}},
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		trees = append(trees, treeID)
+	}
+	return trees
+}
+
+// testServer serves the API for the given active tree of the fake Trillian
+func testServer(t *testing.T, f *faketrillian.Trillian, treeID int64, s signature.Signer) (string, *rclient.Rekor) {
+	t.Helper()
+	viper.Set("rekor_server.hostname", "rekor.test")
+	a := api.ConfigureAPI(uint(treeID), api.WithTrillianClients(f, f), api.WithSigner(s))
+	t.Cleanup(a.Close)
+
+	doc, err := loads.Embedded(restapi.SwaggerJSON, restapi.FlatSwaggerJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := restapi.NewServer(operations.NewRekorServerAPI(doc))
+	server.ConfigureRekorAPI(a)
+	ts := httptest.NewServer(server.GetHandler())
+	t.Cleanup(ts.Close)
+	rekorClient, err := client.GetRekorClient(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts.URL, rekorClient
+}
+
+func TestVerifyLogInfo(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("HOME", t.TempDir())
+	f := faketrillian.New(0)
+	trees := shardedLog(t, f, 3, 2, 1)
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the first shard is signed by its own key
+	config := filepath.Join(t.TempDir(), "sharding-config.yaml")
+	if err := os.WriteFile(config, []byte(fmt.Sprintf("- treeID: %d\n  signer: memory\n- treeID: %d\n", trees[0], trees[1])), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("trillian_log_server.sharding_config", config)
+	defer viper.Set("trillian_log_server.sharding_config", "")
+	viper.Set("store_tree_state", true)
+	defer viper.Set("store_tree_state", false)
+	serverURL, rekorClient := testServer(t, f, trees[2], s)
+
+	verifyInfo := func() (*logInfoCmdOutput, error) {
+		result, err := rekorClient.Tlog.GetLogInfo(tlog.NewGetLogInfoParams())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return verifyLogInfo(ctx, rekorClient, serverURL, result.GetPayload())
+	}
+	out, err := verifyInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.TotalTreeSize != 6 || out.ActiveTreeSize != 1 || len(out.InactiveShards) != 2 ||
+		out.InactiveShards[0].FirstIndex != 0 || out.InactiveShards[1].FirstIndex != 3 {
+		t.Fatalf("unexpected log info %+v", out)
+	}
+	derivation := fmt.Sprintf("Total Tree Size = 3 (tree %d) + 2 (tree %d) + 1 (tree %d, active) = 6", trees[0], trees[1], trees[2])
+	if !strings.Contains(out.String(), derivation) {
+		t.Errorf("expected %q in output:\n%s", derivation, out.String())
+	}
+	// the stored state of the frozen shards is verified again
+	if _, err := verifyInfo(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a pinned key is used for every shard that doesn't have its own key pinned
+	publicKey := func(treeID string) string {
+		params := pubkey.NewGetPublicKeyParams()
+		if treeID != "" {
+			params.SetTreeID(&treeID)
+		}
+		resp, err := rekorClient.Pubkey.GetPublicKey(params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Payload
+	}
+	viper.Set("rekor_server_public_key", publicKey(""))
+	defer viper.Set("rekor_server_public_key", "")
+	if _, err := verifyInfo(); err == nil {
+		t.Error("expected the shard with its own key to fail verification with the pinned key")
+	}
+	viper.Set("rekor_server_shard_public_keys", map[string]string{fmt.Sprint(trees[0]): publicKey(fmt.Sprint(trees[0]))})
+	defer viper.Set("rekor_server_shard_public_keys", nil)
+	if _, err := verifyInfo(); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("rekor_server_public_key", "")
+	viper.Set("rekor_server_shard_public_keys", nil)
+
+	// a frozen shard must keep the root that was stored for it
+	other := state.Load(frozenStateKey(fmt.Sprint(trees[1])))
+	if other == nil {
+		t.Fatal("expected the state of the frozen shard to be stored")
+	}
+	if err := state.Dump(frozenStateKey(fmt.Sprint(trees[0])), other); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifyInfo(); !errors.Is(err, verify.ErrInconsistentCheckpoints) {
+		t.Errorf("expected changed frozen shard to be inconsistent, got %v", err)
+	}
+}
+
+func TestVerifyShardBoundary(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	trees := shardedLog(t, f, 3, 2)
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := filepath.Join(t.TempDir(), "sharding-config.yaml")
+	if err := os.WriteFile(config, []byte(fmt.Sprintf("- treeID: %d\n", trees[0])), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("trillian_log_server.sharding_config", config)
+	defer viper.Set("trillian_log_server.sharding_config", "")
+	_, rekorClient := testServer(t, f, trees[1], s)
+	result, err := rekorClient.Tlog.GetLogInfo(tlog.NewGetLogInfoParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sth util.SignedCheckpoint
+	if err := sth.UnmarshalText([]byte(*result.Payload.InactiveShards[0].SignedTreeHead)); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyShardBoundary(ctx, rekorClient, 0, 5, &sth); err != nil {
+		t.Fatal(err)
+	}
+	// a shard that the server thinks is longer or shorter doesn't line up
+	// with the indexes of its entries
+	for _, offset := range []int64{-1, 1} {
+		if err := verifyShardBoundary(ctx, rekorClient, offset, 5, &sth); err == nil {
+			t.Errorf("expected shard at offset %d to fail", offset)
+		}
+	}
+}
@@ -41,6 +41,7 @@ const (
 	operatorFlag       FlagType = "operator"
 	logIndexFlag       FlagType = "logIndex"
 	pkiFormatFlag      FlagType = "pkiFormat"
+	packageTypeFlag    FlagType = "packageType"
 	typeFlag           FlagType = "type"
 	fileFlag           FlagType = "file"
 	urlFlag            FlagType = "url"
@@ -85,6 +86,10 @@ func initializePFlagMap() {
 			// this ensures a PKI implementation exists for the requested format
 			return valueFactory(pkiFormatFlag, validateString(fmt.Sprintf("required,oneof=%v", strings.Join(pki.SupportedFormats(), " "))), "pgp")
 		},
+		packageTypeFlag: func() pflag.Value {
+			// this ensures packages of the requested type can be searched for
+			return valueFactory(packageTypeFlag, validateString(fmt.Sprintf("required,oneof=%v", strings.Join(packageTypes, " "))), "")
+		},
 		typeFlag: func() pflag.Value {
 			// this ensures the type of the log entry matches a type supported in the CLI
 			return valueFactory(typeFlag, validateTypeFlag, "rekord")
@@ -214,15 +219,20 @@ func isURL(v string) bool {
 }
 
 // validateSHAValue ensures that the supplied string matches the following formats:
+// [sha512:]<128 hexadecimal characters>
 // [sha256:]<64 hexadecimal characters>
 // [sha1:]<40 hexadecimal characters>
-// where [sha256:] and [sha1:] are optional
+// where [sha512:], [sha256:] and [sha1:] are optional
 func validateSHAValue(v string) error {
 	err := util.ValidateSHA1Value(v)
 	if err == nil {
 		return nil
 	}
 
+	if err := util.ValidateSHA512Value(v); err == nil {
+		return nil
+	}
+
 	if err := util.ValidateSHA256Value(v); err != nil {
 		return fmt.Errorf("error parsing %v flag: %w", shaFlag, err)
 	}
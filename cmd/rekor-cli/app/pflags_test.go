@@ -513,6 +513,8 @@ func TestSearchPFlags(t *testing.T) {
 		sha                   string
 		email                 string
 		pkiFormat             string
+		pkg                   string
+		packageType           string
 		expectParseSuccess    bool
 		expectValidateSuccess bool
 	}
@@ -601,6 +603,12 @@ func TestSearchPFlags(t *testing.T) {
 			expectParseSuccess:    true,
 			expectValidateSuccess: true,
 		},
+		{
+			caseDesc:              "valid SHA512",
+			sha:                   "sha512:f7fbba6e0636f890e56fbbf3283e524c6fa3204ae298382d624741d0dc6638326e282c41be5e4254d8820772c5518a2c5a8c0c7f7eda19594a7eb539453e1ed7",
+			expectParseSuccess:    true,
+			expectValidateSuccess: true,
+		},
 		{
 			caseDesc:              "invalid SHA",
 			sha:                   "45c7b11fcbf",
@@ -620,7 +628,27 @@ func TestSearchPFlags(t *testing.T) {
 			expectValidateSuccess: false,
 		},
 		{
-			caseDesc:              "no flags when either artifact, sha, public key, or email are needed",
+			caseDesc:              "valid package",
+			pkg:                   "hello-rekor@1.0.0",
+			packageType:           "npm",
+			expectParseSuccess:    true,
+			expectValidateSuccess: true,
+		},
+		{
+			caseDesc:              "package without type",
+			pkg:                   "hello-rekor@1.0.0",
+			expectParseSuccess:    true,
+			expectValidateSuccess: false,
+		},
+		{
+			caseDesc:              "invalid package type",
+			pkg:                   "hello-rekor@1.0.0",
+			packageType:           "rekord",
+			expectParseSuccess:    false,
+			expectValidateSuccess: false,
+		},
+		{
+			caseDesc:              "no flags when either artifact, sha, public key, email, or package are needed",
 			expectParseSuccess:    true,
 			expectValidateSuccess: false,
 		},
@@ -650,6 +678,12 @@ func TestSearchPFlags(t *testing.T) {
 		if tc.email != "" {
 			args = append(args, "--email", tc.email)
 		}
+		if tc.pkg != "" {
+			args = append(args, "--package", tc.pkg)
+		}
+		if tc.packageType != "" {
+			args = append(args, "--package-type", tc.packageType)
+		}
 
 		if err := blankCmd.ParseFlags(args); (err == nil) != tc.expectParseSuccess {
 			t.Errorf("unexpected result parsing '%v': %v", tc.caseDesc, err)
@@ -739,6 +773,126 @@ func TestParseTypeFlag(t *testing.T) {
 			typeStr:       "cose:0.0.0",
 			expectSuccess: false,
 		},
+		{
+			caseDesc:      "deb",
+			typeStr:       "deb",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "explicit deb v0.0.1",
+			typeStr:       "deb:0.0.1",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "non-existent deb v0.0.0",
+			typeStr:       "deb:0.0.0",
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "dsse",
+			typeStr:       "dsse",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "explicit dsse v0.0.1",
+			typeStr:       "dsse:0.0.1",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "non-existent dsse v0.0.0",
+			typeStr:       "dsse:0.0.0",
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "git",
+			typeStr:       "git",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "explicit git v0.0.1",
+			typeStr:       "git:0.0.1",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "non-existent git v0.0.0",
+			typeStr:       "git:0.0.0",
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "npm",
+			typeStr:       "npm",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "explicit npm v0.0.1",
+			typeStr:       "npm:0.0.1",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "non-existent npm v0.0.0",
+			typeStr:       "npm:0.0.0",
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "oci",
+			typeStr:       "oci",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "explicit oci v0.0.1",
+			typeStr:       "oci:0.0.1",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "non-existent oci v0.0.0",
+			typeStr:       "oci:0.0.0",
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "pe",
+			typeStr:       "pe",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "explicit pe v0.0.1",
+			typeStr:       "pe:0.0.1",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "non-existent pe v0.0.0",
+			typeStr:       "pe:0.0.0",
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "apk-android",
+			typeStr:       "apk-android",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "explicit apk-android v0.0.1",
+			typeStr:       "apk-android:0.0.1",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "non-existent apk-android v0.0.0",
+			typeStr:       "apk-android:0.0.0",
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "gomod",
+			typeStr:       "gomod",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "explicit gomod v0.0.1",
+			typeStr:       "gomod:0.0.1",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "non-existent gomod v0.0.0",
+			typeStr:       "gomod:0.0.0",
+			expectSuccess: false,
+		},
 		{
 			caseDesc:      "helm",
 			typeStr:       "helm",
@@ -789,6 +943,21 @@ func TestParseTypeFlag(t *testing.T) {
 			typeStr:       "jar:0.0.0",
 			expectSuccess: false,
 		},
+		{
+			caseDesc:      "pypi",
+			typeStr:       "pypi",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "explicit pypi v0.0.1",
+			typeStr:       "pypi:0.0.1",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "non-existent pypi v0.0.0",
+			typeStr:       "pypi:0.0.0",
+			expectSuccess: false,
+		},
 		{
 			caseDesc:      "rfc3161",
 			typeStr:       "rfc3161",
@@ -819,6 +988,21 @@ func TestParseTypeFlag(t *testing.T) {
 			typeStr:       "rpm:0.0.0",
 			expectSuccess: false,
 		},
+		{
+			caseDesc:      "sbom",
+			typeStr:       "sbom",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "explicit sbom v0.0.1",
+			typeStr:       "sbom:0.0.1",
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "non-existent sbom v0.0.0",
+			typeStr:       "sbom:0.0.0",
+			expectSuccess: false,
+		},
 		{
 			caseDesc:      "tuf",
 			typeStr:       "tuf",
@@ -48,6 +48,15 @@ func (s *searchCmdOutput) String() string {
 	return strings.Join(s.UUIDs, "\n") + "\n" // one extra /n to terminate the list
 }
 
+// packageTypes are the kinds of entries that can be searched for by package
+var packageTypes = []string{
+	models.SearchIndexPackageTypeNpm,
+	models.SearchIndexPackageTypeGomod,
+	models.SearchIndexPackageTypeOCI,
+	models.SearchIndexPackageTypeDeb,
+	models.SearchIndexPackageTypePypi,
+}
+
 func addSearchPFlags(cmd *cobra.Command) error {
 	cmd.Flags().Var(NewFlagValue(pkiFormatFlag, ""), "pki-format", "format of the signature and/or public key")
 
@@ -55,10 +64,14 @@ func addSearchPFlags(cmd *cobra.Command) error {
 
 	cmd.Flags().Var(NewFlagValue(fileOrURLFlag, ""), "artifact", "path or URL to artifact file")
 
-	cmd.Flags().Var(NewFlagValue(shaFlag, ""), "sha", "the SHA256 or SHA1 sum of the artifact")
+	cmd.Flags().Var(NewFlagValue(shaFlag, ""), "sha", "the SHA512, SHA256 or SHA1 sum of the artifact")
 
 	cmd.Flags().Var(NewFlagValue(emailFlag, ""), "email", "email associated with the public key's subject")
 
+	cmd.Flags().String("package", "", "name of the package, optionally with its version in the notation of the package type")
+
+	cmd.Flags().Var(NewFlagValue(packageTypeFlag, ""), "package-type", fmt.Sprintf("type of the package. supported values are %v", strings.Join(packageTypes, ", ")))
+
 	cmd.Flags().Var(NewFlagValue(operatorFlag, ""), "operator", "operator to use for the search. supported values are 'and' and 'or'")
 	return nil
 }
@@ -69,15 +82,21 @@ func validateSearchPFlags() error {
 	publicKey := viper.GetString("public-key")
 	sha := viper.GetString("sha")
 	email := viper.GetString("email")
+	pkg := viper.GetString("package")
 
-	if artifactStr == "" && publicKey == "" && sha == "" && email == "" {
-		return errors.New("either 'sha' or 'artifact' or 'public-key' or 'email' must be specified")
+	if artifactStr == "" && publicKey == "" && sha == "" && email == "" && pkg == "" {
+		return errors.New("either 'sha' or 'artifact' or 'public-key' or 'email' or 'package' must be specified")
 	}
 	if publicKey != "" {
 		if viper.GetString("pki-format") == "" {
 			return errors.New("pki-format must be specified if searching by public-key")
 		}
 	}
+	if pkg != "" {
+		if viper.GetString("package-type") == "" {
+			return errors.New("package-type must be specified if searching by package")
+		}
+	}
 	return nil
 }
 
@@ -85,7 +104,7 @@ func validateSearchPFlags() error {
 var searchCmd = &cobra.Command{
 	Use:   "search",
 	Short: "Rekor search command",
-	Long:  `Searches the Rekor index to find entries by sha, artifact,  public key, or e-mail`,
+	Long:  `Searches the Rekor index to find entries by sha, artifact,  public key, e-mail, or package`,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		// these are bound here so that they are not overwritten by other commands
 		if err := viper.BindPFlags(cmd.Flags()); err != nil {
@@ -185,6 +204,14 @@ var searchCmd = &cobra.Command{
 		if emailStr != "" {
 			params.Query.Email = strfmt.Email(emailStr)
 		}
+
+		pkg := viper.GetString("package")
+		if pkg != "" {
+			params.Query.Package = &models.SearchIndexPackage{
+				Type: swag.String(viper.GetString("package-type")),
+				Name: swag.String(pkg),
+			}
+		}
 		resp, err := rekorClient.Index.SearchIndex(params)
 		if err != nil {
 			switch t := err.(type) {
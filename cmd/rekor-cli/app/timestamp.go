@@ -0,0 +1,226 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/cmd/rekor-cli/app/format"
+	"github.com/sigstore/rekor/pkg/client"
+	rclient "github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/timestamp"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/rekor/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+type timestampCmdOutput struct {
+	MessageImprint      string
+	Time                time.Time
+	Radius              time.Duration
+	SignedTimestampNote string
+}
+
+func (t *timestampCmdOutput) String() string {
+	// Verification is always successful if we return an object.
+	return fmt.Sprintf(`Verification Successful!
+Message Imprint:        %s
+Time:                   %s
+Radius:                 %s
+
+%s`, t.MessageImprint, t.Time.Format(time.RFC3339Nano), t.Radius, t.SignedTimestampNote)
+}
+
+func validateTimestampPFlags() error {
+	artifactStr := viper.GetString("artifact")
+	sha := viper.GetString("sha")
+
+	if (artifactStr == "") == (sha == "") {
+		return errors.New("exactly one of 'artifact' or 'sha' must be specified")
+	}
+	if sha != "" {
+		if err := util.ValidateSHA256Value(util.PrefixSHA(sha)); err != nil {
+			return fmt.Errorf("'sha' must be a SHA256 sum: %w", err)
+		}
+	}
+	return nil
+}
+
+// timestampCmd represents the timestamp command
+var timestampCmd = &cobra.Command{
+	Use:   "timestamp",
+	Short: "Rekor timestamp command",
+	Long: `Requests a signed timestamp note for an artifact or its SHA256 sum, and verifies it.
+With --timestamp_note, a stored note is verified instead of requesting a new one.
+
+The note must be signed by the log and have the origin of the log, which is the
+hostname in the origin of the log's checkpoints unless --origin is set.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// these are bound here so that they are not overwritten by other commands
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			log.CliLogger.Fatal("Error initializing cmd line args: ", err)
+		}
+		if err := validateTimestampPFlags(); err != nil {
+			log.CliLogger.Error(err)
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+	},
+	Run: format.WrapCmd(func(args []string) (interface{}, error) {
+		rekorClient, err := client.GetRekorClient(viper.GetString("rekor_server"), client.WithUserAgent(UserAgent()))
+		if err != nil {
+			return nil, err
+		}
+
+		messageImprint := util.PrefixSHA(viper.GetString("sha"))
+		if artifactStr := viper.GetString("artifact"); artifactStr != "" {
+			messageImprint, err = artifactImprint(artifactStr)
+			if err != nil {
+				return nil, err
+			}
+		}
+		verifier, err := loadVerifier(rekorClient, "")
+		if err != nil {
+			return nil, err
+		}
+		origin := viper.GetString("origin")
+		if origin == "" {
+			origin, err = logOrigin(rekorClient, verifier)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var signedNote string
+		var nonce []byte
+		if notePath := viper.GetString("timestamp_note"); notePath != "" {
+			b, err := os.ReadFile(filepath.Clean(notePath))
+			if err != nil {
+				return nil, fmt.Errorf("error reading timestamp note '%v': %w", notePath, err)
+			}
+			signedNote = string(b)
+		} else {
+			nonce = make([]byte, 16)
+			if _, err := rand.Read(nonce); err != nil {
+				return nil, err
+			}
+			b64Nonce := strfmt.Base64(nonce)
+
+			params := timestamp.NewGetTimestampNoteParams()
+			params.SetTimeout(viper.GetDuration("timeout"))
+			params.Request = &models.TimestampNoteRequest{
+				MessageImprint: swag.String(messageImprint),
+				Nonce:          &b64Nonce,
+			}
+			resp, err := rekorClient.Timestamp.GetTimestampNote(params)
+			if err != nil {
+				return nil, err
+			}
+			signedNote = swag.StringValue(resp.Payload.SignedTimestampNote)
+		}
+
+		stn := &util.SignedTimestampNote{}
+		if err := stn.UnmarshalText([]byte(signedNote)); err != nil {
+			return nil, err
+		}
+		if err := verify.VerifyTimestampNote(stn, verifier, origin, messageImprint, nonce); err != nil {
+			return nil, err
+		}
+
+		return &timestampCmdOutput{
+			MessageImprint:      stn.MessageImprint,
+			Time:                stn.Time,
+			Radius:              time.Duration(stn.Radius) * time.Microsecond,
+			SignedTimestampNote: signedNote,
+		}, nil
+	}),
+}
+
+// logOrigin returns the origin of the notes signed by the log, which is the
+// hostname in the origin of its current checkpoint
+func logOrigin(rekorClient *rclient.Rekor, verifier signature.Verifier) (string, error) {
+	params := tlog.NewGetLogInfoParams()
+	params.SetTimeout(viper.GetDuration("timeout"))
+	resp, err := rekorClient.Tlog.GetLogInfo(params)
+	if err != nil {
+		return "", err
+	}
+	sth := util.SignedCheckpoint{}
+	if err := sth.UnmarshalText([]byte(swag.StringValue(resp.Payload.SignedTreeHead))); err != nil {
+		return "", err
+	}
+	if !sth.Verify(verifier) {
+		return "", errors.New("signature on tree head did not verify")
+	}
+	origin := strings.TrimSuffix(sth.Origin, " - "+swag.StringValue(resp.Payload.TreeID))
+	if origin == sth.Origin {
+		return "", fmt.Errorf("unexpected origin %q of the checkpoint of tree %s", sth.Origin, swag.StringValue(resp.Payload.TreeID))
+	}
+	return origin, nil
+}
+
+// artifactImprint returns the prefixed SHA256 sum of the artifact at the path or URL
+func artifactImprint(artifactStr string) (string, error) {
+	var r io.ReadCloser
+	if isURL(artifactStr) {
+		rc, err := util.FileOrURLReadCloser(context.Background(), artifactStr, nil)
+		if err != nil {
+			return "", fmt.Errorf("error fetching '%v': %w", artifactStr, err)
+		}
+		r = rc
+	} else {
+		f, err := os.Open(filepath.Clean(artifactStr))
+		if err != nil {
+			return "", fmt.Errorf("error opening file '%v': %w", artifactStr, err)
+		}
+		r = f
+	}
+	defer r.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, r); err != nil {
+		return "", fmt.Errorf("error processing '%v': %w", artifactStr, err)
+	}
+	return "sha256:" + hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func init() {
+	initializePFlagMap()
+	timestampCmd.Flags().Var(NewFlagValue(fileOrURLFlag, ""), "artifact", "path or URL to artifact file")
+	timestampCmd.Flags().Var(NewFlagValue(shaFlag, ""), "sha", "the SHA256 sum of the artifact")
+	timestampCmd.Flags().Var(NewFlagValue(fileFlag, ""), "timestamp_note", "path to a stored signed timestamp note to verify, instead of requesting one")
+	timestampCmd.Flags().String("origin", "", "origin of the log the timestamp note must be from, which is read from the checkpoint of the log if unset")
+
+	rootCmd.AddCommand(timestampCmd)
+}
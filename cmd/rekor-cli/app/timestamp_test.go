@@ -0,0 +1,49 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/sigstore/rekor/pkg/faketrillian"
+	"github.com/sigstore/rekor/pkg/signer"
+)
+
+func TestLogOrigin(t *testing.T) {
+	f := faketrillian.New(0)
+	trees := shardedLog(t, f, 1)
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, rekorClient := testServer(t, f, trees[0], s)
+
+	origin, err := logOrigin(rekorClient, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if origin != "rekor.test" {
+		t.Errorf("expected origin rekor.test, got %s", origin)
+	}
+
+	other, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := logOrigin(rekorClient, other); err == nil {
+		t.Error("expected error reading the origin from a checkpoint signed with another key")
+	}
+}
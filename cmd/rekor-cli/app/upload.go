@@ -134,9 +134,9 @@ var uploadCmd = &cobra.Command{
 		}
 
 		// verify log entry
-		verifier, err := loadVerifier(rekorClient)
+		verifier, err := loadEntryVerifier(rekorClient, swag.StringValue(logEntry.LogID))
 		if err != nil {
-			return nil, fmt.Errorf("retrieving rekor public key")
+			return nil, fmt.Errorf("retrieving rekor public key: %w", err)
 		}
 		if err := verify.VerifySignedEntryTimestamp(ctx, &logEntry, verifier); err != nil {
 			return nil, fmt.Errorf("unable to verify entry was added to log: %w", err)
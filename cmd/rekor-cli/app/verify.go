@@ -33,7 +33,6 @@ import (
 	"github.com/sigstore/rekor/pkg/log"
 	"github.com/sigstore/rekor/pkg/sharding"
 	"github.com/sigstore/rekor/pkg/types"
-	"github.com/sigstore/rekor/pkg/verify"
 )
 
 type verifyCmdOutput struct {
@@ -160,14 +159,8 @@ var verifyCmd = &cobra.Command{
 			}
 		}
 
-		// Get Rekor Pub
 		// TODO(asraa): Replace with sigstore's GetRekorPubs to use TUF.
-		verifier, err := loadVerifier(rekorClient)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := verify.VerifyLogEntry(ctx, &entry, verifier); err != nil {
+		if err := verifyLogEntry(ctx, rekorClient, &entry); err != nil {
 			return nil, fmt.Errorf("validating entry: %w", err)
 		}
 
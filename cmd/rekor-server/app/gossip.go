@@ -0,0 +1,185 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/rekor/pkg/verify"
+)
+
+const (
+	// gossipPath is where a watcher serves its last verified checkpoints to peers
+	gossipPath = "/checkpoints"
+	// maxGossipResponseSize bounds the size of the checkpoints read from a peer
+	maxGossipResponseSize = 1024 * 1024
+)
+
+var metricWatchGossip = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "rekor_watch_gossip",
+	Help: "The total number of checkpoint exchanges with peers, by peer and result",
+}, []string{"peer", "result"})
+
+// serveCheckpoints serves the last verified checkpoints of each tree, in the
+// same format as the persisted watch state
+func (w *watcher) serveCheckpoints(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.mu.RLock()
+	b := w.published
+	w.mu.RUnlock()
+	if b == nil {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	_, _ = rw.Write(b)
+}
+
+// gossip fetches the checkpoints seen by each peer, and proves every one that
+// is signed by the log to be consistent with the last verified checkpoint of
+// the same origin. The returned error wraps verify.ErrInconsistentCheckpoints
+// if any pair was found to be inconsistent.
+func (w *watcher) gossip(ctx context.Context, peers []string) error {
+	ours := map[string]string{}
+	for treeID, sthText := range w.state.Checkpoints {
+		sth := util.SignedCheckpoint{}
+		if err := sth.UnmarshalText([]byte(sthText)); err != nil {
+			return fmt.Errorf("unmarshalling recorded tree head for tree %s: %w", treeID, err)
+		}
+		ours[sth.Origin] = treeID
+	}
+
+	var errs []error
+	for _, peer := range peers {
+		err := w.gossipWith(ctx, peer, ours)
+		switch {
+		case err == nil:
+			metricWatchGossip.WithLabelValues(peer, "success").Inc()
+		case errors.Is(err, verify.ErrInconsistentCheckpoints):
+			metricWatchGossip.WithLabelValues(peer, "inconsistent").Inc()
+			errs = append(errs, fmt.Errorf("peer %s: %w", peer, err))
+		default:
+			metricWatchGossip.WithLabelValues(peer, "error").Inc()
+			errs = append(errs, fmt.Errorf("peer %s: %w", peer, err))
+		}
+	}
+
+	// inconsistencies take precedence over other errors
+	for _, err := range errs {
+		if errors.Is(err, verify.ErrInconsistentCheckpoints) {
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// gossipWith checks the checkpoints of a single peer. ours maps the origin of
+// each checkpoint in the watch state to its tree ID.
+func (w *watcher) gossipWith(ctx context.Context, peer string, ours map[string]string) error {
+	state, err := fetchPeerCheckpoints(ctx, peer)
+	if err != nil {
+		return err
+	}
+	var inconsistent error
+	for _, theirText := range state.Checkpoints {
+		// the tree, and so the key the checkpoint must be signed with, is
+		// found from its origin before the signature is verified
+		unverified := util.SignedCheckpoint{}
+		if err := unverified.UnmarshalText([]byte(theirText)); err != nil {
+			log.Logger.Warnf("ignoring checkpoint from peer %s: %v", peer, err)
+			continue
+		}
+		treeID, ok := ours[unverified.Origin]
+		if !ok {
+			continue
+		}
+		verifier, err := w.verifiers.ForTree(ctx, treeID)
+		if err != nil {
+			return err
+		}
+		theirs, err := verifySignedCheckpoint(theirText, verifier)
+		if err != nil {
+			// a checkpoint not signed by the log is not evidence of anything
+			log.Logger.Warnf("ignoring checkpoint from peer %s: %v", peer, err)
+			continue
+		}
+		ourText := w.state.Checkpoints[treeID]
+		our, err := verifySignedCheckpoint(ourText, verifier)
+		if err != nil {
+			return fmt.Errorf("verifying recorded tree head: %w", err)
+		}
+
+		olderText, newerText, older, newer := ourText, theirText, our, theirs
+		if theirs.Size < our.Size {
+			olderText, newerText, older, newer = theirText, ourText, theirs, our
+		}
+		if err := verify.ProveConsistency(ctx, w.client, older, newer, treeID); err != nil {
+			if !errors.Is(err, verify.ErrInconsistentCheckpoints) {
+				return err
+			}
+			w.alert(ctx, watchAlert{
+				TreeID:        treeID,
+				Peer:          peer,
+				OldCheckpoint: olderText,
+				NewCheckpoint: newerText,
+				Error:         err.Error(),
+			})
+			inconsistent = err
+		}
+	}
+	return inconsistent
+}
+
+func fetchPeerCheckpoints(ctx context.Context, peer string) (*watchState, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(peer, "/")+gossipPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxGossipResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	state := &watchState{}
+	if err := json.Unmarshal(b, state); err != nil {
+		return nil, fmt.Errorf("unmarshalling peer checkpoints: %w", err)
+	}
+	return state, nil
+}
@@ -0,0 +1,183 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/memblob"
+
+	"github.com/sigstore/rekor/pkg/signer"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/rekor/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+func signedCheckpoint(t *testing.T, s signature.Signer, origin string, size uint64, root string) string {
+	t.Helper()
+	hash := sha256.Sum256([]byte(root))
+	sc, err := util.CreateSignedCheckpoint(util.Checkpoint{Origin: origin, Size: size, Hash: hash[:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sc.Sign("rekor.example.com", s, options.WithContext(context.Background())); err != nil {
+		t.Fatal(err)
+	}
+	b, err := sc.SignedNote.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestGossip(t *testing.T) {
+	ctx := context.Background()
+	logSigner, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSigner, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the inactive shard is signed with its own key
+	shardSigner, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origin := "rekor.example.com - 123"
+	shardOrigin := "rekor.example.com - 456"
+	state := watchState{Checkpoints: map[string]string{
+		"123": signedCheckpoint(t, logSigner, origin, 10, "root"),
+		"456": signedCheckpoint(t, shardSigner, shardOrigin, 5, "shard root"),
+	}}
+	ours := state.Checkpoints["123"]
+
+	tests := []struct {
+		name             string
+		theirs           string
+		wantInconsistent bool
+	}{
+		{
+			name:   "same checkpoint",
+			theirs: ours,
+		},
+		{
+			name:             "split view",
+			theirs:           signedCheckpoint(t, logSigner, origin, 10, "other root"),
+			wantInconsistent: true,
+		},
+		{
+			name:   "not signed by the log",
+			theirs: signedCheckpoint(t, otherSigner, origin, 10, "other root"),
+		},
+		{
+			name:   "other origin",
+			theirs: signedCheckpoint(t, logSigner, "rekor.example.com - 789", 10, "other root"),
+		},
+		{
+			name:   "same checkpoint of inactive shard",
+			theirs: state.Checkpoints["456"],
+		},
+		{
+			name:             "split view of inactive shard",
+			theirs:           signedCheckpoint(t, shardSigner, shardOrigin, 5, "other root"),
+			wantInconsistent: true,
+		},
+		{
+			name:   "inactive shard signed with the key of the active tree",
+			theirs: signedCheckpoint(t, logSigner, shardOrigin, 5, "other root"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != gossipPath {
+					rw.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_ = json.NewEncoder(rw).Encode(watchState{Checkpoints: map[string]string{"123": tt.theirs}})
+			}))
+			defer peer.Close()
+
+			bucket, err := blob.OpenBucket(ctx, "mem://")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer bucket.Close()
+			w := &watcher{
+				verifiers: verify.NewTreeVerifiers(nil),
+				bucket:    bucket,
+				state:     state,
+			}
+			if err := w.verifiers.Add("123", logSigner); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.verifiers.Add("456", shardSigner); err != nil {
+				t.Fatal(err)
+			}
+
+			err = w.gossip(ctx, []string{peer.URL})
+			if gotInconsistent := errors.Is(err, verify.ErrInconsistentCheckpoints); gotInconsistent != tt.wantInconsistent {
+				t.Fatalf("gossip() = %v, want inconsistent %v", err, tt.wantInconsistent)
+			}
+			if !tt.wantInconsistent && err != nil {
+				t.Fatalf("gossip() = %v", err)
+			}
+
+			// both signed checkpoints are kept as evidence of an inconsistency
+			var evidence []string
+			iter := bucket.List(&blob.ListOptions{Prefix: "inconsistency-"})
+			for {
+				obj, err := iter.Next(ctx)
+				if err != nil {
+					break
+				}
+				b, err := bucket.ReadAll(ctx, obj.Key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				evidence = append(evidence, string(b))
+			}
+			if !tt.wantInconsistent {
+				if len(evidence) != 0 {
+					t.Errorf("unexpected evidence %v", evidence)
+				}
+				return
+			}
+			if len(evidence) != 1 {
+				t.Fatalf("expected evidence of the inconsistency, got %d objects", len(evidence))
+			}
+			alert := watchAlert{}
+			if err := json.Unmarshal([]byte(evidence[0]), &alert); err != nil {
+				t.Fatal(err)
+			}
+			if alert.Peer != peer.URL || !strings.Contains(alert.OldCheckpoint+alert.NewCheckpoint, state.Checkpoints[alert.TreeID]) ||
+				!strings.Contains(alert.OldCheckpoint+alert.NewCheckpoint, tt.theirs) {
+				t.Errorf("unexpected evidence %+v", alert)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gocloud.dev/gcerrors"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/monitor"
+)
+
+const (
+	// monitorStateObject is the name of the object in the bucket holding the
+	// last verified checkpoint of each tree for the monitor
+	monitorStateObject = "monitor-state.json"
+	// monitorProgressObject is the name of the object in the bucket holding
+	// the index of the next entry to be checked by the monitor
+	monitorProgressObject = "monitor-progress.json"
+	// monitorBatchSize is the number of entries checked between saving progress
+	monitorBatchSize = 100
+)
+
+var metricMonitorNextIndex = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "rekor_monitor_next_index",
+	Help: "The log index of the next entry to be checked by the monitor",
+})
+
+// monitorCmd represents the monitor command
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Start a process to notify about new entries for watched identities",
+	Long: `Start a process to notify about new entries for watched identities. STH's are
+verified as by the watch command, and every entry added between two verified STH's
+is fetched and matched against the emails, subject URIs and public key hashes in
+the watch list. Matches are sent to each sink, which is one of "stdout",
+"file:///path/to/file" or a webhook URL.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// these are bound here so that they are not overwritten by other commands
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			log.Logger.Fatal("Error initializing cmd line args: ", err)
+		}
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// Setup the logger to dev/prod
+		log.ConfigureLogger(viper.GetString("log_type"))
+
+		// workaround for https://github.com/sigstore/rekor/issues/68
+		// from https://github.com/golang/glog/commit/fca8c8854093a154ff1eb580aae10276ad6b1b5f
+		_ = flag.CommandLine.Parse([]string{})
+
+		watchListPath := viper.GetString("watch_list")
+		if watchListPath == "" {
+			return errors.New("--watch_list must be set")
+		}
+		watchList, err := monitor.LoadWatchList(watchListPath)
+		if err != nil {
+			return fmt.Errorf("loading watch list: %w", err)
+		}
+		var sinks []monitor.Sink
+		for _, uri := range viper.GetStringSlice("sink") {
+			s, err := monitor.NewSink(uri)
+			if err != nil {
+				return err
+			}
+			sinks = append(sinks, s)
+		}
+
+		interval := viper.GetDuration("interval")
+		ctx := context.Background()
+		w, err := newWatcher(ctx, monitorStateObject)
+		if err != nil {
+			return err
+		}
+		defer w.bucket.Close()
+
+		progress, err := w.loadProgress(ctx)
+		if err != nil {
+			return err
+		}
+		m := &monitor.Monitor{
+			Client:    w.client,
+			Verifiers: w.verifiers,
+			WatchList: watchList,
+			Sinks:     sinks,
+		}
+		w.serveHTTP()
+
+		tick := time.NewTicker(interval)
+		defer tick.Stop()
+		for {
+			<-tick.C
+			log.Logger.Info("performing check")
+			li, err := w.check(ctx)
+			if err != nil {
+				if err := recordCheck(err); err != nil {
+					return err
+				}
+				continue
+			}
+			_ = recordCheck(nil)
+			if err := w.gossipWithPeers(ctx); err != nil {
+				return err
+			}
+
+			end, err := w.verifiedLogSize(li)
+			if err != nil {
+				log.Logger.Warnf("error computing verified log size: %s", err)
+				continue
+			}
+			if progress.NextIndex < 0 {
+				// only entries added after the monitor first started are checked
+				progress.NextIndex = end
+			}
+			for progress.NextIndex < end {
+				batchEnd := progress.NextIndex + monitorBatchSize
+				if batchEnd > end {
+					batchEnd = end
+				}
+				next, err := m.CheckEntries(ctx, progress.NextIndex, batchEnd)
+				progress.NextIndex = next
+				if serr := w.saveProgress(ctx, progress); serr != nil {
+					log.Logger.Warnf("error saving monitor progress: %s", serr)
+				}
+				if err != nil {
+					log.Logger.Warnf("error checking entries: %s", err)
+					break
+				}
+			}
+			metricMonitorNextIndex.Set(float64(progress.NextIndex))
+		}
+	},
+}
+
+func init() {
+	addWatchFlags(monitorCmd)
+	monitorCmd.Flags().String("watch_list", "", "path to the list of watched identities, in JSON or YAML")
+	monitorCmd.Flags().StringSlice("sink", []string{"stdout"}, "where notifications are sent: stdout, file:///path/to/file or a webhook URL. May be repeated")
+	monitorCmd.Flags().Int64("start_index", -1, "log index of the first entry to check if there is no saved progress. Defaults to entries added after the monitor starts")
+	rootCmd.AddCommand(monitorCmd)
+}
+
+// verifiedLogSize returns the number of entries across every tree in the log,
+// as committed to by the verified STH's. Inactive shards are listed in the
+// order of their virtual log indexes, followed by the active tree.
+func (w *watcher) verifiedLogSize(li *models.LogInfo) (int64, error) {
+	var total int64
+	for _, shard := range li.InactiveShards {
+		size, err := w.verifiedSize(*shard.TreeID)
+		if err != nil {
+			return 0, err
+		}
+		total += int64(size)
+	}
+	size, err := w.verifiedSize(*li.TreeID)
+	if err != nil {
+		return 0, err
+	}
+	return total + int64(size), nil
+}
+
+func (w *watcher) loadProgress(ctx context.Context) (*monitor.Progress, error) {
+	progress := &monitor.Progress{NextIndex: viper.GetInt64("start_index")}
+	b, err := w.bucket.ReadAll(ctx, monitorProgressObject)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return progress, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading monitor progress: %w", err)
+	}
+	if err := json.Unmarshal(b, progress); err != nil {
+		return nil, fmt.Errorf("unmarshalling monitor progress: %w", err)
+	}
+	log.Logger.Infof("Resuming monitor at log index %d", progress.NextIndex)
+	return progress, nil
+}
+
+func (w *watcher) saveProgress(ctx context.Context, progress *monitor.Progress) error {
+	b, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	return w.bucket.WriteAll(ctx, monitorProgressObject, b, nil)
+}
@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"time"
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/sigstore/rekor/pkg/log"
@@ -59,23 +60,40 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.rekor-server.yaml)")
 	rootCmd.PersistentFlags().StringVar(&logType, "log_type", "dev", "logger type to use (dev/prod)")
+	rootCmd.PersistentFlags().String("log_level", "", "minimum level of logs to write, e.g. debug, info or warn. Defaults to debug for dev logs and info for prod logs")
+	rootCmd.PersistentFlags().Duration("config_poll_interval", 10*time.Second, "how often to check the config file and sharding config for changes to reload, or 0 to only reload on SIGHUP")
 	rootCmd.PersistentFlags().BoolVar(&enablePprof, "enable_pprof", false, "enable pprof for profiling on port 6060")
 
+	rootCmd.PersistentFlags().String("backend", "trillian", "where the log is stored. Current valid options include: [trillian, embedded]")
+	rootCmd.PersistentFlags().String("embedded_log.path", "", "path to the file storing the embedded log. The log is only held in memory if unset")
+
 	rootCmd.PersistentFlags().String("trillian_log_server.address", "127.0.0.1", "Trillian log server address")
 	rootCmd.PersistentFlags().Uint16("trillian_log_server.port", 8090, "Trillian log server port")
 	rootCmd.PersistentFlags().Uint("trillian_log_server.tlog_id", 0, "Trillian tree id")
 	rootCmd.PersistentFlags().String("trillian_log_server.sharding_config", "", "path to config file for inactive shards, in JSON or YAML")
+	rootCmd.PersistentFlags().String("trillian_log_server.sharding_store", "file", "where the sharding config is stored. Current valid options include: [file, redis]")
 
 	hostname, err := os.Hostname()
 	if err != nil {
 		hostname = "localhost"
 	}
 	rootCmd.PersistentFlags().String("rekor_server.hostname", hostname, "public hostname of instance")
+	rootCmd.PersistentFlags().String("rekor_server.public_url", "", "public URL of instance, e.g. https://rekor.example.com, which timestamp notes refer to for the timestamping certificate chain")
 	rootCmd.PersistentFlags().String("rekor_server.address", "127.0.0.1", "Address to bind to")
 	rootCmd.PersistentFlags().String("rekor_server.signer", "memory", "Rekor signer to use. Current valid options include: [gcpkms, memory]")
+	rootCmd.PersistentFlags().String("rekor_server.timestamp_chain", "", "path to PEM encoded certificate chain for timestamping, starting with the certificate for the signer's key. Generated if using the memory signer")
+	rootCmd.PersistentFlags().Bool("enable_timestamp_logging", false, "adds issued RFC 3161 timestamps to the transparency log as rfc3161 entries")
+	rootCmd.PersistentFlags().Duration("timestamp_note_radius", time.Second, "certainty of the time in signed timestamp notes")
+	rootCmd.PersistentFlags().String("checkpoint_tsa_url", "", "URL of an RFC 3161 timestamping authority used to timestamp checkpoints of the active tree")
+	rootCmd.PersistentFlags().String("checkpoint_timestamp_bucket", "", "url for checkpoint timestamp storage bucket, e.g. gs://bucket or file:///path")
+	rootCmd.PersistentFlags().Duration("checkpoint_timestamp_interval", time.Minute, "interval at which checkpoints are timestamped")
 
 	rootCmd.PersistentFlags().Uint16("port", 3000, "Port to bind to")
 
+	rootCmd.PersistentFlags().String("admin.address", "127.0.0.1", "Address to bind the admin API to")
+	rootCmd.PersistentFlags().Uint16("admin.port", 0, "Port to serve the admin API on, which is disabled if 0")
+	rootCmd.PersistentFlags().String("admin.token_file", "", "path to a file containing the bearer token required by the admin API")
+
 	rootCmd.PersistentFlags().Bool("enable_retrieve_api", true, "enables Redis-based index API endpoint")
 	rootCmd.PersistentFlags().String("redis_server.address", "127.0.0.1", "Redis server address")
 	rootCmd.PersistentFlags().Uint16("redis_server.port", 6379, "Redis server port")
@@ -0,0 +1,110 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/sigstore/rekor/pkg/api"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/signer"
+)
+
+// rotateCmd represents the rotate command
+var rotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Freeze the active tree and switch the log to a new tree",
+	Long: `Freeze the active tree once queued entries have been integrated, create a new
+tree, and record the frozen tree as an inactive shard in the sharding config along
+with its final length, the signer and its public key, and its final signed checkpoint.
+The new tree becomes the active tree in the sharding config, which rekor-server
+instances reading the same file or Redis index switch to without restarting.
+
+The frozen tree stays signed by the signer it was rotated with, so the signer must be
+the one the servers sign with, and cannot be the memory signer.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// these are bound here so that they are not overwritten by other commands
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			log.Logger.Fatal("Error initializing cmd line args: ", err)
+		}
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// Setup the logger to dev/prod
+		log.ConfigureLogger(viper.GetString("log_type"))
+
+		// workaround for https://github.com/sigstore/rekor/issues/68
+		// from https://github.com/golang/glog/commit/fca8c8854093a154ff1eb580aae10276ad6b1b5f
+		_ = flag.CommandLine.Parse([]string{})
+
+		// a memory signer would be a new key that only this process holds,
+		// which the servers don't sign the frozen tree with
+		signerURI := viper.GetString("rekor_server.signer")
+		if signerURI == signer.MemoryScheme {
+			return errors.New("the log cannot be rotated with the memory signer, set `--rekor_server.signer` to the signer of the servers")
+		}
+
+		ctx := context.Background()
+		store, err := api.NewShardingStore(ctx)
+		if err != nil {
+			return err
+		}
+		if store == nil {
+			return errors.New("the sharding config to update must be set via the `--trillian_log_server.sharding_config` flag, or stored in redis with `--trillian_log_server.sharding_store=redis`")
+		}
+		if c, ok := store.(io.Closer); ok {
+			defer c.Close()
+		}
+
+		logRPCServer := fmt.Sprintf("%s:%d",
+			viper.GetString("trillian_log_server.address"),
+			viper.GetUint("trillian_log_server.port"))
+		tConn, err := grpc.DialContext(ctx, logRPCServer, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return fmt.Errorf("dial: %w", err)
+		}
+		defer tConn.Close()
+
+		rekorSigner, err := signer.New(ctx, signerURI)
+		if err != nil {
+			return fmt.Errorf("getting new signer: %w", err)
+		}
+
+		treeID, err := api.RotateTree(ctx, trillian.NewTrillianLogClient(tConn), trillian.NewTrillianAdminClient(tConn),
+			store, viper.GetInt64("trillian_log_server.tlog_id"), rekorSigner, signerURI, viper.GetString("rekor_server.hostname"), viper.GetDuration("settle_period"))
+		if err != nil {
+			return err
+		}
+		fmt.Println(treeID)
+		return nil
+	},
+}
+
+func init() {
+	rotateCmd.Flags().Duration("settle_period", 10*time.Second, "how long the size of the draining tree must be unchanged before it is frozen")
+	rootCmd.AddCommand(rotateCmd)
+}
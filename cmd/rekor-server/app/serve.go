@@ -16,8 +16,14 @@
 package app
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/go-openapi/loads"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -31,8 +37,18 @@ import (
 	"github.com/sigstore/rekor/pkg/log"
 	"github.com/sigstore/rekor/pkg/types/alpine"
 	alpine_v001 "github.com/sigstore/rekor/pkg/types/alpine/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/apkandroid"
+	apkandroid_v001 "github.com/sigstore/rekor/pkg/types/apkandroid/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/cose"
 	cose_v001 "github.com/sigstore/rekor/pkg/types/cose/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/deb"
+	deb_v001 "github.com/sigstore/rekor/pkg/types/deb/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/dsse"
+	dsse_v001 "github.com/sigstore/rekor/pkg/types/dsse/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/git"
+	git_v001 "github.com/sigstore/rekor/pkg/types/git/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/gomod"
+	gomod_v001 "github.com/sigstore/rekor/pkg/types/gomod/v0.0.1"
 	hashedrekord "github.com/sigstore/rekor/pkg/types/hashedrekord"
 	hashedrekord_v001 "github.com/sigstore/rekor/pkg/types/hashedrekord/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/helm"
@@ -42,12 +58,22 @@ import (
 	intoto_v002 "github.com/sigstore/rekor/pkg/types/intoto/v0.0.2"
 	"github.com/sigstore/rekor/pkg/types/jar"
 	jar_v001 "github.com/sigstore/rekor/pkg/types/jar/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/npm"
+	npm_v001 "github.com/sigstore/rekor/pkg/types/npm/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/oci"
+	oci_v001 "github.com/sigstore/rekor/pkg/types/oci/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/pe"
+	pe_v001 "github.com/sigstore/rekor/pkg/types/pe/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/pypi"
+	pypi_v001 "github.com/sigstore/rekor/pkg/types/pypi/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/rekord"
 	rekord_v001 "github.com/sigstore/rekor/pkg/types/rekord/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/rfc3161"
 	rfc3161_v001 "github.com/sigstore/rekor/pkg/types/rfc3161/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/rpm"
 	rpm_v001 "github.com/sigstore/rekor/pkg/types/rpm/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/sbom"
+	sbom_v001 "github.com/sigstore/rekor/pkg/types/sbom/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/tuf"
 	tuf_v001 "github.com/sigstore/rekor/pkg/types/tuf/v0.0.1"
 )
@@ -61,6 +87,9 @@ var serveCmd = &cobra.Command{
 
 		// Setup the logger to dev/prod
 		log.ConfigureLogger(viper.GetString("log_type"))
+		if err := log.SetLevel(viper.GetString("log_level")); err != nil {
+			log.Logger.Fatal(err)
+		}
 
 		// workaround for https://github.com/sigstore/rekor/issues/68
 		// from https://github.com/golang/glog/commit/fca8c8854093a154ff1eb580aae10276ad6b1b5f
@@ -88,14 +117,24 @@ var serveCmd = &cobra.Command{
 		pluggableTypeMap := map[string][]string{
 			rekord.KIND:       {rekord_v001.APIVERSION},
 			rpm.KIND:          {rpm_v001.APIVERSION},
+			deb.KIND:          {deb_v001.APIVERSION},
 			jar.KIND:          {jar_v001.APIVERSION},
 			intoto.KIND:       {intoto_v001.APIVERSION, intoto_v002.APIVERSION},
 			cose.KIND:         {cose_v001.APIVERSION},
+			dsse.KIND:         {dsse_v001.APIVERSION},
 			rfc3161.KIND:      {rfc3161_v001.APIVERSION},
 			alpine.KIND:       {alpine_v001.APIVERSION},
 			helm.KIND:         {helm_v001.APIVERSION},
 			tuf.KIND:          {tuf_v001.APIVERSION},
 			hashedrekord.KIND: {hashedrekord_v001.APIVERSION},
+			oci.KIND:          {oci_v001.APIVERSION},
+			npm.KIND:          {npm_v001.APIVERSION},
+			pypi.KIND:         {pypi_v001.APIVERSION},
+			git.KIND:          {git_v001.APIVERSION},
+			sbom.KIND:         {sbom_v001.APIVERSION},
+			pe.KIND:           {pe_v001.APIVERSION},
+			apkandroid.KIND:   {apkandroid_v001.APIVERSION},
+			gomod.KIND:        {gomod_v001.APIVERSION},
 		}
 
 		for k, v := range pluggableTypeMap {
@@ -109,15 +148,47 @@ var serveCmd = &cobra.Command{
 
 		treeID := viper.GetUint("trillian_log_server.tlog_id")
 
-		api.ConfigureAPI(treeID)
-		server.ConfigureAPI()
+		rekorAPI := api.ConfigureAPI(treeID)
+		server.ConfigureRekorAPI(rekorAPI)
+
+		// reload the config file and sharding config on SIGHUP
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		go func() {
+			for range hup {
+				if err := rekorAPI.Reload(context.Background()); err != nil {
+					log.Logger.Errorf("reloading config: %v", err)
+				}
+			}
+		}()
+
+		if port := viper.GetUint("admin.port"); port != 0 {
+			token, err := os.ReadFile(viper.GetString("admin.token_file"))
+			if err != nil {
+				log.Logger.Fatalf("reading admin token: %v", err)
+			}
+			if len(strings.TrimSpace(string(token))) == 0 {
+				log.Logger.Fatal("admin token must not be empty")
+			}
+			adminAddr := fmt.Sprintf("%s:%d", viper.GetString("admin.address"), port)
+			go func() {
+				log.Logger.Infof("Serving admin API at %s", adminAddr)
+				if err := http.ListenAndServe(adminAddr, rekorAPI.AdminHandler(strings.TrimSpace(string(token)))); err != nil {
+					log.Logger.Fatal(err)
+				}
+			}()
+		}
 
 		http.Handle("/metrics", promhttp.Handler())
 		go func() {
 			_ = http.ListenAndServe(":2112", nil)
 		}()
 
-		if err := server.Serve(); err != nil {
+		// the API is closed once the server has stopped serving requests, so
+		// that the embedded log file is closed after its last write
+		err = server.Serve()
+		rekorAPI.Close()
+		if err != nil {
 			log.Logger.Fatal(err)
 		}
 	},
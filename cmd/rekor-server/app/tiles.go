@@ -0,0 +1,111 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gocloud.dev/blob"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/signer"
+	"github.com/sigstore/rekor/pkg/tiles"
+)
+
+// tilesCmd represents the tiles command
+var tilesCmd = &cobra.Command{
+	Use:   "tiles",
+	Short: "Start a process to publish the log as static tiles",
+	Long: `Start a process that periodically publishes the active tree as Merkle tiles,
+entry bundles and a signed checkpoint in the tlog-tiles layout to a blob bucket,
+so that reads can be served from a CDN. The signer and hostname must match those
+of the rekor-server instances serving the tree.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// these are bound here so that they are not overwritten by other commands
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			log.Logger.Fatal("Error initializing cmd line args: ", err)
+		}
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// Setup the logger to dev/prod
+		log.ConfigureLogger(viper.GetString("log_type"))
+
+		// workaround for https://github.com/sigstore/rekor/issues/68
+		// from https://github.com/golang/glog/commit/fca8c8854093a154ff1eb580aae10276ad6b1b5f
+		_ = flag.CommandLine.Parse([]string{})
+
+		treeID := viper.GetInt64("trillian_log_server.tlog_id")
+		if treeID == 0 {
+			return errors.New("the tree to publish must be set via the `--trillian_log_server.tlog_id` flag")
+		}
+		bucketURL := viper.GetString("tiles_bucket")
+		if bucketURL == "" {
+			return errors.New("a bucket must be set via the `--tiles_bucket` flag")
+		}
+
+		ctx := context.Background()
+		logRPCServer := fmt.Sprintf("%s:%d",
+			viper.GetString("trillian_log_server.address"),
+			viper.GetUint("trillian_log_server.port"))
+		tConn, err := grpc.DialContext(ctx, logRPCServer, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return fmt.Errorf("dial: %w", err)
+		}
+		defer tConn.Close()
+
+		rekorSigner, err := signer.New(ctx, viper.GetString("rekor_server.signer"))
+		if err != nil {
+			return fmt.Errorf("getting new signer: %w", err)
+		}
+
+		bucket, err := blob.OpenBucket(ctx, bucketURL)
+		if err != nil {
+			return err
+		}
+		defer bucket.Close()
+
+		publisher := tiles.NewPublisher(trillian.NewTrillianLogClient(tConn), treeID, bucket, rekorSigner, viper.GetString("rekor_server.hostname"))
+		tick := time.NewTicker(viper.GetDuration("interval"))
+		for {
+			sth, err := publisher.Publish(ctx)
+			switch {
+			case err != nil:
+				log.Logger.Warnf("error publishing tiles for tree %d: %s", treeID, err)
+			case sth == nil:
+				log.Logger.Infof("Tree %d is empty, nothing to publish", treeID)
+			default:
+				log.Logger.Infof("Published tree %d at size %d", treeID, sth.Size)
+			}
+			<-tick.C
+		}
+	},
+}
+
+func init() {
+	tilesCmd.Flags().Duration("interval", 1*time.Minute, "Publishing interval")
+	tilesCmd.Flags().String("tiles_bucket", "", "url of the bucket to publish tiles to, e.g. gs://bucket or file:///path")
+	rootCmd.AddCommand(tilesCmd)
+}
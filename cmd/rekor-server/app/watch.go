@@ -16,38 +16,70 @@
 package app
 
 import (
+	"bytes"
 	"context"
-	"crypto"
-	"crypto/x509"
 	"encoding/json"
-	"encoding/pem"
 	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	_ "gocloud.dev/blob/fileblob" // fileblob
 	_ "gocloud.dev/blob/gcsblob"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
 
 	"github.com/sigstore/rekor/pkg/client"
 	genclient "github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/log"
 	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/rekor/pkg/verify"
 	"github.com/sigstore/sigstore/pkg/signature"
 )
 
-const rekorSthBucketEnv = "REKOR_STH_BUCKET"
+const (
+	rekorSthBucketEnv = "REKOR_STH_BUCKET"
+	// watchStateObject is the name of the object in the bucket holding the
+	// last verified checkpoint of each tree
+	watchStateObject = "watch-state.json"
+)
+
+var (
+	metricWatchChecks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rekor_watch_checks",
+		Help: "The total number of checks performed by the watcher, by result",
+	}, []string{"result"})
+
+	metricWatchInconsistencies = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rekor_watch_inconsistencies",
+		Help: "The total number of inconsistent checkpoints found by the watcher",
+	}, []string{"tree_id"})
+
+	metricWatchVerifiedSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rekor_watch_verified_tree_size",
+		Help: "The size of the last checkpoint verified by the watcher",
+	}, []string{"tree_id"})
+)
 
 // watchCmd represents the serve command
 var watchCmd = &cobra.Command{
 	Use:   "watch",
 	Short: "Start a process to watch and record STH's from Rekor",
-	Long:  `Start a process to watch and record STH's from Rekor`,
+	Long: `Start a process to watch and record STH's from Rekor. Successive STH's of
+the active tree and of every inactive shard are proven to be consistent with the
+last verified STH, which is persisted to the bucket so that restarts continue
+the chain.`,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		// these are bound here so that they are not overwritten by other commands
 		if err := viper.BindPFlags(cmd.Flags()); err != nil {
@@ -63,97 +95,342 @@ var watchCmd = &cobra.Command{
 		// from https://github.com/golang/glog/commit/fca8c8854093a154ff1eb580aae10276ad6b1b5f
 		_ = flag.CommandLine.Parse([]string{})
 
-		host := viper.GetString("rekor_server.address")
-		port := viper.GetUint("port")
 		interval := viper.GetDuration("interval")
-		url := fmt.Sprintf("http://%s:%d", host, port)
-		c, err := client.GetRekorClient(url)
-		if err != nil {
-			return err
-		}
-
-		keyResp, err := c.Pubkey.GetPublicKey(nil)
-		if err != nil {
-			return err
-		}
-		publicKey := keyResp.Payload
-		block, _ := pem.Decode([]byte(publicKey))
-		if block == nil {
-			return errors.New("failed to decode public key of server")
-		}
-
-		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
-		if err != nil {
-			return err
-		}
-
 		ctx := context.Background()
-		bucketURL := os.Getenv(rekorSthBucketEnv)
-		if bucketURL == "" {
-			log.CliLogger.Fatalf("%s env var must be set", rekorSthBucketEnv)
-		}
-		bucket, err := blob.OpenBucket(ctx, bucketURL)
+		w, err := newWatcher(ctx, watchStateObject)
 		if err != nil {
 			return err
 		}
-		defer bucket.Close()
-		tick := time.NewTicker(interval)
-		var last *SignedAndUnsignedLogRoot
+		defer w.bucket.Close()
+		w.serveHTTP()
 
+		tick := time.NewTicker(interval)
+		defer tick.Stop()
 		for {
 			<-tick.C
 			log.Logger.Info("performing check")
-			lr, err := doCheck(c, pub)
-			if err != nil {
-				log.Logger.Warnf("error verifiying tree: %s", err)
-				continue
-			}
-			log.Logger.Infof("Found and verified state at %d", lr.VerifiedLogRoot.Size)
-			if last != nil && last.VerifiedLogRoot.Size == lr.VerifiedLogRoot.Size {
-				log.Logger.Infof("Last tree size is the same as the current one: %d %d",
-					last.VerifiedLogRoot.Size, lr.VerifiedLogRoot.Size)
-				// If it's the same, it shouldn't have changed but we'll still upload anyway
-				// in case that failed.
+			_, err := w.check(ctx)
+			if err := recordCheck(err); err != nil {
+				return err
 			}
-
-			if err := uploadToBlobStorage(ctx, bucket, lr); err != nil {
-				log.Logger.Warnf("error uploading result: %s", err)
-				continue
+			if err := w.gossipWithPeers(ctx); err != nil {
+				return err
 			}
-			last = lr
 		}
 	},
 }
 
 func init() {
-	watchCmd.Flags().Duration("interval", 1*time.Minute, "Polling interval")
+	addWatchFlags(watchCmd)
 	rootCmd.AddCommand(watchCmd)
 }
 
-func doCheck(c *genclient.Rekor, pub crypto.PublicKey) (*SignedAndUnsignedLogRoot, error) {
-	li, err := c.Tlog.GetLogInfo(nil)
+// addWatchFlags adds the flags shared by the commands that verify STH's
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("interval", 1*time.Minute, "Polling interval")
+	cmd.Flags().String("alert_webhook", "", "URL that alerts are POSTed to as JSON when an inconsistent STH is found")
+	cmd.Flags().Bool("exit_on_inconsistency", false, "exit with a non-zero status when an inconsistent STH is found")
+	cmd.Flags().Uint16("metrics_port", 2113, "Port to serve prometheus metrics, and the last verified STH's for peers, on. It differs from the metrics port of the server, so both can run on one host")
+	cmd.Flags().StringSlice("peers", nil, "base URLs of other watchers to exchange STH's with, to detect split views of the log")
+}
+
+// serveHTTP serves the prometheus metrics, and the last verified STH's for
+// peers, in the background
+func (w *watcher) serveHTTP() {
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		mux.HandleFunc(gossipPath, w.serveCheckpoints)
+		if err := http.ListenAndServe(fmt.Sprintf(":%d", viper.GetUint("metrics_port")), mux); err != nil && err != http.ErrServerClosed {
+			log.Logger.Fatalf("Error when starting or running metrics server: %v", err)
+		}
+	}()
+}
+
+// recordCheck records the result of a check in the metrics, and returns the
+// error if the process should exit because of it
+func recordCheck(err error) error {
+	switch {
+	case err == nil:
+		metricWatchChecks.WithLabelValues("success").Inc()
+	case errors.Is(err, verify.ErrInconsistentCheckpoints):
+		metricWatchChecks.WithLabelValues("inconsistent").Inc()
+		if viper.GetBool("exit_on_inconsistency") {
+			return err
+		}
+	default:
+		metricWatchChecks.WithLabelValues("error").Inc()
+		log.Logger.Warnf("error verifiying tree: %s", err)
+	}
+	return nil
+}
+
+// gossipWithPeers exchanges STH's with the configured peers, and returns an
+// error if the process should exit because of the result
+func (w *watcher) gossipWithPeers(ctx context.Context) error {
+	peers := viper.GetStringSlice("peers")
+	if len(peers) == 0 {
+		return nil
+	}
+	err := w.gossip(ctx, peers)
+	switch {
+	case err == nil:
+	case errors.Is(err, verify.ErrInconsistentCheckpoints):
+		if viper.GetBool("exit_on_inconsistency") {
+			return err
+		}
+	default:
+		log.Logger.Warnf("error exchanging tree heads with peers: %s", err)
+	}
+	return nil
+}
+
+// watchState is the last verified checkpoint of each tree, keyed by tree ID
+type watchState struct {
+	Checkpoints map[string]string `json:"checkpoints"`
+}
+
+// watchAlert is the payload POSTed to the alert webhook
+type watchAlert struct {
+	TreeID        string `json:"treeID"`
+	OldCheckpoint string `json:"oldCheckpoint"`
+	NewCheckpoint string `json:"newCheckpoint"`
+	Error         string `json:"error"`
+	// Peer is set if the inconsistent STH was received from another watcher
+	Peer string `json:"peer,omitempty"`
+}
+
+// newWatcher returns a watcher for the configured server, which persists its
+// state to stateObject in the bucket named by the REKOR_STH_BUCKET env var
+func newWatcher(ctx context.Context, stateObject string) (*watcher, error) {
+	host := viper.GetString("rekor_server.address")
+	port := viper.GetUint("port")
+	url := fmt.Sprintf("http://%s:%d", host, port)
+	c, err := client.GetRekorClient(url)
+	if err != nil {
+		return nil, err
+	}
+
+	bucketURL := os.Getenv(rekorSthBucketEnv)
+	if bucketURL == "" {
+		log.CliLogger.Fatalf("%s env var must be set", rekorSthBucketEnv)
+	}
+	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &watcher{
+		client:      c,
+		verifiers:   verify.NewTreeVerifiers(c),
+		bucket:      bucket,
+		stateObject: stateObject,
+		webhook:     viper.GetString("alert_webhook"),
+	}
+	if err := w.loadState(ctx); err != nil {
+		bucket.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+type watcher struct {
+	client      *genclient.Rekor
+	verifiers   *verify.TreeVerifiers // each shard may be signed with its own key
+	bucket      *blob.Bucket
+	stateObject string
+	webhook     string
+	state       watchState
+
+	// published is the serialized state served to peers
+	mu        sync.RWMutex
+	published []byte
+}
+
+// loadState reads the last verified state from the bucket, if there is one
+func (w *watcher) loadState(ctx context.Context) error {
+	w.state = watchState{Checkpoints: map[string]string{}}
+	b, err := w.bucket.ReadAll(ctx, w.stateObject)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading watch state: %w", err)
+	}
+	if err := json.Unmarshal(b, &w.state); err != nil {
+		return fmt.Errorf("unmarshalling watch state: %w", err)
+	}
+	if w.state.Checkpoints == nil {
+		w.state.Checkpoints = map[string]string{}
+	}
+	log.Logger.Infof("Loaded verified state for %d trees", len(w.state.Checkpoints))
+	return w.publish()
+}
+
+func (w *watcher) saveState(ctx context.Context) error {
+	if err := w.publish(); err != nil {
+		return err
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.bucket.WriteAll(ctx, w.stateObject, w.published, nil)
+}
+
+// publish makes the current state available to peers
+func (w *watcher) publish() error {
+	b, err := json.Marshal(w.state)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.published = b
+	return nil
+}
+
+// check fetches the STH of the active tree and every inactive shard, and
+// proves each to be consistent with the last verified STH of the tree. The
+// returned error wraps verify.ErrInconsistentCheckpoints if any tree was found
+// to be inconsistent. The fetched log info is returned if every STH was
+// verified.
+func (w *watcher) check(ctx context.Context) (*models.LogInfo, error) {
+	li, err := w.client.Tlog.GetLogInfo(tlog.NewGetLogInfoParamsWithContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("getting log info: %w", err)
 	}
+	sths := map[string]string{*li.Payload.TreeID: *li.Payload.SignedTreeHead}
+	for _, shard := range li.Payload.InactiveShards {
+		sths[*shard.TreeID] = *shard.SignedTreeHead
+	}
+
+	var errs []error
+	for treeID, sthText := range sths {
+		lr, err := w.checkTree(ctx, treeID, sthText)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tree %s: %w", treeID, err))
+			continue
+		}
+		if treeID == *li.Payload.TreeID {
+			if err := uploadToBlobStorage(ctx, w.bucket, lr); err != nil {
+				errs = append(errs, fmt.Errorf("uploading result: %w", err))
+			}
+		}
+	}
+	if err := w.saveState(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("saving watch state: %w", err))
+	}
+
+	// inconsistencies take precedence over other errors
+	for _, err := range errs {
+		if errors.Is(err, verify.ErrInconsistentCheckpoints) {
+			return nil, err
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return li.Payload, nil
+}
+
+// verifiedSize returns the size of the last verified STH of the tree
+func (w *watcher) verifiedSize(treeID string) (uint64, error) {
+	sthText, ok := w.state.Checkpoints[treeID]
+	if !ok {
+		return 0, fmt.Errorf("no verified tree head for tree %s", treeID)
+	}
 	sth := util.SignedCheckpoint{}
-	if err := sth.UnmarshalText([]byte(*li.Payload.SignedTreeHead)); err != nil {
-		return nil, fmt.Errorf("unmarshalling tree head: %w", err)
+	if err := sth.UnmarshalText([]byte(sthText)); err != nil {
+		return 0, err
 	}
+	return sth.Size, nil
+}
 
-	verifier, err := signature.LoadVerifier(pub, crypto.SHA256)
+// checkTree verifies the STH of a single tree and, if it is consistent with
+// the last verified STH, records it as the new verified state
+func (w *watcher) checkTree(ctx context.Context, treeID, sthText string) (*SignedAndUnsignedLogRoot, error) {
+	verifier, err := w.verifiers.ForTree(ctx, treeID)
 	if err != nil {
 		return nil, err
 	}
-
-	if !sth.Verify(verifier) {
-		return nil, fmt.Errorf("signed tree head failed verification: %w", err)
+	sth, err := verifySignedCheckpoint(sthText, verifier)
+	if err != nil {
+		return nil, err
+	}
+	if lastText, ok := w.state.Checkpoints[treeID]; ok {
+		last, err := verifySignedCheckpoint(lastText, verifier)
+		if err != nil {
+			return nil, fmt.Errorf("verifying recorded tree head: %w", err)
+		}
+		if err := verify.ProveConsistency(ctx, w.client, last, sth, treeID); err != nil {
+			if errors.Is(err, verify.ErrInconsistentCheckpoints) {
+				w.alert(ctx, watchAlert{
+					TreeID:        treeID,
+					OldCheckpoint: lastText,
+					NewCheckpoint: sthText,
+					Error:         err.Error(),
+				})
+			}
+			return nil, err
+		}
+		if last.Size == sth.Size {
+			log.Logger.Infof("Last tree size is the same as the current one for tree %s: %d", treeID, sth.Size)
+		}
 	}
 
+	log.Logger.Infof("Found and verified state for tree %s at %d", treeID, sth.Size)
+	w.state.Checkpoints[treeID] = sthText
+	metricWatchVerifiedSize.WithLabelValues(treeID).Set(float64(sth.Size))
 	return &SignedAndUnsignedLogRoot{
-		VerifiedLogRoot: &sth,
+		VerifiedLogRoot: sth,
 	}, nil
 }
 
+// alert records an inconsistency in the metrics and the log, stores both
+// signed STH's in the bucket as evidence, and posts it to the webhook if one
+// is configured
+func (w *watcher) alert(ctx context.Context, a watchAlert) {
+	metricWatchInconsistencies.WithLabelValues(a.TreeID).Inc()
+	log.Logger.Errorf("inconsistent tree head for tree %s: %s", a.TreeID, a.Error)
+	b, err := json.Marshal(a)
+	if err != nil {
+		log.Logger.Errorf("marshalling alert: %v", err)
+		return
+	}
+	objName := fmt.Sprintf("inconsistency-%s-%d.json", a.TreeID, time.Now().UnixNano())
+	if err := w.bucket.WriteAll(ctx, objName, b, nil); err != nil {
+		log.Logger.Errorf("storing evidence of inconsistency: %v", err)
+	}
+	if w.webhook == "" {
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.webhook, bytes.NewReader(b))
+	if err != nil {
+		log.Logger.Errorf("creating alert request: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Logger.Errorf("posting alert: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		log.Logger.Errorf("posting alert: unexpected status %s", resp.Status)
+	}
+}
+
+func verifySignedCheckpoint(sthText string, verifier signature.Verifier) (*util.SignedCheckpoint, error) {
+	sth := util.SignedCheckpoint{}
+	if err := sth.UnmarshalText([]byte(sthText)); err != nil {
+		return nil, fmt.Errorf("unmarshalling tree head: %w", err)
+	}
+	if !sth.Verify(verifier) {
+		return nil, errors.New("signed tree head failed verification")
+	}
+	return &sth, nil
+}
+
 func uploadToBlobStorage(ctx context.Context, bucket *blob.Bucket, lr *SignedAndUnsignedLogRoot) error {
 	b, err := json.Marshal(lr)
 	if err != nil {
@@ -0,0 +1,151 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+	"gocloud.dev/blob"
+
+	genclient "github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/pubkey"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/signer"
+	"github.com/sigstore/rekor/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+type tlogClient struct {
+	logInfo models.LogInfo
+}
+
+func (m *tlogClient) GetLogInfo(params *tlog.GetLogInfoParams, opts ...tlog.ClientOption) (*tlog.GetLogInfoOK, error) {
+	return &tlog.GetLogInfoOK{Payload: &m.logInfo}, nil
+}
+
+func (m *tlogClient) GetLogProof(params *tlog.GetLogProofParams, opts ...tlog.ClientOption) (*tlog.GetLogProofOK, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *tlogClient) SetTransport(transport runtime.ClientTransport) {}
+
+type pubkeyClient struct {
+	keys map[string]string
+}
+
+func (m *pubkeyClient) GetPublicKey(params *pubkey.GetPublicKeyParams, opts ...pubkey.ClientOption) (*pubkey.GetPublicKeyOK, error) {
+	k, ok := m.keys[swag.StringValue(params.TreeID)]
+	if !ok {
+		return nil, errors.New("unknown tree")
+	}
+	return &pubkey.GetPublicKeyOK{Payload: k}, nil
+}
+
+func (m *pubkeyClient) SetTransport(transport runtime.ClientTransport) {}
+
+func pemPublicKey(t *testing.T, s signature.Signer) string {
+	t.Helper()
+	pub, err := s.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(cryptoutils.PEMEncode(cryptoutils.PublicKeyPEMType, der))
+}
+
+func TestCheckShardsWithDifferentKeys(t *testing.T) {
+	ctx := context.Background()
+	activeSigner, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	shardSigner, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string]string{
+		"123": pemPublicKey(t, activeSigner),
+		"456": pemPublicKey(t, shardSigner),
+	}
+	activeSTH := signedCheckpoint(t, activeSigner, "rekor.example.com - 123", 10, "root")
+
+	tests := []struct {
+		name     string
+		shardSTH string
+		wantErr  bool
+	}{
+		{
+			name:     "each shard signed with its own key",
+			shardSTH: signedCheckpoint(t, shardSigner, "rekor.example.com - 456", 5, "shard root"),
+		},
+		{
+			name:     "inactive shard signed with the key of the active tree",
+			shardSTH: signedCheckpoint(t, activeSigner, "rekor.example.com - 456", 5, "shard root"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &genclient.Rekor{
+				Pubkey: &pubkeyClient{keys: keys},
+				Tlog: &tlogClient{logInfo: models.LogInfo{
+					TreeID:         swag.String("123"),
+					SignedTreeHead: swag.String(activeSTH),
+					InactiveShards: []*models.InactiveShardLogInfo{{
+						TreeID:         swag.String("456"),
+						SignedTreeHead: swag.String(tt.shardSTH),
+					}},
+				}},
+			}
+			bucket, err := blob.OpenBucket(ctx, "mem://")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer bucket.Close()
+			w := &watcher{
+				client:      c,
+				verifiers:   verify.NewTreeVerifiers(c),
+				bucket:      bucket,
+				stateObject: "state.json",
+				state:       watchState{Checkpoints: map[string]string{}},
+			}
+
+			// the second check verifies the recorded tree heads as well
+			for i := 0; i < 2; i++ {
+				_, err := w.check(ctx)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("check() = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if _, ok := w.state.Checkpoints["123"]; !ok {
+				t.Error("active tree was not verified")
+			}
+			if _, ok := w.state.Checkpoints["456"]; ok == tt.wantErr {
+				t.Errorf("inactive shard verified = %v, want %v", ok, !tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const adminTreesPath = "/api/v1/admin/trees"
+
+// AdminTree describes a tree of the Trillian log server
+type AdminTree struct {
+	TreeID int64  `json:"treeID"`
+	State  string `json:"state"`
+	// Shard is "active" or "inactive" for trees in the log ranges of the
+	// server, and empty for other trees
+	Shard    string `json:"shard,omitempty"`
+	TreeSize uint64 `json:"treeSize"`
+	// ShardLength is the length of an inactive shard in the sharding config
+	ShardLength int64  `json:"shardLength,omitempty"`
+	PublicKey   string `json:"publicKey,omitempty"`
+	// RootHash is the hex-encoded root hash of the tree
+	RootHash string `json:"rootHash,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// AdminTreeCheck is the result of checking an inactive shard against the
+// tree in the Trillian log server
+type AdminTreeCheck struct {
+	AdminTree
+	OK       bool     `json:"ok"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// AdminHandler serves the administrative API, which manages the trees of the
+// Trillian log server. Requests must carry the token as a bearer token.
+//
+//	GET  /api/v1/admin/trees              lists trees
+//	POST /api/v1/admin/trees/{id}/freeze  freezes a tree
+//	GET  /api/v1/admin/trees/{id}/check   checks a frozen tree against the sharding config
+func (a *API) AdminHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(adminTreesPath, a.listTreesHandler)
+	mux.HandleFunc(adminTreesPath+"/", a.treeHandler)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, ok := bearerToken(r)
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(auth), []byte(token)) != 1 {
+			adminError(w, http.StatusUnauthorized, "a valid bearer token is required")
+			return
+		}
+		if a.logAdminClient == nil {
+			adminError(w, http.StatusNotImplemented, "tree management requires the Trillian backend")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// bearerToken returns the token of the Authorization header, which must use
+// the Bearer scheme
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(auth, prefix), true
+}
+
+func adminError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
+
+func adminResponse(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Logger.Errorf("writing admin response: %v", err)
+	}
+}
+
+// grpcStatusCode returns the HTTP status for errors from the Trillian admin API
+func grpcStatusCode(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (a *API) listTreesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		adminError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	resp, err := a.logAdminClient.ListTrees(r.Context(), &trillian.ListTreesRequest{})
+	if err != nil {
+		adminError(w, grpcStatusCode(err), fmt.Sprintf("listing trees: %v", err))
+		return
+	}
+	state := a.state()
+	trees := []AdminTree{}
+	for _, t := range resp.Tree {
+		if t.TreeType != trillian.TreeType_LOG {
+			continue
+		}
+		trees = append(trees, a.adminTree(r, t, state))
+	}
+	adminResponse(w, trees)
+}
+
+func (a *API) treeHandler(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, adminTreesPath+"/"), "/")
+	if len(parts) != 2 {
+		adminError(w, http.StatusNotFound, "not found")
+		return
+	}
+	treeID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		adminError(w, http.StatusBadRequest, fmt.Sprintf("invalid tree ID %q", parts[0]))
+		return
+	}
+	switch {
+	case parts[1] == "freeze" && r.Method == http.MethodPost:
+		a.freezeTree(w, r, treeID)
+	case parts[1] == "check" && r.Method == http.MethodGet:
+		a.checkTree(w, r, treeID)
+	default:
+		adminError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// adminTree describes the tree, including its role in the log ranges
+func (a *API) adminTree(r *http.Request, t *trillian.Tree, state *logState) AdminTree {
+	ranges := state.ranges
+	tree := AdminTree{
+		TreeID: t.TreeId,
+		State:  t.TreeState.String(),
+	}
+	if t.TreeId == ranges.ActiveTreeID() {
+		tree.Shard = "active"
+	}
+	for _, s := range ranges.GetInactive() {
+		if s.TreeID == t.TreeId {
+			tree.Shard = "inactive"
+			tree.ShardLength = s.TreeLength
+		}
+	}
+	if tree.Shard != "" {
+		pk, err := ranges.PublicKey(state.signerFor(t.TreeId).pubkey, strconv.FormatInt(t.TreeId, 10))
+		if err == nil {
+			tree.PublicKey = pk
+		}
+	}
+	root, err := a.newLogClient(r.Context(), t.TreeId).root()
+	if err != nil {
+		tree.Error = err.Error()
+		return tree
+	}
+	tree.TreeSize = root.TreeSize
+	tree.RootHash = hex.EncodeToString(root.RootHash)
+	return tree
+}
+
+func (a *API) freezeTree(w http.ResponseWriter, r *http.Request, treeID int64) {
+	state := a.state()
+	if treeID == state.ranges.ActiveTreeID() {
+		adminError(w, http.StatusConflict, "the active tree can't be frozen, rotate to a new tree instead")
+		return
+	}
+	t, err := a.logAdminClient.GetTree(r.Context(), &trillian.GetTreeRequest{TreeId: treeID})
+	if err != nil {
+		adminError(w, grpcStatusCode(err), fmt.Sprintf("getting tree: %v", err))
+		return
+	}
+	if t.TreeState != trillian.TreeState_FROZEN {
+		if err := setTreeState(r.Context(), a.logAdminClient, treeID, trillian.TreeState_FROZEN); err != nil {
+			adminError(w, grpcStatusCode(err), err.Error())
+			return
+		}
+		log.ContextLogger(r.Context()).Infof("Froze tree %d", treeID)
+		t.TreeState = trillian.TreeState_FROZEN
+	}
+	adminResponse(w, a.adminTree(r, t, state))
+}
+
+// checkTree checks that an inactive shard is frozen, and that the size of the
+// tree is the length of the shard in the sharding config, as otherwise the
+// virtual indexes of entries would be wrong. If the sharding config has the
+// final checkpoint of the shard, the root of the tree must still match it.
+func (a *API) checkTree(w http.ResponseWriter, r *http.Request, treeID int64) {
+	state := a.state()
+	t, err := a.logAdminClient.GetTree(r.Context(), &trillian.GetTreeRequest{TreeId: treeID})
+	if err != nil {
+		adminError(w, grpcStatusCode(err), fmt.Sprintf("getting tree: %v", err))
+		return
+	}
+	check := AdminTreeCheck{AdminTree: a.adminTree(r, t, state)}
+	if check.Shard != "inactive" {
+		check.Problems = append(check.Problems, "tree is not an inactive shard in the sharding config")
+	}
+	if t.TreeState != trillian.TreeState_FROZEN {
+		check.Problems = append(check.Problems, fmt.Sprintf("tree is %s rather than FROZEN", t.TreeState))
+	}
+	if check.Error != "" {
+		check.Problems = append(check.Problems, fmt.Sprintf("getting root: %s", check.Error))
+	} else if check.Shard == "inactive" && int64(check.TreeSize) != check.ShardLength {
+		check.Problems = append(check.Problems, fmt.Sprintf("tree size %d doesn't match shard length %d", check.TreeSize, check.ShardLength))
+	}
+	for _, shard := range state.ranges.GetInactive() {
+		if shard.TreeID != treeID || shard.SignedCheckpoint == "" || check.Error != "" {
+			continue
+		}
+		var checkpoint util.SignedCheckpoint
+		if err := checkpoint.UnmarshalText([]byte(shard.SignedCheckpoint)); err != nil {
+			check.Problems = append(check.Problems, fmt.Sprintf("parsing final checkpoint: %v", err))
+		} else if hash := hex.EncodeToString(checkpoint.Hash); checkpoint.Size != check.TreeSize || hash != check.RootHash {
+			check.Problems = append(check.Problems, fmt.Sprintf("root hash %s at size %d doesn't match final checkpoint hash %s at size %d", check.RootHash, check.TreeSize, hash, checkpoint.Size))
+		}
+	}
+	check.OK = len(check.Problems) == 0
+	adminResponse(w, check)
+}
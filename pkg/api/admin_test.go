@@ -0,0 +1,225 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"github.com/spf13/viper"
+	"google.golang.org/genproto/protobuf/field_mask"
+
+	"github.com/sigstore/rekor/pkg/api"
+	"github.com/sigstore/rekor/pkg/faketrillian"
+	"github.com/sigstore/rekor/pkg/signer"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+func adminRequest(t *testing.T, url, method, token string, v interface{}) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK && v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// finalCheckpoint returns the checkpoint of the latest root of the tree signed
+// by s, quoted for the sharding config
+func finalCheckpoint(t *testing.T, f *faketrillian.Trillian, s signature.Signer, treeID int64) string {
+	t.Helper()
+	ctx := context.Background()
+	resp, err := f.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: treeID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
+		t.Fatal(err)
+	}
+	sc, err := util.CreateSignedCheckpoint(util.Checkpoint{Origin: fmt.Sprintf("rekor.test - %d", treeID), Size: root.TreeSize, Hash: root.RootHash})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sc.Sign("rekor.test", s, options.WithContext(ctx)); err != nil {
+		t.Fatal(err)
+	}
+	b, err := sc.SignedNote.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	quoted, err := json.Marshal(string(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(quoted)
+}
+
+func TestAdmin(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var trees []int64
+	for i := 0; i < 3; i++ {
+		treeID, err := f.CreateLog(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		trees = append(trees, treeID)
+	}
+	first, second, third := trees[0], trees[1], trees[2]
+	for i, treeID := range []int64{first, first, third} {
+		if _, err := f.QueueLeaf(ctx, &trillian.QueueLeafRequest{
+			LogId: treeID,
+			Leaf:  &trillian.LogLeaf{LeafValue: []byte(fmt.Sprintf("leaf %d", i))},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := f.UpdateTree(ctx, &trillian.UpdateTreeRequest{
+		Tree:       &trillian.Tree{TreeId: first, TreeState: trillian.TreeState_FROZEN},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"tree_state"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	pk, err := s.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pem, err := cryptoutils.MarshalPublicKeyToPEM(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := base64.StdEncoding.EncodeToString(pem)
+	config := filepath.Join(t.TempDir(), "sharding-config.yaml")
+	if err := os.WriteFile(config, []byte(fmt.Sprintf("- treeID: %d\n  treeLength: 2\n  encodedPublicKey: %s\n  signedCheckpoint: %s\n- treeID: %d\n  treeLength: 1\n  encodedPublicKey: %s\n  signedCheckpoint: %s\n",
+		first, key, finalCheckpoint(t, f, s, first), third, key, finalCheckpoint(t, f, s, third))), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("trillian_log_server.sharding_config", config)
+	defer viper.Set("trillian_log_server.sharding_config", "")
+	rekorAPI, _ := testServer(t, f, second, s)
+	// the third tree is still active in Trillian, and has grown since the
+	// server checked its length
+	if _, err := f.QueueLeaf(ctx, &trillian.QueueLeafRequest{
+		LogId: third,
+		Leaf:  &trillian.LogLeaf{LeafValue: []byte("leaf 3")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(rekorAPI.AdminHandler("secret"))
+	defer ts.Close()
+	treesURL := ts.URL + "/api/v1/admin/trees"
+
+	if code := adminRequest(t, treesURL, http.MethodGet, "", nil); code != http.StatusUnauthorized {
+		t.Errorf("expected request without token to be unauthorized, got %d", code)
+	}
+	if code := adminRequest(t, treesURL, http.MethodGet, "wrong", nil); code != http.StatusUnauthorized {
+		t.Errorf("expected request with wrong token to be unauthorized, got %d", code)
+	}
+	req, err := http.NewRequest(http.MethodGet, treesURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected token without the Bearer scheme to be unauthorized, got %d", resp.StatusCode)
+	}
+
+	var list []api.AdminTree
+	if code := adminRequest(t, treesURL, http.MethodGet, "secret", &list); code != http.StatusOK {
+		t.Fatalf("listing trees: %d", code)
+	}
+	byID := map[int64]api.AdminTree{}
+	for _, tree := range list {
+		byID[tree.TreeID] = tree
+	}
+	if tree := byID[first]; tree.State != "FROZEN" || tree.Shard != "inactive" || tree.TreeSize != 2 || tree.ShardLength != 2 || tree.PublicKey == "" {
+		t.Errorf("unexpected inactive tree %+v", tree)
+	}
+	if tree := byID[second]; tree.State != "ACTIVE" || tree.Shard != "active" || tree.TreeSize != 0 || tree.PublicKey == "" {
+		t.Errorf("unexpected active tree %+v", tree)
+	}
+
+	var check api.AdminTreeCheck
+	if code := adminRequest(t, fmt.Sprintf("%s/%d/check", treesURL, first), http.MethodGet, "secret", &check); code != http.StatusOK {
+		t.Fatalf("checking tree: %d", code)
+	}
+	if !check.OK {
+		t.Errorf("expected frozen tree to match config, got %v", check.Problems)
+	}
+	if code := adminRequest(t, fmt.Sprintf("%s/%d/check", treesURL, third), http.MethodGet, "secret", &check); code != http.StatusOK {
+		t.Fatalf("checking tree: %d", code)
+	}
+	if check.OK || len(check.Problems) != 3 {
+		t.Errorf("expected active tree with the wrong length and root to fail, got %v", check.Problems)
+	}
+
+	if code := adminRequest(t, fmt.Sprintf("%s/%d/freeze", treesURL, second), http.MethodPost, "secret", nil); code != http.StatusConflict {
+		t.Errorf("expected freezing the active tree to conflict, got %d", code)
+	}
+	var frozen api.AdminTree
+	if code := adminRequest(t, fmt.Sprintf("%s/%d/freeze", treesURL, third), http.MethodPost, "secret", &frozen); code != http.StatusOK {
+		t.Fatalf("freezing tree: %d", code)
+	}
+	if frozen.State != "FROZEN" {
+		t.Errorf("unexpected frozen tree %+v", frozen)
+	}
+	tree, err := f.GetTree(ctx, &trillian.GetTreeRequest{TreeId: third})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.TreeState != trillian.TreeState_FROZEN {
+		t.Errorf("expected tree to be frozen, got %s", tree.TreeState)
+	}
+	if code := adminRequest(t, treesURL+"/1234/freeze", http.MethodPost, "secret", nil); code != http.StatusNotFound {
+		t.Errorf("expected unknown tree to not be found, got %d", code)
+	}
+}
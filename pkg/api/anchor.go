@@ -0,0 +1,149 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+// checkpointAnchor timestamps checkpoints of the active tree with an external
+// RFC 3161 timestamping authority, and stores the tokens in a bucket so that
+// they are shared between replicas and survive restarts
+type checkpointAnchor struct {
+	tsaURL string
+	client *http.Client
+	bucket *blob.Bucket
+	// api serves the tree whose checkpoints are timestamped
+	api *API
+
+	mu         sync.RWMutex
+	treeID     int64
+	treeSize   uint64
+	checkpoint *models.CheckpointTimestamp
+}
+
+func newCheckpointAnchor(ctx context.Context, tsaURL, bucketURL string) (*checkpointAnchor, error) {
+	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return nil, fmt.Errorf("opening checkpoint timestamp bucket: %w", err)
+	}
+	return &checkpointAnchor{
+		tsaURL: tsaURL,
+		client: &http.Client{Timeout: 30 * time.Second},
+		bucket: bucket,
+	}, nil
+}
+
+// latest returns the most recently timestamped checkpoint, or nil if none has
+// been timestamped yet
+func (a *checkpointAnchor) latest() *models.CheckpointTimestamp {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.checkpoint
+}
+
+// run timestamps the latest checkpoint of the active tree every interval
+func (a *checkpointAnchor) run(ctx context.Context, interval time.Duration) {
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		if err := a.anchor(ctx); err != nil {
+			log.Logger.Warnf("error timestamping checkpoint: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+func checkpointTimestampKey(treeID int64, treeSize uint64) string {
+	return fmt.Sprintf("%d/%d.json", treeID, treeSize)
+}
+
+// anchor timestamps the latest checkpoint of the active tree, unless it has
+// not grown since the last checkpoint was timestamped. If another replica
+// already timestamped a checkpoint for the same tree size, its token is used.
+func (a *checkpointAnchor) anchor(ctx context.Context) error {
+	state := a.api.state()
+	treeID := state.ranges.ActiveTreeID()
+	tc := a.api.NewLogClientFromTreeID(ctx, treeID)
+	root, err := tc.root()
+	if err != nil {
+		return fmt.Errorf("getting log root: %w", err)
+	}
+	if root.TreeSize == 0 {
+		return nil
+	}
+	a.mu.RLock()
+	current := a.treeID == treeID && a.treeSize == root.TreeSize
+	a.mu.RUnlock()
+	if current {
+		return nil
+	}
+
+	key := checkpointTimestampKey(treeID, root.TreeSize)
+	ct := &models.CheckpointTimestamp{}
+	b, err := a.bucket.ReadAll(ctx, key)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(b, ct); err != nil {
+			return fmt.Errorf("unmarshalling stored checkpoint timestamp %s: %w", key, err)
+		}
+	case gcerrors.Code(err) == gcerrors.NotFound:
+		sc, err := signCheckpoint(ctx, state.signerFor(treeID), a.api.hostname, &root, treeID)
+		if err != nil {
+			return fmt.Errorf("signing checkpoint: %w", err)
+		}
+		tsr, err := util.RequestTimestamp(ctx, a.client, a.tsaURL, sc)
+		if err != nil {
+			return fmt.Errorf("requesting timestamp: %w", err)
+		}
+		b64 := strfmt.Base64(tsr)
+		ct.SignedCheckpoint = swag.String(string(sc))
+		ct.TimestampResponse = &b64
+		b, err := json.Marshal(ct)
+		if err != nil {
+			return err
+		}
+		if err := a.bucket.WriteAll(ctx, key, b, nil); err != nil {
+			return fmt.Errorf("storing checkpoint timestamp %s: %w", key, err)
+		}
+		log.Logger.Infof("Timestamped checkpoint for tree %d at size %d", treeID, root.TreeSize)
+	default:
+		return fmt.Errorf("reading checkpoint timestamp %s: %w", key, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.treeID, a.treeSize, a.checkpoint = treeID, root.TreeSize, ct
+	return nil
+}
@@ -17,10 +17,14 @@ package api
 
 import (
 	"context"
-	"crypto/sha256"
 	"crypto/x509"
-	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/trillian"
@@ -29,13 +33,13 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/sigstore/rekor/pkg/embedded"
 	"github.com/sigstore/rekor/pkg/log"
 	"github.com/sigstore/rekor/pkg/sharding"
 	"github.com/sigstore/rekor/pkg/signer"
 	"github.com/sigstore/rekor/pkg/storage"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature"
-	"github.com/sigstore/sigstore/pkg/signature/options"
 )
 
 func dial(ctx context.Context, rpcServer string) (*grpc.ClientConn, error) {
@@ -52,97 +56,364 @@ func dial(ctx context.Context, rpcServer string) (*grpc.ClientConn, error) {
 }
 
 type API struct {
-	logClient  trillian.TrillianLogClient
-	logID      int64
-	logRanges  sharding.LogRanges
+	logClient      trillian.TrillianLogClient   // nil unless using the Trillian backend
+	logAdminClient trillian.TrillianAdminClient // nil unless using the Trillian backend
+	// newLogClient returns a client for the tree with the given ID
+	newLogClient func(context.Context, int64) LogClient
+	// logState holds the *logState with the active and inactive trees and
+	// their signers, which is replaced as a whole when the log is rotated to
+	// a new tree
+	logState atomic.Value
+	// config holds the settings that can be reloaded
+	config     atomic.Value
+	reload     chan struct{} // requests an immediate reload of the config
+	reloader   *configReloader
 	pubkey     string // PEM encoded public key
 	pubkeyHash string // SHA256 hash of DER-encoded public key
 	signer     signature.Signer
+	hostname   string // public hostname, the origin of checkpoints and timestamp notes
+	// timestamping fields
+	certChain    []*x509.Certificate // timestamping certificate chain, empty if timestamping is disabled
+	certChainPem string              // PEM encoded timestamping certificate chain
+	certChainRef *url.URL            // public URL of the timestamping certificate chain, nil if unknown
+	anchor       *checkpointAnchor   // timestamps checkpoints with an external TSA, nil if disabled
+	// clients set by ConfigureAPI
+	redisClient   radix.Client               // nil unless the retrieve API is enabled
+	storageClient storage.AttestationStorage // nil unless attestation storage is enabled
+	// stop stops the background work started by ConfigureAPI
+	stop context.CancelFunc
+	// closers are the connections and the embedded log opened by NewAPI,
+	// which are closed by Close
+	closers []io.Closer
 }
 
-func NewAPI(treeID uint) (*API, error) {
-	logRPCServer := fmt.Sprintf("%s:%d",
-		viper.GetString("trillian_log_server.address"),
-		viper.GetUint("trillian_log_server.port"))
-	ctx := context.Background()
-	tConn, err := dial(ctx, logRPCServer)
-	if err != nil {
-		return nil, fmt.Errorf("dial: %w", err)
+// Close stops the background work of the API and closes the connections and
+// the embedded log it opened. Dependencies passed in as options are not closed.
+func (a *API) Close() {
+	if a.stop != nil {
+		a.stop()
 	}
-	logAdminClient := trillian.NewTrillianAdminClient(tConn)
-	logClient := trillian.NewTrillianLogClient(tConn)
+	closeAll(a.closers)
+}
 
-	shardingConfig := viper.GetString("trillian_log_server.sharding_config")
-	ranges, err := sharding.NewLogRanges(ctx, logClient, shardingConfig, treeID)
-	if err != nil {
-		return nil, fmt.Errorf("unable get sharding details from sharding config: %w", err)
+func closeAll(closers []io.Closer) {
+	for _, c := range closers {
+		if err := c.Close(); err != nil {
+			log.Logger.Warnf("closing connection: %v", err)
+		}
+	}
+}
+
+const (
+	// TrillianBackend stores the log in trees of a Trillian log server
+	TrillianBackend = "trillian"
+	// EmbeddedBackend stores the log in a single tree within the server process
+	EmbeddedBackend = "embedded"
+
+	// FileShardingStore reads the sharding config from the file set by the
+	// sharding_config flag, which must be the same for every replica
+	FileShardingStore = "file"
+	// RedisShardingStore stores the sharding config in the Redis index
+	RedisShardingStore = "redis"
+)
+
+// Option overrides a dependency of the API that is otherwise configured from
+// flags, such as to inject fakes in tests
+type Option func(*apiOptions)
+
+type apiOptions struct {
+	logClient      trillian.TrillianLogClient
+	logAdminClient trillian.TrillianAdminClient
+	signer         signature.Signer
+	shardingStore  sharding.Store
+}
+
+// WithTrillianClients uses the given clients instead of dialing the Trillian
+// log server
+func WithTrillianClients(logClient trillian.TrillianLogClient, logAdminClient trillian.TrillianAdminClient) Option {
+	return func(o *apiOptions) {
+		o.logClient = logClient
+		o.logAdminClient = logAdminClient
+	}
+}
+
+// WithShardingStore reads the sharding config from the given store instead of
+// the configured one
+func WithShardingStore(store sharding.Store) Option {
+	return func(o *apiOptions) {
+		o.shardingStore = store
+	}
+}
+
+// WithSigner uses the given signer instead of the configured one
+func WithSigner(s signature.Signer) Option {
+	return func(o *apiOptions) {
+		o.signer = s
+	}
+}
+
+func NewAPI(treeID uint, opts ...Option) (_ *API, err error) {
+	o := &apiOptions{}
+	for _, opt := range opts {
+		opt(o)
 	}
+	ctx := context.Background()
+	// connections opened here are closed if the API can't be created
+	var closers []io.Closer
+	defer func() {
+		if err != nil {
+			closeAll(closers)
+		}
+	}()
+	var (
+		logClient      trillian.TrillianLogClient
+		logAdminClient trillian.TrillianAdminClient
+		newLogClient   func(context.Context, int64) LogClient
+		shardingStore  sharding.Store
+		ranges         sharding.LogRanges
+		tid            int64
+	)
+	switch backend := viper.GetString("backend"); backend {
+	case TrillianBackend, "":
+		logClient = o.logClient
+		logAdminClient = o.logAdminClient
+		if logClient == nil {
+			logRPCServer := fmt.Sprintf("%s:%d",
+				viper.GetString("trillian_log_server.address"),
+				viper.GetUint("trillian_log_server.port"))
+			tConn, err := dial(ctx, logRPCServer)
+			if err != nil {
+				return nil, fmt.Errorf("dial: %w", err)
+			}
+			closers = append(closers, tConn)
+			logAdminClient = trillian.NewTrillianAdminClient(tConn)
+			logClient = trillian.NewTrillianLogClient(tConn)
+		}
+		newLogClient = trillianClients(logClient)
 
-	tid := int64(treeID)
-	if tid == 0 {
-		log.Logger.Info("No tree ID specified, attempting to create a new tree")
-		t, err := createAndInitTree(ctx, logAdminClient, logClient)
+		var err error
+		shardingStore = o.shardingStore
+		if shardingStore == nil {
+			shardingStore, err = NewShardingStore(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if c, ok := shardingStore.(io.Closer); ok {
+				closers = append(closers, c)
+			}
+		}
+		if shardingStore != nil {
+			ranges, err = sharding.NewLogRangesFromStore(ctx, logClient, shardingStore, treeID)
+			if err != nil {
+				return nil, fmt.Errorf("unable get sharding details from sharding config: %w", err)
+			}
+		} else {
+			log.Logger.Info("No sharding config specified, skipping init of logRange map")
+		}
+
+		tid = ranges.ActiveTreeID()
+		if tid == 0 {
+			tid = int64(treeID)
+		}
+		if tid == 0 {
+			log.Logger.Info("No tree ID specified, attempting to create a new tree")
+			t, err := createAndInitTree(ctx, logAdminClient, logClient, "")
+			if err != nil {
+				return nil, fmt.Errorf("create and init tree: %w", err)
+			}
+			tid = t.TreeId
+		}
+	case EmbeddedBackend:
+		if o.shardingStore != nil || viper.GetString("trillian_log_server.sharding_config") != "" ||
+			viper.GetString("trillian_log_server.sharding_store") == RedisShardingStore {
+			return nil, errors.New("sharding is not supported by the embedded backend")
+		}
+		path := viper.GetString("embedded_log.path")
+		embeddedLog, err := embedded.Open(path)
 		if err != nil {
-			return nil, fmt.Errorf("create and init tree: %w", err)
+			return nil, fmt.Errorf("opening embedded log: %w", err)
+		}
+		closers = append(closers, embeddedLog)
+		if path == "" {
+			log.Logger.Warn("No embedded log path specified, entries will be lost when the server stops")
 		}
-		tid = t.TreeId
+		newLogClient = embeddedClients(embeddedLog)
+		tid = embeddedLog.TreeID()
+	default:
+		return nil, fmt.Errorf("unknown backend %q", backend)
 	}
 	log.Logger.Infof("Starting Rekor server with active tree %v", tid)
 	ranges.SetActive(tid)
 
-	rekorSigner, err := signer.New(ctx, viper.GetString("rekor_server.signer"))
+	rekorSigner := o.signer
+	if rekorSigner == nil {
+		var err error
+		rekorSigner, err = signer.New(ctx, viper.GetString("rekor_server.signer"))
+		if err != nil {
+			return nil, fmt.Errorf("getting new signer: %w", err)
+		}
+	}
+	active, err := newLogSigner(ctx, rekorSigner)
 	if err != nil {
-		return nil, fmt.Errorf("getting new signer: %w", err)
+		return nil, err
 	}
-	pk, err := rekorSigner.PublicKey(options.WithContext(ctx))
+	signers, err := shardSigners(ctx, ranges, nil)
 	if err != nil {
-		return nil, fmt.Errorf("getting public key: %w", err)
+		return nil, err
 	}
-	b, err := x509.MarshalPKIXPublicKey(pk)
+
+	certChain, err := timestampCertChain(ctx, rekorSigner)
 	if err != nil {
-		return nil, fmt.Errorf("marshalling public key: %w", err)
+		return nil, fmt.Errorf("loading timestamping certificate chain: %w", err)
+	}
+	var certChainPem []byte
+	if len(certChain) > 0 {
+		certChainPem, err = cryptoutils.MarshalCertificatesToPEM(certChain)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling timestamping certificate chain: %w", err)
+		}
+	} else {
+		log.Logger.Info("No timestamping certificate chain configured, timestamping is disabled")
+	}
+	var certChainRef *url.URL
+	if publicURL := viper.GetString("rekor_server.public_url"); publicURL != "" && len(certChain) > 0 {
+		certChainRef, err = url.Parse(publicURL)
+		if err != nil || !certChainRef.IsAbs() {
+			return nil, fmt.Errorf("rekor_server.public_url must be an absolute URL: %q", publicURL)
+		}
+		certChainRef.Path = strings.TrimSuffix(certChainRef.Path, "/") + "/api/v1/log/timestamp/certchain"
 	}
-	pubkeyHashBytes := sha256.Sum256(b)
 
-	pubkey := cryptoutils.PEMEncode(cryptoutils.PublicKeyPEMType, b)
+	var anchor *checkpointAnchor
+	if tsaURL := viper.GetString("checkpoint_tsa_url"); tsaURL != "" {
+		bucketURL := viper.GetString("checkpoint_timestamp_bucket")
+		if bucketURL == "" {
+			return nil, errors.New("checkpoint_timestamp_bucket must be set when timestamping checkpoints")
+		}
+		anchor, err = newCheckpointAnchor(ctx, tsaURL, bucketURL)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	return &API{
+	a := &API{
 		// Transparency Log Stuff
-		logClient: logClient,
-		logID:     tid,
-		logRanges: ranges,
+		logClient:      logClient,
+		logAdminClient: logAdminClient,
+		newLogClient:   newLogClient,
+		reload:         make(chan struct{}, 1),
+		reloader:       newConfigReloader(ctx, shardingStore),
 		// Signing/verifying fields
-		pubkey:     string(pubkey),
-		pubkeyHash: hex.EncodeToString(pubkeyHashBytes[:]),
+		pubkey:     active.pubkey,
+		pubkeyHash: active.pubkeyHash,
 		signer:     rekorSigner,
-	}, nil
+		hostname:   viper.GetString("rekor_server.hostname"),
+		// Timestamping fields
+		certChain:    certChain,
+		certChainPem: string(certChainPem),
+		certChainRef: certChainRef,
+		anchor:       anchor,
+		closers:      closers,
+	}
+	config := settingsFrom(viper.GetViper())
+	config.tlogID = treeID
+	if anchor != nil {
+		anchor.api = a
+	}
+	a.config.Store(config)
+	a.logState.Store(&logState{ranges: ranges, active: active, signers: signers})
+	return a, nil
 }
 
-var (
-	api           *API
-	redisClient   radix.Client
-	storageClient storage.AttestationStorage
-)
+// state returns the current trees of the log and their signers. Handlers
+// should take a single snapshot per request, as the state may change between
+// calls.
+func (a *API) state() *logState {
+	return a.logState.Load().(*logState)
+}
+
+// ranges returns the current active and inactive trees, for handlers that
+// don't sign anything for them
+func (a *API) ranges() sharding.LogRanges {
+	return a.state().ranges
+}
 
-func ConfigureAPI(treeID uint) {
+// NewShardingStore returns the store of the sharding config set by flags, or
+// nil if the log isn't sharded
+func NewShardingStore(ctx context.Context) (sharding.Store, error) {
+	switch store := viper.GetString("trillian_log_server.sharding_store"); store {
+	case FileShardingStore, "":
+		if path := viper.GetString("trillian_log_server.sharding_config"); path != "" {
+			return sharding.FileStore(path), nil
+		}
+		return nil, nil
+	case RedisShardingStore:
+		client, err := radix.PoolConfig{}.New(ctx, "tcp", fmt.Sprintf("%v:%v", viper.GetString("redis_server.address"), viper.GetUint64("redis_server.port")))
+		if err != nil {
+			return nil, fmt.Errorf("connecting to redis for the sharding config: %w", err)
+		}
+		return sharding.RedisStore{Client: client}, nil
+	default:
+		return nil, fmt.Errorf("unknown sharding store %q", store)
+	}
+}
+
+// timestampCertChain loads the configured timestamping certificate chain for
+// the signer, or generates one when using the memory signer
+func timestampCertChain(ctx context.Context, rekorSigner signature.Signer) ([]*x509.Certificate, error) {
+	chainPath := viper.GetString("rekor_server.timestamp_chain")
+	if chainPath == "" {
+		if viper.GetString("rekor_server.signer") == signer.MemoryScheme {
+			return signer.NewTimestampingCertWithChain(ctx, rekorSigner)
+		}
+		return nil, nil
+	}
+	b, err := os.ReadFile(chainPath)
+	if err != nil {
+		return nil, err
+	}
+	certChain, err := cryptoutils.UnmarshalCertificatesFromPEM(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := signer.VerifyTimestampingCertChain(ctx, certChain, rekorSigner); err != nil {
+		return nil, err
+	}
+	return certChain, nil
+}
+
+// ConfigureAPI creates the API, connects to the index and attestation storage
+// if they are enabled, and starts the background work of the API, which is
+// stopped by Close
+func ConfigureAPI(treeID uint, opts ...Option) *API {
 	cfg := radix.PoolConfig{}
-	var err error
 
-	api, err = NewAPI(treeID)
+	a, err := NewAPI(treeID, opts...)
 	if err != nil {
 		log.Logger.Panic(err)
 	}
+	ctx, stop := context.WithCancel(context.Background())
+	a.stop = stop
 	if viper.GetBool("enable_retrieve_api") {
-		redisClient, err = cfg.New(context.Background(), "tcp", fmt.Sprintf("%v:%v", viper.GetString("redis_server.address"), viper.GetUint64("redis_server.port")))
+		a.redisClient, err = cfg.New(context.Background(), "tcp", fmt.Sprintf("%v:%v", viper.GetString("redis_server.address"), viper.GetUint64("redis_server.port")))
 		if err != nil {
 			log.Logger.Panic("failure connecting to redis instance: ", err)
 		}
+		a.closers = append(a.closers, a.redisClient)
 	}
 
 	if viper.GetBool("enable_attestation_storage") {
-		storageClient, err = storage.NewAttestationStorage()
+		a.storageClient, err = storage.NewAttestationStorage()
 		if err != nil {
 			log.Logger.Panic(err)
 		}
 	}
+
+	go a.watchConfig(ctx, viper.GetDuration("config_poll_interval"))
+
+	if a.anchor != nil {
+		log.Logger.Infof("Timestamping checkpoints with %s", viper.GetString("checkpoint_tsa_url"))
+		go a.anchor.run(ctx, viper.GetDuration("checkpoint_timestamp_interval"))
+	}
+	return a
 }
@@ -0,0 +1,945 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/trillian"
+	radix "github.com/mediocregopher/radix/v4"
+	"github.com/mediocregopher/radix/v4/resp/resp3"
+	"github.com/spf13/viper"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/genproto/protobuf/field_mask"
+
+	"github.com/sigstore/rekor/pkg/api"
+	"github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/rekor/pkg/faketrillian"
+	generatedclient "github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/generated/client/pubkey"
+	"github.com/sigstore/rekor/pkg/generated/client/timestamp"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/restapi"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations"
+	"github.com/sigstore/rekor/pkg/sharding"
+	"github.com/sigstore/rekor/pkg/signer"
+	"github.com/sigstore/rekor/pkg/types"
+	_ "github.com/sigstore/rekor/pkg/types/hashedrekord/v0.0.1"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/rekor/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// testServer serves the API for the given active tree of the fake Trillian
+func testServer(t *testing.T, f *faketrillian.Trillian, treeID int64, s signature.Signer, opts ...api.Option) (*api.API, *generatedclient.Rekor) {
+	t.Helper()
+	viper.Set("rekor_server.hostname", "rekor.test")
+	a := api.ConfigureAPI(uint(treeID), append([]api.Option{api.WithTrillianClients(f, f), api.WithSigner(s)}, opts...)...)
+	t.Cleanup(a.Close)
+
+	doc, err := loads.Embedded(restapi.SwaggerJSON, restapi.FlatSwaggerJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := restapi.NewServer(operations.NewRekorServerAPI(doc))
+	server.ConfigureRekorAPI(a)
+	ts := httptest.NewServer(server.GetHandler())
+	t.Cleanup(ts.Close)
+
+	c, err := client.GetRekorClient(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a, c
+}
+
+// hashedRekord returns a new signed hashedrekord entry
+func hashedRekord(t *testing.T) models.ProposedEntry {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(fmt.Sprintf("artifact %d", time.Now().UnixNano())))
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := cryptoutils.MarshalPublicKeyToPEM(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &models.Hashedrekord{
+		APIVersion: swag.String("0.0.1"),
+		Spec: models.HashedrekordV001Schema{
+			Data: &models.HashedrekordV001SchemaData{
+				Hash: &models.HashedrekordV001SchemaDataHash{
+					Algorithm: swag.String(models.HashedrekordV001SchemaDataHashAlgorithmSha256),
+					Value:     swag.String(hex.EncodeToString(digest[:])),
+				},
+			},
+			Signature: &models.HashedrekordV001SchemaSignature{
+				Content: sig,
+				PublicKey: &models.HashedrekordV001SchemaSignaturePublicKey{
+					Content: pub,
+				},
+			},
+		},
+	}
+}
+
+// createEntry uploads the entry and verifies the returned log entry
+func createEntry(t *testing.T, c *generatedclient.Rekor, v signature.Verifier, pe models.ProposedEntry) (string, models.LogEntryAnon) {
+	t.Helper()
+	resp, err := c.Entries.CreateLogEntry(entries.NewCreateLogEntryParams().WithProposedEntry(pe))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for uuid, e := range resp.Payload {
+		if err := verify.VerifySignedEntryTimestamp(context.Background(), &e, v); err != nil {
+			t.Fatalf("verifying signed entry timestamp: %v", err)
+		}
+		return uuid, e
+	}
+	t.Fatal("no entry returned")
+	return "", models.LogEntryAnon{}
+}
+
+// getEntry fetches the entry by its UUID, or its log index if the UUID is
+// empty, and verifies it
+func getEntry(t *testing.T, c *generatedclient.Rekor, v signature.Verifier, uuid string, index int64) models.LogEntryAnon {
+	t.Helper()
+	var payload models.LogEntry
+	if uuid != "" {
+		resp, err := c.Entries.GetLogEntryByUUID(entries.NewGetLogEntryByUUIDParams().WithEntryUUID(uuid))
+		if err != nil {
+			t.Fatal(err)
+		}
+		payload = resp.Payload
+	} else {
+		resp, err := c.Entries.GetLogEntryByIndex(entries.NewGetLogEntryByIndexParams().WithLogIndex(index))
+		if err != nil {
+			t.Fatal(err)
+		}
+		payload = resp.Payload
+	}
+	for _, e := range payload {
+		if err := verify.VerifyLogEntry(context.Background(), &e, v); err != nil {
+			t.Fatalf("verifying log entry: %v", err)
+		}
+		return e
+	}
+	t.Fatal("no entry returned")
+	return models.LogEntryAnon{}
+}
+
+func TestEntries(t *testing.T) {
+	ctx := context.Background()
+	// leaves are integrated after a delay, as with a Trillian log signer
+	f := faketrillian.New(10 * time.Millisecond)
+	treeID, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, c := testServer(t, f, treeID, s)
+
+	var uuids []string
+	var proposed []models.ProposedEntry
+	for i := 0; i < 3; i++ {
+		pe := hashedRekord(t)
+		uuid, e := createEntry(t, c, s, pe)
+		if *e.LogIndex != int64(i) {
+			t.Errorf("expected log index %d, got %d", i, *e.LogIndex)
+		}
+		uuids = append(uuids, uuid)
+		proposed = append(proposed, pe)
+	}
+
+	// a duplicate entry is rejected
+	_, err = c.Entries.CreateLogEntry(entries.NewCreateLogEntryParams().WithProposedEntry(proposed[1]))
+	conflict := &entries.CreateLogEntryConflict{}
+	if !errors.As(err, &conflict) {
+		t.Errorf("expected conflict, got %v", err)
+	}
+
+	for i, uuid := range uuids {
+		if e := getEntry(t, c, s, uuid, 0); *e.LogIndex != int64(i) {
+			t.Errorf("expected log index %d for %s, got %d", i, uuid, *e.LogIndex)
+		}
+		if e := getEntry(t, c, s, "", int64(i)); *e.LogIndex != int64(i) {
+			t.Errorf("expected log index %d, got %d", i, *e.LogIndex)
+		}
+	}
+	if _, err := c.Entries.GetLogEntryByIndex(entries.NewGetLogEntryByIndexParams().WithLogIndex(3)); err == nil {
+		t.Error("expected entry beyond the log to not be found")
+	}
+
+	query := &models.SearchLogQuery{
+		EntryUUIDs: []string{uuids[0]},
+		LogIndexes: []*int64{swag.Int64(1)},
+	}
+	query.SetEntries([]models.ProposedEntry{proposed[2]})
+	search, err := c.Entries.SearchLogQuery(entries.NewSearchLogQueryParams().WithEntry(query))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found []int64
+	for _, le := range search.Payload {
+		for _, e := range le {
+			e := e
+			if err := verify.VerifyLogEntry(ctx, &e, s); err != nil {
+				t.Errorf("verifying log entry: %v", err)
+			}
+			found = append(found, *e.LogIndex)
+		}
+	}
+	if len(found) != 3 {
+		t.Errorf("expected 3 entries from search, got %v", found)
+	}
+
+	// the checkpoints of the tree at two sizes are consistent
+	info, err := c.Tlog.GetLogInfo(tlog.NewGetLogInfoParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *info.Payload.TreeSize != 3 || *info.Payload.TreeID != fmt.Sprint(treeID) {
+		t.Fatalf("unexpected log info %+v", info.Payload)
+	}
+	oldRoot, err := hex.DecodeString(*info.Payload.RootHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createEntry(t, c, s, hashedRekord(t))
+	info, err = c.Tlog.GetLogInfo(tlog.NewGetLogInfoParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRoot, err := hex.DecodeString(*info.Payload.RootHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proofResp, err := c.Tlog.GetLogProof(tlog.NewGetLogProofParams().WithFirstSize(swag.Int64(3)).WithLastSize(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hashes [][]byte
+	for _, h := range proofResp.Payload.Hashes {
+		b, err := hex.DecodeString(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashes = append(hashes, b)
+	}
+	if err := proof.VerifyConsistency(rfc6962.DefaultHasher, 3, 4, hashes, oldRoot, newRoot); err != nil {
+		t.Errorf("verifying consistency: %v", err)
+	}
+	if _, err := c.Tlog.GetLogProof(tlog.NewGetLogProofParams().WithFirstSize(swag.Int64(3)).WithLastSize(5)); err == nil {
+		t.Error("expected proof beyond the tree size to fail")
+	}
+}
+
+func TestSeparateServers(t *testing.T) {
+	ctx := context.Background()
+	var clients []*generatedclient.Rekor
+	var signers []signature.SignerVerifier
+	for i := 0; i < 2; i++ {
+		f := faketrillian.New(0)
+		treeID, err := f.CreateLog(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := signer.NewMemory()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, c := testServer(t, f, treeID, s)
+		clients = append(clients, c)
+		signers = append(signers, s)
+	}
+
+	// each server writes to its own fake, and signs with its own key
+	createEntry(t, clients[0], signers[0], hashedRekord(t))
+	for i, c := range clients {
+		info, err := c.Tlog.GetLogInfo(tlog.NewGetLogInfoParams())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := int64(1 - i); *info.Payload.TreeSize != want {
+			t.Errorf("server %d: expected tree size %d, got %d", i, want, *info.Payload.TreeSize)
+		}
+		var sth util.SignedCheckpoint
+		if err := sth.UnmarshalText([]byte(*info.Payload.SignedTreeHead)); err != nil {
+			t.Fatal(err)
+		}
+		if !sth.Verify(signers[i]) {
+			t.Errorf("server %d: expected the checkpoint to be signed by its own key", i)
+		}
+	}
+}
+
+func TestTimestampNote(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	treeID, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	imprint := "sha256:" + strings.Repeat("0", 64)
+
+	tests := []struct {
+		name         string
+		signer       string
+		publicURL    string
+		certChainRef string
+	}{
+		{
+			name: "no timestamping certificate chain",
+		},
+		{
+			name:   "no public URL",
+			signer: signer.MemoryScheme,
+		},
+		{
+			name:         "certificate chain served at the public URL",
+			signer:       signer.MemoryScheme,
+			publicURL:    "http://rekor.example.com:3000/",
+			certChainRef: "http://rekor.example.com:3000/api/v1/log/timestamp/certchain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the memory signer generates a timestamping certificate chain
+			viper.Set("rekor_server.signer", tt.signer)
+			defer viper.Set("rekor_server.signer", "")
+			viper.Set("rekor_server.public_url", tt.publicURL)
+			defer viper.Set("rekor_server.public_url", "")
+			_, c := testServer(t, f, treeID, s)
+			// the origin is the hostname the API was configured with
+			viper.Set("rekor_server.hostname", "other.test")
+			defer viper.Set("rekor_server.hostname", "rekor.test")
+
+			nonce := strfmt.Base64("nonce")
+			resp, err := c.Timestamp.GetTimestampNote(timestamp.NewGetTimestampNoteParams().WithRequest(&models.TimestampNoteRequest{
+				MessageImprint: swag.String(imprint),
+				Nonce:          &nonce,
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			stn := &util.SignedTimestampNote{}
+			if err := stn.UnmarshalText([]byte(*resp.Payload.SignedTimestampNote)); err != nil {
+				t.Fatal(err)
+			}
+			if err := verify.VerifyTimestampNote(stn, s, "rekor.test", imprint, nonce); err != nil {
+				t.Fatal(err)
+			}
+			certChainRef := ""
+			if stn.CertChainRef != nil {
+				certChainRef = stn.CertChainRef.String()
+			}
+			if certChainRef != tt.certChainRef {
+				t.Errorf("expected cert chain reference %q, got %q", tt.certChainRef, certChainRef)
+			}
+		})
+	}
+}
+
+func TestShards(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, c := testServer(t, f, first, s)
+	var uuids []string
+	for i := 0; i < 2; i++ {
+		uuid, _ := createEntry(t, c, s, hashedRekord(t))
+		uuids = append(uuids, uuid)
+	}
+
+	// freeze the first tree and make it an inactive shard
+	if _, err := f.UpdateTree(ctx, &trillian.UpdateTreeRequest{
+		Tree:       &trillian.Tree{TreeId: first, TreeState: trillian.TreeState_FROZEN},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"tree_state"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	second, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := filepath.Join(t.TempDir(), "sharding-config.yaml")
+	if err := os.WriteFile(config, []byte(fmt.Sprintf("- treeID: %d\n", first)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("trillian_log_server.sharding_config", config)
+	defer viper.Set("trillian_log_server.sharding_config", "")
+	_, c = testServer(t, f, second, s)
+
+	// entries in the active shard follow those in the inactive shard
+	uuid, e := createEntry(t, c, s, hashedRekord(t))
+	if *e.LogIndex != 2 {
+		t.Errorf("expected log index 2, got %d", *e.LogIndex)
+	}
+	uuids = append(uuids, uuid)
+	for i, uuid := range uuids {
+		if e := getEntry(t, c, s, uuid, 0); *e.LogIndex != int64(i) {
+			t.Errorf("expected log index %d for %s, got %d", i, uuid, *e.LogIndex)
+		}
+		if e := getEntry(t, c, s, "", int64(i)); *e.LogIndex != int64(i) {
+			t.Errorf("expected log index %d, got %d", i, *e.LogIndex)
+		}
+	}
+
+	info, err := c.Tlog.GetLogInfo(tlog.NewGetLogInfoParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *info.Payload.TreeSize != 1 || *info.Payload.TreeID != fmt.Sprint(second) {
+		t.Errorf("unexpected active shard %+v", info.Payload)
+	}
+	if len(info.Payload.InactiveShards) != 1 || *info.Payload.InactiveShards[0].TreeSize != 2 ||
+		*info.Payload.InactiveShards[0].TreeID != fmt.Sprint(first) {
+		t.Errorf("unexpected inactive shards %+v", info.Payload.InactiveShards)
+	}
+}
+
+// shardVerifier returns a verifier for the public key of the tree
+func shardVerifier(t *testing.T, c *generatedclient.Rekor, treeID string) (signature.Verifier, string) {
+	t.Helper()
+	resp, err := c.Pubkey.GetPublicKey(pubkey.NewGetPublicKeyParams().WithTreeID(swag.String(treeID)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(resp.Payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := signature.LoadVerifier(pk, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID := sha256.Sum256(der)
+	return v, hex.EncodeToString(logID[:])
+}
+
+func TestShardSigners(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, c := testServer(t, f, first, s)
+	uuid, _ := createEntry(t, c, s, hashedRekord(t))
+	firstInfo, err := c.Tlog.GetLogInfo(tlog.NewGetLogInfoParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.UpdateTree(ctx, &trillian.UpdateTreeRequest{
+		Tree:       &trillian.Tree{TreeId: first, TreeState: trillian.TreeState_FROZEN},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"tree_state"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	second, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the signer of the inactive shard can't have another public key
+	config := filepath.Join(t.TempDir(), "sharding-config.yaml")
+	pk, err := s.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pem, err := cryptoutils.MarshalPublicKeyToPEM(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(config, []byte(fmt.Sprintf("- treeID: %d\n  signer: memory\n  encodedPublicKey: %s\n", first, base64.StdEncoding.EncodeToString(pem))), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("trillian_log_server.sharding_config", config)
+	defer viper.Set("trillian_log_server.sharding_config", "")
+	if _, err := api.NewAPI(uint(second), api.WithTrillianClients(f, f), api.WithSigner(s)); err == nil {
+		t.Fatal("expected signer with a different public key to fail")
+	}
+
+	// nor have signed the final checkpoint with another key
+	checkpoint, err := json.Marshal(*firstInfo.Payload.SignedTreeHead)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(config, []byte(fmt.Sprintf("- treeID: %d\n  signer: memory\n  signedCheckpoint: %s\n", first, checkpoint)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.NewAPI(uint(second), api.WithTrillianClients(f, f), api.WithSigner(s)); err == nil {
+		t.Fatal("expected final checkpoint signed by another key to fail")
+	}
+
+	if err := os.WriteFile(config, []byte(fmt.Sprintf("- treeID: %d\n  signer: memory\n", first)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, c = testServer(t, f, second, s)
+	shard, shardLogID := shardVerifier(t, c, fmt.Sprint(first))
+	_, activeLogID := shardVerifier(t, c, "")
+	if shardLogID == activeLogID {
+		t.Fatal("expected the inactive shard to have its own key")
+	}
+
+	// entries and checkpoints of each shard are signed by its own key
+	if e := getEntry(t, c, shard, uuid, 0); *e.LogID != shardLogID {
+		t.Errorf("expected log ID %s for the inactive shard, got %s", shardLogID, *e.LogID)
+	}
+	if e := getEntry(t, c, shard, "", 0); *e.LogID != shardLogID {
+		t.Errorf("expected log ID %s for the inactive shard, got %s", shardLogID, *e.LogID)
+	}
+	_, e := createEntry(t, c, s, hashedRekord(t))
+	if *e.LogID != activeLogID {
+		t.Errorf("expected log ID %s for the active shard, got %s", activeLogID, *e.LogID)
+	}
+	if e := getEntry(t, c, s, "", 1); *e.LogID != activeLogID {
+		t.Errorf("expected log ID %s for the active shard, got %s", activeLogID, *e.LogID)
+	}
+
+	info, err := c.Tlog.GetLogInfo(tlog.NewGetLogInfoParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Payload.InactiveShards) != 1 {
+		t.Fatalf("unexpected inactive shards %+v", info.Payload.InactiveShards)
+	}
+	var sth util.SignedCheckpoint
+	if err := sth.UnmarshalText([]byte(*info.Payload.InactiveShards[0].SignedTreeHead)); err != nil {
+		t.Fatal(err)
+	}
+	if !sth.Verify(shard) {
+		t.Error("expected the checkpoint of the inactive shard to be signed by its key")
+	}
+}
+
+// fakeRedis returns a client for an in-memory Redis that supports GET and SET,
+// and transactions of them by a single client
+func fakeRedis() radix.Client {
+	var mu sync.Mutex
+	values := map[string]string{}
+	var queued [][]string
+	multi := false
+	return radix.NewStubConn("tcp", "redis.test:6379", func(_ context.Context, args []string) interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		switch strings.ToUpper(args[0]) {
+		case "GET":
+			if v, ok := values[args[1]]; ok {
+				return v
+			}
+			return nil
+		case "SET":
+			if multi {
+				queued = append(queued, args)
+				return "QUEUED"
+			}
+			values[args[1]] = args[2]
+			return "OK"
+		case "WATCH", "UNWATCH":
+			return "OK"
+		case "MULTI":
+			multi, queued = true, nil
+			return "OK"
+		case "DISCARD":
+			multi, queued = false, nil
+			return "OK"
+		case "EXEC":
+			replies := []string{}
+			for _, q := range queued {
+				values[q[1]] = q[2]
+				replies = append(replies, "OK")
+			}
+			multi, queued = false, nil
+			return replies
+		default:
+			return resp3.SimpleError{S: "unsupported command " + args[0]}
+		}
+	})
+}
+
+func TestRotate(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := sharding.RedisStore{Client: fakeRedis()}
+	// only reload when asked to by a failed write to the frozen tree
+	viper.Set("config_poll_interval", time.Hour)
+	defer viper.Set("config_poll_interval", 0)
+	_, c := testServer(t, f, first, s, api.WithShardingStore(store))
+	var uuids []string
+	for i := 0; i < 2; i++ {
+		uuid, _ := createEntry(t, c, s, hashedRekord(t))
+		uuids = append(uuids, uuid)
+	}
+
+	second, err := api.RotateTree(ctx, f, f, store, first, s, signer.MemoryScheme, "rekor.test", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := store.ReadConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ActiveTreeID != second || len(config.Inactive) != 1 || config.Inactive[0].SignedCheckpoint == "" {
+		t.Errorf("unexpected sharding config %+v", config)
+	}
+	tree, err := f.GetTree(ctx, &trillian.GetTreeRequest{TreeId: first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.TreeState != trillian.TreeState_FROZEN {
+		t.Errorf("expected rotated tree to be frozen, got %s", tree.TreeState)
+	}
+
+	// the server is still writing to the frozen tree until it reloads the
+	// sharding config, which it does when the write fails
+	_, err = c.Entries.CreateLogEntry(entries.NewCreateLogEntryParams().WithProposedEntry(hashedRekord(t)))
+	var unavailable *entries.CreateLogEntryDefault
+	if !errors.As(err, &unavailable) || unavailable.Code() != http.StatusServiceUnavailable {
+		t.Fatalf("expected write to frozen tree to be unavailable, got %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		info, err := c.Tlog.GetLogInfo(tlog.NewGetLogInfoParams())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *info.Payload.TreeID == fmt.Sprint(second) {
+			if len(info.Payload.InactiveShards) != 1 || *info.Payload.InactiveShards[0].TreeSize != 2 {
+				t.Fatalf("unexpected inactive shards %+v", info.Payload.InactiveShards)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("server did not switch to tree %d", second)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	uuid, e := createEntry(t, c, s, hashedRekord(t))
+	if *e.LogIndex != 2 {
+		t.Errorf("expected log index 2, got %d", *e.LogIndex)
+	}
+	uuids = append(uuids, uuid)
+	for i, uuid := range uuids {
+		if e := getEntry(t, c, s, uuid, 0); *e.LogIndex != int64(i) {
+			t.Errorf("expected log index %d for %s, got %d", i, uuid, *e.LogIndex)
+		}
+		if e := getEntry(t, c, s, "", int64(i)); *e.LogIndex != int64(i) {
+			t.Errorf("expected log index %d, got %d", i, *e.LogIndex)
+		}
+	}
+}
+
+// failingStore fails to write the sharding config the first time
+type failingStore struct {
+	sharding.Store
+	failed bool
+}
+
+func (s *failingStore) WriteConfig(ctx context.Context, prev, config sharding.Config) error {
+	if !s.failed {
+		s.failed = true
+		return errors.New("write failed")
+	}
+	return s.Store.WriteConfig(ctx, prev, config)
+}
+
+func TestRotateRetry(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &failingStore{Store: sharding.RedisStore{Client: fakeRedis()}}
+
+	if _, err := api.RotateTree(ctx, f, f, store, first, s, signer.MemoryScheme, "rekor.test", time.Millisecond); err == nil {
+		t.Fatal("expected rotation to fail")
+	}
+	second, err := api.RotateTree(ctx, f, f, store, first, s, signer.MemoryScheme, "rekor.test", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the retry switches to the tree created by the failed rotation
+	trees, err := f.ListTrees(ctx, &trillian.ListTreesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trees.Tree) != 2 || trees.Tree[1].TreeId != second {
+		t.Errorf("expected trees %d and %d, got %+v", first, second, trees.Tree)
+	}
+	config, err := store.ReadConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ActiveTreeID != second || len(config.Inactive) != 1 || config.Inactive[0].TreeID != first {
+		t.Errorf("unexpected sharding config %+v", config)
+	}
+}
+
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "rekor-server.yaml")
+	writeConfig := func(contents string) {
+		t.Helper()
+		if err := os.WriteFile(configFile, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	shardingConfig := filepath.Join(dir, "sharding-config.yaml")
+	writeConfig(fmt.Sprintf("trillian_log_server:\n  sharding_config: %s\nmax_attestation_size: 10\n", shardingConfig))
+	if err := os.WriteFile(shardingConfig, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	// settings set by other tests would override the config file
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(configFile)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	rekorAPI, c := testServer(t, f, first, s)
+	createEntry(t, c, s, hashedRekord(t))
+
+	// settings read on each request are reloaded
+	writeConfig(fmt.Sprintf("trillian_log_server:\n  sharding_config: %s\nmax_attestation_size: 20\nlog_level: warn\n", shardingConfig))
+	if err := rekorAPI.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if size := types.MaxAttestationSize(); size != 20 {
+		t.Errorf("expected reloaded max attestation size, got %d", size)
+	}
+	// viper is not safe to change while requests are handled
+	if size := viper.GetInt("max_attestation_size"); size != 10 {
+		t.Errorf("expected reload to leave viper unchanged, got max attestation size %d", size)
+	}
+
+	for _, tt := range []struct {
+		description string
+		config      string
+		sharding    string
+	}{
+		{
+			description: "setting requiring a restart",
+			config:      "rekor_server:\n  hostname: other.test\n",
+		}, {
+			description: "invalid log level",
+			config:      "log_level: loud\n",
+		}, {
+			description: "enabling attestation storage",
+			config:      "enable_attestation_storage: true\n",
+		}, {
+			description: "inactive range before the active tree",
+			sharding:    "- treeID: 999\n  treeLength: 5\n",
+		},
+	} {
+		t.Run(tt.description, func(t *testing.T) {
+			writeConfig(fmt.Sprintf("trillian_log_server:\n  sharding_config: %s\nmax_attestation_size: 20\nlog_level: warn\n%s", shardingConfig, tt.config))
+			if err := os.WriteFile(shardingConfig, []byte(tt.sharding), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if err := rekorAPI.Reload(ctx); err == nil {
+				t.Error("expected reload to be refused")
+			}
+		})
+	}
+	if hostname := viper.GetString("rekor_server.hostname"); hostname != "rekor.test" {
+		t.Errorf("expected refused reload to not change settings, got hostname %s", hostname)
+	}
+	info, err := c.Tlog.GetLogInfo(tlog.NewGetLogInfoParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Payload.InactiveShards) != 0 || *info.Payload.TreeID != fmt.Sprint(first) {
+		t.Fatalf("expected refused reload to not change shards, got %+v", info.Payload)
+	}
+
+	// switch to a new tree by changing the active tree in the config file
+	if _, err := f.UpdateTree(ctx, &trillian.UpdateTreeRequest{
+		Tree:       &trillian.Tree{TreeId: first, TreeState: trillian.TreeState_FROZEN},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"tree_state"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	second, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeConfig(fmt.Sprintf("trillian_log_server:\n  sharding_config: %s\n  tlog_id: %d\nmax_attestation_size: 20\nlog_level: warn\n", shardingConfig, second))
+	if err := os.WriteFile(shardingConfig, []byte(fmt.Sprintf("- treeID: %d\n", first)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := rekorAPI.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, e := createEntry(t, c, s, hashedRekord(t)); *e.LogIndex != 1 {
+		t.Errorf("expected log index 1, got %d", *e.LogIndex)
+	}
+}
+
+// TestReloadWhileServing reloads the config while requests are handled, for
+// the race detector to check that reloading doesn't change what handlers read
+func TestReloadWhileServing(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeID, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configFile := filepath.Join(t.TempDir(), "rekor-server.yaml")
+	writeConfig := func(contents string) {
+		t.Helper()
+		if err := os.WriteFile(configFile, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeConfig("max_attestation_size: 10\n")
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(configFile)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	rekorAPI, c := testServer(t, f, treeID, s)
+	uuid, _ := createEntry(t, c, s, hashedRekord(t))
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if _, err := c.Entries.CreateLogEntry(entries.NewCreateLogEntryParams().WithProposedEntry(hashedRekord(t))); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := c.Entries.GetLogEntryByUUID(entries.NewGetLogEntryByUUIDParams().WithEntryUUID(uuid)); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		level := "info"
+		if i%2 == 0 {
+			level = "warn"
+		}
+		writeConfig(fmt.Sprintf("max_attestation_size: %d\nlog_level: %s\n", 10+i, level))
+		if err := rekorAPI.Reload(ctx); err != nil {
+			t.Error(err)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if size := types.MaxAttestationSize(); size != 29 {
+		t.Errorf("expected reloaded max attestation size, got %d", size)
+	}
+}
@@ -0,0 +1,206 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sigstore/rekor/pkg/embedded"
+)
+
+// EmbeddedClient is the LogClient for the single tree of a log embedded in
+// the server process. Leaves are integrated as soon as they are added.
+type EmbeddedClient struct {
+	log   *embedded.Log
+	logID int64
+}
+
+// embeddedClients returns a constructor of clients for the embedded log
+func embeddedClients(l *embedded.Log) func(context.Context, int64) LogClient {
+	return func(_ context.Context, tid int64) LogClient {
+		return &EmbeddedClient{
+			log:   l,
+			logID: tid,
+		}
+	}
+}
+
+// embeddedError converts errors from the embedded log to gRPC errors, as
+// would be returned by Trillian
+func embeddedError(err error) error {
+	switch {
+	case errors.Is(err, embedded.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, embedded.ErrOutOfRange):
+		return status.Error(codes.OutOfRange, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func errorResponse(err error) *Response {
+	return &Response{
+		status: status.Code(err),
+		err:    err,
+	}
+}
+
+func logLeaf(leaf embedded.Leaf) *trillian.LogLeaf {
+	return &trillian.LogLeaf{
+		MerkleLeafHash:     leaf.MerkleLeafHash,
+		LeafValue:          leaf.Value,
+		LeafIndex:          int64(leaf.Index),
+		IntegrateTimestamp: timestamppb.New(leaf.IntegratedTime),
+	}
+}
+
+func (e *EmbeddedClient) root() (types.LogRootV1, error) {
+	if e.logID != e.log.TreeID() {
+		return types.LogRootV1{}, status.Errorf(codes.NotFound, "tree %d not found", e.logID)
+	}
+	size, hash, err := e.log.Root()
+	if err != nil {
+		return types.LogRootV1{}, embeddedError(err)
+	}
+	return types.LogRootV1{
+		TreeSize:       size,
+		RootHash:       hash,
+		TimestampNanos: uint64(time.Now().UnixNano()),
+	}, nil
+}
+
+func (e *EmbeddedClient) signedRoot() (types.LogRootV1, *trillian.SignedLogRoot, error) {
+	root, err := e.root()
+	if err != nil {
+		return types.LogRootV1{}, nil, err
+	}
+	b, err := root.MarshalBinary()
+	if err != nil {
+		return types.LogRootV1{}, nil, status.Error(codes.Internal, err.Error())
+	}
+	return root, &trillian.SignedLogRoot{LogRoot: b}, nil
+}
+
+func (e *EmbeddedClient) addLeaf(byteValue []byte) *Response {
+	if e.logID != e.log.TreeID() {
+		return errorResponse(status.Errorf(codes.NotFound, "tree %d not found", e.logID))
+	}
+	leaf, added, err := e.log.Append(byteValue)
+	if err != nil {
+		return errorResponse(embeddedError(err))
+	}
+	queued := &trillian.QueuedLogLeaf{Leaf: logLeaf(leaf)}
+	if !added {
+		queued.Status = status.New(codes.AlreadyExists, "leaf already exists").Proto()
+	}
+	return &Response{
+		status:       codes.OK,
+		getAddResult: &trillian.QueueLeafResponse{QueuedLeaf: queued},
+	}
+}
+
+func (e *EmbeddedClient) getLeafAndProofByHash(hash []byte) *Response {
+	if e.logID != e.log.TreeID() {
+		return errorResponse(status.Errorf(codes.NotFound, "tree %d not found", e.logID))
+	}
+	leaf, err := e.log.LeafByHash(hash)
+	if err != nil {
+		return errorResponse(embeddedError(err))
+	}
+	return e.getLeafAndProofByIndex(int64(leaf.Index))
+}
+
+func (e *EmbeddedClient) getLeafAndProofByIndex(index int64) *Response {
+	root, signedRoot, err := e.signedRoot()
+	if err != nil {
+		return errorResponse(err)
+	}
+	if index < 0 {
+		return errorResponse(status.Errorf(codes.InvalidArgument, "invalid leaf index %d", index))
+	}
+	leaf, err := e.log.LeafByIndex(uint64(index))
+	if err != nil {
+		return errorResponse(embeddedError(err))
+	}
+	hashes, err := e.log.InclusionProof(uint64(index), root.TreeSize)
+	if err != nil {
+		return errorResponse(embeddedError(err))
+	}
+	return &Response{
+		status: codes.OK,
+		getLeafAndProofResult: &trillian.GetEntryAndProofResponse{
+			Proof: &trillian.Proof{
+				LeafIndex: index,
+				Hashes:    hashes,
+			},
+			Leaf:          logLeaf(leaf),
+			SignedLogRoot: signedRoot,
+		},
+	}
+}
+
+func (e *EmbeddedClient) getLatest(leafSizeInt int64) *Response {
+	root, signedRoot, err := e.signedRoot()
+	if err != nil {
+		return errorResponse(err)
+	}
+	result := &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: signedRoot}
+	// as with Trillian, a consistency proof is included if the first size is
+	// set and within the tree
+	if leafSizeInt > 0 && uint64(leafSizeInt) <= root.TreeSize {
+		hashes, err := e.log.ConsistencyProof(uint64(leafSizeInt), root.TreeSize)
+		if err != nil {
+			return errorResponse(embeddedError(err))
+		}
+		result.Proof = &trillian.Proof{Hashes: hashes}
+	}
+	return &Response{
+		status:          codes.OK,
+		getLatestResult: result,
+	}
+}
+
+func (e *EmbeddedClient) getConsistencyProof(firstSize, lastSize int64) *Response {
+	if firstSize < 1 || lastSize < firstSize {
+		return errorResponse(status.Errorf(codes.InvalidArgument, "invalid tree sizes %d and %d", firstSize, lastSize))
+	}
+	root, signedRoot, err := e.signedRoot()
+	if err != nil {
+		return errorResponse(err)
+	}
+	result := &trillian.GetConsistencyProofResponse{SignedLogRoot: signedRoot}
+	// as with Trillian, the proof is left empty if the last size is larger
+	// than the tree
+	if uint64(lastSize) <= root.TreeSize {
+		hashes, err := e.log.ConsistencyProof(uint64(firstSize), uint64(lastSize))
+		if err != nil {
+			return errorResponse(embeddedError(err))
+		}
+		result.Proof = &trillian.Proof{Hashes: hashes}
+	}
+	return &Response{
+		status:                    codes.OK,
+		getConsistencyProofResult: result,
+	}
+}
@@ -0,0 +1,102 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/trillian/client"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/codes"
+
+	"github.com/sigstore/rekor/pkg/embedded"
+)
+
+func TestEmbeddedClient(t *testing.T) {
+	l, err := embedded.Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	tc := embeddedClients(l)(context.Background(), l.TreeID())
+
+	for _, v := range []string{"foo", "bar", "baz"} {
+		resp := tc.addLeaf([]byte(v))
+		if resp.status != codes.OK || resp.getAddResult.QueuedLeaf.Status != nil {
+			t.Fatalf("addLeaf(%s) = %v", v, resp.err)
+		}
+	}
+	resp := tc.addLeaf([]byte("bar"))
+	if resp.status != codes.OK {
+		t.Fatal(resp.err)
+	}
+	if s := resp.getAddResult.QueuedLeaf.Status; s == nil || s.Code != int32(code.Code_ALREADY_EXISTS) {
+		t.Errorf("expected duplicate leaf to already exist, got %v", s)
+	}
+	if idx := resp.getAddResult.QueuedLeaf.Leaf.LeafIndex; idx != 1 {
+		t.Errorf("expected existing leaf at index 1, got %d", idx)
+	}
+
+	root, err := tc.root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.TreeSize != 3 {
+		t.Fatalf("unexpected tree size %d", root.TreeSize)
+	}
+	v := client.NewLogVerifier(rfc6962.DefaultHasher)
+
+	resp = tc.getLeafAndProofByHash(rfc6962.DefaultHasher.HashLeaf([]byte("baz")))
+	if resp.status != codes.OK {
+		t.Fatal(resp.err)
+	}
+	result := resp.getLeafAndProofResult
+	if result.Leaf.LeafIndex != 2 || string(result.Leaf.LeafValue) != "baz" {
+		t.Errorf("unexpected leaf %v", result.Leaf)
+	}
+	if err := v.VerifyInclusionByHash(&root, result.Leaf.MerkleLeafHash, result.Proof); err != nil {
+		t.Errorf("verifying inclusion: %v", err)
+	}
+
+	if resp := tc.getLeafAndProofByHash([]byte("missing")); resp.status != codes.NotFound {
+		t.Errorf("expected not found, got %v", resp.status)
+	}
+	if resp := tc.getLeafAndProofByIndex(3); resp.status != codes.OutOfRange {
+		t.Errorf("expected out of range, got %v", resp.status)
+	}
+
+	resp = tc.getConsistencyProof(1, 3)
+	if resp.status != codes.OK {
+		t.Fatal(resp.err)
+	}
+	oldRoot := rfc6962.DefaultHasher.HashLeaf([]byte("foo"))
+	if err := proof.VerifyConsistency(rfc6962.DefaultHasher, 1, 3, resp.getConsistencyProofResult.Proof.Hashes, oldRoot, root.RootHash); err != nil {
+		t.Errorf("verifying consistency: %v", err)
+	}
+	// the proof is empty if the tree is smaller than the last size
+	resp = tc.getConsistencyProof(1, 4)
+	if resp.status != codes.OK || resp.getConsistencyProofResult.Proof != nil {
+		t.Errorf("expected empty proof, got %v %v", resp.err, resp.getConsistencyProofResult)
+	}
+
+	other := embeddedClients(l)(context.Background(), l.TreeID()+1)
+	if resp := other.getLatest(0); resp.status != codes.NotFound {
+		t.Errorf("expected unknown tree to be not found, got %v", resp.status)
+	}
+}
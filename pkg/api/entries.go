@@ -66,8 +66,9 @@ func signEntry(ctx context.Context, signer signature.Signer, entry models.LogEnt
 	return signature, nil
 }
 
-// logEntryFromLeaf creates a signed LogEntry struct from trillian structs
-func logEntryFromLeaf(ctx context.Context, signer signature.Signer, tc TrillianClient, leaf *trillian.LogLeaf,
+// logEntryFromLeaf creates a LogEntry struct from trillian structs, signed by
+// the signer of the tree
+func (a *API) logEntryFromLeaf(ctx context.Context, signer logSigner, leaf *trillian.LogLeaf,
 	signedLogRoot *trillian.SignedLogRoot, proof *trillian.Proof, tid int64, ranges sharding.LogRanges) (models.LogEntry, error) {
 
 	root := &ttypes.LogRootV1{}
@@ -81,7 +82,7 @@ func logEntryFromLeaf(ctx context.Context, signer signature.Signer, tc TrillianC
 
 	virtualIndex := sharding.VirtualLogIndex(leaf.GetLeafIndex(), tid, ranges)
 	logEntryAnon := models.LogEntryAnon{
-		LogID:          swag.String(api.pubkeyHash),
+		LogID:          swag.String(signer.pubkeyHash),
 		LogIndex:       &virtualIndex,
 		Body:           leaf.LeafValue,
 		IntegratedTime: swag.Int64(leaf.IntegrateTimestamp.AsTime().Unix()),
@@ -100,7 +101,7 @@ func logEntryFromLeaf(ctx context.Context, signer signature.Signer, tc TrillianC
 	}
 
 	uuid := hex.EncodeToString(leaf.MerkleLeafHash)
-	if viper.GetBool("enable_attestation_storage") {
+	if a.settings().enableAttestationStorage {
 		pe, err := models.UnmarshalProposedEntry(bytes.NewReader(leaf.LeafValue), runtime.JSONConsumer())
 		if err != nil {
 			return nil, err
@@ -116,19 +117,19 @@ func logEntryFromLeaf(ctx context.Context, signer signature.Signer, tc TrillianC
 			attKey := entryWithAtt.AttestationKey()
 			// if we're given a key by the type logic, let's try that first
 			if attKey != "" {
-				att, fetchErr = storageClient.FetchAttestation(ctx, attKey)
+				att, fetchErr = a.storageClient.FetchAttestation(ctx, attKey)
 				if fetchErr != nil {
 					log.ContextLogger(ctx).Errorf("error fetching attestation by key, trying by UUID: %s %w", attKey, fetchErr)
 				}
 			}
 			// if looking up by key failed or we weren't able to generate a key, try looking up by uuid
 			if attKey == "" || fetchErr != nil {
-				activeTree := fmt.Sprintf("%x", tc.logID)
+				activeTree := fmt.Sprintf("%x", tid)
 				entryIDstruct, err := sharding.CreateEntryIDFromParts(activeTree, uuid)
 				if err != nil {
 					return nil, fmt.Errorf("error creating EntryID from active treeID %v and uuid %v: %w", activeTree, uuid, err)
 				}
-				att, fetchErr = storageClient.FetchAttestation(ctx, entryIDstruct.UUID)
+				att, fetchErr = a.storageClient.FetchAttestation(ctx, entryIDstruct.UUID)
 				if fetchErr != nil {
 					log.ContextLogger(ctx).Errorf("error fetching attestation by uuid: %s %v", entryIDstruct.UUID, fetchErr)
 				}
@@ -151,9 +152,9 @@ func logEntryFromLeaf(ctx context.Context, signer signature.Signer, tc TrillianC
 }
 
 // GetLogEntryAndProofByIndexHandler returns the entry and inclusion proof for a specified log index
-func GetLogEntryByIndexHandler(params entries.GetLogEntryByIndexParams) middleware.Responder {
+func (a *API) GetLogEntryByIndexHandler(params entries.GetLogEntryByIndexParams) middleware.Responder {
 	ctx := params.HTTPRequest.Context()
-	logEntry, err := retrieveLogEntryByIndex(ctx, int(params.LogIndex))
+	logEntry, err := a.retrieveLogEntryByIndex(ctx, a.state(), int(params.LogIndex))
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
 			return handleRekorAPIError(params, http.StatusNotFound, fmt.Errorf("grpc error: %w", err), "")
@@ -163,7 +164,7 @@ func GetLogEntryByIndexHandler(params entries.GetLogEntryByIndexParams) middlewa
 	return entries.NewGetLogEntryByIndexOK().WithPayload(logEntry)
 }
 
-func createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middleware.Responder) {
+func (a *API) createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middleware.Responder) {
 	ctx := params.HTTPRequest.Context()
 	entry, err := types.CreateVersionedEntry(params.ProposedEntry)
 	if err != nil {
@@ -177,11 +178,19 @@ func createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middl
 		return nil, handleRekorAPIError(params, http.StatusInternalServerError, err, failedToGenerateCanonicalEntry)
 	}
 
-	tc := NewTrillianClient(ctx)
+	ranges := a.ranges()
+	tc := a.NewLogClientFromTreeID(ctx, ranges.ActiveTreeID())
 
 	resp := tc.addLeaf(leaf)
 	// this represents overall GRPC response state (not the results of insertion into the log)
-	if resp.status != codes.OK {
+	switch resp.status {
+	case codes.OK:
+	case codes.FailedPrecondition:
+		// the active tree has been frozen by a rotation this replica hasn't
+		// picked up yet; reload the sharding config and let the client retry
+		a.requestReload()
+		return nil, handleRekorAPIError(params, http.StatusServiceUnavailable, fmt.Errorf("grpc error: %w", resp.err), activeTreeNotWritable)
+	default:
 		return nil, handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianUnexpectedResult)
 	}
 
@@ -206,7 +215,7 @@ func createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middl
 	queuedLeaf := resp.getAddResult.QueuedLeaf.Leaf
 
 	uuid := hex.EncodeToString(queuedLeaf.GetMerkleLeafHash())
-	activeTree := fmt.Sprintf("%x", tc.logID)
+	activeTree := fmt.Sprintf("%x", ranges.ActiveTreeID())
 	entryIDstruct, err := sharding.CreateEntryIDFromParts(activeTree, uuid)
 	if err != nil {
 		err := fmt.Errorf("error creating EntryID from active treeID %v and uuid %v: %w", activeTree, uuid, err)
@@ -215,9 +224,9 @@ func createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middl
 	entryID := entryIDstruct.ReturnEntryIDString()
 
 	// The log index should be the virtual log index across all shards
-	virtualIndex := sharding.VirtualLogIndex(queuedLeaf.LeafIndex, api.logRanges.ActiveTreeID(), api.logRanges)
+	virtualIndex := sharding.VirtualLogIndex(queuedLeaf.LeafIndex, ranges.ActiveTreeID(), ranges)
 	logEntryAnon := models.LogEntryAnon{
-		LogID:          swag.String(api.pubkeyHash),
+		LogID:          swag.String(a.pubkeyHash),
 		LogIndex:       swag.Int64(virtualIndex),
 		Body:           queuedLeaf.GetLeafValue(),
 		IntegratedTime: swag.Int64(queuedLeaf.IntegrateTimestamp.AsTime().Unix()),
@@ -231,19 +240,19 @@ func createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middl
 				return
 			}
 			for _, key := range keys {
-				if err := addToIndex(context.Background(), key, entryID); err != nil {
+				if err := a.addToIndex(context.Background(), key, entryID); err != nil {
 					log.ContextLogger(ctx).Error(err)
 				}
 			}
 		}()
 	}
 
-	if viper.GetBool("enable_attestation_storage") {
+	if a.settings().enableAttestationStorage {
 		if entryWithAtt, ok := entry.(types.EntryWithAttestationImpl); ok {
 			attKey, attVal := entryWithAtt.AttestationKeyValue()
 			if attVal != nil {
 				go func() {
-					if err := storeAttestation(context.Background(), attKey, attVal); err != nil {
+					if err := a.storeAttestation(context.Background(), attKey, attVal); err != nil {
 						// entryIDstruct.UUID
 						log.ContextLogger(ctx).Errorf("error storing attestation: %s", err)
 					} else {
@@ -256,7 +265,7 @@ func createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middl
 		}
 	}
 
-	signature, err := signEntry(ctx, api.signer, logEntryAnon)
+	signature, err := signEntry(ctx, a.signer, logEntryAnon)
 	if err != nil {
 		return nil, handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("signing entry error: %v", err), signingError)
 	}
@@ -272,10 +281,10 @@ func createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middl
 }
 
 // CreateLogEntryHandler creates new entry into log
-func CreateLogEntryHandler(params entries.CreateLogEntryParams) middleware.Responder {
+func (a *API) CreateLogEntryHandler(params entries.CreateLogEntryParams) middleware.Responder {
 	httpReq := params.HTTPRequest
 
-	logEntry, err := createLogEntry(params)
+	logEntry, err := a.createLogEntry(params)
 	if err != nil {
 		return err
 	}
@@ -300,8 +309,8 @@ func getEntryURL(locationURL url.URL, uuid string) strfmt.URI {
 }
 
 // GetLogEntryByUUIDHandler gets log entry and inclusion proof for specified UUID aka merkle leaf hash
-func GetLogEntryByUUIDHandler(params entries.GetLogEntryByUUIDParams) middleware.Responder {
-	logEntry, err := retrieveLogEntry(params.HTTPRequest.Context(), params.EntryUUID)
+func (a *API) GetLogEntryByUUIDHandler(params entries.GetLogEntryByUUIDParams) middleware.Responder {
+	logEntry, err := a.retrieveLogEntry(params.HTTPRequest.Context(), a.state(), params.EntryUUID)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
 			return handleRekorAPIError(params, http.StatusNotFound, err, "")
@@ -315,10 +324,12 @@ func GetLogEntryByUUIDHandler(params entries.GetLogEntryByUUIDParams) middleware
 }
 
 // SearchLogQueryHandler searches log by index, UUID, or proposed entry and returns array of entries found with inclusion proofs
-func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Responder {
+func (a *API) SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Responder {
 	httpReqCtx := params.HTTPRequest.Context()
 	resultPayload := []models.LogEntry{}
-	tc := NewTrillianClient(httpReqCtx)
+	state := a.state()
+	ranges := state.ranges
+	tc := a.NewLogClientFromTreeID(httpReqCtx, ranges.ActiveTreeID())
 
 	totalQueries := len(params.Entry.EntryUUIDs) + len(params.Entry.Entries()) + len(params.Entry.LogIndexes)
 	if totalQueries > maxSearchQueries {
@@ -331,7 +342,7 @@ func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Respo
 		var searchHashes [][]byte
 		for _, entryID := range params.Entry.EntryUUIDs {
 			if sharding.ValidateEntryID(entryID) == nil {
-				logEntry, err := retrieveLogEntry(httpReqCtx, entryID)
+				logEntry, err := a.retrieveLogEntry(httpReqCtx, state, entryID)
 				if err != nil {
 					return handleRekorAPIError(params, http.StatusBadRequest, err, fmt.Sprintf("error getting log entry for %s", entryID))
 				}
@@ -406,7 +417,7 @@ func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Respo
 
 		for _, leafResp := range searchByHashResults {
 			if leafResp != nil {
-				logEntry, err := logEntryFromLeaf(httpReqCtx, api.signer, tc, leafResp.Leaf, leafResp.SignedLogRoot, leafResp.Proof, api.logRanges.ActiveTreeID(), api.logRanges)
+				logEntry, err := a.logEntryFromLeaf(httpReqCtx, state.active, leafResp.Leaf, leafResp.SignedLogRoot, leafResp.Proof, ranges.ActiveTreeID(), ranges)
 				if err != nil {
 					return handleRekorAPIError(params, code, err, err.Error())
 				}
@@ -422,7 +433,7 @@ func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Respo
 		for _, logIndex := range params.Entry.LogIndexes {
 			logIndex := logIndex // https://golang.org/doc/faq#closures_and_goroutines
 			g.Go(func() error {
-				logEntry, err := retrieveLogEntryByIndex(httpReqCtx, int(swag.Int64Value(logIndex)))
+				logEntry, err := a.retrieveLogEntryByIndex(httpReqCtx, state, int(swag.Int64Value(logIndex)))
 				if err != nil {
 					return err
 				}
@@ -445,9 +456,9 @@ func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Respo
 
 var ErrNotFound = errors.New("grpc returned 0 leaves with success code")
 
-func retrieveLogEntryByIndex(ctx context.Context, logIndex int) (models.LogEntry, error) {
-	tid, resolvedIndex := api.logRanges.ResolveVirtualIndex(logIndex)
-	tc := NewTrillianClientFromTreeID(ctx, tid)
+func (a *API) retrieveLogEntryByIndex(ctx context.Context, state *logState, logIndex int) (models.LogEntry, error) {
+	tid, resolvedIndex := state.ranges.ResolveVirtualIndex(logIndex)
+	tc := a.NewLogClientFromTreeID(ctx, tid)
 	log.ContextLogger(ctx).Debugf("Retrieving resolved index %v from TreeID %v", resolvedIndex, tid)
 
 	resp := tc.getLeafAndProofByIndex(resolvedIndex)
@@ -465,13 +476,13 @@ func retrieveLogEntryByIndex(ctx context.Context, logIndex int) (models.LogEntry
 		return models.LogEntry{}, ErrNotFound
 	}
 
-	return logEntryFromLeaf(ctx, api.signer, tc, leaf, result.SignedLogRoot, result.Proof, tid, api.logRanges)
+	return a.logEntryFromLeaf(ctx, state.signerFor(tid), leaf, result.SignedLogRoot, result.Proof, tid, state.ranges)
 }
 
 // Retrieve a Log Entry
 // If a tree ID is specified, look in that tree
 // Otherwise, look through all inactive and active shards
-func retrieveLogEntry(ctx context.Context, entryUUID string) (models.LogEntry, error) {
+func (a *API) retrieveLogEntry(ctx context.Context, state *logState, entryUUID string) (models.LogEntry, error) {
 	uuid, err := sharding.GetUUIDFromIDString(entryUUID)
 	if err != nil {
 		return nil, sharding.ErrPlainUUID
@@ -480,16 +491,16 @@ func retrieveLogEntry(ctx context.Context, entryUUID string) (models.LogEntry, e
 	// Get the tree ID and check that shard for the entry
 	tid, err := sharding.TreeID(entryUUID)
 	if err == nil {
-		return retrieveUUIDFromTree(ctx, uuid, tid)
+		return a.retrieveUUIDFromTree(ctx, state, uuid, tid)
 	}
 
 	// If we got a UUID instead of an EntryID, search all shards
 	if errors.Is(err, sharding.ErrPlainUUID) {
-		trees := []sharding.LogRange{{TreeID: api.logRanges.ActiveTreeID()}}
-		trees = append(trees, api.logRanges.GetInactive()...)
+		trees := []sharding.LogRange{{TreeID: state.ranges.ActiveTreeID()}}
+		trees = append(trees, state.ranges.GetInactive()...)
 
 		for _, t := range trees {
-			logEntry, err := retrieveUUIDFromTree(ctx, uuid, t.TreeID)
+			logEntry, err := a.retrieveUUIDFromTree(ctx, state, uuid, t.TreeID)
 			if err != nil {
 				continue
 			}
@@ -501,13 +512,13 @@ func retrieveLogEntry(ctx context.Context, entryUUID string) (models.LogEntry, e
 	return nil, err
 }
 
-func retrieveUUIDFromTree(ctx context.Context, uuid string, tid int64) (models.LogEntry, error) {
+func (a *API) retrieveUUIDFromTree(ctx context.Context, state *logState, uuid string, tid int64) (models.LogEntry, error) {
 	hashValue, err := hex.DecodeString(uuid)
 	if err != nil {
 		return models.LogEntry{}, types.ValidationError(err)
 	}
 
-	tc := NewTrillianClientFromTreeID(ctx, tid)
+	tc := a.NewLogClientFromTreeID(ctx, tid)
 	log.ContextLogger(ctx).Debugf("Attempting to retrieve UUID %v from TreeID %v", uuid, tid)
 
 	resp := tc.getLeafAndProofByHash(hashValue)
@@ -519,7 +530,7 @@ func retrieveUUIDFromTree(ctx context.Context, uuid string, tid int64) (models.L
 			return models.LogEntry{}, ErrNotFound
 		}
 
-		logEntry, err := logEntryFromLeaf(ctx, api.signer, tc, leaf, result.SignedLogRoot, result.Proof, tid, api.logRanges)
+		logEntry, err := a.logEntryFromLeaf(ctx, state.signerFor(tid), leaf, result.SignedLogRoot, result.Proof, tid, state.ranges)
 		if err != nil {
 			return models.LogEntry{}, errors.New("could not create log entry from leaf")
 		}
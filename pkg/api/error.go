@@ -28,6 +28,7 @@ import (
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/index"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/pubkey"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/timestamp"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/tlog"
 	"github.com/sigstore/rekor/pkg/log"
 )
@@ -48,7 +49,14 @@ const (
 	sthGenerateError               = "Error generating signed tree head"
 	unsupportedPKIFormat           = "The PKI format requested is not supported by this server"
 	unexpectedInactiveShardError   = "Unexpected error communicating with inactive shard"
+	activeTreeNotWritable          = "The log is not accepting new entries while switching to a new shard, please retry"
 	maxSearchQueryLimit            = "more than max allowed %d entries in request"
+	timestampingDisabled           = "This server is not configured to issue timestamps"
+	failedToReadTimestampRequest   = "Error reading timestamp request"
+	timestampRequestTooLarge       = "Timestamp request exceeds maximum allowed size (%d bytes)"
+	invalidTimestampRequest        = "Error processing timestamp request: %v"
+	timestampNonceTooLarge         = "Nonce exceeds maximum allowed size (%d bytes)"
+	timestampNoteGenerateError     = "Error generating signed timestamp note"
 )
 
 func errorMsg(message string, code int) *models.Error {
@@ -147,6 +155,22 @@ func handleRekorAPIError(params interface{}, code int, err error, message string
 		default:
 			return index.NewSearchIndexDefault(code).WithPayload(errorMsg(message, code))
 		}
+	case timestamp.GetTimestampResponseParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusBadRequest:
+			return timestamp.NewGetTimestampResponseBadRequest().WithPayload(errorMsg(message, code))
+		default:
+			return timestamp.NewGetTimestampResponseDefault(code).WithPayload(errorMsg(message, code))
+		}
+	case timestamp.GetTimestampNoteParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusBadRequest:
+			return timestamp.NewGetTimestampNoteBadRequest().WithPayload(errorMsg(message, code))
+		default:
+			return timestamp.NewGetTimestampNoteDefault(code).WithPayload(errorMsg(message, code))
+		}
 	default:
 		log.Logger.Errorf("unable to find method for type %T; error: %v", params, err)
 		return middleware.Error(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
@@ -29,10 +29,11 @@ import (
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/index"
 	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
 	"github.com/sigstore/rekor/pkg/util"
 )
 
-func SearchIndexHandler(params index.SearchIndexParams) middleware.Responder {
+func (a *API) SearchIndexHandler(params index.SearchIndexParams) middleware.Responder {
 	httpReqCtx := params.HTTPRequest.Context()
 
 	queryOperator := params.Query.Operator
@@ -43,10 +44,10 @@ func SearchIndexHandler(params index.SearchIndexParams) middleware.Responder {
 	var result = NewCollection(queryOperator)
 
 	if params.Query.Hash != "" {
-		// This must be a valid sha256 hash
+		// This must be a valid sha512, sha256 or sha1 hash
 		sha := util.PrefixSHA(params.Query.Hash)
 		var resultUUIDs []string
-		if err := redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", strings.ToLower(sha), "0", "-1")); err != nil {
+		if err := a.redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", strings.ToLower(sha), "0", "-1")); err != nil {
 			return handleRekorAPIError(params, http.StatusInternalServerError, err, redisUnexpectedResult)
 		}
 		result.Add(resultUUIDs)
@@ -73,14 +74,22 @@ func SearchIndexHandler(params index.SearchIndexParams) middleware.Responder {
 
 		keyHash := sha256.Sum256(canonicalKey)
 		var resultUUIDs []string
-		if err := redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", strings.ToLower(hex.EncodeToString(keyHash[:])), "0", "-1")); err != nil {
+		if err := a.redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", strings.ToLower(hex.EncodeToString(keyHash[:])), "0", "-1")); err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, err, redisUnexpectedResult)
+		}
+		result.Add(resultUUIDs)
+	}
+	if params.Query.Package != nil {
+		key := types.PackageIndexKey(swag.StringValue(params.Query.Package.Type), swag.StringValue(params.Query.Package.Name))
+		var resultUUIDs []string
+		if err := a.redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", key, "0", "-1")); err != nil {
 			return handleRekorAPIError(params, http.StatusInternalServerError, err, redisUnexpectedResult)
 		}
 		result.Add(resultUUIDs)
 	}
 	if params.Query.Email != "" {
 		var resultUUIDs []string
-		if err := redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", strings.ToLower(params.Query.Email.String()), "0", "-1")); err != nil {
+		if err := a.redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", strings.ToLower(params.Query.Email.String()), "0", "-1")); err != nil {
 			return handleRekorAPIError(params, http.StatusInternalServerError, err, redisUnexpectedResult)
 		}
 		result.Add(resultUUIDs)
@@ -99,12 +108,12 @@ func SearchIndexNotImplementedHandler(params index.SearchIndexParams) middleware
 
 }
 
-func addToIndex(ctx context.Context, key, value string) error {
-	return redisClient.Do(ctx, radix.Cmd(nil, "LPUSH", key, value))
+func (a *API) addToIndex(ctx context.Context, key, value string) error {
+	return a.redisClient.Do(ctx, radix.Cmd(nil, "LPUSH", key, value))
 }
 
-func storeAttestation(ctx context.Context, uuid string, attestation []byte) error {
-	return storageClient.StoreAttestation(ctx, uuid, attestation)
+func (a *API) storeAttestation(ctx context.Context, uuid string, attestation []byte) error {
+	return a.storageClient.StoreAttestation(ctx, uuid, attestation)
 }
 
 // Uniq is a collection of unique elements.
@@ -18,17 +18,19 @@ package api
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/pubkey"
 )
 
-func GetPublicKeyHandler(params pubkey.GetPublicKeyParams) middleware.Responder {
-	ctx := params.HTTPRequest.Context()
+func (a *API) GetPublicKeyHandler(params pubkey.GetPublicKeyParams) middleware.Responder {
 	treeID := swag.StringValue(params.TreeID)
-	tc := NewTrillianClient(ctx)
-	pk, err := tc.ranges.PublicKey(api.pubkey, treeID)
+	state := a.state()
+	// an unparseable tree ID is rejected by ranges.PublicKey
+	tid, _ := strconv.ParseInt(treeID, 10, 64)
+	pk, err := state.ranges.PublicKey(state.signerFor(tid).pubkey, treeID)
 	if err != nil {
 		return handleRekorAPIError(params, http.StatusBadRequest, err, "")
 	}
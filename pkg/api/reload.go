@@ -0,0 +1,312 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/sharding"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+// reloadableSettings are the settings in the config file that can be changed
+// without restarting the server. Settings that are read on each request take
+// effect for requests started after the reload.
+var reloadableSettings = map[string]bool{
+	"log_level":                           true,
+	"enable_attestation_storage":          true,
+	"max_attestation_size":                true,
+	"enable_timestamp_logging":            true,
+	"timestamp_note_radius":               true,
+	"trillian_log_server.tlog_id":         true,
+	"trillian_log_server.sharding_config": true,
+}
+
+// settings are the settings that can be changed by reloading the config.
+// Handlers read them from API.settings rather than from viper, which is not
+// safe for concurrent use and so is never changed after the server starts.
+type settings struct {
+	logLevel                 string
+	enableAttestationStorage bool
+	maxAttestationSize       int
+	enableTimestampLogging   bool
+	timestampNoteRadius      time.Duration
+	tlogID                   uint
+	shardingConfig           string
+}
+
+// settingsFrom returns the reloadable settings in v
+func settingsFrom(v *viper.Viper) settings {
+	return settings{
+		logLevel:                 v.GetString("log_level"),
+		enableAttestationStorage: v.GetBool("enable_attestation_storage"),
+		maxAttestationSize:       v.GetInt("max_attestation_size"),
+		enableTimestampLogging:   v.GetBool("enable_timestamp_logging"),
+		timestampNoteRadius:      v.GetDuration("timestamp_note_radius"),
+		tlogID:                   v.GetUint("trillian_log_server.tlog_id"),
+		shardingConfig:           v.GetString("trillian_log_server.sharding_config"),
+	}
+}
+
+// update returns the settings with those that changed set from the config
+// file read into v. A setting removed from the config file keeps its value.
+func (s settings) update(v *viper.Viper, changed map[string]bool) settings {
+	next := settingsFrom(v)
+	set := func(key string) bool {
+		return changed[key] && v.IsSet(key)
+	}
+	if set("log_level") {
+		s.logLevel = next.logLevel
+	}
+	if set("enable_attestation_storage") {
+		s.enableAttestationStorage = next.enableAttestationStorage
+	}
+	if set("max_attestation_size") {
+		s.maxAttestationSize = next.maxAttestationSize
+	}
+	if set("enable_timestamp_logging") {
+		s.enableTimestampLogging = next.enableTimestampLogging
+	}
+	if set("timestamp_note_radius") {
+		s.timestampNoteRadius = next.timestampNoteRadius
+	}
+	if set("trillian_log_server.tlog_id") {
+		s.tlogID = next.tlogID
+	}
+	if set("trillian_log_server.sharding_config") {
+		s.shardingConfig = next.shardingConfig
+	}
+	return s
+}
+
+// settings returns the current reloadable settings
+func (a *API) settings() settings {
+	return a.config.Load().(settings)
+}
+
+// configReloader reloads the config file and the sharding config
+type configReloader struct {
+	mu         sync.Mutex
+	configFile string
+	// settings from the config file when it was last loaded
+	settings *viper.Viper
+	// contents of the config file when it was last loaded
+	contents []byte
+	// store holds the sharding config, which is shared by every replica when
+	// stored in Redis and is otherwise the file set in the config
+	store sharding.Store
+	// sharding config when it was last loaded
+	sharding sharding.Config
+}
+
+func newConfigReloader(ctx context.Context, store sharding.Store) *configReloader {
+	r := &configReloader{
+		configFile: viper.ConfigFileUsed(),
+		settings:   viper.New(),
+		store:      store,
+	}
+	if r.configFile != "" {
+		r.settings.SetConfigFile(r.configFile)
+		if err := r.settings.ReadInConfig(); err != nil {
+			log.Logger.Warnf("reading config file: %v", err)
+		}
+	}
+	r.contents, r.sharding = r.read(ctx, r.storeFor(viper.GetString("trillian_log_server.sharding_config")))
+	return r
+}
+
+// storeFor returns the store of the sharding config when the sharding_config
+// setting is path
+func (r *configReloader) storeFor(path string) sharding.Store {
+	if _, ok := r.store.(sharding.FileStore); r.store != nil && !ok {
+		return r.store
+	}
+	if path == "" {
+		return nil
+	}
+	return sharding.FileStore(path)
+}
+
+// read returns the contents of the config file and the sharding config
+func (r *configReloader) read(ctx context.Context, store sharding.Store) ([]byte, sharding.Config) {
+	var contents []byte
+	if r.configFile != "" {
+		var err error
+		if contents, err = os.ReadFile(r.configFile); err != nil {
+			log.Logger.Warnf("reading %s: %v", r.configFile, err)
+		}
+	}
+	var config sharding.Config
+	if store != nil {
+		var err error
+		if config, err = store.ReadConfig(ctx); err != nil {
+			log.Logger.Warnf("reading sharding config: %v", err)
+		}
+	}
+	return contents, config
+}
+
+// changed returns whether the config file or the sharding config at path has
+// changed since it was loaded
+func (r *configReloader) changed(ctx context.Context, path string) bool {
+	contents, config := r.read(ctx, r.storeFor(path))
+	return !bytes.Equal(contents, r.contents) || !reflect.DeepEqual(config, r.sharding)
+}
+
+// Reload reloads the config file and the sharding config. The reload is
+// refused if it changes settings that require a restart, or the virtual index
+// of entries in the log.
+func (a *API) Reload(ctx context.Context) error {
+	return a.reloadConfig(ctx, true)
+}
+
+// requestReload asks the watcher of the config to reload it now rather than
+// at the next poll
+func (a *API) requestReload() {
+	select {
+	case a.reload <- struct{}{}:
+	default:
+	}
+}
+
+// watchConfig reloads the config when requested, or when the config file or
+// sharding config changes if the poll interval is set. This lets replicas
+// switch to a new active tree after a rotation without restarting.
+func (a *API) watchConfig(ctx context.Context, interval time.Duration) {
+	var poll <-chan time.Time
+	if interval > 0 {
+		tick := time.NewTicker(interval)
+		defer tick.Stop()
+		poll = tick.C
+	}
+	for {
+		force := false
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll:
+		case <-a.reload:
+			force = true
+		}
+		if err := a.reloadConfig(ctx, force); err != nil {
+			log.Logger.Warnf("reloading config: %v", err)
+		}
+	}
+}
+
+// reloadConfig reloads the config if forced or if it has changed. A refused
+// config isn't reloaded again until it changes or a reload is forced.
+func (a *API) reloadConfig(ctx context.Context, force bool) error {
+	r := a.reloader
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := a.settings()
+	if !force && !r.changed(ctx, current.shardingConfig) {
+		return nil
+	}
+
+	nextConfig := viper.New()
+	if r.configFile != "" {
+		nextConfig.SetConfigFile(r.configFile)
+		if err := nextConfig.ReadInConfig(); err != nil {
+			return fmt.Errorf("reading config file: %w", err)
+		}
+	}
+	changed := map[string]bool{}
+	var restart []string
+	for _, key := range append(r.settings.AllKeys(), nextConfig.AllKeys()...) {
+		if reflect.DeepEqual(r.settings.Get(key), nextConfig.Get(key)) || changed[key] {
+			continue
+		}
+		changed[key] = true
+		if !reloadableSettings[key] {
+			restart = append(restart, key)
+		}
+	}
+	if len(restart) > 0 {
+		sort.Strings(restart)
+		return fmt.Errorf("changing %s requires a restart", strings.Join(restart, ", "))
+	}
+	// the new settings are validated before any are applied
+	next := current.update(nextConfig, changed)
+	// remember the contents now, so that changes made while reloading are
+	// picked up by the next poll
+	store := r.storeFor(next.shardingConfig)
+	r.contents, r.sharding = r.read(ctx, store)
+	if next.logLevel != "" {
+		if _, err := zapcore.ParseLevel(next.logLevel); err != nil {
+			return fmt.Errorf("invalid log level: %w", err)
+		}
+	}
+	if next.enableAttestationStorage && a.storageClient == nil {
+		return errors.New("enabling attestation storage requires a restart")
+	}
+	currentState := a.state()
+	currentRanges := currentState.ranges
+	ranges := currentRanges
+	if a.logClient != nil {
+		var err error
+		treeID := next.tlogID
+		ranges = sharding.LogRanges{}
+		if store != nil {
+			ranges, err = sharding.NewLogRangesFromStore(ctx, a.logClient, store, treeID)
+			if err != nil {
+				return err
+			}
+		}
+		if ranges.ActiveTreeID() == 0 {
+			ranges.SetActive(int64(treeID))
+		}
+		if ranges.ActiveTreeID() == 0 {
+			// the tree was created when the server started, or the server
+			// started with the active tree of the sharding config
+			ranges.SetActive(currentRanges.ActiveTreeID())
+		}
+		if err := ranges.Extends(currentRanges); err != nil {
+			return fmt.Errorf("refusing to change existing log ranges: %w", err)
+		}
+	}
+	signers, err := shardSigners(ctx, ranges, currentState.signers)
+	if err != nil {
+		return err
+	}
+
+	if err := log.SetLevel(next.logLevel); err != nil {
+		return err
+	}
+	r.settings = nextConfig
+	a.config.Store(next)
+	types.SetMaxAttestationSize(next.maxAttestationSize)
+	a.logState.Store(&logState{ranges: ranges, active: currentState.active, signers: signers})
+	if ranges.ActiveTreeID() != currentRanges.ActiveTreeID() {
+		log.Logger.Infof("Switched active tree from %d to %d", currentRanges.ActiveTreeID(), ranges.ActiveTreeID())
+	}
+	log.Logger.Infof("Reloaded config with ranges %s", ranges.String())
+	return nil
+}
@@ -0,0 +1,203 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/sharding"
+	"github.com/sigstore/rekor/pkg/signer"
+)
+
+// RotateTree freezes the active tree and switches the log to a new tree.
+//
+// The active tree is read from the sharding config in the store, or is treeID
+// if the config doesn't record one. The tree is drained until its size has
+// been stable for the settle period, then frozen. A new tree is created, and
+// the frozen tree is appended to the inactive ranges of the sharding config
+// with its final length, the public key of the signer and its final
+// checkpoint signed by the signer, with the hostname as its origin. The signer
+// URI is recorded too, so that the frozen tree stays signed by its own key
+// once the active signer changes, unless it's the memory signer whose key is
+// lost on restart. Replicas
+// watching the sharding config switch to the new tree once the config is
+// written.
+//
+// Rotation can be retried if it fails part way through, as a tree that is
+// already draining or frozen is not written to again, and the new tree is
+// labelled with the tree it succeeds so that a retry reuses it rather than
+// creating another.
+func RotateTree(ctx context.Context, logClient trillian.TrillianLogClient, adminClient trillian.TrillianAdminClient, store sharding.Store, treeID int64, s signature.Signer, signerURI, hostname string, settle time.Duration) (int64, error) {
+	pk, err := s.PublicKey(options.WithContext(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("getting public key: %w", err)
+	}
+	pubkey, err := cryptoutils.MarshalPublicKeyToPEM(pk)
+	if err != nil {
+		return 0, fmt.Errorf("marshalling public key: %w", err)
+	}
+	config, err := store.ReadConfig(ctx)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		config = sharding.Config{}
+	case err != nil:
+		return 0, fmt.Errorf("reading sharding config: %w", err)
+	}
+	// the config is only written if it's unchanged when the rotation ends
+	prev := sharding.Config{ActiveTreeID: config.ActiveTreeID, Inactive: append(sharding.Ranges{}, config.Inactive...)}
+	active := config.ActiveTreeID
+	if active == 0 {
+		active = treeID
+	}
+	if active == 0 {
+		return 0, errors.New("the active tree must be set in the sharding config or by tlog_id")
+	}
+	for _, r := range config.Inactive {
+		if r.TreeID == active {
+			return 0, fmt.Errorf("active tree %d is already an inactive shard", active)
+		}
+	}
+
+	tree, err := adminClient.GetTree(ctx, &trillian.GetTreeRequest{TreeId: active})
+	if err != nil {
+		return 0, fmt.Errorf("getting tree %d: %w", active, err)
+	}
+	if tree.TreeState == trillian.TreeState_ACTIVE {
+		log.Logger.Infof("Draining tree %d", active)
+		if err := setTreeState(ctx, adminClient, active, trillian.TreeState_DRAINING); err != nil {
+			return 0, err
+		}
+	}
+	size, err := settledTreeSize(ctx, logClient, active, settle)
+	if err != nil {
+		return 0, err
+	}
+	if tree.TreeState != trillian.TreeState_FROZEN {
+		log.Logger.Infof("Freezing tree %d at size %d", active, size)
+		if err := setTreeState(ctx, adminClient, active, trillian.TreeState_FROZEN); err != nil {
+			return 0, err
+		}
+	}
+	// the tree could only have grown before it was frozen if the settle
+	// period was too short, in which case the final size is still correct
+	root, err := trillianClients(logClient)(ctx, active).root()
+	if err != nil {
+		return 0, fmt.Errorf("getting final root of tree %d: %w", active, err)
+	}
+	checkpoint, err := signCheckpoint(ctx, s, hostname, &root, active)
+	if err != nil {
+		return 0, fmt.Errorf("signing final checkpoint of tree %d: %w", active, err)
+	}
+
+	t, err := successorTree(ctx, logClient, adminClient, active)
+	if err != nil {
+		return 0, err
+	}
+	frozen := sharding.LogRange{
+		TreeID:           active,
+		TreeLength:       int64(root.TreeSize),
+		EncodedPublicKey: base64.StdEncoding.EncodeToString(pubkey),
+		SignedCheckpoint: string(checkpoint),
+	}
+	if signerURI != signer.MemoryScheme {
+		frozen.Signer = signerURI
+	}
+	config.Inactive = append(config.Inactive, frozen)
+	config.ActiveTreeID = t.TreeId
+	if err := store.WriteConfig(ctx, prev, config); err != nil {
+		return 0, fmt.Errorf("writing sharding config: %w", err)
+	}
+	log.Logger.Infof("Rotated from tree %d of size %d to tree %d", active, root.TreeSize, t.TreeId)
+	return t.TreeId, nil
+}
+
+// successorTree returns the tree that succeeds the active tree, creating it
+// unless an earlier rotation that failed before writing the sharding config
+// already did
+func successorTree(ctx context.Context, logClient trillian.TrillianLogClient, adminClient trillian.TrillianAdminClient, active int64) (*trillian.Tree, error) {
+	description := fmt.Sprintf("rekor shard succeeding tree %d", active)
+	trees, err := adminClient.ListTrees(ctx, &trillian.ListTreesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("listing trees: %w", err)
+	}
+	for _, t := range trees.Tree {
+		if t.Description != description || t.TreeState != trillian.TreeState_ACTIVE {
+			continue
+		}
+		// the earlier rotation may have failed before the tree was initialised
+		if _, err := logClient.InitLog(ctx, &trillian.InitLogRequest{LogId: t.TreeId}); err != nil && status.Code(err) != codes.AlreadyExists {
+			return nil, fmt.Errorf("init log: %w", err)
+		}
+		log.Logger.Infof("Reusing tree %d created by an earlier rotation", t.TreeId)
+		return t, nil
+	}
+	t, err := createAndInitTree(ctx, adminClient, logClient, description)
+	if err != nil {
+		return nil, fmt.Errorf("create and init tree: %w", err)
+	}
+	return t, nil
+}
+
+func setTreeState(ctx context.Context, adminClient trillian.TrillianAdminClient, treeID int64, state trillian.TreeState) error {
+	_, err := adminClient.UpdateTree(ctx, &trillian.UpdateTreeRequest{
+		Tree:       &trillian.Tree{TreeId: treeID, TreeState: state},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"tree_state"}},
+	})
+	if err != nil {
+		return fmt.Errorf("setting tree %d to %s: %w", treeID, state, err)
+	}
+	return nil
+}
+
+// settledTreeSize waits until leaves queued before the tree stopped accepting
+// new leaves are integrated, which is assumed once the size of the tree is
+// unchanged over the settle period
+func settledTreeSize(ctx context.Context, logClient trillian.TrillianLogClient, treeID int64, settle time.Duration) (uint64, error) {
+	tc := trillianClients(logClient)(ctx, treeID)
+	root, err := tc.root()
+	if err != nil {
+		return 0, fmt.Errorf("getting root of tree %d: %w", treeID, err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(settle):
+		}
+		latest, err := tc.root()
+		if err != nil {
+			return 0, fmt.Errorf("getting root of tree %d: %w", treeID, err)
+		}
+		if latest.TreeSize == root.TreeSize {
+			return root.TreeSize, nil
+		}
+		root = latest
+	}
+}
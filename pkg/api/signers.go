@@ -0,0 +1,139 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+
+	"github.com/sigstore/rekor/pkg/sharding"
+	"github.com/sigstore/rekor/pkg/signer"
+)
+
+// logSigner signs the checkpoints and entries of a tree
+type logSigner struct {
+	signature.Signer
+	uri        string // signer URI from the sharding config, empty for the active signer
+	pubkey     string // PEM encoded public key
+	pubkeyHash string // SHA256 hash of DER-encoded public key, which is the log ID
+}
+
+func newLogSigner(ctx context.Context, s signature.Signer) (logSigner, error) {
+	pk, err := s.PublicKey(options.WithContext(ctx))
+	if err != nil {
+		return logSigner{}, fmt.Errorf("getting public key: %w", err)
+	}
+	b, err := x509.MarshalPKIXPublicKey(pk)
+	if err != nil {
+		return logSigner{}, fmt.Errorf("marshalling public key: %w", err)
+	}
+	pubkeyHashBytes := sha256.Sum256(b)
+	return logSigner{
+		Signer:     s,
+		pubkey:     string(cryptoutils.PEMEncode(cryptoutils.PublicKeyPEMType, b)),
+		pubkeyHash: hex.EncodeToString(pubkeyHashBytes[:]),
+	}, nil
+}
+
+// shardSigners loads the signers of the inactive shards that set one in the
+// sharding config, reusing signers in prev with the same URI. The signer of a
+// shard that also sets a public key must have that key, and must have signed
+// the final checkpoint of the shard.
+func shardSigners(ctx context.Context, ranges sharding.LogRanges, prev map[int64]logSigner) (map[int64]logSigner, error) {
+	signers := map[int64]logSigner{}
+	for _, r := range ranges.GetInactive() {
+		if r.Signer == "" {
+			continue
+		}
+		ls, ok := prev[r.TreeID]
+		if !ok || ls.uri != r.Signer {
+			s, err := signer.New(ctx, r.Signer)
+			if err != nil {
+				return nil, fmt.Errorf("getting signer of tree %d: %w", r.TreeID, err)
+			}
+			ls, err = newLogSigner(ctx, s)
+			if err != nil {
+				return nil, fmt.Errorf("signer of tree %d: %w", r.TreeID, err)
+			}
+			ls.uri = r.Signer
+		}
+		pubkey, err := ranges.PublicKey(ls.pubkey, fmt.Sprint(r.TreeID))
+		if err != nil {
+			return nil, err
+		}
+		if pubkey != ls.pubkey {
+			if err := equalPEMKeys(pubkey, ls.pubkey); err != nil {
+				return nil, fmt.Errorf("signer of tree %d doesn't match its public key: %w", r.TreeID, err)
+			}
+		}
+		// a final checkpoint with a public key in the config was verified with
+		// it when the config was read
+		if r.EncodedPublicKey == "" {
+			pk, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(ls.pubkey))
+			if err != nil {
+				return nil, err
+			}
+			verifier, err := signature.LoadVerifier(pk, crypto.SHA256)
+			if err != nil {
+				return nil, err
+			}
+			if err := r.VerifyCheckpoint(verifier); err != nil {
+				return nil, err
+			}
+		}
+		signers[r.TreeID] = ls
+	}
+	return signers, nil
+}
+
+func equalPEMKeys(first, second string) error {
+	k1, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(first))
+	if err != nil {
+		return err
+	}
+	k2, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(second))
+	if err != nil {
+		return err
+	}
+	return cryptoutils.EqualKeys(k1, k2)
+}
+
+// logState holds the trees of the log along with their signers, which are
+// replaced together when the log is rotated or the config is reloaded
+type logState struct {
+	ranges sharding.LogRanges
+	// active is the signer of the active tree
+	active logSigner
+	// signers holds the signers of inactive shards by tree ID
+	signers map[int64]logSigner
+}
+
+// signerFor returns the signer of the tree, which is the signer set for the
+// shard in the sharding config, or otherwise the signer of the active tree
+func (s *logState) signerFor(tid int64) logSigner {
+	if ls, ok := s.signers[tid]; ok {
+		return ls
+	}
+	return s.active
+}
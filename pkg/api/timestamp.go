@@ -0,0 +1,136 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/timestamp"
+	rfc3161_v001 "github.com/sigstore/rekor/pkg/types/rfc3161/v0.0.1"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+const (
+	// maxTimestampRequestSize bounds the size of an accepted TimeStampReq
+	maxTimestampRequestSize = 10 * 1024
+	// maxTimestampNonceSize bounds the size of the nonce in a timestamp note
+	maxTimestampNonceSize = 64
+)
+
+// TimestampResponseHandler issues an RFC 3161 timestamp for the request, and
+// adds it to the log as an rfc3161 entry if timestamp logging is enabled
+func (a *API) TimestampResponseHandler(params timestamp.GetTimestampResponseParams) middleware.Responder {
+	if len(a.certChain) == 0 {
+		return handleRekorAPIError(params, http.StatusNotImplemented, errors.New("no timestamping certificate chain configured"), timestampingDisabled)
+	}
+	ctx := params.HTTPRequest.Context()
+
+	requestBytes, err := io.ReadAll(io.LimitReader(params.Request, maxTimestampRequestSize+1))
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusBadRequest, err, failedToReadTimestampRequest)
+	}
+	if len(requestBytes) > maxTimestampRequestSize {
+		return handleRekorAPIError(params, http.StatusBadRequest, errors.New("request too large"), fmt.Sprintf(timestampRequestTooLarge, maxTimestampRequestSize))
+	}
+	req, err := util.ParseTimestampRequest(requestBytes)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusBadRequest, err, fmt.Sprintf(invalidTimestampRequest, err))
+	}
+
+	resp, err := util.CreateRfc3161Response(ctx, *req, a.certChain, a.signer)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, err, signingError)
+	}
+	body, err := asn1.Marshal(*resp)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, err, signingError)
+	}
+
+	created := timestamp.NewGetTimestampResponseCreated().WithPayload(io.NopCloser(bytes.NewReader(body)))
+	if !a.settings().enableTimestampLogging {
+		return created
+	}
+
+	// the entry is created as if it was submitted to the entries endpoint,
+	// so that the returned location points at the log entry
+	entryReq := params.HTTPRequest.Clone(ctx)
+	entryReq.URL.Path = "/api/v1/log/entries"
+	logEntry, errResp := a.createLogEntry(entries.CreateLogEntryParams{
+		HTTPRequest:   entryReq,
+		ProposedEntry: rfc3161_v001.NewEntryFromBytes(body),
+	})
+	if errResp != nil {
+		return errResp
+	}
+	for uuid := range logEntry {
+		created = created.WithETag(uuid).WithLocation(getEntryURL(*entryReq.URL, uuid))
+	}
+	return created
+}
+
+// GetTimestampCertChainHandler returns the PEM encoded certificate chain
+// used to verify timestamps issued by this server
+func (a *API) GetTimestampCertChainHandler(params timestamp.GetTimestampCertChainParams) middleware.Responder {
+	if len(a.certChain) == 0 {
+		return timestamp.NewGetTimestampCertChainNotFound()
+	}
+	return timestamp.NewGetTimestampCertChainOK().WithPayload(a.certChainPem)
+}
+
+// TimestampNoteHandler returns a timestamp note for the message imprint and
+// nonce, signed by the log signer
+func (a *API) TimestampNoteHandler(params timestamp.GetTimestampNoteParams) middleware.Responder {
+	ctx := params.HTTPRequest.Context()
+	nonce := []byte(*params.Request.Nonce)
+	if len(nonce) > maxTimestampNonceSize {
+		return handleRekorAPIError(params, http.StatusBadRequest, errors.New("nonce too large"), fmt.Sprintf(timestampNonceTooLarge, maxTimestampNonceSize))
+	}
+
+	stn, err := util.CreateSignedTimestampNote(util.TimestampNote{
+		Origin:         a.hostname,
+		MessageImprint: swag.StringValue(params.Request.MessageImprint),
+		Nonce:          nonce,
+		Time:           time.Now().UTC(),
+		Radius:         a.settings().timestampNoteRadius.Microseconds(),
+		CertChainRef:   a.certChainRef,
+	})
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("marshalling error: %w", err), timestampNoteGenerateError)
+	}
+	if _, err := stn.Sign(a.hostname, a.signer, options.WithContext(ctx)); err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("signing error: %w", err), signingError)
+	}
+	b, err := stn.SignedNote.MarshalText()
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("marshalling error: %w", err), timestampNoteGenerateError)
+	}
+
+	return timestamp.NewGetTimestampNoteCreated().WithPayload(&models.TimestampNoteResponse{
+		SignedTimestampNote: swag.String(string(b)),
+	})
+}
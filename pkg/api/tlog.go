@@ -26,28 +26,30 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
 	"github.com/google/trillian/types"
-	"github.com/spf13/viper"
 	"google.golang.org/grpc/codes"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/tlog"
 	"github.com/sigstore/rekor/pkg/log"
 	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/sigstore/sigstore/pkg/signature/options"
 )
 
 // GetLogInfoHandler returns the current size of the tree and the STH
-func GetLogInfoHandler(params tlog.GetLogInfoParams) middleware.Responder {
-	tc := NewTrillianClient(params.HTTPRequest.Context())
+func (a *API) GetLogInfoHandler(params tlog.GetLogInfoParams) middleware.Responder {
+	state := a.state()
+	ranges := state.ranges
+	tc := a.NewLogClientFromTreeID(params.HTTPRequest.Context(), ranges.ActiveTreeID())
 
 	// for each inactive shard, get the loginfo
 	var inactiveShards []*models.InactiveShardLogInfo
-	for _, shard := range tc.ranges.GetInactive() {
-		if shard.TreeID == tc.ranges.ActiveTreeID() {
+	for _, shard := range ranges.GetInactive() {
+		if shard.TreeID == ranges.ActiveTreeID() {
 			break
 		}
 		// Get details for this inactive shard
-		is, err := inactiveShardLogInfo(params.HTTPRequest.Context(), shard.TreeID)
+		is, err := a.inactiveShardLogInfo(params.HTTPRequest.Context(), state, shard.TreeID)
 		if err != nil {
 			return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("inactive shard error: %w", err), unexpectedInactiveShardError)
 		}
@@ -69,7 +71,7 @@ func GetLogInfoHandler(params tlog.GetLogInfoParams) middleware.Responder {
 	treeSize := int64(root.TreeSize)
 
 	sth, err := util.CreateSignedCheckpoint(util.Checkpoint{
-		Origin: fmt.Sprintf("%s - %d", viper.GetString("rekor_server.hostname"), tc.ranges.ActiveTreeID()),
+		Origin: fmt.Sprintf("%s - %d", a.hostname, ranges.ActiveTreeID()),
 		Size:   root.TreeSize,
 		Hash:   root.RootHash,
 	})
@@ -79,7 +81,7 @@ func GetLogInfoHandler(params tlog.GetLogInfoParams) middleware.Responder {
 	sth.SetTimestamp(uint64(time.Now().UnixNano()))
 
 	// sign the log root ourselves to get the log root signature
-	_, err = sth.Sign(viper.GetString("rekor_server.hostname"), api.signer, options.WithContext(params.HTTPRequest.Context()))
+	_, err = sth.Sign(a.hostname, state.active, options.WithContext(params.HTTPRequest.Context()))
 	if err != nil {
 		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("signing error: %w", err), signingError)
 	}
@@ -94,9 +96,12 @@ func GetLogInfoHandler(params tlog.GetLogInfoParams) middleware.Responder {
 		RootHash:       &hashString,
 		TreeSize:       &treeSize,
 		SignedTreeHead: &scString,
-		TreeID:         stringPointer(fmt.Sprintf("%d", tc.logID)),
+		TreeID:         stringPointer(fmt.Sprintf("%d", ranges.ActiveTreeID())),
 		InactiveShards: inactiveShards,
 	}
+	if a.anchor != nil {
+		logInfo.CheckpointTimestamp = a.anchor.latest()
+	}
 
 	return tlog.NewGetLogInfoOK().WithPayload(&logInfo)
 }
@@ -106,17 +111,17 @@ func stringPointer(s string) *string {
 }
 
 // GetLogProofHandler returns information required to compute a consistency proof between two snapshots of log
-func GetLogProofHandler(params tlog.GetLogProofParams) middleware.Responder {
+func (a *API) GetLogProofHandler(params tlog.GetLogProofParams) middleware.Responder {
 	if *params.FirstSize > params.LastSize {
 		return handleRekorAPIError(params, http.StatusBadRequest, nil, fmt.Sprintf(firstSizeLessThanLastSize, *params.FirstSize, params.LastSize))
 	}
-	tc := NewTrillianClient(params.HTTPRequest.Context())
+	tc := a.NewLogClient(params.HTTPRequest.Context())
 	if treeID := swag.StringValue(params.TreeID); treeID != "" {
 		id, err := strconv.Atoi(treeID)
 		if err != nil {
 			log.Logger.Infof("Unable to convert %s to string, skipping initializing client with Tree ID: %v", treeID, err)
 		} else {
-			tc = NewTrillianClientFromTreeID(params.HTTPRequest.Context(), int64(id))
+			tc = a.NewLogClientFromTreeID(params.HTTPRequest.Context(), int64(id))
 		}
 	}
 
@@ -153,8 +158,8 @@ func GetLogProofHandler(params tlog.GetLogProofParams) middleware.Responder {
 	return tlog.NewGetLogProofOK().WithPayload(&consistencyProof)
 }
 
-func inactiveShardLogInfo(ctx context.Context, tid int64) (*models.InactiveShardLogInfo, error) {
-	tc := NewTrillianClientFromTreeID(ctx, tid)
+func (a *API) inactiveShardLogInfo(ctx context.Context, state *logState, tid int64) (*models.InactiveShardLogInfo, error) {
+	tc := a.NewLogClientFromTreeID(ctx, tid)
 	resp := tc.getLatest(0)
 	if resp.status != codes.OK {
 		return nil, fmt.Errorf("resp code is %d", resp.status)
@@ -169,8 +174,24 @@ func inactiveShardLogInfo(ctx context.Context, tid int64) (*models.InactiveShard
 	hashString := hex.EncodeToString(root.RootHash)
 	treeSize := int64(root.TreeSize)
 
+	scBytes, err := signCheckpoint(ctx, state.signerFor(tid), a.hostname, root, tid)
+	if err != nil {
+		return nil, err
+	}
+	m := models.InactiveShardLogInfo{
+		RootHash:       &hashString,
+		TreeSize:       &treeSize,
+		TreeID:         stringPointer(fmt.Sprintf("%d", tid)),
+		SignedTreeHead: stringPointer(string(scBytes)),
+	}
+	return &m, nil
+}
+
+// signCheckpoint returns a checkpoint for the log root of the tree, signed
+// with the given signer under the hostname
+func signCheckpoint(ctx context.Context, s signature.Signer, hostname string, root *types.LogRootV1, tid int64) ([]byte, error) {
 	sth, err := util.CreateSignedCheckpoint(util.Checkpoint{
-		Origin: fmt.Sprintf("%s - %d", viper.GetString("rekor_server.hostname"), tid),
+		Origin: fmt.Sprintf("%s - %d", hostname, tid),
 		Size:   root.TreeSize,
 		Hash:   root.RootHash,
 	})
@@ -180,19 +201,8 @@ func inactiveShardLogInfo(ctx context.Context, tid int64) (*models.InactiveShard
 	sth.SetTimestamp(uint64(time.Now().UnixNano()))
 
 	// sign the log root ourselves to get the log root signature
-	if _, err := sth.Sign(viper.GetString("rekor_server.hostname"), api.signer, options.WithContext(ctx)); err != nil {
+	if _, err := sth.Sign(hostname, s, options.WithContext(ctx)); err != nil {
 		return nil, err
 	}
-
-	scBytes, err := sth.SignedNote.MarshalText()
-	if err != nil {
-		return nil, err
-	}
-	m := models.InactiveShardLogInfo{
-		RootHash:       &hashString,
-		TreeSize:       &treeSize,
-		TreeID:         stringPointer(fmt.Sprintf("%d", tid)),
-		SignedTreeHead: stringPointer(string(scBytes)),
-	}
-	return &m, nil
+	return sth.SignedNote.MarshalText()
 }
@@ -22,7 +22,6 @@ import (
 	"time"
 
 	"github.com/sigstore/rekor/pkg/log"
-	"github.com/sigstore/rekor/pkg/sharding"
 	"github.com/transparency-dev/merkle/proof"
 	"github.com/transparency-dev/merkle/rfc6962"
 
@@ -35,27 +34,44 @@ import (
 	"github.com/google/trillian/types"
 )
 
+// LogClient is implemented by each backend holding the Merkle trees of the
+// log. Responses are expressed as Trillian API results regardless of backend.
+type LogClient interface {
+	root() (types.LogRootV1, error)
+	addLeaf(byteValue []byte) *Response
+	getLeafAndProofByHash(hash []byte) *Response
+	getLeafAndProofByIndex(index int64) *Response
+	getLatest(leafSizeInt int64) *Response
+	getConsistencyProof(firstSize, lastSize int64) *Response
+}
+
+// NewLogClient returns a client for the active tree
+func (a *API) NewLogClient(ctx context.Context) LogClient {
+	ranges := a.ranges()
+	return a.newLogClient(ctx, ranges.ActiveTreeID())
+}
+
+// NewLogClientFromTreeID returns a client for the tree with the given ID
+func (a *API) NewLogClientFromTreeID(ctx context.Context, tid int64) LogClient {
+	return a.newLogClient(ctx, tid)
+}
+
+// TrillianClient is the LogClient for trees stored in a Trillian log server
 type TrillianClient struct {
 	client  trillian.TrillianLogClient
-	ranges  sharding.LogRanges
 	logID   int64
 	context context.Context
 }
 
-func NewTrillianClient(ctx context.Context) TrillianClient {
-	return TrillianClient{
-		client:  api.logClient,
-		ranges:  api.logRanges,
-		logID:   api.logID,
-		context: ctx,
-	}
-}
-
-func NewTrillianClientFromTreeID(ctx context.Context, tid int64) TrillianClient {
-	return TrillianClient{
-		client:  api.logClient,
-		logID:   tid,
-		context: ctx,
+// trillianClients returns a constructor of clients using the connection to
+// the Trillian log server
+func trillianClients(logClient trillian.TrillianLogClient) func(context.Context, int64) LogClient {
+	return func(ctx context.Context, tid int64) LogClient {
+		return &TrillianClient{
+			client:  logClient,
+			logID:   tid,
+			context: ctx,
+		}
 	}
 }
 
@@ -351,11 +367,12 @@ func (t *TrillianClient) getConsistencyProof(firstSize, lastSize int64) *Respons
 	}
 }
 
-func createAndInitTree(ctx context.Context, adminClient trillian.TrillianAdminClient, logClient trillian.TrillianLogClient) (*trillian.Tree, error) {
+func createAndInitTree(ctx context.Context, adminClient trillian.TrillianAdminClient, logClient trillian.TrillianLogClient, description string) (*trillian.Tree, error) {
 	t, err := adminClient.CreateTree(ctx, &trillian.CreateTreeRequest{
 		Tree: &trillian.Tree{
 			TreeType:        trillian.TreeType_LOG,
 			TreeState:       trillian.TreeState_ACTIVE,
+			Description:     description,
 			MaxRootDuration: durationpb.New(time.Hour),
 		},
 	})
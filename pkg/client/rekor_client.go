@@ -48,5 +48,6 @@ func GetRekorClient(rekorServerURL string, opts ...Option) (*client.Rekor, error
 
 	registry := strfmt.Default
 	registry.Add("signedCheckpoint", &util.SignedNote{}, util.SignedCheckpointValidator)
+	registry.Add("signedTimestampNote", &util.SignedNote{}, util.SignedTimestampNoteValidator)
 	return client.New(rt, registry), nil
 }
@@ -0,0 +1,380 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package embedded implements a Merkle tree log that runs inside the rekor
+// server process, persisted to a local append-only file, as an alternative to
+// a Trillian log server for development and integration tests.
+package embedded
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+// fileMagic starts every log file, followed by the big-endian tree ID
+var fileMagic = []byte("rekorlg1")
+
+// maxLeafSize bounds the size of a single leaf read from a log file
+const maxLeafSize = 64 * 1024 * 1024
+
+var (
+	// ErrNotFound is returned when a leaf is not in the log
+	ErrNotFound = errors.New("leaf not found")
+	// ErrOutOfRange is returned when a tree size or index is beyond the log
+	ErrOutOfRange = errors.New("out of range")
+)
+
+// Leaf is an entry in the log
+type Leaf struct {
+	Index          uint64
+	Value          []byte
+	MerkleLeafHash []byte
+	IntegratedTime time.Time
+}
+
+// logFile is the file a log is persisted to
+type logFile interface {
+	io.ReadWriteSeeker
+	io.Closer
+	Stat() (os.FileInfo, error)
+	Sync() error
+	Truncate(size int64) error
+}
+
+// Log is an append-only Merkle tree log. The whole log is held in memory, and
+// every appended leaf is written to the log file, if there is one, before it
+// is integrated. It is safe for concurrent use.
+type Log struct {
+	mu     sync.RWMutex
+	treeID int64
+	file   logFile
+	// broken is set if a record that failed to be written could not be
+	// removed from the log file, after which nothing more can be appended
+	broken error
+
+	leaves []Leaf
+	byHash map[string]uint64
+	// nodes holds the hash of every perfect subtree, by level and index
+	nodes [][][]byte
+	rng   *compact.Range
+}
+
+// Open opens the log persisted at path, creating it with a random tree ID if
+// it does not exist. If path is empty the log is only held in memory.
+func Open(path string) (*Log, error) {
+	l := &Log{
+		byHash: map[string]uint64{},
+		rng:    (&compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren}).NewEmptyRange(0),
+	}
+	if path == "" {
+		treeID, err := newTreeID()
+		if err != nil {
+			return nil, err
+		}
+		l.treeID = treeID
+		return l, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	l.file = f
+	if err := l.load(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("loading log from %s: %w", path, err)
+	}
+	return l, nil
+}
+
+func newTreeID() (int64, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		return 0, err
+	}
+	return n.Int64() + 1, nil
+}
+
+// load reads the log file, writing a header to it if it is empty. A record
+// that was only partially written is discarded.
+func (l *Log) load() error {
+	fi, err := l.file.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.Size() == 0 {
+		treeID, err := newTreeID()
+		if err != nil {
+			return err
+		}
+		header := make([]byte, len(fileMagic)+8)
+		copy(header, fileMagic)
+		binary.BigEndian.PutUint64(header[len(fileMagic):], uint64(treeID))
+		if _, err := l.file.Write(header); err != nil {
+			return err
+		}
+		l.treeID = treeID
+		return l.file.Sync()
+	}
+
+	r := bufio.NewReader(l.file)
+	header := make([]byte, len(fileMagic)+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return fmt.Errorf("reading header: %w", err)
+	}
+	if !bytes.Equal(header[:len(fileMagic)], fileMagic) {
+		return errors.New("not an embedded log file")
+	}
+	l.treeID = int64(binary.BigEndian.Uint64(header[len(fileMagic):]))
+
+	offset := int64(len(header))
+	for {
+		ts, value, n, err := readRecord(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			// the server stopped while the record was written, so it was never
+			// integrated or returned to a client
+			if err := l.file.Truncate(offset); err != nil {
+				return err
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := l.integrate(value, ts); err != nil {
+			return err
+		}
+		offset += n
+	}
+	_, err = l.file.Seek(offset, io.SeekStart)
+	return err
+}
+
+// readRecord reads a record of the integration time in nanoseconds, the
+// length of the value and the value
+func readRecord(r io.Reader) (time.Time, []byte, int64, error) {
+	prefix := make([]byte, 12)
+	if n, err := io.ReadFull(r, prefix); err != nil {
+		if n > 0 && errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return time.Time{}, nil, 0, err
+	}
+	ts := time.Unix(0, int64(binary.BigEndian.Uint64(prefix[:8]))).UTC()
+	size := binary.BigEndian.Uint32(prefix[8:])
+	if size > maxLeafSize {
+		return time.Time{}, nil, 0, fmt.Errorf("leaf of %d bytes exceeds the maximum size", size)
+	}
+	value := make([]byte, size)
+	if _, err := io.ReadFull(r, value); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return time.Time{}, nil, 0, err
+	}
+	return ts, value, int64(len(prefix)) + int64(size), nil
+}
+
+// Close closes the log file
+func (l *Log) Close() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+// TreeID returns the ID of the tree, which is fixed when the log is created
+func (l *Log) TreeID() int64 {
+	return l.treeID
+}
+
+// Append adds a leaf to the log and returns it. If a leaf with the same value
+// is already in the log, the existing leaf is returned along with false.
+func (l *Log) Append(value []byte) (Leaf, bool, error) {
+	hash := rfc6962.DefaultHasher.HashLeaf(value)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index, ok := l.byHash[string(hash)]; ok {
+		return l.leaves[index], false, nil
+	}
+	if len(value) > maxLeafSize {
+		return Leaf{}, false, fmt.Errorf("leaf of %d bytes exceeds the maximum size", len(value))
+	}
+
+	if l.broken != nil {
+		return Leaf{}, false, l.broken
+	}
+
+	ts := time.Now().UTC()
+	if l.file == nil {
+		if err := l.integrate(value, ts); err != nil {
+			return Leaf{}, false, err
+		}
+		return l.leaves[len(l.leaves)-1], true, nil
+	}
+
+	offset, err := l.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return Leaf{}, false, err
+	}
+	record := make([]byte, 12+len(value))
+	binary.BigEndian.PutUint64(record[:8], uint64(ts.UnixNano()))
+	binary.BigEndian.PutUint32(record[8:12], uint32(len(value)))
+	copy(record[12:], value)
+	if _, err := l.file.Write(record); err != nil {
+		return Leaf{}, false, l.discard(offset, fmt.Errorf("writing leaf: %w", err))
+	}
+	if err := l.file.Sync(); err != nil {
+		return Leaf{}, false, l.discard(offset, fmt.Errorf("syncing log file: %w", err))
+	}
+	if err := l.integrate(value, ts); err != nil {
+		return Leaf{}, false, l.discard(offset, err)
+	}
+	return l.leaves[len(l.leaves)-1], true, nil
+}
+
+// discard truncates the log file back to offset, removing a record that may
+// have been partially written, as it is not in the tree. Otherwise the next
+// record would be written after it, and the log could not be loaded again.
+// The caller must hold the lock.
+func (l *Log) discard(offset int64, err error) error {
+	if terr := l.file.Truncate(offset); terr != nil {
+		l.broken = fmt.Errorf("log file has a partially written record: %w", terr)
+		return fmt.Errorf("%v, and truncating the log file: %w", err, terr)
+	}
+	if _, serr := l.file.Seek(offset, io.SeekStart); serr != nil {
+		l.broken = fmt.Errorf("log file has a partially written record: %w", serr)
+		return fmt.Errorf("%v, and seeking in the log file: %w", err, serr)
+	}
+	return err
+}
+
+// integrate adds the leaf to the in-memory tree. The caller must hold the lock.
+func (l *Log) integrate(value []byte, ts time.Time) error {
+	hash := rfc6962.DefaultHasher.HashLeaf(value)
+	index := uint64(len(l.leaves))
+	if err := l.rng.Append(hash, func(id compact.NodeID, h []byte) {
+		for uint(len(l.nodes)) <= id.Level {
+			l.nodes = append(l.nodes, nil)
+		}
+		l.nodes[id.Level] = append(l.nodes[id.Level], h)
+	}); err != nil {
+		return err
+	}
+	l.leaves = append(l.leaves, Leaf{
+		Index:          index,
+		Value:          value,
+		MerkleLeafHash: hash,
+		IntegratedTime: ts,
+	})
+	l.byHash[string(hash)] = index
+	return nil
+}
+
+// Root returns the size and root hash of the tree
+func (l *Log) Root() (uint64, []byte, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	size := uint64(len(l.leaves))
+	if size == 0 {
+		return 0, rfc6962.DefaultHasher.EmptyRoot(), nil
+	}
+	root, err := l.rng.GetRootHash(nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	return size, root, nil
+}
+
+// LeafByIndex returns the leaf at index
+func (l *Log) LeafByIndex(index uint64) (Leaf, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if index >= uint64(len(l.leaves)) {
+		return Leaf{}, ErrOutOfRange
+	}
+	return l.leaves[index], nil
+}
+
+// LeafByHash returns the leaf with the Merkle leaf hash
+func (l *Log) LeafByHash(hash []byte) (Leaf, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	index, ok := l.byHash[string(hash)]
+	if !ok {
+		return Leaf{}, ErrNotFound
+	}
+	return l.leaves[index], nil
+}
+
+// InclusionProof returns the inclusion proof for the leaf at index in the
+// tree of the given size
+func (l *Log) InclusionProof(index, size uint64) ([][]byte, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if size > uint64(len(l.leaves)) || index >= size {
+		return nil, ErrOutOfRange
+	}
+	nodes, err := proof.Inclusion(index, size)
+	if err != nil {
+		return nil, err
+	}
+	return l.rehash(nodes)
+}
+
+// ConsistencyProof returns the consistency proof between the trees of the
+// given sizes
+func (l *Log) ConsistencyProof(size1, size2 uint64) ([][]byte, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if size2 > uint64(len(l.leaves)) || size1 > size2 {
+		return nil, ErrOutOfRange
+	}
+	nodes, err := proof.Consistency(size1, size2)
+	if err != nil {
+		return nil, err
+	}
+	return l.rehash(nodes)
+}
+
+// rehash looks up the hashes of the perfect subtrees in the proof, and
+// combines those of ephemeral nodes. The caller must hold the lock.
+func (l *Log) rehash(nodes proof.Nodes) ([][]byte, error) {
+	hashes := make([][]byte, 0, len(nodes.IDs))
+	for _, id := range nodes.IDs {
+		if id.Level >= uint(len(l.nodes)) || id.Index >= uint64(len(l.nodes[id.Level])) {
+			return nil, fmt.Errorf("missing node (%d, %d)", id.Level, id.Index)
+		}
+		hashes = append(hashes, l.nodes[id.Level][id.Index])
+	}
+	return nodes.Rehash(hashes, rfc6962.DefaultHasher.HashChildren)
+}
@@ -0,0 +1,270 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedded
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+func appendLeaves(t *testing.T, l *Log, start, end int) {
+	t.Helper()
+	for i := start; i < end; i++ {
+		if _, added, err := l.Append([]byte(fmt.Sprintf("leaf %d", i))); err != nil || !added {
+			t.Fatalf("Append(%d) = %v, %v", i, added, err)
+		}
+	}
+}
+
+func TestProofs(t *testing.T) {
+	l, err := Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	size, root, err := l.Root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 0 || !bytes.Equal(root, rfc6962.DefaultHasher.EmptyRoot()) {
+		t.Fatalf("unexpected empty root %d %x", size, root)
+	}
+
+	roots := map[uint64][]byte{0: root}
+	for i := 0; i < 33; i++ {
+		appendLeaves(t, l, i, i+1)
+		size, root, err := l.Root()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size != uint64(i+1) {
+			t.Fatalf("unexpected size %d after %d leaves", size, i+1)
+		}
+		roots[size] = root
+	}
+
+	for size := uint64(1); size <= 33; size++ {
+		for index := uint64(0); index < size; index++ {
+			leaf, err := l.LeafByIndex(index)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hashes, err := l.InclusionProof(index, size)
+			if err != nil {
+				t.Fatalf("InclusionProof(%d, %d): %v", index, size, err)
+			}
+			if err := proof.VerifyInclusion(rfc6962.DefaultHasher, index, size, leaf.MerkleLeafHash, hashes, roots[size]); err != nil {
+				t.Errorf("VerifyInclusion(%d, %d): %v", index, size, err)
+			}
+		}
+		for size1 := uint64(0); size1 <= size; size1++ {
+			hashes, err := l.ConsistencyProof(size1, size)
+			if err != nil {
+				t.Fatalf("ConsistencyProof(%d, %d): %v", size1, size, err)
+			}
+			if err := proof.VerifyConsistency(rfc6962.DefaultHasher, size1, size, hashes, roots[size1], roots[size]); err != nil {
+				t.Errorf("VerifyConsistency(%d, %d): %v", size1, size, err)
+			}
+		}
+	}
+
+	if _, err := l.InclusionProof(0, 34); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("expected out of range error, got %v", err)
+	}
+	if _, err := l.ConsistencyProof(10, 34); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("expected out of range error, got %v", err)
+	}
+	if _, err := l.LeafByIndex(33); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("expected out of range error, got %v", err)
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	l, err := Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	appendLeaves(t, l, 0, 3)
+
+	leaf, added, err := l.Append([]byte("leaf 1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added || leaf.Index != 1 {
+		t.Errorf("expected existing leaf 1, got %d (added %v)", leaf.Index, added)
+	}
+	if size, _, _ := l.Root(); size != 3 {
+		t.Errorf("duplicate was appended, size %d", size)
+	}
+
+	byHash, err := l.LeafByHash(rfc6962.DefaultHasher.HashLeaf([]byte("leaf 2")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byHash.Index != 2 || string(byHash.Value) != "leaf 2" {
+		t.Errorf("unexpected leaf %+v", byHash)
+	}
+	if _, err := l.LeafByHash([]byte("missing")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appendLeaves(t, l, 0, 10)
+	treeID := l.TreeID()
+	_, root, err := l.Root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := l.LeafByIndex(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a crash part way through writing a record
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte{0, 0, 0, 1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	l, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if l.TreeID() != treeID {
+		t.Errorf("tree ID changed from %d to %d", treeID, l.TreeID())
+	}
+	size, reopenedRoot, err := l.Root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 10 || !bytes.Equal(root, reopenedRoot) {
+		t.Fatalf("unexpected root after reopening: %d %x", size, reopenedRoot)
+	}
+	reopenedFirst, err := l.LeafByIndex(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reopenedFirst.IntegratedTime.Equal(first.IntegratedTime) {
+		t.Errorf("integrated time changed from %v to %v", first.IntegratedTime, reopenedFirst.IntegratedTime)
+	}
+
+	// leaves appended after the truncated record are persisted as well
+	appendLeaves(t, l, 10, 12)
+	l.Close()
+	l, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if size, _, _ := l.Root(); size != 12 {
+		t.Errorf("expected 12 leaves, got %d", size)
+	}
+}
+
+// failingFile writes only part of the first record written to it, or fails
+// to sync it
+type failingFile struct {
+	logFile
+	failWrite, failSync bool
+}
+
+func (f *failingFile) Write(p []byte) (int, error) {
+	if f.failWrite {
+		f.failWrite = false
+		n, _ := f.logFile.Write(p[:len(p)/2])
+		return n, errors.New("disk full")
+	}
+	return f.logFile.Write(p)
+}
+
+func (f *failingFile) Sync() error {
+	if f.failSync {
+		f.failSync = false
+		return errors.New("sync failed")
+	}
+	return f.logFile.Sync()
+}
+
+func TestFailedAppend(t *testing.T) {
+	for _, f := range []*failingFile{{failWrite: true}, {failSync: true}} {
+		path := filepath.Join(t.TempDir(), "log")
+		l, err := Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		appendLeaves(t, l, 0, 2)
+		f.logFile = l.file
+		l.file = f
+		if _, _, err := l.Append([]byte("failed leaf")); err == nil {
+			t.Fatal("expected append to fail")
+		}
+		// the failed record is removed, so later leaves can be loaded
+		appendLeaves(t, l, 2, 4)
+		_, root, err := l.Root()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := l.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		l, err = Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size, reopenedRoot, err := l.Root()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size != 4 || !bytes.Equal(root, reopenedRoot) {
+			t.Errorf("unexpected root after reopening: %d %x", size, reopenedRoot)
+		}
+		l.Close()
+	}
+}
+
+func TestOpenInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	if err := os.WriteFile(path, []byte("this is not a log file"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("expected error opening invalid file")
+	}
+}
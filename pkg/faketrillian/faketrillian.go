@@ -0,0 +1,573 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faketrillian implements an in-process fake of the Trillian log and
+// admin APIs for tests. Trees have real Merkle semantics: leaves are queued,
+// integrated in order once the integration delay has passed, and proofs are
+// computed over the integrated leaves.
+package faketrillian
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sigstore/rekor/pkg/embedded"
+)
+
+// Trillian is a fake Trillian server, implementing both
+// trillian.TrillianLogClient and trillian.TrillianAdminClient. It is safe for
+// concurrent use.
+type Trillian struct {
+	mu    sync.Mutex
+	delay time.Duration
+	trees map[int64]*tree
+	next  int64
+}
+
+var (
+	_ trillian.TrillianLogClient   = &Trillian{}
+	_ trillian.TrillianAdminClient = &Trillian{}
+)
+
+type queuedLeaf struct {
+	leaf     *trillian.LogLeaf
+	queuedAt time.Time
+}
+
+type tree struct {
+	tree *trillian.Tree
+	// log holds the integrated leaves
+	log   *embedded.Log
+	queue []queuedLeaf
+	// root is the latest integrated root, nil until the log is initialized
+	root *types.LogRootV1
+}
+
+// New returns a fake Trillian server. Queued leaves are integrated once
+// integrationDelay has passed, as observed by the next call reading the tree.
+func New(integrationDelay time.Duration) *Trillian {
+	return &Trillian{
+		delay: integrationDelay,
+		trees: map[int64]*tree{},
+		next:  1,
+	}
+}
+
+// CreateLog creates and initializes a log tree, returning its ID
+func (f *Trillian) CreateLog(ctx context.Context) (int64, error) {
+	t, err := f.CreateTree(ctx, &trillian.CreateTreeRequest{
+		Tree: &trillian.Tree{
+			TreeType:  trillian.TreeType_LOG,
+			TreeState: trillian.TreeState_ACTIVE,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := f.InitLog(ctx, &trillian.InitLogRequest{LogId: t.TreeId}); err != nil {
+		return 0, err
+	}
+	return t.TreeId, nil
+}
+
+// Integrate integrates every queued leaf of the tree, regardless of the
+// integration delay
+func (f *Trillian) Integrate(treeID int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.trees[treeID]
+	if !ok || t.root == nil {
+		return fmt.Errorf("log %d not found", treeID)
+	}
+	return t.integrate(time.Time{})
+}
+
+// integrate adds the leaves queued before the cutoff to the tree, and
+// advances the root if any were added. A zero cutoff integrates every leaf.
+func (t *tree) integrate(cutoff time.Time) error {
+	n := 0
+	for _, q := range t.queue {
+		if !cutoff.IsZero() && q.queuedAt.After(cutoff) {
+			break
+		}
+		if _, _, err := t.log.Append(q.leaf.LeafValue); err != nil {
+			return err
+		}
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+	t.queue = t.queue[n:]
+
+	size, hash, err := t.log.Root()
+	if err != nil {
+		return err
+	}
+	ts := uint64(time.Now().UnixNano())
+	if ts <= t.root.TimestampNanos {
+		// roots must have strictly increasing timestamps for clients to apply them
+		ts = t.root.TimestampNanos + 1
+	}
+	t.root = &types.LogRootV1{
+		TreeSize:       size,
+		RootHash:       hash,
+		TimestampNanos: ts,
+		Revision:       t.root.Revision + 1,
+	}
+	return nil
+}
+
+func (t *tree) signedLogRoot() (*trillian.SignedLogRoot, error) {
+	b, err := t.root.MarshalBinary()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &trillian.SignedLogRoot{LogRoot: b}, nil
+}
+
+func (t *tree) logLeaf(index uint64) (*trillian.LogLeaf, error) {
+	leaf, err := t.log.LeafByIndex(index)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &trillian.LogLeaf{
+		MerkleLeafHash:     leaf.MerkleLeafHash,
+		LeafValue:          leaf.Value,
+		LeafIndex:          int64(leaf.Index),
+		LeafIdentityHash:   leaf.MerkleLeafHash,
+		IntegrateTimestamp: timestamppb.New(leaf.IntegratedTime),
+	}, nil
+}
+
+// readLog returns the initialized log tree, after integrating any leaves
+// whose integration delay has passed. The caller must hold the lock.
+func (f *Trillian) readLog(treeID int64) (*tree, error) {
+	t, ok := f.trees[treeID]
+	if !ok || t.tree.Deleted {
+		return nil, status.Errorf(codes.NotFound, "tree %d not found", treeID)
+	}
+	if t.root == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "log %d is not initialized", treeID)
+	}
+	if err := t.integrate(time.Now().Add(-f.delay)); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return t, nil
+}
+
+// QueueLeaf implements trillian.TrillianLogClient
+func (f *Trillian) QueueLeaf(ctx context.Context, in *trillian.QueueLeafRequest, opts ...grpc.CallOption) (*trillian.QueueLeafResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.readLog(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	if t.tree.TreeState != trillian.TreeState_ACTIVE {
+		return nil, status.Errorf(codes.FailedPrecondition, "tree %d is %s", in.LogId, t.tree.TreeState)
+	}
+	if in.Leaf == nil || len(in.Leaf.LeafValue) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "QueueLeafRequest.Leaf.LeafValue: empty")
+	}
+
+	hash := rfc6962.DefaultHasher.HashLeaf(in.Leaf.LeafValue)
+	if existing, err := t.log.LeafByHash(hash); err == nil {
+		leaf, err := t.logLeaf(existing.Index)
+		if err != nil {
+			return nil, err
+		}
+		return duplicate(leaf), nil
+	}
+	for _, q := range t.queue {
+		if string(q.leaf.MerkleLeafHash) == string(hash) {
+			return duplicate(proto.Clone(q.leaf).(*trillian.LogLeaf)), nil
+		}
+	}
+
+	now := time.Now()
+	leaf := &trillian.LogLeaf{
+		MerkleLeafHash:   hash,
+		LeafValue:        in.Leaf.LeafValue,
+		ExtraData:        in.Leaf.ExtraData,
+		LeafIdentityHash: hash,
+		QueueTimestamp:   timestamppb.New(now),
+	}
+	t.queue = append(t.queue, queuedLeaf{leaf: leaf, queuedAt: now})
+	if f.delay == 0 {
+		if err := t.integrate(now); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return &trillian.QueueLeafResponse{
+		QueuedLeaf: &trillian.QueuedLogLeaf{Leaf: proto.Clone(leaf).(*trillian.LogLeaf)},
+	}, nil
+}
+
+func duplicate(leaf *trillian.LogLeaf) *trillian.QueueLeafResponse {
+	return &trillian.QueueLeafResponse{
+		QueuedLeaf: &trillian.QueuedLogLeaf{
+			Leaf:   leaf,
+			Status: status.New(codes.AlreadyExists, "leaf already exists").Proto(),
+		},
+	}
+}
+
+// AddSequencedLeaves implements trillian.TrillianLogClient. Pre-ordered logs
+// are not supported.
+func (f *Trillian) AddSequencedLeaves(ctx context.Context, in *trillian.AddSequencedLeavesRequest, opts ...grpc.CallOption) (*trillian.AddSequencedLeavesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "pre-ordered logs are not supported")
+}
+
+// GetInclusionProof implements trillian.TrillianLogClient
+func (f *Trillian) GetInclusionProof(ctx context.Context, in *trillian.GetInclusionProofRequest, opts ...grpc.CallOption) (*trillian.GetInclusionProofResponse, error) {
+	switch {
+	case in.TreeSize <= 0:
+		return nil, status.Errorf(codes.InvalidArgument, "GetInclusionProofRequest.TreeSize: %v, want > 0", in.TreeSize)
+	case in.LeafIndex < 0 || in.LeafIndex >= in.TreeSize:
+		return nil, status.Errorf(codes.InvalidArgument, "GetInclusionProofRequest.LeafIndex: %v, want in [0, %v)", in.LeafIndex, in.TreeSize)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.readLog(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	slr, err := t.signedLogRoot()
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetInclusionProofResponse{SignedLogRoot: slr}
+	if uint64(in.TreeSize) > t.root.TreeSize {
+		return resp, nil
+	}
+	resp.Proof, err = t.inclusionProof(in.LeafIndex, in.TreeSize)
+	return resp, err
+}
+
+func (t *tree) inclusionProof(index, size int64) (*trillian.Proof, error) {
+	hashes, err := t.log.InclusionProof(uint64(index), uint64(size))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &trillian.Proof{LeafIndex: index, Hashes: hashes}, nil
+}
+
+// GetInclusionProofByHash implements trillian.TrillianLogClient
+func (f *Trillian) GetInclusionProofByHash(ctx context.Context, in *trillian.GetInclusionProofByHashRequest, opts ...grpc.CallOption) (*trillian.GetInclusionProofByHashResponse, error) {
+	switch {
+	case in.TreeSize <= 0:
+		return nil, status.Errorf(codes.InvalidArgument, "GetInclusionProofByHashRequest.TreeSize: %v, want > 0", in.TreeSize)
+	case len(in.LeafHash) != rfc6962.DefaultHasher.Size():
+		return nil, status.Errorf(codes.InvalidArgument, "GetInclusionProofByHashRequest.LeafHash: %d bytes, want %d", len(in.LeafHash), rfc6962.DefaultHasher.Size())
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.readLog(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	leaf, err := t.log.LeafByHash(in.LeafHash)
+	if err != nil || leaf.Index >= uint64(in.TreeSize) || uint64(in.TreeSize) > t.root.TreeSize {
+		return nil, status.Errorf(codes.NotFound, "No leaf found for hash: %x in tree size %v", in.LeafHash, in.TreeSize)
+	}
+	slr, err := t.signedLogRoot()
+	if err != nil {
+		return nil, err
+	}
+	p, err := t.inclusionProof(int64(leaf.Index), in.TreeSize)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetInclusionProofByHashResponse{
+		SignedLogRoot: slr,
+		Proof:         []*trillian.Proof{p},
+	}, nil
+}
+
+// GetConsistencyProof implements trillian.TrillianLogClient
+func (f *Trillian) GetConsistencyProof(ctx context.Context, in *trillian.GetConsistencyProofRequest, opts ...grpc.CallOption) (*trillian.GetConsistencyProofResponse, error) {
+	if err := validateConsistencyProofRequest(in.FirstTreeSize, in.SecondTreeSize); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.readLog(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	slr, err := t.signedLogRoot()
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetConsistencyProofResponse{SignedLogRoot: slr}
+	if uint64(in.SecondTreeSize) > t.root.TreeSize {
+		return resp, nil
+	}
+	resp.Proof, err = t.consistencyProof(in.FirstTreeSize, in.SecondTreeSize)
+	return resp, err
+}
+
+func validateConsistencyProofRequest(first, second int64) error {
+	switch {
+	case first <= 0:
+		return status.Errorf(codes.InvalidArgument, "GetConsistencyProofRequest.FirstTreeSize: %v, want > 0", first)
+	case second < first:
+		return status.Errorf(codes.InvalidArgument, "GetConsistencyProofRequest.SecondTreeSize: %v < GetConsistencyProofRequest.FirstTreeSize: %v, want >= ", second, first)
+	}
+	return nil
+}
+
+func (t *tree) consistencyProof(first, second int64) (*trillian.Proof, error) {
+	hashes, err := t.log.ConsistencyProof(uint64(first), uint64(second))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &trillian.Proof{Hashes: hashes}, nil
+}
+
+// GetLatestSignedLogRoot implements trillian.TrillianLogClient
+func (f *Trillian) GetLatestSignedLogRoot(ctx context.Context, in *trillian.GetLatestSignedLogRootRequest, opts ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.readLog(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	slr, err := t.signedLogRoot()
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: slr}
+	if in.FirstTreeSize == 0 {
+		return resp, nil
+	}
+	if err := validateConsistencyProofRequest(in.FirstTreeSize, int64(t.root.TreeSize)); err != nil {
+		return nil, err
+	}
+	resp.Proof, err = t.consistencyProof(in.FirstTreeSize, int64(t.root.TreeSize))
+	return resp, err
+}
+
+// GetEntryAndProof implements trillian.TrillianLogClient
+func (f *Trillian) GetEntryAndProof(ctx context.Context, in *trillian.GetEntryAndProofRequest, opts ...grpc.CallOption) (*trillian.GetEntryAndProofResponse, error) {
+	switch {
+	case in.TreeSize <= 0:
+		return nil, status.Errorf(codes.InvalidArgument, "GetEntryAndProofRequest.TreeSize: %v, want > 0", in.TreeSize)
+	case in.LeafIndex < 0 || in.LeafIndex >= in.TreeSize:
+		return nil, status.Errorf(codes.InvalidArgument, "GetEntryAndProofRequest.LeafIndex: %v, want in [0, %v)", in.LeafIndex, in.TreeSize)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.readLog(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	slr, err := t.signedLogRoot()
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetEntryAndProofResponse{SignedLogRoot: slr}
+	size := in.TreeSize
+	if uint64(size) > t.root.TreeSize && uint64(in.LeafIndex) < t.root.TreeSize {
+		// return the latest proof available
+		size = int64(t.root.TreeSize)
+	}
+	if uint64(size) > t.root.TreeSize {
+		return resp, nil
+	}
+	if resp.Proof, err = t.inclusionProof(in.LeafIndex, size); err != nil {
+		return nil, err
+	}
+	if resp.Leaf, err = t.logLeaf(uint64(in.LeafIndex)); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// InitLog implements trillian.TrillianLogClient
+func (f *Trillian) InitLog(ctx context.Context, in *trillian.InitLogRequest, opts ...grpc.CallOption) (*trillian.InitLogResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.trees[in.LogId]
+	if !ok || t.tree.Deleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "tree %d not found", in.LogId)
+	}
+	if t.root != nil {
+		return nil, status.Error(codes.AlreadyExists, "log is already initialised")
+	}
+	l, err := embedded.Open("")
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	t.log = l
+	t.root = &types.LogRootV1{
+		RootHash:       rfc6962.DefaultHasher.EmptyRoot(),
+		TimestampNanos: uint64(time.Now().UnixNano()),
+	}
+	slr, err := t.signedLogRoot()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.InitLogResponse{Created: slr}, nil
+}
+
+// GetLeavesByRange implements trillian.TrillianLogClient
+func (f *Trillian) GetLeavesByRange(ctx context.Context, in *trillian.GetLeavesByRangeRequest, opts ...grpc.CallOption) (*trillian.GetLeavesByRangeResponse, error) {
+	switch {
+	case in.StartIndex < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "GetLeavesByRangeRequest.StartIndex: %v, want >= 0", in.StartIndex)
+	case in.Count <= 0:
+		return nil, status.Errorf(codes.InvalidArgument, "GetLeavesByRangeRequest.Count: %v, want > 0", in.Count)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.readLog(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	slr, err := t.signedLogRoot()
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetLeavesByRangeResponse{SignedLogRoot: slr}
+	for i := uint64(in.StartIndex); i < uint64(in.StartIndex+in.Count) && i < t.root.TreeSize; i++ {
+		leaf, err := t.logLeaf(i)
+		if err != nil {
+			return nil, err
+		}
+		resp.Leaves = append(resp.Leaves, leaf)
+	}
+	return resp, nil
+}
+
+// ListTrees implements trillian.TrillianAdminClient
+func (f *Trillian) ListTrees(ctx context.Context, in *trillian.ListTreesRequest, opts ...grpc.CallOption) (*trillian.ListTreesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &trillian.ListTreesResponse{}
+	for id := int64(1); id < f.next; id++ {
+		t := f.trees[id]
+		if t.tree.Deleted && !in.ShowDeleted {
+			continue
+		}
+		resp.Tree = append(resp.Tree, proto.Clone(t.tree).(*trillian.Tree))
+	}
+	return resp, nil
+}
+
+// GetTree implements trillian.TrillianAdminClient
+func (f *Trillian) GetTree(ctx context.Context, in *trillian.GetTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.trees[in.TreeId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "tree %d not found", in.TreeId)
+	}
+	return proto.Clone(t.tree).(*trillian.Tree), nil
+}
+
+// CreateTree implements trillian.TrillianAdminClient. Only log trees are
+// supported.
+func (f *Trillian) CreateTree(ctx context.Context, in *trillian.CreateTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	if in.Tree == nil {
+		return nil, status.Error(codes.InvalidArgument, "a tree is required")
+	}
+	if in.Tree.TreeType != trillian.TreeType_LOG {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported tree type %s", in.Tree.TreeType)
+	}
+	if in.Tree.TreeState != trillian.TreeState_ACTIVE {
+		return nil, status.Errorf(codes.InvalidArgument, "new trees must be %s", trillian.TreeState_ACTIVE)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := proto.Clone(in.Tree).(*trillian.Tree)
+	t.TreeId = f.next
+	t.CreateTime = timestamppb.Now()
+	t.UpdateTime = t.CreateTime
+	f.next++
+	f.trees[t.TreeId] = &tree{tree: t}
+	return proto.Clone(t).(*trillian.Tree), nil
+}
+
+// UpdateTree implements trillian.TrillianAdminClient. The state, display name
+// and description of a tree may be updated.
+func (f *Trillian) UpdateTree(ctx context.Context, in *trillian.UpdateTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	if in.Tree == nil || in.UpdateMask == nil {
+		return nil, status.Error(codes.InvalidArgument, "a tree and update mask are required")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.trees[in.Tree.TreeId]
+	if !ok || t.tree.Deleted {
+		return nil, status.Errorf(codes.NotFound, "tree %d not found", in.Tree.TreeId)
+	}
+	updated := proto.Clone(t.tree).(*trillian.Tree)
+	for _, path := range in.UpdateMask.Paths {
+		switch path {
+		case "tree_state":
+			updated.TreeState = in.Tree.TreeState
+		case "display_name":
+			updated.DisplayName = in.Tree.DisplayName
+		case "description":
+			updated.Description = in.Tree.Description
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+	updated.UpdateTime = timestamppb.Now()
+	t.tree = updated
+	return proto.Clone(updated).(*trillian.Tree), nil
+}
+
+// DeleteTree implements trillian.TrillianAdminClient
+func (f *Trillian) DeleteTree(ctx context.Context, in *trillian.DeleteTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	return f.setDeleted(in.TreeId, true)
+}
+
+// UndeleteTree implements trillian.TrillianAdminClient
+func (f *Trillian) UndeleteTree(ctx context.Context, in *trillian.UndeleteTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	return f.setDeleted(in.TreeId, false)
+}
+
+func (f *Trillian) setDeleted(treeID int64, deleted bool) (*trillian.Tree, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.trees[treeID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "tree %d not found", treeID)
+	}
+	if t.tree.Deleted == deleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "tree %d has deleted=%v", treeID, deleted)
+	}
+	t.tree.Deleted = deleted
+	if deleted {
+		t.tree.DeleteTime = timestamppb.Now()
+	} else {
+		t.tree.DeleteTime = nil
+	}
+	return proto.Clone(t.tree).(*trillian.Tree), nil
+}
@@ -0,0 +1,214 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faketrillian
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/client"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func latestRoot(t *testing.T, f *Trillian, treeID int64) types.LogRootV1 {
+	t.Helper()
+	resp, err := f.GetLatestSignedLogRoot(context.Background(), &trillian.GetLatestSignedLogRootRequest{LogId: treeID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestQueueAndIntegrate(t *testing.T) {
+	ctx := context.Background()
+	f := New(time.Hour)
+	treeID, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 5; i++ {
+		resp, err := f.QueueLeaf(ctx, &trillian.QueueLeafRequest{
+			LogId: treeID,
+			Leaf:  &trillian.LogLeaf{LeafValue: []byte(fmt.Sprintf("leaf %d", i))},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.QueuedLeaf.Status != nil {
+			t.Fatalf("unexpected status %v", resp.QueuedLeaf.Status)
+		}
+	}
+	// leaves are not visible until they are integrated
+	if root := latestRoot(t, f, treeID); root.TreeSize != 0 {
+		t.Fatalf("expected empty tree before integration, got %d", root.TreeSize)
+	}
+	hash := rfc6962.DefaultHasher.HashLeaf([]byte("leaf 3"))
+	if _, err := f.GetInclusionProofByHash(ctx, &trillian.GetInclusionProofByHashRequest{LogId: treeID, LeafHash: hash, TreeSize: 1}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected queued leaf to not be found, got %v", err)
+	}
+	// a queued duplicate is rejected
+	resp, err := f.QueueLeaf(ctx, &trillian.QueueLeafRequest{LogId: treeID, Leaf: &trillian.LogLeaf{LeafValue: []byte("leaf 1")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.QueuedLeaf.Status == nil || resp.QueuedLeaf.Status.Code != int32(codes.AlreadyExists) {
+		t.Errorf("expected queued duplicate to already exist, got %v", resp.QueuedLeaf.Status)
+	}
+
+	old := latestRoot(t, f, treeID)
+	if err := f.Integrate(treeID); err != nil {
+		t.Fatal(err)
+	}
+	root := latestRoot(t, f, treeID)
+	if root.TreeSize != 5 || root.TimestampNanos <= old.TimestampNanos || root.Revision != old.Revision+1 {
+		t.Fatalf("unexpected root after integration %+v", root)
+	}
+
+	v := client.NewLogVerifier(rfc6962.DefaultHasher)
+	proofResp, err := f.GetInclusionProofByHash(ctx, &trillian.GetInclusionProofByHashRequest{LogId: treeID, LeafHash: hash, TreeSize: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proofResp.Proof) != 1 || proofResp.Proof[0].LeafIndex != 3 {
+		t.Fatalf("unexpected proofs %v", proofResp.Proof)
+	}
+	if err := v.VerifyInclusionByHash(&root, hash, proofResp.Proof[0]); err != nil {
+		t.Error(err)
+	}
+
+	entryResp, err := f.GetEntryAndProof(ctx, &trillian.GetEntryAndProofRequest{LogId: treeID, LeafIndex: 2, TreeSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(entryResp.Leaf.LeafValue) != "leaf 2" || entryResp.Leaf.IntegrateTimestamp == nil {
+		t.Errorf("unexpected leaf %v", entryResp.Leaf)
+	}
+	if err := v.VerifyInclusionByHash(&root, entryResp.Leaf.MerkleLeafHash, entryResp.Proof); err != nil {
+		t.Error(err)
+	}
+
+	// an integrated duplicate is returned with its index
+	resp, err = f.QueueLeaf(ctx, &trillian.QueueLeafRequest{LogId: treeID, Leaf: &trillian.LogLeaf{LeafValue: []byte("leaf 4")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.QueuedLeaf.Status == nil || resp.QueuedLeaf.Leaf.LeafIndex != 4 {
+		t.Errorf("unexpected duplicate %v", resp.QueuedLeaf)
+	}
+
+	latest, err := f.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: treeID, FirstTreeSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Proof == nil || len(latest.Proof.Hashes) == 0 {
+		t.Errorf("expected consistency proof, got %v", latest.Proof)
+	}
+	consistency, err := f.GetConsistencyProof(ctx, &trillian.GetConsistencyProofRequest{LogId: treeID, FirstTreeSize: 2, SecondTreeSize: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if consistency.Proof != nil {
+		t.Errorf("expected no proof beyond the tree size, got %v", consistency.Proof)
+	}
+}
+
+func TestLogClient(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	f := New(50 * time.Millisecond)
+	tree, err := client.CreateAndInitTree(ctx, &trillian.CreateTreeRequest{
+		Tree: &trillian.Tree{TreeType: trillian.TreeType_LOG, TreeState: trillian.TreeState_ACTIVE},
+	}, f, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lc, err := client.NewFromTree(f, tree, types.LogRootV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the Trillian client waits for each leaf to be integrated, and verifies
+	// inclusion and consistency proofs along the way
+	for i := 0; i < 3; i++ {
+		if err := lc.AddLeaf(ctx, []byte(fmt.Sprintf("leaf %d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	leaves, err := lc.ListByIndex(ctx, 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leaves) != 3 || string(leaves[1].LeafValue) != "leaf 1" {
+		t.Errorf("unexpected leaves %v", leaves)
+	}
+}
+
+func TestTreeStates(t *testing.T) {
+	ctx := context.Background()
+	f := New(0)
+	tree, err := f.CreateTree(ctx, &trillian.CreateTreeRequest{
+		Tree: &trillian.Tree{TreeType: trillian.TreeType_LOG, TreeState: trillian.TreeState_ACTIVE},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: tree.TreeId}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected uninitialized log to fail, got %v", err)
+	}
+	if _, err := f.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected second init to fail, got %v", err)
+	}
+
+	tree.TreeState = trillian.TreeState_FROZEN
+	if _, err := f.UpdateTree(ctx, &trillian.UpdateTreeRequest{Tree: tree, UpdateMask: &field_mask.FieldMask{Paths: []string{"tree_state"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.QueueLeaf(ctx, &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: []byte("leaf")}}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected frozen log to reject leaves, got %v", err)
+	}
+	// frozen trees can still be read
+	if root := latestRoot(t, f, tree.TreeId); root.TreeSize != 0 {
+		t.Errorf("unexpected tree size %d", root.TreeSize)
+	}
+
+	if _, err := f.DeleteTree(ctx, &trillian.DeleteTreeRequest{TreeId: tree.TreeId}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: tree.TreeId}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected deleted log to be not found, got %v", err)
+	}
+	trees, err := f.ListTrees(ctx, &trillian.ListTreesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trees.Tree) != 0 {
+		t.Errorf("expected deleted tree to not be listed, got %v", trees.Tree)
+	}
+}
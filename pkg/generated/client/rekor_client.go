@@ -30,6 +30,7 @@ import (
 	"github.com/sigstore/rekor/pkg/generated/client/index"
 	"github.com/sigstore/rekor/pkg/generated/client/pubkey"
 	serverops "github.com/sigstore/rekor/pkg/generated/client/server"
+	"github.com/sigstore/rekor/pkg/generated/client/timestamp"
 	"github.com/sigstore/rekor/pkg/generated/client/tlog"
 )
 
@@ -79,6 +80,7 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *Rekor {
 	cli.Index = index.New(transport, formats)
 	cli.Pubkey = pubkey.New(transport, formats)
 	cli.Server = serverops.New(transport, formats)
+	cli.Timestamp = timestamp.New(transport, formats)
 	cli.Tlog = tlog.New(transport, formats)
 	return cli
 }
@@ -132,6 +134,8 @@ type Rekor struct {
 
 	Server serverops.ClientService
 
+	Timestamp timestamp.ClientService
+
 	Tlog tlog.ClientService
 
 	Transport runtime.ClientTransport
@@ -144,5 +148,6 @@ func (c *Rekor) SetTransport(transport runtime.ClientTransport) {
 	c.Index.SetTransport(transport)
 	c.Pubkey.SetTransport(transport)
 	c.Server.SetTransport(transport)
+	c.Timestamp.SetTransport(transport)
 	c.Tlog.SetTransport(transport)
 }
@@ -0,0 +1,607 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// APKAndroidV001Schema Android APK v0.0.1 Schema
+//
+// Schema for Android APK entries
+//
+// swagger:model apkAndroidV001Schema
+type APKAndroidV001Schema struct {
+
+	// apk
+	// Required: true
+	APK *APKAndroidV001SchemaAPK `json:"apk"`
+
+	// signature
+	Signature *APKAndroidV001SchemaSignature `json:"signature,omitempty"`
+}
+
+// Validate validates this apk android v001 schema
+func (m *APKAndroidV001Schema) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAPK(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSignature(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APKAndroidV001Schema) validateAPK(formats strfmt.Registry) error {
+
+	if err := validate.Required("apk", "body", m.APK); err != nil {
+		return err
+	}
+
+	if m.APK != nil {
+		if err := m.APK.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("apk")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("apk")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *APKAndroidV001Schema) validateSignature(formats strfmt.Registry) error {
+	if swag.IsZero(m.Signature) { // not required
+		return nil
+	}
+
+	if m.Signature != nil {
+		if err := m.Signature.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this apk android v001 schema based on the context it is used
+func (m *APKAndroidV001Schema) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateAPK(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateSignature(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APKAndroidV001Schema) contextValidateAPK(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.APK != nil {
+		if err := m.APK.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("apk")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("apk")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *APKAndroidV001Schema) contextValidateSignature(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Signature != nil {
+		if err := m.Signature.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APKAndroidV001Schema) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APKAndroidV001Schema) UnmarshalBinary(b []byte) error {
+	var res APKAndroidV001Schema
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// APKAndroidV001SchemaAPK Information about the APK associated with the entry
+//
+// swagger:model APKAndroidV001SchemaAPK
+type APKAndroidV001SchemaAPK struct {
+
+	// Specifies the APK inline within the document
+	// Format: byte
+	Content strfmt.Base64 `json:"content,omitempty"`
+
+	// hash
+	Hash *APKAndroidV001SchemaAPKHash `json:"hash,omitempty"`
+
+	// The package name from the manifest of the APK
+	// Read Only: true
+	PackageName string `json:"packageName,omitempty"`
+}
+
+// Validate validates this APK android v001 schema APK
+func (m *APKAndroidV001SchemaAPK) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateHash(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APKAndroidV001SchemaAPK) validateHash(formats strfmt.Registry) error {
+	if swag.IsZero(m.Hash) { // not required
+		return nil
+	}
+
+	if m.Hash != nil {
+		if err := m.Hash.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("apk" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("apk" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this APK android v001 schema APK based on the context it is used
+func (m *APKAndroidV001SchemaAPK) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateHash(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidatePackageName(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APKAndroidV001SchemaAPK) contextValidateHash(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Hash != nil {
+		if err := m.Hash.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("apk" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("apk" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *APKAndroidV001SchemaAPK) contextValidatePackageName(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "apk"+"."+"packageName", "body", string(m.PackageName)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APKAndroidV001SchemaAPK) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APKAndroidV001SchemaAPK) UnmarshalBinary(b []byte) error {
+	var res APKAndroidV001SchemaAPK
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// APKAndroidV001SchemaAPKHash Specifies the hash algorithm and value encompassing the entire signed APK
+//
+// swagger:model APKAndroidV001SchemaAPKHash
+type APKAndroidV001SchemaAPKHash struct {
+
+	// The hashing function used to compute the hash value
+	// Required: true
+	// Enum: [sha256]
+	Algorithm *string `json:"algorithm"`
+
+	// The hash value for the APK
+	// Required: true
+	Value *string `json:"value"`
+}
+
+// Validate validates this APK android v001 schema APK hash
+func (m *APKAndroidV001SchemaAPKHash) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAlgorithm(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateValue(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var apkAndroidV001SchemaApkHashTypeAlgorithmPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["sha256"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		apkAndroidV001SchemaApkHashTypeAlgorithmPropEnum = append(apkAndroidV001SchemaApkHashTypeAlgorithmPropEnum, v)
+	}
+}
+
+const (
+
+	// APKAndroidV001SchemaAPKHashAlgorithmSha256 captures enum value "sha256"
+	APKAndroidV001SchemaAPKHashAlgorithmSha256 string = "sha256"
+)
+
+// prop value enum
+func (m *APKAndroidV001SchemaAPKHash) validateAlgorithmEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, apkAndroidV001SchemaApkHashTypeAlgorithmPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *APKAndroidV001SchemaAPKHash) validateAlgorithm(formats strfmt.Registry) error {
+
+	if err := validate.Required("apk"+"."+"hash"+"."+"algorithm", "body", m.Algorithm); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateAlgorithmEnum("apk"+"."+"hash"+"."+"algorithm", "body", *m.Algorithm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *APKAndroidV001SchemaAPKHash) validateValue(formats strfmt.Registry) error {
+
+	if err := validate.Required("apk"+"."+"hash"+"."+"value", "body", m.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this APK android v001 schema APK hash based on context it is used
+func (m *APKAndroidV001SchemaAPKHash) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APKAndroidV001SchemaAPKHash) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APKAndroidV001SchemaAPKHash) UnmarshalBinary(b []byte) error {
+	var res APKAndroidV001SchemaAPKHash
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// APKAndroidV001SchemaSignature Information about the signer in the APK Signing Block of the APK
+//
+// swagger:model APKAndroidV001SchemaSignature
+type APKAndroidV001SchemaSignature struct {
+
+	// Specifies the signer block of the scheme, which holds the signed data and the signatures over it
+	// Required: true
+	// Format: byte
+	Content *strfmt.Base64 `json:"content"`
+
+	// public key
+	// Required: true
+	PublicKey *APKAndroidV001SchemaSignaturePublicKey `json:"publicKey"`
+
+	// The most recent APK Signature Scheme the APK is signed with
+	// Required: true
+	// Enum: [v2 v3]
+	Scheme *string `json:"scheme"`
+}
+
+// Validate validates this APK android v001 schema signature
+func (m *APKAndroidV001SchemaSignature) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePublicKey(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateScheme(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APKAndroidV001SchemaSignature) validateContent(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"content", "body", m.Content); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *APKAndroidV001SchemaSignature) validatePublicKey(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"publicKey", "body", m.PublicKey); err != nil {
+		return err
+	}
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature" + "." + "publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature" + "." + "publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+var apkAndroidV001SchemaSignatureTypeSchemePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["v2","v3"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		apkAndroidV001SchemaSignatureTypeSchemePropEnum = append(apkAndroidV001SchemaSignatureTypeSchemePropEnum, v)
+	}
+}
+
+const (
+
+	// APKAndroidV001SchemaSignatureSchemeV2 captures enum value "v2"
+	APKAndroidV001SchemaSignatureSchemeV2 string = "v2"
+
+	// APKAndroidV001SchemaSignatureSchemeV3 captures enum value "v3"
+	APKAndroidV001SchemaSignatureSchemeV3 string = "v3"
+)
+
+// prop value enum
+func (m *APKAndroidV001SchemaSignature) validateSchemeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, apkAndroidV001SchemaSignatureTypeSchemePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *APKAndroidV001SchemaSignature) validateScheme(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"scheme", "body", m.Scheme); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateSchemeEnum("signature"+"."+"scheme", "body", *m.Scheme); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this APK android v001 schema signature based on the context it is used
+func (m *APKAndroidV001SchemaSignature) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidatePublicKey(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APKAndroidV001SchemaSignature) contextValidatePublicKey(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature" + "." + "publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature" + "." + "publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APKAndroidV001SchemaSignature) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APKAndroidV001SchemaSignature) UnmarshalBinary(b []byte) error {
+	var res APKAndroidV001SchemaSignature
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// APKAndroidV001SchemaSignaturePublicKey The X509 certificate of the signer of the APK
+//
+// swagger:model APKAndroidV001SchemaSignaturePublicKey
+type APKAndroidV001SchemaSignaturePublicKey struct {
+
+	// Specifies the content of the X509 certificate containing the public key used to verify the signature
+	// Required: true
+	// Format: byte
+	Content *strfmt.Base64 `json:"content"`
+}
+
+// Validate validates this APK android v001 schema signature public key
+func (m *APKAndroidV001SchemaSignaturePublicKey) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APKAndroidV001SchemaSignaturePublicKey) validateContent(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"publicKey"+"."+"content", "body", m.Content); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this APK android v001 schema signature public key based on context it is used
+func (m *APKAndroidV001SchemaSignaturePublicKey) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APKAndroidV001SchemaSignaturePublicKey) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APKAndroidV001SchemaSignaturePublicKey) UnmarshalBinary(b []byte) error {
+	var res APKAndroidV001SchemaSignaturePublicKey
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,699 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// DebV001Schema Debian v0.0.1 Schema
+//
+// Schema for Debian package and APT repository metadata entries
+//
+// swagger:model debV001Schema
+type DebV001Schema struct {
+
+	// package
+	Package *DebV001SchemaPackage `json:"package,omitempty"`
+
+	// public key
+	// Required: true
+	PublicKey *DebV001SchemaPublicKey `json:"publicKey"`
+
+	// release
+	Release *DebV001SchemaRelease `json:"release,omitempty"`
+}
+
+// Validate validates this deb v001 schema
+func (m *DebV001Schema) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validatePackage(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePublicKey(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRelease(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DebV001Schema) validatePackage(formats strfmt.Registry) error {
+	if swag.IsZero(m.Package) { // not required
+		return nil
+	}
+
+	if m.Package != nil {
+		if err := m.Package.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("package")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("package")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *DebV001Schema) validatePublicKey(formats strfmt.Registry) error {
+
+	if err := validate.Required("publicKey", "body", m.PublicKey); err != nil {
+		return err
+	}
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *DebV001Schema) validateRelease(formats strfmt.Registry) error {
+	if swag.IsZero(m.Release) { // not required
+		return nil
+	}
+
+	if m.Release != nil {
+		if err := m.Release.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("release")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("release")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this deb v001 schema based on the context it is used
+func (m *DebV001Schema) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidatePackage(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidatePublicKey(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateRelease(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DebV001Schema) contextValidatePackage(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Package != nil {
+		if err := m.Package.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("package")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("package")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *DebV001Schema) contextValidatePublicKey(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *DebV001Schema) contextValidateRelease(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Release != nil {
+		if err := m.Release.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("release")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("release")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DebV001Schema) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DebV001Schema) UnmarshalBinary(b []byte) error {
+	var res DebV001Schema
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// DebV001SchemaPackage Information about the Debian package associated with the entry
+//
+// swagger:model DebV001SchemaPackage
+type DebV001SchemaPackage struct {
+
+	// Specifies the package inline within the document
+	// Format: byte
+	Content strfmt.Base64 `json:"content,omitempty"`
+
+	// hash
+	Hash *DebV001SchemaPackageHash `json:"hash,omitempty"`
+
+	// Values of the Package, Version and Architecture fields of the package's control file
+	// Read Only: true
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Validate validates this deb v001 schema package
+func (m *DebV001SchemaPackage) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateHash(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DebV001SchemaPackage) validateHash(formats strfmt.Registry) error {
+	if swag.IsZero(m.Hash) { // not required
+		return nil
+	}
+
+	if m.Hash != nil {
+		if err := m.Hash.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("package" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("package" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this deb v001 schema package based on the context it is used
+func (m *DebV001SchemaPackage) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateHash(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateHeaders(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DebV001SchemaPackage) contextValidateHash(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Hash != nil {
+		if err := m.Hash.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("package" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("package" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *DebV001SchemaPackage) contextValidateHeaders(ctx context.Context, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DebV001SchemaPackage) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DebV001SchemaPackage) UnmarshalBinary(b []byte) error {
+	var res DebV001SchemaPackage
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// DebV001SchemaPackageHash Specifies the hash algorithm and value for the package
+//
+// swagger:model DebV001SchemaPackageHash
+type DebV001SchemaPackageHash struct {
+
+	// The hashing function used to compute the hash value
+	// Required: true
+	// Enum: [sha256]
+	Algorithm *string `json:"algorithm"`
+
+	// The hash value for the package
+	// Required: true
+	Value *string `json:"value"`
+}
+
+// Validate validates this deb v001 schema package hash
+func (m *DebV001SchemaPackageHash) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAlgorithm(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateValue(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var debV001SchemaPackageHashTypeAlgorithmPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["sha256"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		debV001SchemaPackageHashTypeAlgorithmPropEnum = append(debV001SchemaPackageHashTypeAlgorithmPropEnum, v)
+	}
+}
+
+const (
+
+	// DebV001SchemaPackageHashAlgorithmSha256 captures enum value "sha256"
+	DebV001SchemaPackageHashAlgorithmSha256 string = "sha256"
+)
+
+// prop value enum
+func (m *DebV001SchemaPackageHash) validateAlgorithmEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, debV001SchemaPackageHashTypeAlgorithmPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *DebV001SchemaPackageHash) validateAlgorithm(formats strfmt.Registry) error {
+
+	if err := validate.Required("package"+"."+"hash"+"."+"algorithm", "body", m.Algorithm); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateAlgorithmEnum("package"+"."+"hash"+"."+"algorithm", "body", *m.Algorithm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DebV001SchemaPackageHash) validateValue(formats strfmt.Registry) error {
+
+	if err := validate.Required("package"+"."+"hash"+"."+"value", "body", m.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this deb v001 schema package hash based on context it is used
+func (m *DebV001SchemaPackageHash) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DebV001SchemaPackageHash) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DebV001SchemaPackageHash) UnmarshalBinary(b []byte) error {
+	var res DebV001SchemaPackageHash
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// DebV001SchemaPublicKey The PGP public key that can verify the package or repository metadata signature
+//
+// swagger:model DebV001SchemaPublicKey
+type DebV001SchemaPublicKey struct {
+
+	// Specifies the content of the public key inline within the document
+	// Required: true
+	// Format: byte
+	Content *strfmt.Base64 `json:"content"`
+}
+
+// Validate validates this deb v001 schema public key
+func (m *DebV001SchemaPublicKey) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DebV001SchemaPublicKey) validateContent(formats strfmt.Registry) error {
+
+	if err := validate.Required("publicKey"+"."+"content", "body", m.Content); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this deb v001 schema public key based on context it is used
+func (m *DebV001SchemaPublicKey) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DebV001SchemaPublicKey) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DebV001SchemaPublicKey) UnmarshalBinary(b []byte) error {
+	var res DebV001SchemaPublicKey
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// DebV001SchemaRelease Information about the clearsigned InRelease file of an APT repository associated with the entry
+//
+// swagger:model DebV001SchemaRelease
+type DebV001SchemaRelease struct {
+
+	// Specifies the InRelease file inline within the document
+	// Format: byte
+	Content strfmt.Base64 `json:"content,omitempty"`
+
+	// hash
+	Hash *DebV001SchemaReleaseHash `json:"hash,omitempty"`
+
+	// SHA256 hashes of the Packages indices listed in the InRelease file, keyed by their path
+	// Read Only: true
+	PackagesIndices map[string]string `json:"packagesIndices,omitempty"`
+}
+
+// Validate validates this deb v001 schema release
+func (m *DebV001SchemaRelease) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateHash(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DebV001SchemaRelease) validateHash(formats strfmt.Registry) error {
+	if swag.IsZero(m.Hash) { // not required
+		return nil
+	}
+
+	if m.Hash != nil {
+		if err := m.Hash.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("release" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("release" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this deb v001 schema release based on the context it is used
+func (m *DebV001SchemaRelease) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateHash(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidatePackagesIndices(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DebV001SchemaRelease) contextValidateHash(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Hash != nil {
+		if err := m.Hash.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("release" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("release" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *DebV001SchemaRelease) contextValidatePackagesIndices(ctx context.Context, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DebV001SchemaRelease) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DebV001SchemaRelease) UnmarshalBinary(b []byte) error {
+	var res DebV001SchemaRelease
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// DebV001SchemaReleaseHash Specifies the hash algorithm and value for the InRelease file
+//
+// swagger:model DebV001SchemaReleaseHash
+type DebV001SchemaReleaseHash struct {
+
+	// The hashing function used to compute the hash value
+	// Required: true
+	// Enum: [sha256]
+	Algorithm *string `json:"algorithm"`
+
+	// The hash value for the InRelease file
+	// Required: true
+	Value *string `json:"value"`
+}
+
+// Validate validates this deb v001 schema release hash
+func (m *DebV001SchemaReleaseHash) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAlgorithm(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateValue(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var debV001SchemaReleaseHashTypeAlgorithmPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["sha256"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		debV001SchemaReleaseHashTypeAlgorithmPropEnum = append(debV001SchemaReleaseHashTypeAlgorithmPropEnum, v)
+	}
+}
+
+const (
+
+	// DebV001SchemaReleaseHashAlgorithmSha256 captures enum value "sha256"
+	DebV001SchemaReleaseHashAlgorithmSha256 string = "sha256"
+)
+
+// prop value enum
+func (m *DebV001SchemaReleaseHash) validateAlgorithmEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, debV001SchemaReleaseHashTypeAlgorithmPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *DebV001SchemaReleaseHash) validateAlgorithm(formats strfmt.Registry) error {
+
+	if err := validate.Required("release"+"."+"hash"+"."+"algorithm", "body", m.Algorithm); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateAlgorithmEnum("release"+"."+"hash"+"."+"algorithm", "body", *m.Algorithm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DebV001SchemaReleaseHash) validateValue(formats strfmt.Registry) error {
+
+	if err := validate.Required("release"+"."+"hash"+"."+"value", "body", m.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this deb v001 schema release hash based on context it is used
+func (m *DebV001SchemaReleaseHash) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DebV001SchemaReleaseHash) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DebV001SchemaReleaseHash) UnmarshalBinary(b []byte) error {
+	var res DebV001SchemaReleaseHash
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,601 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// GitV001Schema Git v0.0.1 Schema
+//
+// Schema for signed git commit and tag entries
+//
+// swagger:model gitV001Schema
+type GitV001Schema struct {
+
+	// object
+	// Required: true
+	Object *GitV001SchemaObject `json:"object"`
+
+	// signature
+	Signature *GitV001SchemaSignature `json:"signature,omitempty"`
+}
+
+// Validate validates this git v001 schema
+func (m *GitV001Schema) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateObject(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSignature(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GitV001Schema) validateObject(formats strfmt.Registry) error {
+
+	if err := validate.Required("object", "body", m.Object); err != nil {
+		return err
+	}
+
+	if m.Object != nil {
+		if err := m.Object.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("object")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("object")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *GitV001Schema) validateSignature(formats strfmt.Registry) error {
+	if swag.IsZero(m.Signature) { // not required
+		return nil
+	}
+
+	if m.Signature != nil {
+		if err := m.Signature.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this git v001 schema based on the context it is used
+func (m *GitV001Schema) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateObject(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateSignature(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GitV001Schema) contextValidateObject(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Object != nil {
+		if err := m.Object.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("object")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("object")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *GitV001Schema) contextValidateSignature(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Signature != nil {
+		if err := m.Signature.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *GitV001Schema) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *GitV001Schema) UnmarshalBinary(b []byte) error {
+	var res GitV001Schema
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// GitV001SchemaObject Information about the signed commit or tag
+//
+// swagger:model GitV001SchemaObject
+type GitV001SchemaObject struct {
+
+	// The email address of the committer of the commit, or the tagger of the tag
+	// Read Only: true
+	Committer string `json:"committer,omitempty"`
+
+	// Specifies the raw commit or tag object, including its signature, inline within the document
+	// Format: byte
+	Content strfmt.Base64 `json:"content,omitempty"`
+
+	// The SHA1 object ID of the commit or tag
+	// Read Only: true
+	// Pattern: ^[0-9a-f]{40}$
+	ID string `json:"id,omitempty"`
+
+	// The SHA1 object ID of the tree of the commit
+	// Read Only: true
+	// Pattern: ^[0-9a-f]{40}$
+	TreeID string `json:"treeId,omitempty"`
+
+	// The type of the git object
+	// Read Only: true
+	// Enum: [commit tag]
+	Type string `json:"type,omitempty"`
+}
+
+// Validate validates this git v001 schema object
+func (m *GitV001SchemaObject) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTreeID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GitV001SchemaObject) validateID(formats strfmt.Registry) error {
+	if swag.IsZero(m.ID) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("object"+"."+"id", "body", m.ID, `^[0-9a-f]{40}$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *GitV001SchemaObject) validateTreeID(formats strfmt.Registry) error {
+	if swag.IsZero(m.TreeID) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("object"+"."+"treeId", "body", m.TreeID, `^[0-9a-f]{40}$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var gitV001SchemaObjectTypeTypePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["commit","tag"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		gitV001SchemaObjectTypeTypePropEnum = append(gitV001SchemaObjectTypeTypePropEnum, v)
+	}
+}
+
+const (
+
+	// GitV001SchemaObjectTypeCommit captures enum value "commit"
+	GitV001SchemaObjectTypeCommit string = "commit"
+
+	// GitV001SchemaObjectTypeTag captures enum value "tag"
+	GitV001SchemaObjectTypeTag string = "tag"
+)
+
+// prop value enum
+func (m *GitV001SchemaObject) validateTypeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, gitV001SchemaObjectTypeTypePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *GitV001SchemaObject) validateType(formats strfmt.Registry) error {
+	if swag.IsZero(m.Type) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateTypeEnum("object"+"."+"type", "body", m.Type); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this git v001 schema object based on the context it is used
+func (m *GitV001SchemaObject) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateCommitter(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateID(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateTreeID(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateType(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GitV001SchemaObject) contextValidateCommitter(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "object"+"."+"committer", "body", string(m.Committer)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *GitV001SchemaObject) contextValidateID(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "object"+"."+"id", "body", string(m.ID)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *GitV001SchemaObject) contextValidateTreeID(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "object"+"."+"treeId", "body", string(m.TreeID)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *GitV001SchemaObject) contextValidateType(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "object"+"."+"type", "body", string(m.Type)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *GitV001SchemaObject) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *GitV001SchemaObject) UnmarshalBinary(b []byte) error {
+	var res GitV001SchemaObject
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// GitV001SchemaSignature Information about the signature embedded in the object
+//
+// swagger:model GitV001SchemaSignature
+type GitV001SchemaSignature struct {
+
+	// Specifies the content of the signature
+	// Read Only: true
+	// Format: byte
+	Content strfmt.Base64 `json:"content,omitempty"`
+
+	// Specifies the type of signature
+	// Read Only: true
+	// Enum: [pgp ssh x509]
+	Format string `json:"format,omitempty"`
+
+	// public key
+	PublicKey *GitV001SchemaSignaturePublicKey `json:"publicKey,omitempty"`
+}
+
+// Validate validates this git v001 schema signature
+func (m *GitV001SchemaSignature) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateFormat(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePublicKey(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var gitV001SchemaSignatureTypeFormatPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["pgp","ssh","x509"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		gitV001SchemaSignatureTypeFormatPropEnum = append(gitV001SchemaSignatureTypeFormatPropEnum, v)
+	}
+}
+
+const (
+
+	// GitV001SchemaSignatureFormatPgp captures enum value "pgp"
+	GitV001SchemaSignatureFormatPgp string = "pgp"
+
+	// GitV001SchemaSignatureFormatSSH captures enum value "ssh"
+	GitV001SchemaSignatureFormatSSH string = "ssh"
+
+	// GitV001SchemaSignatureFormatX509 captures enum value "x509"
+	GitV001SchemaSignatureFormatX509 string = "x509"
+)
+
+// prop value enum
+func (m *GitV001SchemaSignature) validateFormatEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, gitV001SchemaSignatureTypeFormatPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *GitV001SchemaSignature) validateFormat(formats strfmt.Registry) error {
+	if swag.IsZero(m.Format) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateFormatEnum("signature"+"."+"format", "body", m.Format); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *GitV001SchemaSignature) validatePublicKey(formats strfmt.Registry) error {
+	if swag.IsZero(m.PublicKey) { // not required
+		return nil
+	}
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature" + "." + "publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature" + "." + "publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this git v001 schema signature based on the context it is used
+func (m *GitV001SchemaSignature) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateContent(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateFormat(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidatePublicKey(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GitV001SchemaSignature) contextValidateContent(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "signature"+"."+"content", "body", strfmt.Base64(m.Content)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *GitV001SchemaSignature) contextValidateFormat(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "signature"+"."+"format", "body", string(m.Format)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *GitV001SchemaSignature) contextValidatePublicKey(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature" + "." + "publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature" + "." + "publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *GitV001SchemaSignature) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *GitV001SchemaSignature) UnmarshalBinary(b []byte) error {
+	var res GitV001SchemaSignature
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// GitV001SchemaSignaturePublicKey The public key or certificate that can verify the signature; required for pgp and ssh signatures
+//
+// swagger:model GitV001SchemaSignaturePublicKey
+type GitV001SchemaSignaturePublicKey struct {
+
+	// Specifies the content of the public key or certificate inline within the document
+	// Required: true
+	// Format: byte
+	Content *strfmt.Base64 `json:"content"`
+}
+
+// Validate validates this git v001 schema signature public key
+func (m *GitV001SchemaSignaturePublicKey) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GitV001SchemaSignaturePublicKey) validateContent(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"publicKey"+"."+"content", "body", m.Content); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this git v001 schema signature public key based on context it is used
+func (m *GitV001SchemaSignaturePublicKey) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *GitV001SchemaSignaturePublicKey) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *GitV001SchemaSignaturePublicKey) UnmarshalBinary(b []byte) error {
+	var res GitV001SchemaSignaturePublicKey
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
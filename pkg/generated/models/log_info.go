@@ -36,6 +36,9 @@ import (
 // swagger:model LogInfo
 type LogInfo struct {
 
+	// checkpoint timestamp
+	CheckpointTimestamp *CheckpointTimestamp `json:"checkpointTimestamp,omitempty"`
+
 	// inactive shards
 	InactiveShards []*InactiveShardLogInfo `json:"inactiveShards"`
 
@@ -63,6 +66,10 @@ type LogInfo struct {
 func (m *LogInfo) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateCheckpointTimestamp(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateInactiveShards(formats); err != nil {
 		res = append(res, err)
 	}
@@ -89,6 +96,25 @@ func (m *LogInfo) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *LogInfo) validateCheckpointTimestamp(formats strfmt.Registry) error {
+	if swag.IsZero(m.CheckpointTimestamp) { // not required
+		return nil
+	}
+
+	if m.CheckpointTimestamp != nil {
+		if err := m.CheckpointTimestamp.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("checkpointTimestamp")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("checkpointTimestamp")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *LogInfo) validateInactiveShards(formats strfmt.Registry) error {
 	if swag.IsZero(m.InactiveShards) { // not required
 		return nil
@@ -167,6 +193,10 @@ func (m *LogInfo) validateTreeSize(formats strfmt.Registry) error {
 func (m *LogInfo) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateCheckpointTimestamp(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateInactiveShards(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -177,6 +207,22 @@ func (m *LogInfo) ContextValidate(ctx context.Context, formats strfmt.Registry)
 	return nil
 }
 
+func (m *LogInfo) contextValidateCheckpointTimestamp(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.CheckpointTimestamp != nil {
+		if err := m.CheckpointTimestamp.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("checkpointTimestamp")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("checkpointTimestamp")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *LogInfo) contextValidateInactiveShards(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.InactiveShards); i++ {
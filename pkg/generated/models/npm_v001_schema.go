@@ -0,0 +1,682 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// NpmV001Schema npm v0.0.1 Schema
+//
+// Schema for npm registry signature and provenance entries
+//
+// swagger:model npmV001Schema
+type NpmV001Schema struct {
+
+	// package
+	Package *NpmV001SchemaPackage `json:"package,omitempty"`
+
+	// provenance
+	Provenance *NpmV001SchemaProvenance `json:"provenance,omitempty"`
+
+	// signature
+	Signature *NpmV001SchemaSignature `json:"signature,omitempty"`
+}
+
+// Validate validates this npm v001 schema
+func (m *NpmV001Schema) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validatePackage(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateProvenance(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSignature(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NpmV001Schema) validatePackage(formats strfmt.Registry) error {
+	if swag.IsZero(m.Package) { // not required
+		return nil
+	}
+
+	if m.Package != nil {
+		if err := m.Package.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("package")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("package")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *NpmV001Schema) validateProvenance(formats strfmt.Registry) error {
+	if swag.IsZero(m.Provenance) { // not required
+		return nil
+	}
+
+	if m.Provenance != nil {
+		if err := m.Provenance.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("provenance")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("provenance")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *NpmV001Schema) validateSignature(formats strfmt.Registry) error {
+	if swag.IsZero(m.Signature) { // not required
+		return nil
+	}
+
+	if m.Signature != nil {
+		if err := m.Signature.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this npm v001 schema based on the context it is used
+func (m *NpmV001Schema) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidatePackage(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateProvenance(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateSignature(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NpmV001Schema) contextValidatePackage(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Package != nil {
+		if err := m.Package.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("package")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("package")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *NpmV001Schema) contextValidateProvenance(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Provenance != nil {
+		if err := m.Provenance.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("provenance")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("provenance")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *NpmV001Schema) contextValidateSignature(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Signature != nil {
+		if err := m.Signature.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *NpmV001Schema) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *NpmV001Schema) UnmarshalBinary(b []byte) error {
+	var res NpmV001Schema
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// NpmV001SchemaPackage The npm package version associated with the entry
+//
+// swagger:model NpmV001SchemaPackage
+type NpmV001SchemaPackage struct {
+
+	// The subresource integrity string of the SHA512 hash of the package tarball
+	// Required: true
+	// Pattern: ^sha512-[A-Za-z0-9+/]{86}==$
+	Integrity *string `json:"integrity"`
+
+	// The name of the package, including its scope
+	// Required: true
+	Name *string `json:"name"`
+
+	// The version of the package
+	// Required: true
+	Version *string `json:"version"`
+}
+
+// Validate validates this npm v001 schema package
+func (m *NpmV001SchemaPackage) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateIntegrity(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateVersion(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NpmV001SchemaPackage) validateIntegrity(formats strfmt.Registry) error {
+
+	if err := validate.Required("package"+"."+"integrity", "body", m.Integrity); err != nil {
+		return err
+	}
+
+	if err := validate.Pattern("package"+"."+"integrity", "body", *m.Integrity, `^sha512-[A-Za-z0-9+/]{86}==$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *NpmV001SchemaPackage) validateName(formats strfmt.Registry) error {
+
+	if err := validate.Required("package"+"."+"name", "body", m.Name); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *NpmV001SchemaPackage) validateVersion(formats strfmt.Registry) error {
+
+	if err := validate.Required("package"+"."+"version", "body", m.Version); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this npm v001 schema package based on context it is used
+func (m *NpmV001SchemaPackage) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *NpmV001SchemaPackage) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *NpmV001SchemaPackage) UnmarshalBinary(b []byte) error {
+	var res NpmV001SchemaPackage
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// NpmV001SchemaProvenance The Sigstore bundle holding the provenance of the package
+//
+// swagger:model NpmV001SchemaProvenance
+type NpmV001SchemaProvenance struct {
+
+	// Specifies the bundle inline within the document
+	// Format: byte
+	Content strfmt.Base64 `json:"content,omitempty"`
+
+	// hash
+	Hash *NpmV001SchemaProvenanceHash `json:"hash,omitempty"`
+}
+
+// Validate validates this npm v001 schema provenance
+func (m *NpmV001SchemaProvenance) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateHash(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NpmV001SchemaProvenance) validateHash(formats strfmt.Registry) error {
+	if swag.IsZero(m.Hash) { // not required
+		return nil
+	}
+
+	if m.Hash != nil {
+		if err := m.Hash.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("provenance" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("provenance" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this npm v001 schema provenance based on the context it is used
+func (m *NpmV001SchemaProvenance) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateHash(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NpmV001SchemaProvenance) contextValidateHash(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Hash != nil {
+		if err := m.Hash.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("provenance" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("provenance" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *NpmV001SchemaProvenance) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *NpmV001SchemaProvenance) UnmarshalBinary(b []byte) error {
+	var res NpmV001SchemaProvenance
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// NpmV001SchemaProvenanceHash Specifies the hash algorithm and value for the bundle
+//
+// swagger:model NpmV001SchemaProvenanceHash
+type NpmV001SchemaProvenanceHash struct {
+
+	// The hashing function used to compute the hash value
+	// Required: true
+	// Enum: [sha256]
+	Algorithm *string `json:"algorithm"`
+
+	// The hash value for the bundle
+	// Required: true
+	Value *string `json:"value"`
+}
+
+// Validate validates this npm v001 schema provenance hash
+func (m *NpmV001SchemaProvenanceHash) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAlgorithm(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateValue(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var npmV001SchemaProvenanceHashTypeAlgorithmPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["sha256"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		npmV001SchemaProvenanceHashTypeAlgorithmPropEnum = append(npmV001SchemaProvenanceHashTypeAlgorithmPropEnum, v)
+	}
+}
+
+const (
+
+	// NpmV001SchemaProvenanceHashAlgorithmSha256 captures enum value "sha256"
+	NpmV001SchemaProvenanceHashAlgorithmSha256 string = "sha256"
+)
+
+// prop value enum
+func (m *NpmV001SchemaProvenanceHash) validateAlgorithmEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, npmV001SchemaProvenanceHashTypeAlgorithmPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *NpmV001SchemaProvenanceHash) validateAlgorithm(formats strfmt.Registry) error {
+
+	if err := validate.Required("provenance"+"."+"hash"+"."+"algorithm", "body", m.Algorithm); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateAlgorithmEnum("provenance"+"."+"hash"+"."+"algorithm", "body", *m.Algorithm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *NpmV001SchemaProvenanceHash) validateValue(formats strfmt.Registry) error {
+
+	if err := validate.Required("provenance"+"."+"hash"+"."+"value", "body", m.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this npm v001 schema provenance hash based on the context it is used
+func (m *NpmV001SchemaProvenanceHash) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *NpmV001SchemaProvenanceHash) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *NpmV001SchemaProvenanceHash) UnmarshalBinary(b []byte) error {
+	var res NpmV001SchemaProvenanceHash
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// NpmV001SchemaSignature The signature over the package, and the public key or certificate that verifies it
+//
+// swagger:model NpmV001SchemaSignature
+type NpmV001SchemaSignature struct {
+
+	// Specifies the content of the signature inline within the document
+	// Required: true
+	// Format: byte
+	Content *strfmt.Base64 `json:"content"`
+
+	// public key
+	// Required: true
+	PublicKey *NpmV001SchemaSignaturePublicKey `json:"publicKey"`
+}
+
+// Validate validates this npm v001 schema signature
+func (m *NpmV001SchemaSignature) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePublicKey(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NpmV001SchemaSignature) validateContent(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"content", "body", m.Content); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *NpmV001SchemaSignature) validatePublicKey(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"publicKey", "body", m.PublicKey); err != nil {
+		return err
+	}
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature" + "." + "publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature" + "." + "publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this npm v001 schema signature based on the context it is used
+func (m *NpmV001SchemaSignature) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidatePublicKey(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NpmV001SchemaSignature) contextValidatePublicKey(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature" + "." + "publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature" + "." + "publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *NpmV001SchemaSignature) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *NpmV001SchemaSignature) UnmarshalBinary(b []byte) error {
+	var res NpmV001SchemaSignature
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// NpmV001SchemaSignaturePublicKey The public key or certificate that can verify the signature
+//
+// swagger:model NpmV001SchemaSignaturePublicKey
+type NpmV001SchemaSignaturePublicKey struct {
+
+	// Specifies the content of the public key or certificate inline within the document
+	// Required: true
+	// Format: byte
+	Content *strfmt.Base64 `json:"content"`
+}
+
+// Validate validates this npm v001 schema signature public key
+func (m *NpmV001SchemaSignaturePublicKey) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NpmV001SchemaSignaturePublicKey) validateContent(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"publicKey"+"."+"content", "body", m.Content); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this npm v001 schema signature public key based on context it is used
+func (m *NpmV001SchemaSignaturePublicKey) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *NpmV001SchemaSignaturePublicKey) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *NpmV001SchemaSignaturePublicKey) UnmarshalBinary(b []byte) error {
+	var res NpmV001SchemaSignaturePublicKey
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,722 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// PEV001Schema PE v0.0.1 Schema
+//
+// Schema for PE entries
+//
+// swagger:model peV001Schema
+type PEV001Schema struct {
+
+	// image
+	// Required: true
+	Image *PEV001SchemaImage `json:"image"`
+
+	// signature
+	Signature *PEV001SchemaSignature `json:"signature,omitempty"`
+}
+
+// Validate validates this pe v001 schema
+func (m *PEV001Schema) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateImage(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSignature(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PEV001Schema) validateImage(formats strfmt.Registry) error {
+
+	if err := validate.Required("image", "body", m.Image); err != nil {
+		return err
+	}
+
+	if m.Image != nil {
+		if err := m.Image.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("image")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("image")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *PEV001Schema) validateSignature(formats strfmt.Registry) error {
+	if swag.IsZero(m.Signature) { // not required
+		return nil
+	}
+
+	if m.Signature != nil {
+		if err := m.Signature.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this pe v001 schema based on the context it is used
+func (m *PEV001Schema) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateImage(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateSignature(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PEV001Schema) contextValidateImage(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Image != nil {
+		if err := m.Image.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("image")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("image")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *PEV001Schema) contextValidateSignature(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Signature != nil {
+		if err := m.Signature.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *PEV001Schema) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *PEV001Schema) UnmarshalBinary(b []byte) error {
+	var res PEV001Schema
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// PEV001SchemaImage Information about the PE file associated with the entry
+//
+// swagger:model PEV001SchemaImage
+type PEV001SchemaImage struct {
+
+	// Specifies the PE file inline within the document
+	// Format: byte
+	Content strfmt.Base64 `json:"content,omitempty"`
+
+	// digest
+	Digest *PEV001SchemaImageDigest `json:"digest,omitempty"`
+
+	// hash
+	Hash *PEV001SchemaImageHash `json:"hash,omitempty"`
+}
+
+// Validate validates this PE v001 schema image
+func (m *PEV001SchemaImage) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDigest(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateHash(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PEV001SchemaImage) validateDigest(formats strfmt.Registry) error {
+	if swag.IsZero(m.Digest) { // not required
+		return nil
+	}
+
+	if m.Digest != nil {
+		if err := m.Digest.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("image" + "." + "digest")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("image" + "." + "digest")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *PEV001SchemaImage) validateHash(formats strfmt.Registry) error {
+	if swag.IsZero(m.Hash) { // not required
+		return nil
+	}
+
+	if m.Hash != nil {
+		if err := m.Hash.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("image" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("image" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this PE v001 schema image based on the context it is used
+func (m *PEV001SchemaImage) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateDigest(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateHash(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PEV001SchemaImage) contextValidateDigest(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Digest != nil {
+		if err := m.Digest.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("image" + "." + "digest")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("image" + "." + "digest")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *PEV001SchemaImage) contextValidateHash(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Hash != nil {
+		if err := m.Hash.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("image" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("image" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *PEV001SchemaImage) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *PEV001SchemaImage) UnmarshalBinary(b []byte) error {
+	var res PEV001SchemaImage
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// PEV001SchemaImageDigest Specifies the Authenticode digest of the PE file that is signed by the signature
+//
+// swagger:model PEV001SchemaImageDigest
+type PEV001SchemaImageDigest struct {
+
+	// The hashing function used to compute the Authenticode digest
+	// Required: true
+	// Enum: [sha1 sha256 sha384 sha512]
+	Algorithm *string `json:"algorithm"`
+
+	// The Authenticode digest of the PE file
+	// Required: true
+	Value *string `json:"value"`
+}
+
+// Validate validates this PE v001 schema image digest
+func (m *PEV001SchemaImageDigest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAlgorithm(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateValue(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var peV001SchemaImageDigestTypeAlgorithmPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["sha1","sha256","sha384","sha512"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		peV001SchemaImageDigestTypeAlgorithmPropEnum = append(peV001SchemaImageDigestTypeAlgorithmPropEnum, v)
+	}
+}
+
+const (
+
+	// PEV001SchemaImageDigestAlgorithmSha1 captures enum value "sha1"
+	PEV001SchemaImageDigestAlgorithmSha1 string = "sha1"
+
+	// PEV001SchemaImageDigestAlgorithmSha256 captures enum value "sha256"
+	PEV001SchemaImageDigestAlgorithmSha256 string = "sha256"
+
+	// PEV001SchemaImageDigestAlgorithmSha384 captures enum value "sha384"
+	PEV001SchemaImageDigestAlgorithmSha384 string = "sha384"
+
+	// PEV001SchemaImageDigestAlgorithmSha512 captures enum value "sha512"
+	PEV001SchemaImageDigestAlgorithmSha512 string = "sha512"
+)
+
+// prop value enum
+func (m *PEV001SchemaImageDigest) validateAlgorithmEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, peV001SchemaImageDigestTypeAlgorithmPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *PEV001SchemaImageDigest) validateAlgorithm(formats strfmt.Registry) error {
+
+	if err := validate.Required("image"+"."+"digest"+"."+"algorithm", "body", m.Algorithm); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateAlgorithmEnum("image"+"."+"digest"+"."+"algorithm", "body", *m.Algorithm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *PEV001SchemaImageDigest) validateValue(formats strfmt.Registry) error {
+
+	if err := validate.Required("image"+"."+"digest"+"."+"value", "body", m.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this PE v001 schema image digest based on the context it is used
+func (m *PEV001SchemaImageDigest) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *PEV001SchemaImageDigest) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *PEV001SchemaImageDigest) UnmarshalBinary(b []byte) error {
+	var res PEV001SchemaImageDigest
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// PEV001SchemaImageHash Specifies the hash algorithm and value encompassing the entire signed PE file
+//
+// swagger:model PEV001SchemaImageHash
+type PEV001SchemaImageHash struct {
+
+	// The hashing function used to compute the hash value
+	// Required: true
+	// Enum: [sha256]
+	Algorithm *string `json:"algorithm"`
+
+	// The hash value for the PE file
+	// Required: true
+	Value *string `json:"value"`
+}
+
+// Validate validates this PE v001 schema image hash
+func (m *PEV001SchemaImageHash) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAlgorithm(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateValue(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var peV001SchemaImageHashTypeAlgorithmPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["sha256"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		peV001SchemaImageHashTypeAlgorithmPropEnum = append(peV001SchemaImageHashTypeAlgorithmPropEnum, v)
+	}
+}
+
+const (
+
+	// PEV001SchemaImageHashAlgorithmSha256 captures enum value "sha256"
+	PEV001SchemaImageHashAlgorithmSha256 string = "sha256"
+)
+
+// prop value enum
+func (m *PEV001SchemaImageHash) validateAlgorithmEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, peV001SchemaImageHashTypeAlgorithmPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *PEV001SchemaImageHash) validateAlgorithm(formats strfmt.Registry) error {
+
+	if err := validate.Required("image"+"."+"hash"+"."+"algorithm", "body", m.Algorithm); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateAlgorithmEnum("image"+"."+"hash"+"."+"algorithm", "body", *m.Algorithm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *PEV001SchemaImageHash) validateValue(formats strfmt.Registry) error {
+
+	if err := validate.Required("image"+"."+"hash"+"."+"value", "body", m.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this PE v001 schema image hash based on context it is used
+func (m *PEV001SchemaImageHash) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *PEV001SchemaImageHash) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *PEV001SchemaImageHash) UnmarshalBinary(b []byte) error {
+	var res PEV001SchemaImageHash
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// PEV001SchemaSignature Information about the Authenticode signature embedded in the PE file
+//
+// swagger:model PEV001SchemaSignature
+type PEV001SchemaSignature struct {
+
+	// Specifies the PKCS7 signature extracted from the certificate table of the PE file
+	// Required: true
+	// Read Only: true
+	// Format: byte
+	Content strfmt.Base64 `json:"content"`
+
+	// public key
+	// Required: true
+	PublicKey *PEV001SchemaSignaturePublicKey `json:"publicKey"`
+}
+
+// Validate validates this PE v001 schema signature
+func (m *PEV001SchemaSignature) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePublicKey(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PEV001SchemaSignature) validateContent(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"content", "body", strfmt.Base64(m.Content)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *PEV001SchemaSignature) validatePublicKey(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"publicKey", "body", m.PublicKey); err != nil {
+		return err
+	}
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature" + "." + "publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature" + "." + "publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this PE v001 schema signature based on the context it is used
+func (m *PEV001SchemaSignature) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateContent(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidatePublicKey(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PEV001SchemaSignature) contextValidateContent(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "signature"+"."+"content", "body", strfmt.Base64(m.Content)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *PEV001SchemaSignature) contextValidatePublicKey(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature" + "." + "publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature" + "." + "publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *PEV001SchemaSignature) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *PEV001SchemaSignature) UnmarshalBinary(b []byte) error {
+	var res PEV001SchemaSignature
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// PEV001SchemaSignaturePublicKey The X509 certificate of the signer of the PE file
+//
+// swagger:model PEV001SchemaSignaturePublicKey
+type PEV001SchemaSignaturePublicKey struct {
+
+	// Specifies the content of the X509 certificate containing the public key used to verify the signature
+	// Required: true
+	// Format: byte
+	Content *strfmt.Base64 `json:"content"`
+}
+
+// Validate validates this PE v001 schema signature public key
+func (m *PEV001SchemaSignaturePublicKey) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PEV001SchemaSignaturePublicKey) validateContent(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"publicKey"+"."+"content", "body", m.Content); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this PE v001 schema signature public key based on the context it is used
+func (m *PEV001SchemaSignaturePublicKey) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *PEV001SchemaSignaturePublicKey) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *PEV001SchemaSignaturePublicKey) UnmarshalBinary(b []byte) error {
+	var res PEV001SchemaSignaturePublicKey
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,662 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// SbomV001Schema SBOM v0.0.1 Schema
+//
+// Schema for signed SPDX and CycloneDX documents
+//
+// swagger:model sbomV001Schema
+type SbomV001Schema struct {
+
+	// document
+	// Required: true
+	Document *SbomV001SchemaDocument `json:"document"`
+
+	// signature
+	// Required: true
+	Signature *SbomV001SchemaSignature `json:"signature"`
+}
+
+// Validate validates this sbom v001 schema
+func (m *SbomV001Schema) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDocument(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSignature(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SbomV001Schema) validateDocument(formats strfmt.Registry) error {
+
+	if err := validate.Required("document", "body", m.Document); err != nil {
+		return err
+	}
+
+	if m.Document != nil {
+		if err := m.Document.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("document")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("document")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *SbomV001Schema) validateSignature(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature", "body", m.Signature); err != nil {
+		return err
+	}
+
+	if m.Signature != nil {
+		if err := m.Signature.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this sbom v001 schema based on the context it is used
+func (m *SbomV001Schema) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateDocument(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateSignature(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SbomV001Schema) contextValidateDocument(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Document != nil {
+		if err := m.Document.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("document")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("document")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *SbomV001Schema) contextValidateSignature(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Signature != nil {
+		if err := m.Signature.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SbomV001Schema) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SbomV001Schema) UnmarshalBinary(b []byte) error {
+	var res SbomV001Schema
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// SbomV001SchemaDocument Information about the SBOM document associated with the entry
+//
+// swagger:model SbomV001SchemaDocument
+type SbomV001SchemaDocument struct {
+
+	// Specifies the document inline within the document
+	// Format: byte
+	Content strfmt.Base64 `json:"content,omitempty"`
+
+	// The format of the document, which is only known when the document is provided
+	// Read Only: true
+	// Enum: [spdx cyclonedx]
+	Format string `json:"format,omitempty"`
+
+	// hash
+	Hash *SbomV001SchemaDocumentHash `json:"hash,omitempty"`
+}
+
+// Validate validates this sbom v001 schema document
+func (m *SbomV001SchemaDocument) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateFormat(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateHash(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var sbomV001SchemaDocumentTypeFormatPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["spdx","cyclonedx"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		sbomV001SchemaDocumentTypeFormatPropEnum = append(sbomV001SchemaDocumentTypeFormatPropEnum, v)
+	}
+}
+
+const (
+
+	// SbomV001SchemaDocumentFormatSpdx captures enum value "spdx"
+	SbomV001SchemaDocumentFormatSpdx string = "spdx"
+
+	// SbomV001SchemaDocumentFormatCyclonedx captures enum value "cyclonedx"
+	SbomV001SchemaDocumentFormatCyclonedx string = "cyclonedx"
+)
+
+// prop value enum
+func (m *SbomV001SchemaDocument) validateFormatEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, sbomV001SchemaDocumentTypeFormatPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *SbomV001SchemaDocument) validateFormat(formats strfmt.Registry) error {
+	if swag.IsZero(m.Format) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateFormatEnum("document"+"."+"format", "body", m.Format); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *SbomV001SchemaDocument) validateHash(formats strfmt.Registry) error {
+	if swag.IsZero(m.Hash) { // not required
+		return nil
+	}
+
+	if m.Hash != nil {
+		if err := m.Hash.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("document" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("document" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this sbom v001 schema document based on the context it is used
+func (m *SbomV001SchemaDocument) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateFormat(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateHash(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SbomV001SchemaDocument) contextValidateFormat(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "document"+"."+"format", "body", string(m.Format)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *SbomV001SchemaDocument) contextValidateHash(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Hash != nil {
+		if err := m.Hash.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("document" + "." + "hash")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("document" + "." + "hash")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SbomV001SchemaDocument) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SbomV001SchemaDocument) UnmarshalBinary(b []byte) error {
+	var res SbomV001SchemaDocument
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// SbomV001SchemaDocumentHash Specifies the hash algorithm and value for the document
+//
+// swagger:model SbomV001SchemaDocumentHash
+type SbomV001SchemaDocumentHash struct {
+
+	// The hashing function used to compute the hash value
+	// Required: true
+	// Enum: [sha256]
+	Algorithm *string `json:"algorithm"`
+
+	// The hash value for the document
+	// Required: true
+	Value *string `json:"value"`
+}
+
+// Validate validates this sbom v001 schema document hash
+func (m *SbomV001SchemaDocumentHash) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAlgorithm(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateValue(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var sbomV001SchemaDocumentHashTypeAlgorithmPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["sha256"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		sbomV001SchemaDocumentHashTypeAlgorithmPropEnum = append(sbomV001SchemaDocumentHashTypeAlgorithmPropEnum, v)
+	}
+}
+
+const (
+
+	// SbomV001SchemaDocumentHashAlgorithmSha256 captures enum value "sha256"
+	SbomV001SchemaDocumentHashAlgorithmSha256 string = "sha256"
+)
+
+// prop value enum
+func (m *SbomV001SchemaDocumentHash) validateAlgorithmEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, sbomV001SchemaDocumentHashTypeAlgorithmPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *SbomV001SchemaDocumentHash) validateAlgorithm(formats strfmt.Registry) error {
+
+	if err := validate.Required("document"+"."+"hash"+"."+"algorithm", "body", m.Algorithm); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateAlgorithmEnum("document"+"."+"hash"+"."+"algorithm", "body", *m.Algorithm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *SbomV001SchemaDocumentHash) validateValue(formats strfmt.Registry) error {
+
+	if err := validate.Required("document"+"."+"hash"+"."+"value", "body", m.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this sbom v001 schema document hash based on context it is used
+func (m *SbomV001SchemaDocumentHash) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SbomV001SchemaDocumentHash) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SbomV001SchemaDocumentHash) UnmarshalBinary(b []byte) error {
+	var res SbomV001SchemaDocumentHash
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// SbomV001SchemaSignature Information about the detached signature over the document
+//
+// swagger:model SbomV001SchemaSignature
+type SbomV001SchemaSignature struct {
+
+	// Specifies the content of the signature inline within the document
+	// Required: true
+	// Format: byte
+	Content *strfmt.Base64 `json:"content"`
+
+	// Specifies the type of signature
+	// Required: true
+	// Enum: [pgp minisign x509 ssh]
+	Format *string `json:"format"`
+
+	// public key
+	// Required: true
+	PublicKey *SbomV001SchemaSignaturePublicKey `json:"publicKey"`
+}
+
+// Validate validates this sbom v001 schema signature
+func (m *SbomV001SchemaSignature) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateFormat(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePublicKey(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SbomV001SchemaSignature) validateContent(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"content", "body", m.Content); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var sbomV001SchemaSignatureTypeFormatPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["pgp","minisign","x509","ssh"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		sbomV001SchemaSignatureTypeFormatPropEnum = append(sbomV001SchemaSignatureTypeFormatPropEnum, v)
+	}
+}
+
+const (
+
+	// SbomV001SchemaSignatureFormatPgp captures enum value "pgp"
+	SbomV001SchemaSignatureFormatPgp string = "pgp"
+
+	// SbomV001SchemaSignatureFormatMinisign captures enum value "minisign"
+	SbomV001SchemaSignatureFormatMinisign string = "minisign"
+
+	// SbomV001SchemaSignatureFormatX509 captures enum value "x509"
+	SbomV001SchemaSignatureFormatX509 string = "x509"
+
+	// SbomV001SchemaSignatureFormatSSH captures enum value "ssh"
+	SbomV001SchemaSignatureFormatSSH string = "ssh"
+)
+
+// prop value enum
+func (m *SbomV001SchemaSignature) validateFormatEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, sbomV001SchemaSignatureTypeFormatPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *SbomV001SchemaSignature) validateFormat(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"format", "body", m.Format); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateFormatEnum("signature"+"."+"format", "body", *m.Format); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *SbomV001SchemaSignature) validatePublicKey(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"publicKey", "body", m.PublicKey); err != nil {
+		return err
+	}
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature" + "." + "publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature" + "." + "publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this sbom v001 schema signature based on the context it is used
+func (m *SbomV001SchemaSignature) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidatePublicKey(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SbomV001SchemaSignature) contextValidatePublicKey(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.PublicKey != nil {
+		if err := m.PublicKey.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("signature" + "." + "publicKey")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("signature" + "." + "publicKey")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SbomV001SchemaSignature) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SbomV001SchemaSignature) UnmarshalBinary(b []byte) error {
+	var res SbomV001SchemaSignature
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// SbomV001SchemaSignaturePublicKey The public key that can verify the signature
+//
+// swagger:model SbomV001SchemaSignaturePublicKey
+type SbomV001SchemaSignaturePublicKey struct {
+
+	// Specifies the content of the public key inline within the document
+	// Required: true
+	// Format: byte
+	Content *strfmt.Base64 `json:"content"`
+}
+
+// Validate validates this sbom v001 schema signature public key
+func (m *SbomV001SchemaSignaturePublicKey) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SbomV001SchemaSignaturePublicKey) validateContent(formats strfmt.Registry) error {
+
+	if err := validate.Required("signature"+"."+"publicKey"+"."+"content", "body", m.Content); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this sbom v001 schema signature public key based on context it is used
+func (m *SbomV001SchemaSignaturePublicKey) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SbomV001SchemaSignaturePublicKey) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SbomV001SchemaSignaturePublicKey) UnmarshalBinary(b []byte) error {
+	var res SbomV001SchemaSignaturePublicKey
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -41,13 +41,16 @@ type SearchIndex struct {
 	Email strfmt.Email `json:"email,omitempty"`
 
 	// hash
-	// Pattern: ^(sha256:)?[0-9a-fA-F]{64}$|^(sha1:)?[0-9a-fA-F]{40}$
+	// Pattern: ^(sha512:)?[0-9a-fA-F]{128}$|^(sha256:)?[0-9a-fA-F]{64}$|^(sha1:)?[0-9a-fA-F]{40}$
 	Hash string `json:"hash,omitempty"`
 
 	// operator
 	// Enum: [and or]
 	Operator string `json:"operator,omitempty"`
 
+	// package
+	Package *SearchIndexPackage `json:"package,omitempty"`
+
 	// public key
 	PublicKey *SearchIndexPublicKey `json:"publicKey,omitempty"`
 }
@@ -68,6 +71,10 @@ func (m *SearchIndex) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePackage(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validatePublicKey(formats); err != nil {
 		res = append(res, err)
 	}
@@ -95,7 +102,7 @@ func (m *SearchIndex) validateHash(formats strfmt.Registry) error {
 		return nil
 	}
 
-	if err := validate.Pattern("hash", "body", m.Hash, `^(sha256:)?[0-9a-fA-F]{64}$|^(sha1:)?[0-9a-fA-F]{40}$`); err != nil {
+	if err := validate.Pattern("hash", "body", m.Hash, `^(sha512:)?[0-9a-fA-F]{128}$|^(sha256:)?[0-9a-fA-F]{64}$|^(sha1:)?[0-9a-fA-F]{40}$`); err != nil {
 		return err
 	}
 
@@ -144,6 +151,25 @@ func (m *SearchIndex) validateOperator(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SearchIndex) validatePackage(formats strfmt.Registry) error {
+	if swag.IsZero(m.Package) { // not required
+		return nil
+	}
+
+	if m.Package != nil {
+		if err := m.Package.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("package")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("package")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *SearchIndex) validatePublicKey(formats strfmt.Registry) error {
 	if swag.IsZero(m.PublicKey) { // not required
 		return nil
@@ -167,6 +193,10 @@ func (m *SearchIndex) validatePublicKey(formats strfmt.Registry) error {
 func (m *SearchIndex) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidatePackage(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidatePublicKey(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -177,6 +207,22 @@ func (m *SearchIndex) ContextValidate(ctx context.Context, formats strfmt.Regist
 	return nil
 }
 
+func (m *SearchIndex) contextValidatePackage(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Package != nil {
+		if err := m.Package.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("package")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("package")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *SearchIndex) contextValidatePublicKey(ctx context.Context, formats strfmt.Registry) error {
 
 	if m.PublicKey != nil {
@@ -211,6 +257,128 @@ func (m *SearchIndex) UnmarshalBinary(b []byte) error {
 	return nil
 }
 
+// SearchIndexPackage search index package
+//
+// swagger:model SearchIndexPackage
+type SearchIndexPackage struct {
+
+	// The name of the package, optionally with its version in the notation of its type, such as name@version for npm, path@version for gomod, name_version_architecture for deb or name==version for pypi, or the image reference for oci
+	// Required: true
+	// Min Length: 1
+	Name *string `json:"name"`
+
+	// The kind of the entries that the package was logged in
+	// Required: true
+	// Enum: [npm gomod oci deb pypi]
+	Type *string `json:"type"`
+}
+
+// Validate validates this search index package
+func (m *SearchIndexPackage) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SearchIndexPackage) validateName(formats strfmt.Registry) error {
+
+	if err := validate.Required("package"+"."+"name", "body", m.Name); err != nil {
+		return err
+	}
+
+	if err := validate.MinLength("package"+"."+"name", "body", *m.Name, 1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var searchIndexPackageTypeTypePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["npm","gomod","oci","deb","pypi"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		searchIndexPackageTypeTypePropEnum = append(searchIndexPackageTypeTypePropEnum, v)
+	}
+}
+
+const (
+
+	// SearchIndexPackageTypeNpm captures enum value "npm"
+	SearchIndexPackageTypeNpm string = "npm"
+
+	// SearchIndexPackageTypeGomod captures enum value "gomod"
+	SearchIndexPackageTypeGomod string = "gomod"
+
+	// SearchIndexPackageTypeOCI captures enum value "oci"
+	SearchIndexPackageTypeOCI string = "oci"
+
+	// SearchIndexPackageTypeDeb captures enum value "deb"
+	SearchIndexPackageTypeDeb string = "deb"
+
+	// SearchIndexPackageTypePypi captures enum value "pypi"
+	SearchIndexPackageTypePypi string = "pypi"
+)
+
+// prop value enum
+func (m *SearchIndexPackage) validateTypeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, searchIndexPackageTypeTypePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *SearchIndexPackage) validateType(formats strfmt.Registry) error {
+
+	if err := validate.Required("package"+"."+"type", "body", m.Type); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateTypeEnum("package"+"."+"type", "body", *m.Type); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this search index package based on context it is used
+func (m *SearchIndexPackage) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SearchIndexPackage) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SearchIndexPackage) UnmarshalBinary(b []byte) error {
+	var res SearchIndexPackage
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
 // SearchIndexPublicKey search index public key
 //
 // swagger:model SearchIndexPublicKey
@@ -39,6 +39,7 @@ import (
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/index"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/pubkey"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/server"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/timestamp"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/tlog"
 	"github.com/sigstore/rekor/pkg/log"
 	"github.com/sigstore/rekor/pkg/util"
@@ -52,6 +53,39 @@ func configureFlags(api *operations.RekorServerAPI) {
 	// api.CommandLineOptionsGroups = []swag.CommandLineOptionsGroup{ ... }
 }
 
+// ConfigureRekorAPI configures the API and the handlers, which serve the
+// given API. Endpoints are not implemented until this is called.
+func (s *Server) ConfigureRekorAPI(a *pkgapi.API) {
+	if s.api != nil {
+		configureHandlers(s.api, a)
+	}
+	s.ConfigureAPI()
+}
+
+func configureHandlers(api *operations.RekorServerAPI, a *pkgapi.API) {
+	api.EntriesCreateLogEntryHandler = entries.CreateLogEntryHandlerFunc(a.CreateLogEntryHandler)
+	api.EntriesGetLogEntryByIndexHandler = entries.GetLogEntryByIndexHandlerFunc(a.GetLogEntryByIndexHandler)
+	api.EntriesGetLogEntryByUUIDHandler = entries.GetLogEntryByUUIDHandlerFunc(a.GetLogEntryByUUIDHandler)
+	api.EntriesSearchLogQueryHandler = entries.SearchLogQueryHandlerFunc(a.SearchLogQueryHandler)
+
+	api.PubkeyGetPublicKeyHandler = pubkey.GetPublicKeyHandlerFunc(a.GetPublicKeyHandler)
+
+	api.TlogGetLogInfoHandler = tlog.GetLogInfoHandlerFunc(a.GetLogInfoHandler)
+	api.TlogGetLogProofHandler = tlog.GetLogProofHandlerFunc(a.GetLogProofHandler)
+
+	api.ServerGetRekorVersionHandler = server.GetRekorVersionHandlerFunc(pkgapi.GetRekorVersionHandler)
+
+	api.TimestampGetTimestampResponseHandler = timestamp.GetTimestampResponseHandlerFunc(a.TimestampResponseHandler)
+	api.TimestampGetTimestampCertChainHandler = timestamp.GetTimestampCertChainHandlerFunc(a.GetTimestampCertChainHandler)
+	api.TimestampGetTimestampNoteHandler = timestamp.GetTimestampNoteHandlerFunc(a.TimestampNoteHandler)
+
+	if viper.GetBool("enable_retrieve_api") {
+		api.IndexSearchIndexHandler = index.SearchIndexHandlerFunc(a.SearchIndexHandler)
+	} else {
+		api.IndexSearchIndexHandler = index.SearchIndexHandlerFunc(pkgapi.SearchIndexNotImplementedHandler)
+	}
+}
+
 func configureAPI(api *operations.RekorServerAPI) http.Handler {
 	// configure the api here
 	api.ServeError = logAndServeError
@@ -71,26 +105,12 @@ func configureAPI(api *operations.RekorServerAPI) http.Handler {
 	api.JSONProducer = runtime.JSONProducer()
 
 	api.ApplicationXPemFileProducer = runtime.TextProducer()
-
-	api.EntriesCreateLogEntryHandler = entries.CreateLogEntryHandlerFunc(pkgapi.CreateLogEntryHandler)
-	api.EntriesGetLogEntryByIndexHandler = entries.GetLogEntryByIndexHandlerFunc(pkgapi.GetLogEntryByIndexHandler)
-	api.EntriesGetLogEntryByUUIDHandler = entries.GetLogEntryByUUIDHandlerFunc(pkgapi.GetLogEntryByUUIDHandler)
-	api.EntriesSearchLogQueryHandler = entries.SearchLogQueryHandlerFunc(pkgapi.SearchLogQueryHandler)
-
-	api.PubkeyGetPublicKeyHandler = pubkey.GetPublicKeyHandlerFunc(pkgapi.GetPublicKeyHandler)
-
-	api.TlogGetLogInfoHandler = tlog.GetLogInfoHandlerFunc(pkgapi.GetLogInfoHandler)
-	api.TlogGetLogProofHandler = tlog.GetLogProofHandlerFunc(pkgapi.GetLogProofHandler)
-
-	api.ServerGetRekorVersionHandler = server.GetRekorVersionHandlerFunc(pkgapi.GetRekorVersionHandler)
-
-	if viper.GetBool("enable_retrieve_api") {
-		api.IndexSearchIndexHandler = index.SearchIndexHandlerFunc(pkgapi.SearchIndexHandler)
-	} else {
-		api.IndexSearchIndexHandler = index.SearchIndexHandlerFunc(pkgapi.SearchIndexNotImplementedHandler)
-	}
+	api.ApplicationPemCertificateChainProducer = runtime.TextProducer()
+	api.ApplicationTimestampQueryConsumer = runtime.ByteStreamConsumer()
+	api.ApplicationTimestampReplyProducer = runtime.ByteStreamProducer()
 
 	api.RegisterFormat("signedCheckpoint", &util.SignedNote{}, util.SignedCheckpointValidator)
+	api.RegisterFormat("signedTimestampNote", &util.SignedNote{}, util.SignedTimestampNoteValidator)
 
 	api.PreServerShutdown = func() {}
 
@@ -101,7 +121,8 @@ func configureAPI(api *operations.RekorServerAPI) http.Handler {
 	api.AddMiddlewareFor("GET", "/api/v1/log/proof", middleware.NoCache)
 	api.AddMiddlewareFor("GET", "/api/v1/log/entries", middleware.NoCache)
 	api.AddMiddlewareFor("GET", "/api/v1/log/entries/{entryUUID}", middleware.NoCache)
-	api.AddMiddlewareFor("GET", "/api/v1/timestamp", middleware.NoCache)
+	api.AddMiddlewareFor("POST", "/api/v1/timestamp", middleware.NoCache)
+	api.AddMiddlewareFor("POST", "/api/v1/timestamp/note", middleware.NoCache)
 
 	// cache forever
 	api.AddMiddlewareFor("GET", "/api/v1/log/publicKey", cacheForever)
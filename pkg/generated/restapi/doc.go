@@ -25,9 +25,12 @@
 //  Version: 0.0.1
 //
 //  Consumes:
+//    - application/timestamp-query
 //    - application/json
 //
 //  Produces:
+//    - application/pem-certificate-chain
+//    - application/timestamp-reply
 //    - application/x-pem-file
 //    - application/json
 //
@@ -340,6 +340,120 @@ func init() {
         }
       }
     },
+    "/api/v1/log/timestamp/certchain": {
+      "get": {
+        "description": "Returns the PEM encoded certificate chain of the timestamping authority, starting with the signing certificate",
+        "produces": [
+          "application/pem-certificate-chain"
+        ],
+        "tags": [
+          "timestamp"
+        ],
+        "summary": "Retrieve the certificate chain used to validate timestamp responses",
+        "operationId": "getTimestampCertChain",
+        "responses": {
+          "200": {
+            "description": "The PEM encoded certificate chain",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/api/v1/timestamp": {
+      "post": {
+        "description": "Returns a signed timestamp response for the timestamp request. If the server is configured to log timestamps, the response is also added to the transparency log as an rfc3161 entry.\n",
+        "consumes": [
+          "application/timestamp-query"
+        ],
+        "produces": [
+          "application/timestamp-reply"
+        ],
+        "tags": [
+          "timestamp"
+        ],
+        "summary": "Generates a new RFC 3161 timestamp response",
+        "operationId": "getTimestampResponse",
+        "parameters": [
+          {
+            "name": "request",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Returns a timestamp response",
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "UUID of the log entry, if the timestamp was logged"
+              },
+              "Location": {
+                "type": "string",
+                "format": "uri",
+                "description": "URI location of the log entry, if the timestamp was logged"
+              }
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/api/v1/timestamp/note": {
+      "post": {
+        "description": "Returns a timestamp note for the message imprint and nonce, signed by the log signer",
+        "tags": [
+          "timestamp"
+        ],
+        "summary": "Generates a new signed timestamp note",
+        "operationId": "getTimestampNote",
+        "parameters": [
+          {
+            "name": "request",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/TimestampNoteRequest"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Returns a signed timestamp note",
+            "schema": {
+              "$ref": "#/definitions/TimestampNoteResponse"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
     "/api/v1/version": {
       "get": {
         "tags": [
@@ -362,6 +476,26 @@ func init() {
     }
   },
   "definitions": {
+    "CheckpointTimestamp": {
+      "description": "An RFC 3161 timestamp from an external timestamping authority over a signed checkpoint of the active tree, proving that all entries below the checkpoint's tree size were integrated before the timestamped time\n",
+      "type": "object",
+      "required": [
+        "signedCheckpoint",
+        "timestampResponse"
+      ],
+      "properties": {
+        "signedCheckpoint": {
+          "description": "The signed checkpoint that was timestamped",
+          "type": "string",
+          "format": "signedCheckpoint"
+        },
+        "timestampResponse": {
+          "description": "The DER encoded RFC 3161 timestamp response over the signed checkpoint",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
     "ConsistencyProof": {
       "type": "object",
       "required": [
@@ -520,6 +654,9 @@ func init() {
         "treeID"
       ],
       "properties": {
+        "checkpointTimestamp": {
+          "$ref": "#/definitions/CheckpointTimestamp"
+        },
         "inactiveShards": {
           "type": "array",
           "items": {
@@ -592,7 +729,7 @@ func init() {
         },
         "hash": {
           "type": "string",
-          "pattern": "^(sha256:)?[0-9a-fA-F]{64}$|^(sha1:)?[0-9a-fA-F]{40}$"
+          "pattern": "^(sha512:)?[0-9a-fA-F]{128}$|^(sha256:)?[0-9a-fA-F]{64}$|^(sha1:)?[0-9a-fA-F]{40}$"
         },
         "operator": {
           "type": "string",
@@ -601,6 +738,31 @@ func init() {
             "or"
           ]
         },
+        "package": {
+          "type": "object",
+          "required": [
+            "type",
+            "name"
+          ],
+          "properties": {
+            "name": {
+              "description": "The name of the package, optionally with its version in the notation of its type, such as name@version for npm, path@version for gomod, name_version_architecture for deb or name==version for pypi, or the image reference for oci",
+              "type": "string",
+              "minLength": 1
+            },
+            "type": {
+              "description": "The kind of the entries that the package was logged in",
+              "type": "string",
+              "enum": [
+                "npm",
+                "gomod",
+                "oci",
+                "deb",
+                "pypi"
+              ]
+            }
+          }
+        },
         "publicKey": {
           "type": "object",
           "required": [
@@ -659,6 +821,39 @@ func init() {
         }
       }
     },
+    "TimestampNoteRequest": {
+      "type": "object",
+      "required": [
+        "messageImprint",
+        "nonce"
+      ],
+      "properties": {
+        "messageImprint": {
+          "description": "The hash of the message to timestamp",
+          "type": "string",
+          "pattern": "^sha256:[0-9a-fA-F]{64}$"
+        },
+        "nonce": {
+          "description": "Random bytes, returned in the signed note to prove its freshness",
+          "type": "string",
+          "format": "byte",
+          "minLength": 1
+        }
+      }
+    },
+    "TimestampNoteResponse": {
+      "type": "object",
+      "required": [
+        "signedTimestampNote"
+      ],
+      "properties": {
+        "signedTimestampNote": {
+          "description": "The signed timestamp note",
+          "type": "string",
+          "format": "signedTimestampNote"
+        }
+      }
+    },
     "alpine": {
       "description": "Alpine package",
       "type": "object",
@@ -685,6 +880,32 @@ func init() {
         }
       ]
     },
+    "apk-android": {
+      "description": "Android application package signed with APK Signature Scheme v2 or v3",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/apkandroid/apk_android_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
     "cose": {
       "description": "COSE object",
       "type": "object",
@@ -711,6 +932,110 @@ func init() {
         }
       ]
     },
+    "deb": {
+      "description": "Debian package or APT repository metadata",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/deb/deb_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "dsse": {
+      "description": "DSSE envelope",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/dsse/dsse_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "git": {
+      "description": "Signed git commit or tag",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/git/git_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "gomod": {
+      "description": "Signed Go module checksums",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/gomod/gomod_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
     "hashedrekord": {
       "description": "Hashed Rekord object",
       "type": "object",
@@ -814,8 +1139,8 @@ func init() {
         }
       ]
     },
-    "rekord": {
-      "description": "Rekord object",
+    "npm": {
+      "description": "npm package registry signature or provenance",
       "type": "object",
       "allOf": [
         {
@@ -833,15 +1158,15 @@ func init() {
             },
             "spec": {
               "type": "object",
-              "$ref": "pkg/types/rekord/rekord_schema.json"
+              "$ref": "pkg/types/npm/npm_schema.json"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "rfc3161": {
-      "description": "RFC3161 Timestamp",
+    "oci": {
+      "description": "OCI image signature",
       "type": "object",
       "allOf": [
         {
@@ -859,15 +1184,15 @@ func init() {
             },
             "spec": {
               "type": "object",
-              "$ref": "pkg/types/rfc3161/rfc3161_schema.json"
+              "$ref": "pkg/types/oci/oci_schema.json"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "rpm": {
-      "description": "RPM package",
+    "pe": {
+      "description": "Authenticode-signed PE binary",
       "type": "object",
       "allOf": [
         {
@@ -885,15 +1210,15 @@ func init() {
             },
             "spec": {
               "type": "object",
-              "$ref": "pkg/types/rpm/rpm_schema.json"
+              "$ref": "pkg/types/pe/pe_schema.json"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "tuf": {
-      "description": "TUF metadata",
+    "pypi": {
+      "description": "Python wheel or sdist signature",
       "type": "object",
       "allOf": [
         {
@@ -911,7 +1236,137 @@ func init() {
             },
             "spec": {
               "type": "object",
-              "$ref": "pkg/types/tuf/tuf_schema.json"
+              "$ref": "pkg/types/pypi/pypi_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "rekord": {
+      "description": "Rekord object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/rekord/rekord_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "rfc3161": {
+      "description": "RFC3161 Timestamp",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/rfc3161/rfc3161_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "rpm": {
+      "description": "RPM package",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/rpm/rpm_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "sbom": {
+      "description": "Signed SPDX or CycloneDX software bill of materials",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/sbom/sbom_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "tuf": {
+      "description": "TUF metadata",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/tuf/tuf_schema.json"
             }
           },
           "additionalProperties": false
@@ -1302,6 +1757,135 @@ func init() {
         }
       }
     },
+    "/api/v1/log/timestamp/certchain": {
+      "get": {
+        "description": "Returns the PEM encoded certificate chain of the timestamping authority, starting with the signing certificate",
+        "produces": [
+          "application/pem-certificate-chain"
+        ],
+        "tags": [
+          "timestamp"
+        ],
+        "summary": "Retrieve the certificate chain used to validate timestamp responses",
+        "operationId": "getTimestampCertChain",
+        "responses": {
+          "200": {
+            "description": "The PEM encoded certificate chain",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "The content requested could not be found"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/timestamp": {
+      "post": {
+        "description": "Returns a signed timestamp response for the timestamp request. If the server is configured to log timestamps, the response is also added to the transparency log as an rfc3161 entry.\n",
+        "consumes": [
+          "application/timestamp-query"
+        ],
+        "produces": [
+          "application/timestamp-reply"
+        ],
+        "tags": [
+          "timestamp"
+        ],
+        "summary": "Generates a new RFC 3161 timestamp response",
+        "operationId": "getTimestampResponse",
+        "parameters": [
+          {
+            "name": "request",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Returns a timestamp response",
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "UUID of the log entry, if the timestamp was logged"
+              },
+              "Location": {
+                "type": "string",
+                "format": "uri",
+                "description": "URI location of the log entry, if the timestamp was logged"
+              }
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/timestamp/note": {
+      "post": {
+        "description": "Returns a timestamp note for the message imprint and nonce, signed by the log signer",
+        "tags": [
+          "timestamp"
+        ],
+        "summary": "Generates a new signed timestamp note",
+        "operationId": "getTimestampNote",
+        "parameters": [
+          {
+            "name": "request",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/TimestampNoteRequest"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Returns a signed timestamp note",
+            "schema": {
+              "$ref": "#/definitions/TimestampNoteResponse"
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/api/v1/version": {
       "get": {
         "tags": [
@@ -1327,8 +1911,8 @@ func init() {
     }
   },
   "definitions": {
-    "AlpineV001SchemaPackage": {
-      "description": "Information about the package associated with the entry",
+    "APKAndroidV001SchemaAPK": {
+      "description": "Information about the APK associated with the entry",
       "type": "object",
       "oneOf": [
         {
@@ -1344,13 +1928,13 @@ func init() {
       ],
       "properties": {
         "content": {
-          "description": "Specifies the package inline within the document",
+          "description": "Specifies the APK inline within the document",
           "type": "string",
           "format": "byte",
           "writeOnly": true
         },
         "hash": {
-          "description": "Specifies the hash algorithm and value for the package",
+          "description": "Specifies the hash algorithm and value encompassing the entire signed APK",
           "type": "object",
           "required": [
             "algorithm",
@@ -1365,24 +1949,20 @@ func init() {
               ]
             },
             "value": {
-              "description": "The hash value for the package",
+              "description": "The hash value for the APK",
               "type": "string"
             }
-          },
-          "readOnly": true
+          }
         },
-        "pkginfo": {
-          "description": "Values of the .PKGINFO key / value pairs",
-          "type": "object",
-          "additionalProperties": {
-            "type": "string"
-          },
+        "packageName": {
+          "description": "The package name from the manifest of the APK",
+          "type": "string",
           "readOnly": true
         }
       }
     },
-    "AlpineV001SchemaPackageHash": {
-      "description": "Specifies the hash algorithm and value for the package",
+    "APKAndroidV001SchemaAPKHash": {
+      "description": "Specifies the hash algorithm and value encompassing the entire signed APK",
       "type": "object",
       "required": [
         "algorithm",
@@ -1397,28 +1977,176 @@ func init() {
           ]
         },
         "value": {
-          "description": "The hash value for the package",
+          "description": "The hash value for the APK",
           "type": "string"
         }
-      },
-      "readOnly": true
+      }
     },
-    "AlpineV001SchemaPublicKey": {
-      "description": "The public key that can verify the package signature",
+    "APKAndroidV001SchemaSignature": {
+      "description": "Information about the signer in the APK Signing Block of the APK",
       "type": "object",
       "required": [
+        "scheme",
+        "publicKey",
         "content"
       ],
       "properties": {
         "content": {
-          "description": "Specifies the content of the public key inline within the document",
+          "description": "Specifies the signer block of the scheme, which holds the signed data and the signatures over it",
           "type": "string",
           "format": "byte"
-        }
-      }
-    },
-    "ConsistencyProof": {
-      "type": "object",
+        },
+        "publicKey": {
+          "description": "The X509 certificate of the signer of the APK",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        },
+        "scheme": {
+          "description": "The most recent APK Signature Scheme the APK is signed with",
+          "type": "string",
+          "enum": [
+            "v2",
+            "v3"
+          ]
+        }
+      },
+      "readOnly": true
+    },
+    "APKAndroidV001SchemaSignaturePublicKey": {
+      "description": "The X509 certificate of the signer of the APK",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "AlpineV001SchemaPackage": {
+      "description": "Information about the package associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "hash"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the package inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the package",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the package",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        },
+        "pkginfo": {
+          "description": "Values of the .PKGINFO key / value pairs",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "AlpineV001SchemaPackageHash": {
+      "description": "Specifies the hash algorithm and value for the package",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the package",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "AlpineV001SchemaPublicKey": {
+      "description": "The public key that can verify the package signature",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "CheckpointTimestamp": {
+      "description": "An RFC 3161 timestamp from an external timestamping authority over a signed checkpoint of the active tree, proving that all entries below the checkpoint's tree size were integrated before the timestamped time\n",
+      "type": "object",
+      "required": [
+        "signedCheckpoint",
+        "timestampResponse"
+      ],
+      "properties": {
+        "signedCheckpoint": {
+          "description": "The signed checkpoint that was timestamped",
+          "type": "string",
+          "format": "signedCheckpoint"
+        },
+        "timestampResponse": {
+          "description": "The DER encoded RFC 3161 timestamp response over the signed checkpoint",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "ConsistencyProof": {
+      "type": "object",
       "required": [
         "rootHash",
         "hashes"
@@ -1539,46 +2267,30 @@ func init() {
       },
       "readOnly": true
     },
-    "Error": {
+    "DSSEV001SchemaEnvelopeHash": {
+      "description": "Specifies the hash algorithm and value encompassing the entire envelope sent to Rekor",
       "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
       "properties": {
-        "code": {
-          "type": "integer"
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
         },
-        "message": {
+        "value": {
+          "description": "The value of the computed digest over the entire envelope",
           "type": "string"
         }
-      }
-    },
-    "HashedrekordV001SchemaData": {
-      "description": "Information about the content associated with the entry",
-      "type": "object",
-      "properties": {
-        "hash": {
-          "description": "Specifies the hash algorithm and value for the content",
-          "type": "object",
-          "required": [
-            "algorithm",
-            "value"
-          ],
-          "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
-              "type": "string",
-              "enum": [
-                "sha256"
-              ]
-            },
-            "value": {
-              "description": "The hash value for the content",
-              "type": "string"
-            }
-          }
-        }
-      }
+      },
+      "readOnly": true
     },
-    "HashedrekordV001SchemaDataHash": {
-      "description": "Specifies the hash algorithm and value for the content",
+    "DSSEV001SchemaPayloadHash": {
+      "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
       "type": "object",
       "required": [
         "algorithm",
@@ -1593,53 +2305,81 @@ func init() {
           ]
         },
         "value": {
-          "description": "The hash value for the content",
+          "description": "The value of the computed digest over the payload within the envelope",
           "type": "string"
         }
-      }
+      },
+      "readOnly": true
     },
-    "HashedrekordV001SchemaSignature": {
-      "description": "Information about the detached signature associated with the entry",
+    "DSSEV001SchemaProposedContent": {
       "type": "object",
+      "required": [
+        "envelope",
+        "verifiers"
+      ],
       "properties": {
-        "content": {
-          "description": "Specifies the content of the signature inline within the document",
+        "envelope": {
+          "description": "DSSE envelope specified as a stringified JSON object",
           "type": "string",
-          "format": "byte"
+          "writeOnly": true
         },
-        "publicKey": {
-          "description": "The public key that can verify the signature; this can also be an X509 code signing certificate that contains the raw public key information",
-          "type": "object",
-          "properties": {
-            "content": {
-              "description": "Specifies the content of the public key or code signing certificate inline within the document",
-              "type": "string",
-              "format": "byte"
-            }
-          }
+        "verifiers": {
+          "description": "collection of all verification material (e.g. public keys or certificates) used to verify signatures over envelope's payload, specified as base64-encoded strings",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string",
+            "format": "byte"
+          },
+          "writeOnly": true
         }
-      }
+      },
+      "writeOnly": true
     },
-    "HashedrekordV001SchemaSignaturePublicKey": {
-      "description": "The public key that can verify the signature; this can also be an X509 code signing certificate that contains the raw public key information",
+    "DSSEV001SchemaSignaturesItems0": {
+      "description": "a signature of the envelope's payload along with the verification material for the signature",
       "type": "object",
+      "required": [
+        "signature",
+        "verifier"
+      ],
       "properties": {
-        "content": {
-          "description": "Specifies the content of the public key or code signing certificate inline within the document",
+        "signature": {
+          "description": "base64 encoded signature of the payload",
+          "type": "string",
+          "pattern": "^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=|[A-Za-z0-9+/]{2}={0,1})?$"
+        },
+        "verifier": {
+          "description": "verification material that was used to verify the corresponding signature, specified as a base64 encoded string",
           "type": "string",
           "format": "byte"
         }
       }
     },
-    "HelmV001SchemaChart": {
-      "description": "Information about the Helm chart associated with the entry",
+    "DebV001SchemaPackage": {
+      "description": "Information about the Debian package associated with the entry",
       "type": "object",
-      "required": [
-        "provenance"
+      "oneOf": [
+        {
+          "required": [
+            "hash"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
       ],
       "properties": {
+        "content": {
+          "description": "Specifies the package inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
         "hash": {
-          "description": "Specifies the hash algorithm and value for the chart",
+          "description": "Specifies the hash algorithm and value for the package",
           "type": "object",
           "required": [
             "algorithm",
@@ -1654,56 +2394,23 @@ func init() {
               ]
             },
             "value": {
-              "description": "The hash value for the chart",
+              "description": "The hash value for the package",
               "type": "string"
             }
-          },
-          "readOnly": true
+          }
         },
-        "provenance": {
-          "description": "The provenance entry associated with the signed Helm Chart",
+        "headers": {
+          "description": "Values of the Package, Version and Architecture fields of the package's control file",
           "type": "object",
-          "oneOf": [
-            {
-              "required": [
-                "signature"
-              ]
-            },
-            {
-              "required": [
-                "content"
-              ]
-            }
-          ],
-          "properties": {
-            "content": {
-              "description": "Specifies the content of the provenance file inline within the document",
-              "type": "string",
-              "format": "byte",
-              "writeOnly": true
-            },
-            "signature": {
-              "description": "Information about the included signature in the provenance file",
-              "type": "object",
-              "required": [
-                "content"
-              ],
-              "properties": {
-                "content": {
-                  "description": "Specifies the signature embedded within the provenance file ",
-                  "type": "string",
-                  "format": "byte",
-                  "readOnly": true
-                }
-              },
-              "readOnly": true
-            }
-          }
+          "additionalProperties": {
+            "type": "string"
+          },
+          "readOnly": true
         }
       }
     },
-    "HelmV001SchemaChartHash": {
-      "description": "Specifies the hash algorithm and value for the chart",
+    "DebV001SchemaPackageHash": {
+      "description": "Specifies the hash algorithm and value for the package",
       "type": "object",
       "required": [
         "algorithm",
@@ -1718,19 +2425,32 @@ func init() {
           ]
         },
         "value": {
-          "description": "The hash value for the chart",
+          "description": "The hash value for the package",
           "type": "string"
         }
-      },
-      "readOnly": true
+      }
     },
-    "HelmV001SchemaChartProvenance": {
-      "description": "The provenance entry associated with the signed Helm Chart",
+    "DebV001SchemaPublicKey": {
+      "description": "The PGP public key that can verify the package or repository metadata signature",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "DebV001SchemaRelease": {
+      "description": "Information about the clearsigned InRelease file of an APT repository associated with the entry",
       "type": "object",
       "oneOf": [
         {
           "required": [
-            "signature"
+            "hash"
           ]
         },
         {
@@ -1741,417 +2461,252 @@ func init() {
       ],
       "properties": {
         "content": {
-          "description": "Specifies the content of the provenance file inline within the document",
+          "description": "Specifies the InRelease file inline within the document",
           "type": "string",
           "format": "byte",
           "writeOnly": true
         },
-        "signature": {
-          "description": "Information about the included signature in the provenance file",
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the InRelease file",
           "type": "object",
           "required": [
-            "content"
+            "algorithm",
+            "value"
           ],
           "properties": {
-            "content": {
-              "description": "Specifies the signature embedded within the provenance file ",
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
               "type": "string",
-              "format": "byte",
-              "readOnly": true
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the InRelease file",
+              "type": "string"
             }
+          }
+        },
+        "packagesIndices": {
+          "description": "SHA256 hashes of the Packages indices listed in the InRelease file, keyed by their path",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
           },
           "readOnly": true
         }
       }
     },
-    "HelmV001SchemaChartProvenanceSignature": {
-      "description": "Information about the included signature in the provenance file",
+    "DebV001SchemaReleaseHash": {
+      "description": "Specifies the hash algorithm and value for the InRelease file",
       "type": "object",
       "required": [
-        "content"
+        "algorithm",
+        "value"
       ],
       "properties": {
-        "content": {
-          "description": "Specifies the signature embedded within the provenance file ",
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
           "type": "string",
-          "format": "byte",
-          "readOnly": true
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the InRelease file",
+          "type": "string"
         }
-      },
-      "readOnly": true
+      }
     },
-    "HelmV001SchemaPublicKey": {
-      "description": "The public key that can verify the package signature",
+    "Error": {
       "type": "object",
-      "required": [
-        "content"
-      ],
       "properties": {
-        "content": {
-          "description": "Specifies the content of the public key inline within the document",
-          "type": "string",
-          "format": "byte"
+        "code": {
+          "type": "integer"
+        },
+        "message": {
+          "type": "string"
         }
       }
     },
-    "InactiveShardLogInfo": {
+    "GitV001SchemaObject": {
+      "description": "Information about the signed commit or tag",
       "type": "object",
-      "required": [
-        "rootHash",
-        "treeSize",
-        "signedTreeHead",
-        "treeID"
-      ],
       "properties": {
-        "rootHash": {
-          "description": "The current hash value stored at the root of the merkle tree",
+        "committer": {
+          "description": "The email address of the committer of the commit, or the tagger of the tag",
           "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
+          "readOnly": true
         },
-        "signedTreeHead": {
-          "description": "The current signed tree head",
+        "content": {
+          "description": "Specifies the raw commit or tag object, including its signature, inline within the document",
           "type": "string",
-          "format": "signedCheckpoint"
+          "format": "byte",
+          "writeOnly": true
         },
-        "treeID": {
-          "description": "The current treeID",
+        "id": {
+          "description": "The SHA1 object ID of the commit or tag",
           "type": "string",
-          "pattern": "^[0-9]+$"
-        },
-        "treeSize": {
-          "description": "The current number of nodes in the merkle tree",
-          "type": "integer",
-          "minimum": 1
-        }
-      }
-    },
-    "InclusionProof": {
-      "type": "object",
-      "required": [
-        "logIndex",
-        "rootHash",
-        "treeSize",
-        "hashes"
-      ],
-      "properties": {
-        "hashes": {
-          "description": "A list of hashes required to compute the inclusion proof, sorted in order from leaf to root",
-          "type": "array",
-          "items": {
-            "description": "SHA256 hash value expressed in hexadecimal format",
-            "type": "string",
-            "pattern": "^[0-9a-fA-F]{64}$"
-          }
-        },
-        "logIndex": {
-          "description": "The index of the entry in the transparency log",
-          "type": "integer",
-          "minimum": 0
+          "pattern": "^[0-9a-f]{40}$",
+          "readOnly": true
         },
-        "rootHash": {
-          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
+        "treeId": {
+          "description": "The SHA1 object ID of the tree of the commit",
           "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
+          "pattern": "^[0-9a-f]{40}$",
+          "readOnly": true
         },
-        "treeSize": {
-          "description": "The size of the merkle tree at the time the inclusion proof was generated",
-          "type": "integer",
-          "minimum": 1
+        "type": {
+          "description": "The type of the git object",
+          "type": "string",
+          "enum": [
+            "commit",
+            "tag"
+          ],
+          "readOnly": true
         }
       }
     },
-    "IntotoV001SchemaContent": {
+    "GitV001SchemaSignature": {
+      "description": "Information about the signature embedded in the object",
       "type": "object",
       "properties": {
-        "envelope": {
-          "description": "envelope",
+        "content": {
+          "description": "Specifies the content of the signature",
           "type": "string",
-          "writeOnly": true
+          "format": "byte",
+          "readOnly": true
         },
-        "hash": {
-          "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
-          "type": "object",
-          "required": [
-            "algorithm",
-            "value"
+        "format": {
+          "description": "Specifies the type of signature",
+          "type": "string",
+          "enum": [
+            "pgp",
+            "ssh",
+            "x509"
           ],
-          "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
-              "type": "string",
-              "enum": [
-                "sha256"
-              ]
-            },
-            "value": {
-              "description": "The hash value for the archive",
-              "type": "string"
-            }
-          },
           "readOnly": true
         },
-        "payloadHash": {
-          "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
+        "publicKey": {
+          "description": "The public key or certificate that can verify the signature; required for pgp and ssh signatures",
           "type": "object",
           "required": [
-            "algorithm",
-            "value"
+            "content"
           ],
           "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
+            "content": {
+              "description": "Specifies the content of the public key or certificate inline within the document",
               "type": "string",
-              "enum": [
-                "sha256"
-              ]
-            },
-            "value": {
-              "description": "The hash value for the envelope's payload",
-              "type": "string"
+              "format": "byte"
             }
-          },
-          "readOnly": true
+          }
         }
       }
     },
-    "IntotoV001SchemaContentHash": {
-      "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+    "GitV001SchemaSignaturePublicKey": {
+      "description": "The public key or certificate that can verify the signature; required for pgp and ssh signatures",
       "type": "object",
       "required": [
-        "algorithm",
-        "value"
+        "content"
       ],
       "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
+        "content": {
+          "description": "Specifies the content of the public key or certificate inline within the document",
           "type": "string",
-          "enum": [
-            "sha256"
-          ]
-        },
-        "value": {
-          "description": "The hash value for the archive",
-          "type": "string"
-        }
-      },
-      "readOnly": true
-    },
-    "IntotoV001SchemaContentPayloadHash": {
-      "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
-      "type": "object",
-      "required": [
-        "algorithm",
-        "value"
-      ],
-      "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
-          "type": "string",
-          "enum": [
-            "sha256"
-          ]
-        },
-        "value": {
-          "description": "The hash value for the envelope's payload",
-          "type": "string"
-        }
-      },
-      "readOnly": true
-    },
-    "IntotoV002SchemaContent": {
-      "type": "object",
-      "properties": {
-        "envelope": {
-          "description": "dsse envelope",
-          "type": "object",
-          "required": [
-            "payloadType",
-            "signatures"
-          ],
-          "properties": {
-            "payload": {
-              "description": "payload of the envelope",
-              "type": "string",
-              "format": "byte",
-              "writeOnly": true
-            },
-            "payloadType": {
-              "description": "type describing the payload",
-              "type": "string"
-            },
-            "signatures": {
-              "description": "collection of all signatures of the envelope's payload",
-              "type": "array",
-              "minItems": 1,
-              "items": {
-                "$ref": "#/definitions/IntotoV002SchemaContentEnvelopeSignaturesItems0"
-              }
-            }
-          }
-        },
-        "hash": {
-          "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
-          "type": "object",
-          "required": [
-            "algorithm",
-            "value"
-          ],
-          "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
-              "type": "string",
-              "enum": [
-                "sha256"
-              ]
-            },
-            "value": {
-              "description": "The hash value for the archive",
-              "type": "string"
-            }
-          },
-          "readOnly": true
-        },
-        "payloadHash": {
-          "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
-          "type": "object",
-          "required": [
-            "algorithm",
-            "value"
-          ],
-          "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
-              "type": "string",
-              "enum": [
-                "sha256"
-              ]
-            },
-            "value": {
-              "description": "The hash value of the payload",
-              "type": "string"
-            }
-          },
-          "readOnly": true
+          "format": "byte"
         }
       }
     },
-    "IntotoV002SchemaContentEnvelope": {
-      "description": "dsse envelope",
+    "GomodV001SchemaModule": {
+      "description": "Information about the version of the Go module associated with the entry",
       "type": "object",
       "required": [
-        "payloadType",
-        "signatures"
+        "path",
+        "version",
+        "zipHash",
+        "modHash"
       ],
       "properties": {
-        "payload": {
-          "description": "payload of the envelope",
+        "modHash": {
+          "description": "The h1: hash of the go.mod file of the module, as found in go.sum",
           "type": "string",
-          "format": "byte",
-          "writeOnly": true
+          "pattern": "^h1:[A-Za-z0-9+/]{43}=$"
         },
-        "payloadType": {
-          "description": "type describing the payload",
+        "path": {
+          "description": "The module path",
           "type": "string"
         },
-        "signatures": {
-          "description": "collection of all signatures of the envelope's payload",
-          "type": "array",
-          "minItems": 1,
-          "items": {
-            "$ref": "#/definitions/IntotoV002SchemaContentEnvelopeSignaturesItems0"
-          }
-        }
-      }
-    },
-    "IntotoV002SchemaContentEnvelopeSignaturesItems0": {
-      "description": "a signature of the envelope's payload along with the public key for the signature",
-      "type": "object",
-      "properties": {
-        "keyid": {
-          "description": "optional id of the key used to create the signature",
+        "version": {
+          "description": "The canonical semantic version of the module",
           "type": "string"
         },
-        "publicKey": {
-          "description": "public key that corresponds to this signature",
-          "type": "string",
-          "format": "byte",
-          "readOnly": true
-        },
-        "sig": {
-          "description": "signature of the payload",
+        "zipHash": {
+          "description": "The h1: hash of the module zip file, as found in go.sum",
           "type": "string",
-          "format": "byte"
+          "pattern": "^h1:[A-Za-z0-9+/]{43}=$"
         }
       }
     },
-    "IntotoV002SchemaContentHash": {
-      "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+    "GomodV001SchemaSignature": {
+      "description": "Information about the detached signature over the go.sum lines of the module",
       "type": "object",
       "required": [
-        "algorithm",
-        "value"
+        "format",
+        "content",
+        "publicKey"
       ],
       "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the type of signature",
           "type": "string",
           "enum": [
-            "sha256"
+            "pgp",
+            "minisign",
+            "x509",
+            "ssh"
           ]
         },
-        "value": {
-          "description": "The hash value for the archive",
-          "type": "string"
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            }
+          }
         }
-      },
-      "readOnly": true
+      }
     },
-    "IntotoV002SchemaContentPayloadHash": {
-      "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
+    "GomodV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
       "type": "object",
       "required": [
-        "algorithm",
-        "value"
+        "content"
       ],
       "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
           "type": "string",
-          "enum": [
-            "sha256"
-          ]
-        },
-        "value": {
-          "description": "The hash value of the payload",
-          "type": "string"
+          "format": "byte"
         }
-      },
-      "readOnly": true
+      }
     },
-    "JarV001SchemaArchive": {
-      "description": "Information about the archive associated with the entry",
+    "HashedrekordV001SchemaData": {
+      "description": "Information about the content associated with the entry",
       "type": "object",
-      "oneOf": [
-        {
-          "required": [
-            "hash"
-          ]
-        },
-        {
-          "required": [
-            "content"
-          ]
-        }
-      ],
       "properties": {
-        "content": {
-          "description": "Specifies the archive inline within the document",
-          "type": "string",
-          "format": "byte",
-          "writeOnly": true
-        },
         "hash": {
-          "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
+          "description": "Specifies the hash algorithm and value for the content",
           "type": "object",
           "required": [
             "algorithm",
@@ -2166,15 +2721,15 @@ func init() {
               ]
             },
             "value": {
-              "description": "The hash value for the archive",
+              "description": "The hash value for the content",
               "type": "string"
             }
           }
         }
       }
     },
-    "JarV001SchemaArchiveHash": {
-      "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
+    "HashedrekordV001SchemaDataHash": {
+      "description": "Specifies the hash algorithm and value for the content",
       "type": "object",
       "required": [
         "algorithm",
@@ -2189,135 +2744,208 @@ func init() {
           ]
         },
         "value": {
-          "description": "The hash value for the archive",
+          "description": "The hash value for the content",
           "type": "string"
         }
       }
     },
-    "JarV001SchemaSignature": {
-      "description": "Information about the included signature in the JAR file",
+    "HashedrekordV001SchemaSignature": {
+      "description": "Information about the detached signature associated with the entry",
       "type": "object",
-      "required": [
-        "publicKey",
-        "content"
-      ],
       "properties": {
         "content": {
-          "description": "Specifies the PKCS7 signature embedded within the JAR file ",
+          "description": "Specifies the content of the signature inline within the document",
           "type": "string",
-          "format": "byte",
-          "readOnly": true
+          "format": "byte"
         },
         "publicKey": {
-          "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
+          "description": "The public key that can verify the signature; this can also be an X509 code signing certificate that contains the raw public key information",
           "type": "object",
-          "required": [
-            "content"
-          ],
           "properties": {
             "content": {
-              "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+              "description": "Specifies the content of the public key or code signing certificate inline within the document",
               "type": "string",
               "format": "byte"
             }
-          },
-          "readOnly": true
+          }
         }
       }
     },
-    "JarV001SchemaSignaturePublicKey": {
-      "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
+    "HashedrekordV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature; this can also be an X509 code signing certificate that contains the raw public key information",
       "type": "object",
-      "required": [
-        "content"
-      ],
       "properties": {
         "content": {
-          "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+          "description": "Specifies the content of the public key or code signing certificate inline within the document",
           "type": "string",
           "format": "byte"
         }
-      },
-      "readOnly": true
-    },
-    "LogEntry": {
-      "type": "object",
-      "additionalProperties": {
-        "$ref": "#/definitions/LogEntryAnon"
       }
     },
-    "LogEntryAnon": {
+    "HelmV001SchemaChart": {
+      "description": "Information about the Helm chart associated with the entry",
       "type": "object",
       "required": [
-        "logID",
-        "logIndex",
-        "body",
-        "integratedTime"
+        "provenance"
       ],
       "properties": {
-        "attestation": {
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the chart",
           "type": "object",
-          "format": "byte",
+          "required": [
+            "algorithm",
+            "value"
+          ],
           "properties": {
-            "data": {
-              "format": "byte"
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the chart",
+              "type": "string"
             }
-          }
+          },
+          "readOnly": true
         },
-        "body": {
+        "provenance": {
+          "description": "The provenance entry associated with the signed Helm Chart",
           "type": "object",
-          "additionalProperties": true
+          "oneOf": [
+            {
+              "required": [
+                "signature"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the provenance file inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "signature": {
+              "description": "Information about the included signature in the provenance file",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the signature embedded within the provenance file ",
+                  "type": "string",
+                  "format": "byte",
+                  "readOnly": true
+                }
+              },
+              "readOnly": true
+            }
+          }
+        }
+      }
+    },
+    "HelmV001SchemaChartHash": {
+      "description": "Specifies the hash algorithm and value for the chart",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
         },
-        "integratedTime": {
-          "type": "integer"
+        "value": {
+          "description": "The hash value for the chart",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "HelmV001SchemaChartProvenance": {
+      "description": "The provenance entry associated with the signed Helm Chart",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "signature"
+          ]
         },
-        "logID": {
-          "description": "This is the SHA256 hash of the DER-encoded public key for the log at the time the entry was included in the log",
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the provenance file inline within the document",
           "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
-        },
-        "logIndex": {
-          "type": "integer",
-          "minimum": 0
+          "format": "byte",
+          "writeOnly": true
         },
-        "verification": {
+        "signature": {
+          "description": "Information about the included signature in the provenance file",
           "type": "object",
+          "required": [
+            "content"
+          ],
           "properties": {
-            "inclusionProof": {
-              "$ref": "#/definitions/InclusionProof"
-            },
-            "signedEntryTimestamp": {
-              "description": "Signature over the logID, logIndex, body and integratedTime.",
+            "content": {
+              "description": "Specifies the signature embedded within the provenance file ",
               "type": "string",
-              "format": "byte"
+              "format": "byte",
+              "readOnly": true
             }
-          }
+          },
+          "readOnly": true
         }
       }
     },
-    "LogEntryAnonAttestation": {
+    "HelmV001SchemaChartProvenanceSignature": {
+      "description": "Information about the included signature in the provenance file",
       "type": "object",
-      "format": "byte",
+      "required": [
+        "content"
+      ],
       "properties": {
-        "data": {
-          "format": "byte"
+        "content": {
+          "description": "Specifies the signature embedded within the provenance file ",
+          "type": "string",
+          "format": "byte",
+          "readOnly": true
         }
-      }
+      },
+      "readOnly": true
     },
-    "LogEntryAnonVerification": {
+    "HelmV001SchemaPublicKey": {
+      "description": "The public key that can verify the package signature",
       "type": "object",
+      "required": [
+        "content"
+      ],
       "properties": {
-        "inclusionProof": {
-          "$ref": "#/definitions/InclusionProof"
-        },
-        "signedEntryTimestamp": {
-          "description": "Signature over the logID, logIndex, body and integratedTime.",
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
           "type": "string",
           "format": "byte"
         }
       }
     },
-    "LogInfo": {
+    "InactiveShardLogInfo": {
       "type": "object",
       "required": [
         "rootHash",
@@ -2326,12 +2954,6 @@ func init() {
         "treeID"
       ],
       "properties": {
-        "inactiveShards": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/InactiveShardLogInfo"
-          }
-        },
         "rootHash": {
           "description": "The current hash value stored at the root of the merkle tree",
           "type": "string",
@@ -2354,65 +2976,73 @@ func init() {
         }
       }
     },
-    "ProposedEntry": {
-      "type": "object",
-      "required": [
-        "kind"
-      ],
-      "properties": {
-        "kind": {
-          "type": "string"
-        }
-      },
-      "discriminator": "kind"
-    },
-    "RekorVersion": {
+    "InclusionProof": {
       "type": "object",
       "required": [
-        "version",
-        "commit",
-        "treestate",
-        "builddate"
+        "logIndex",
+        "rootHash",
+        "treeSize",
+        "hashes"
       ],
       "properties": {
-        "builddate": {
-          "type": "string"
+        "hashes": {
+          "description": "A list of hashes required to compute the inclusion proof, sorted in order from leaf to root",
+          "type": "array",
+          "items": {
+            "description": "SHA256 hash value expressed in hexadecimal format",
+            "type": "string",
+            "pattern": "^[0-9a-fA-F]{64}$"
+          }
         },
-        "commit": {
-          "type": "string"
+        "logIndex": {
+          "description": "The index of the entry in the transparency log",
+          "type": "integer",
+          "minimum": 0
         },
-        "treestate": {
-          "type": "string"
+        "rootHash": {
+          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
         },
-        "version": {
-          "type": "string"
+        "treeSize": {
+          "description": "The size of the merkle tree at the time the inclusion proof was generated",
+          "type": "integer",
+          "minimum": 1
         }
       }
     },
-    "RekordV001SchemaData": {
-      "description": "Information about the content associated with the entry",
+    "IntotoV001SchemaContent": {
       "type": "object",
-      "oneOf": [
-        {
-          "required": [
-            "hash"
-          ]
+      "properties": {
+        "envelope": {
+          "description": "envelope",
+          "type": "string",
+          "writeOnly": true
         },
-        {
+        "hash": {
+          "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+          "type": "object",
           "required": [
-            "content"
-          ]
-        }
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the content inline within the document",
-          "type": "string",
-          "format": "byte",
-          "writeOnly": true
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the archive",
+              "type": "string"
+            }
+          },
+          "readOnly": true
         },
-        "hash": {
-          "description": "Specifies the hash algorithm and value for the content",
+        "payloadHash": {
+          "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
           "type": "object",
           "required": [
             "algorithm",
@@ -2427,7 +3057,7 @@ func init() {
               ]
             },
             "value": {
-              "description": "The hash value for the content",
+              "description": "The hash value for the envelope's payload",
               "type": "string"
             }
           },
@@ -2435,8 +3065,8 @@ func init() {
         }
       }
     },
-    "RekordV001SchemaDataHash": {
-      "description": "Specifies the hash algorithm and value for the content",
+    "IntotoV001SchemaContentHash": {
+      "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
       "type": "object",
       "required": [
         "algorithm",
@@ -2451,82 +3081,206 @@ func init() {
           ]
         },
         "value": {
-          "description": "The hash value for the content",
+          "description": "The hash value for the archive",
           "type": "string"
         }
       },
       "readOnly": true
     },
-    "RekordV001SchemaSignature": {
-      "description": "Information about the detached signature associated with the entry",
+    "IntotoV001SchemaContentPayloadHash": {
+      "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
       "type": "object",
       "required": [
-        "format",
-        "publicKey",
-        "content"
+        "algorithm",
+        "value"
       ],
       "properties": {
-        "content": {
-          "description": "Specifies the content of the signature inline within the document",
-          "type": "string",
-          "format": "byte"
-        },
-        "format": {
-          "description": "Specifies the format of the signature",
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
           "type": "string",
           "enum": [
-            "pgp",
-            "minisign",
-            "x509",
-            "ssh"
+            "sha256"
           ]
         },
-        "publicKey": {
-          "description": "The public key that can verify the signature",
+        "value": {
+          "description": "The hash value for the envelope's payload",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "IntotoV002SchemaContent": {
+      "type": "object",
+      "properties": {
+        "envelope": {
+          "description": "dsse envelope",
           "type": "object",
           "required": [
-            "content"
+            "payloadType",
+            "signatures"
           ],
           "properties": {
-            "content": {
-              "description": "Specifies the content of the public key inline within the document",
+            "payload": {
+              "description": "payload of the envelope",
               "type": "string",
-              "format": "byte"
+              "format": "byte",
+              "writeOnly": true
+            },
+            "payloadType": {
+              "description": "type describing the payload",
+              "type": "string"
+            },
+            "signatures": {
+              "description": "collection of all signatures of the envelope's payload",
+              "type": "array",
+              "minItems": 1,
+              "items": {
+                "$ref": "#/definitions/IntotoV002SchemaContentEnvelopeSignaturesItems0"
+              }
             }
           }
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the archive",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        },
+        "payloadHash": {
+          "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value of the payload",
+              "type": "string"
+            }
+          },
+          "readOnly": true
         }
       }
     },
-    "RekordV001SchemaSignaturePublicKey": {
-      "description": "The public key that can verify the signature",
+    "IntotoV002SchemaContentEnvelope": {
+      "description": "dsse envelope",
       "type": "object",
       "required": [
-        "content"
+        "payloadType",
+        "signatures"
       ],
       "properties": {
-        "content": {
-          "description": "Specifies the content of the public key inline within the document",
+        "payload": {
+          "description": "payload of the envelope",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "payloadType": {
+          "description": "type describing the payload",
+          "type": "string"
+        },
+        "signatures": {
+          "description": "collection of all signatures of the envelope's payload",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/IntotoV002SchemaContentEnvelopeSignaturesItems0"
+          }
+        }
+      }
+    },
+    "IntotoV002SchemaContentEnvelopeSignaturesItems0": {
+      "description": "a signature of the envelope's payload along with the public key for the signature",
+      "type": "object",
+      "properties": {
+        "keyid": {
+          "description": "optional id of the key used to create the signature",
+          "type": "string"
+        },
+        "publicKey": {
+          "description": "public key that corresponds to this signature",
+          "type": "string",
+          "format": "byte",
+          "readOnly": true
+        },
+        "sig": {
+          "description": "signature of the payload",
           "type": "string",
           "format": "byte"
         }
       }
     },
-    "Rfc3161V001SchemaTsr": {
-      "description": "Information about the tsr file associated with the entry",
+    "IntotoV002SchemaContentHash": {
+      "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
       "type": "object",
       "required": [
-        "content"
+        "algorithm",
+        "value"
       ],
       "properties": {
-        "content": {
-          "description": "Specifies the tsr file content inline within the document",
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
           "type": "string",
-          "format": "byte"
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the archive",
+          "type": "string"
         }
-      }
+      },
+      "readOnly": true
     },
-    "RpmV001SchemaPackage": {
-      "description": "Information about the package associated with the entry",
+    "IntotoV002SchemaContentPayloadHash": {
+      "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value of the payload",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "JarV001SchemaArchive": {
+      "description": "Information about the archive associated with the entry",
       "type": "object",
       "oneOf": [
         {
@@ -2542,13 +3296,13 @@ func init() {
       ],
       "properties": {
         "content": {
-          "description": "Specifies the package inline within the document",
+          "description": "Specifies the archive inline within the document",
           "type": "string",
           "format": "byte",
           "writeOnly": true
         },
         "hash": {
-          "description": "Specifies the hash algorithm and value for the package",
+          "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
           "type": "object",
           "required": [
             "algorithm",
@@ -2563,23 +3317,15 @@ func init() {
               ]
             },
             "value": {
-              "description": "The hash value for the package",
+              "description": "The hash value for the archive",
               "type": "string"
             }
           }
-        },
-        "headers": {
-          "description": "Values of the RPM headers",
-          "type": "object",
-          "additionalProperties": {
-            "type": "string"
-          },
-          "readOnly": true
         }
       }
     },
-    "RpmV001SchemaPackageHash": {
-      "description": "Specifies the hash algorithm and value for the package",
+    "JarV001SchemaArchiveHash": {
+      "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
       "type": "object",
       "required": [
         "algorithm",
@@ -2594,158 +3340,2351 @@ func init() {
           ]
         },
         "value": {
-          "description": "The hash value for the package",
+          "description": "The hash value for the archive",
           "type": "string"
         }
       }
     },
-    "RpmV001SchemaPublicKey": {
-      "description": "The PGP public key that can verify the RPM signature",
+    "JarV001SchemaSignature": {
+      "description": "Information about the included signature in the JAR file",
+      "type": "object",
+      "required": [
+        "publicKey",
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the PKCS7 signature embedded within the JAR file ",
+          "type": "string",
+          "format": "byte",
+          "readOnly": true
+        },
+        "publicKey": {
+          "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+              "type": "string",
+              "format": "byte"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "JarV001SchemaSignaturePublicKey": {
+      "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+          "type": "string",
+          "format": "byte"
+        }
+      },
+      "readOnly": true
+    },
+    "LogEntry": {
+      "type": "object",
+      "additionalProperties": {
+        "$ref": "#/definitions/LogEntryAnon"
+      }
+    },
+    "LogEntryAnon": {
+      "type": "object",
+      "required": [
+        "logID",
+        "logIndex",
+        "body",
+        "integratedTime"
+      ],
+      "properties": {
+        "attestation": {
+          "type": "object",
+          "format": "byte",
+          "properties": {
+            "data": {
+              "format": "byte"
+            }
+          }
+        },
+        "body": {
+          "type": "object",
+          "additionalProperties": true
+        },
+        "integratedTime": {
+          "type": "integer"
+        },
+        "logID": {
+          "description": "This is the SHA256 hash of the DER-encoded public key for the log at the time the entry was included in the log",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "logIndex": {
+          "type": "integer",
+          "minimum": 0
+        },
+        "verification": {
+          "type": "object",
+          "properties": {
+            "inclusionProof": {
+              "$ref": "#/definitions/InclusionProof"
+            },
+            "signedEntryTimestamp": {
+              "description": "Signature over the logID, logIndex, body and integratedTime.",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      }
+    },
+    "LogEntryAnonAttestation": {
+      "type": "object",
+      "format": "byte",
+      "properties": {
+        "data": {
+          "format": "byte"
+        }
+      }
+    },
+    "LogEntryAnonVerification": {
+      "type": "object",
+      "properties": {
+        "inclusionProof": {
+          "$ref": "#/definitions/InclusionProof"
+        },
+        "signedEntryTimestamp": {
+          "description": "Signature over the logID, logIndex, body and integratedTime.",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "LogInfo": {
+      "type": "object",
+      "required": [
+        "rootHash",
+        "treeSize",
+        "signedTreeHead",
+        "treeID"
+      ],
+      "properties": {
+        "checkpointTimestamp": {
+          "$ref": "#/definitions/CheckpointTimestamp"
+        },
+        "inactiveShards": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/InactiveShardLogInfo"
+          }
+        },
+        "rootHash": {
+          "description": "The current hash value stored at the root of the merkle tree",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "signedTreeHead": {
+          "description": "The current signed tree head",
+          "type": "string",
+          "format": "signedCheckpoint"
+        },
+        "treeID": {
+          "description": "The current treeID",
+          "type": "string",
+          "pattern": "^[0-9]+$"
+        },
+        "treeSize": {
+          "description": "The current number of nodes in the merkle tree",
+          "type": "integer",
+          "minimum": 1
+        }
+      }
+    },
+    "NpmV001SchemaPackage": {
+      "description": "The npm package version associated with the entry",
+      "type": "object",
+      "required": [
+        "name",
+        "version",
+        "integrity"
+      ],
+      "properties": {
+        "integrity": {
+          "description": "The subresource integrity string of the SHA512 hash of the package tarball",
+          "type": "string",
+          "pattern": "^sha512-[A-Za-z0-9+/]{86}==$"
+        },
+        "name": {
+          "description": "The name of the package, including its scope",
+          "type": "string"
+        },
+        "version": {
+          "description": "The version of the package",
+          "type": "string"
+        }
+      }
+    },
+    "NpmV001SchemaProvenance": {
+      "description": "The Sigstore bundle holding the provenance of the package",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "Specifies the bundle inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the bundle",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the bundle",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "NpmV001SchemaProvenanceHash": {
+      "description": "Specifies the hash algorithm and value for the bundle",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the bundle",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "NpmV001SchemaSignature": {
+      "description": "The signature over the package, and the public key or certificate that verifies it",
+      "type": "object",
+      "required": [
+        "content",
+        "publicKey"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "publicKey": {
+          "description": "The public key or certificate that can verify the signature",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key or certificate inline within the document",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      }
+    },
+    "NpmV001SchemaSignaturePublicKey": {
+      "description": "The public key or certificate that can verify the signature",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key or certificate inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "OCIV001SchemaImage": {
+      "description": "The image the payload was signed for",
+      "type": "object",
+      "properties": {
+        "digest": {
+          "description": "The digest of the image manifest",
+          "type": "string",
+          "pattern": "^sha256:[0-9a-f]{64}$"
+        },
+        "reference": {
+          "description": "The repository reference of the image, which is taken from simple signing payloads; it cannot be provided for image manifests, as their signature does not cover it",
+          "type": "string"
+        }
+      }
+    },
+    "OCIV001SchemaPayload": {
+      "description": "Information about the signed payload, which is either a simple signing payload or an image manifest",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the payload inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the payload",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the payload",
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "OCIV001SchemaPayloadHash": {
+      "description": "Specifies the hash algorithm and value for the payload",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the payload",
+          "type": "string"
+        }
+      }
+    },
+    "OCIV001SchemaSignature": {
+      "description": "Information about the signature over the payload",
+      "type": "object",
+      "required": [
+        "content",
+        "publicKey"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature; this can also be an X509 code signing certificate that contains the raw public key information",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key or code signing certificate inline within the document",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      }
+    },
+    "OCIV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature; this can also be an X509 code signing certificate that contains the raw public key information",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key or code signing certificate inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "PEV001SchemaImage": {
+      "description": "Information about the PE file associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "hash"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the PE file inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "digest": {
+          "description": "Specifies the Authenticode digest of the PE file that is signed by the signature",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the Authenticode digest",
+              "type": "string",
+              "enum": [
+                "sha1",
+                "sha256",
+                "sha384",
+                "sha512"
+              ]
+            },
+            "value": {
+              "description": "The Authenticode digest of the PE file",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value encompassing the entire signed PE file",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the PE file",
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "PEV001SchemaImageDigest": {
+      "description": "Specifies the Authenticode digest of the PE file that is signed by the signature",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the Authenticode digest",
+          "type": "string",
+          "enum": [
+            "sha1",
+            "sha256",
+            "sha384",
+            "sha512"
+          ]
+        },
+        "value": {
+          "description": "The Authenticode digest of the PE file",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "PEV001SchemaImageHash": {
+      "description": "Specifies the hash algorithm and value encompassing the entire signed PE file",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the PE file",
+          "type": "string"
+        }
+      }
+    },
+    "PEV001SchemaSignature": {
+      "description": "Information about the Authenticode signature embedded in the PE file",
+      "type": "object",
+      "required": [
+        "publicKey",
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the PKCS7 signature extracted from the certificate table of the PE file",
+          "type": "string",
+          "format": "byte",
+          "readOnly": true
+        },
+        "publicKey": {
+          "description": "The X509 certificate of the signer of the PE file",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+              "type": "string",
+              "format": "byte"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "PEV001SchemaSignaturePublicKey": {
+      "description": "The X509 certificate of the signer of the PE file",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+          "type": "string",
+          "format": "byte"
+        }
+      },
+      "readOnly": true
+    },
+    "ProposedEntry": {
+      "type": "object",
+      "required": [
+        "kind"
+      ],
+      "properties": {
+        "kind": {
+          "type": "string"
+        }
+      },
+      "discriminator": "kind"
+    },
+    "PypiV001SchemaPackage": {
+      "description": "Information about the wheel or sdist associated with the entry",
+      "type": "object",
+      "required": [
+        "filename"
+      ],
+      "oneOf": [
+        {
+          "required": [
+            "hash"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the distribution inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "filename": {
+          "description": "The filename of the wheel or sdist",
+          "type": "string"
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the distribution",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the distribution",
+              "type": "string"
+            }
+          }
+        },
+        "name": {
+          "description": "The normalized name of the project",
+          "type": "string",
+          "readOnly": true
+        },
+        "version": {
+          "description": "The version of the project",
+          "type": "string",
+          "readOnly": true
+        }
+      }
+    },
+    "PypiV001SchemaPackageHash": {
+      "description": "Specifies the hash algorithm and value for the distribution",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the distribution",
+          "type": "string"
+        }
+      }
+    },
+    "PypiV001SchemaSignature": {
+      "description": "Information about the detached signature over the distribution",
+      "type": "object",
+      "required": [
+        "format",
+        "content",
+        "publicKey"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the type of signature",
+          "type": "string",
+          "enum": [
+            "pgp",
+            "minisign",
+            "x509",
+            "ssh"
+          ]
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      }
+    },
+    "PypiV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "RekorVersion": {
+      "type": "object",
+      "required": [
+        "version",
+        "commit",
+        "treestate",
+        "builddate"
+      ],
+      "properties": {
+        "builddate": {
+          "type": "string"
+        },
+        "commit": {
+          "type": "string"
+        },
+        "treestate": {
+          "type": "string"
+        },
+        "version": {
+          "type": "string"
+        }
+      }
+    },
+    "RekordV001SchemaData": {
+      "description": "Information about the content associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "hash"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the content",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the content",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "RekordV001SchemaDataHash": {
+      "description": "Specifies the hash algorithm and value for the content",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the content",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "RekordV001SchemaSignature": {
+      "description": "Information about the detached signature associated with the entry",
+      "type": "object",
+      "required": [
+        "format",
+        "publicKey",
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the format of the signature",
+          "type": "string",
+          "enum": [
+            "pgp",
+            "minisign",
+            "x509",
+            "ssh"
+          ]
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      }
+    },
+    "RekordV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "Rfc3161V001SchemaTsr": {
+      "description": "Information about the tsr file associated with the entry",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the tsr file content inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "RpmV001SchemaPackage": {
+      "description": "Information about the package associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "hash"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the package inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the package",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the package",
+              "type": "string"
+            }
+          }
+        },
+        "headers": {
+          "description": "Values of the RPM headers",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "RpmV001SchemaPackageHash": {
+      "description": "Specifies the hash algorithm and value for the package",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the package",
+          "type": "string"
+        }
+      }
+    },
+    "RpmV001SchemaPublicKey": {
+      "description": "The PGP public key that can verify the RPM signature",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "SbomV001SchemaDocument": {
+      "description": "Information about the SBOM document associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "hash"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the document inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "format": {
+          "description": "The format of the document, which is only known when the document is provided",
+          "type": "string",
+          "enum": [
+            "spdx",
+            "cyclonedx"
+          ],
+          "readOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the document",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the document",
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "SbomV001SchemaDocumentHash": {
+      "description": "Specifies the hash algorithm and value for the document",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the document",
+          "type": "string"
+        }
+      }
+    },
+    "SbomV001SchemaSignature": {
+      "description": "Information about the detached signature over the document",
+      "type": "object",
+      "required": [
+        "format",
+        "content",
+        "publicKey"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the type of signature",
+          "type": "string",
+          "enum": [
+            "pgp",
+            "minisign",
+            "x509",
+            "ssh"
+          ]
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      }
+    },
+    "SbomV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "SearchIndex": {
+      "type": "object",
+      "properties": {
+        "email": {
+          "type": "string",
+          "format": "email"
+        },
+        "hash": {
+          "type": "string",
+          "pattern": "^(sha512:)?[0-9a-fA-F]{128}$|^(sha256:)?[0-9a-fA-F]{64}$|^(sha1:)?[0-9a-fA-F]{40}$"
+        },
+        "operator": {
+          "type": "string",
+          "enum": [
+            "and",
+            "or"
+          ]
+        },
+        "package": {
+          "type": "object",
+          "required": [
+            "type",
+            "name"
+          ],
+          "properties": {
+            "name": {
+              "description": "The name of the package, optionally with its version in the notation of its type, such as name@version for npm, path@version for gomod, name_version_architecture for deb or name==version for pypi, or the image reference for oci",
+              "type": "string",
+              "minLength": 1
+            },
+            "type": {
+              "description": "The kind of the entries that the package was logged in",
+              "type": "string",
+              "enum": [
+                "npm",
+                "gomod",
+                "oci",
+                "deb",
+                "pypi"
+              ]
+            }
+          }
+        },
+        "publicKey": {
+          "type": "object",
+          "required": [
+            "format"
+          ],
+          "properties": {
+            "content": {
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "type": "string",
+              "enum": [
+                "pgp",
+                "x509",
+                "minisign",
+                "ssh",
+                "tuf"
+              ]
+            },
+            "url": {
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        }
+      }
+    },
+    "SearchIndexPackage": {
+      "type": "object",
+      "required": [
+        "type",
+        "name"
+      ],
+      "properties": {
+        "name": {
+          "description": "The name of the package, optionally with its version in the notation of its type, such as name@version for npm, path@version for gomod, name_version_architecture for deb or name==version for pypi, or the image reference for oci",
+          "type": "string",
+          "minLength": 1
+        },
+        "type": {
+          "description": "The kind of the entries that the package was logged in",
+          "type": "string",
+          "enum": [
+            "npm",
+            "gomod",
+            "oci",
+            "deb",
+            "pypi"
+          ]
+        }
+      }
+    },
+    "SearchIndexPublicKey": {
+      "type": "object",
+      "required": [
+        "format"
+      ],
+      "properties": {
+        "content": {
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "type": "string",
+          "enum": [
+            "pgp",
+            "x509",
+            "minisign",
+            "ssh",
+            "tuf"
+          ]
+        },
+        "url": {
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "SearchLogQuery": {
+      "type": "object",
+      "properties": {
+        "entries": {
+          "type": "array",
+          "maxItems": 10,
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/ProposedEntry"
+          }
+        },
+        "entryUUIDs": {
+          "type": "array",
+          "maxItems": 10,
+          "minItems": 1,
+          "items": {
+            "type": "string",
+            "pattern": "^([0-9a-fA-F]{64}|[0-9a-fA-F]{80})$"
+          }
+        },
+        "logIndexes": {
+          "type": "array",
+          "maxItems": 10,
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "minimum": 0
+          }
+        }
+      }
+    },
+    "TUFV001SchemaMetadata": {
+      "description": "TUF metadata",
+      "type": "object",
+      "required": [
+        "metadata"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the metadata inline within the document",
+          "type": "object",
+          "additionalProperties": true
+        }
+      }
+    },
+    "TUFV001SchemaRoot": {
+      "description": "root metadata containing about the public keys used to sign the manifest",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the metadata inline within the document",
+          "type": "object",
+          "additionalProperties": true
+        }
+      }
+    },
+    "TimestampNoteRequest": {
+      "type": "object",
+      "required": [
+        "messageImprint",
+        "nonce"
+      ],
+      "properties": {
+        "messageImprint": {
+          "description": "The hash of the message to timestamp",
+          "type": "string",
+          "pattern": "^sha256:[0-9a-fA-F]{64}$"
+        },
+        "nonce": {
+          "description": "Random bytes, returned in the signed note to prove its freshness",
+          "type": "string",
+          "format": "byte",
+          "minLength": 1
+        }
+      }
+    },
+    "TimestampNoteResponse": {
+      "type": "object",
+      "required": [
+        "signedTimestampNote"
+      ],
+      "properties": {
+        "signedTimestampNote": {
+          "description": "The signed timestamp note",
+          "type": "string",
+          "format": "signedTimestampNote"
+        }
+      }
+    },
+    "alpine": {
+      "description": "Alpine package",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/alpineSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "alpineSchema": {
+      "description": "Schema for Alpine package objects",
+      "type": "object",
+      "title": "Alpine Package Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/alpineV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/alpine/alpine_schema.json"
+    },
+    "alpineV001Schema": {
+      "description": "Schema for Alpine Package entries",
+      "type": "object",
+      "title": "Alpine v0.0.1 Schema",
+      "required": [
+        "publicKey",
+        "package"
+      ],
+      "properties": {
+        "package": {
+          "description": "Information about the package associated with the entry",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "hash"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the package inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the package",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the package",
+                  "type": "string"
+                }
+              },
+              "readOnly": true
+            },
+            "pkginfo": {
+              "description": "Values of the .PKGINFO key / value pairs",
+              "type": "object",
+              "additionalProperties": {
+                "type": "string"
+              },
+              "readOnly": true
+            }
+          }
+        },
+        "publicKey": {
+          "description": "The public key that can verify the package signature",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/alpine/alpine_v0_0_1_schema.json"
+    },
+    "apk-android": {
+      "description": "Android application package signed with APK Signature Scheme v2 or v3",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/apkAndroidSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "apkAndroidSchema": {
+      "description": "Schema for Android application packages signed with APK Signature Scheme v2 or v3",
+      "type": "object",
+      "title": "Android APK Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/apkAndroidV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/apkandroid/apk_android_schema.json"
+    },
+    "apkAndroidV001Schema": {
+      "description": "Schema for Android APK entries",
+      "type": "object",
+      "title": "Android APK v0.0.1 Schema",
+      "required": [
+        "apk"
+      ],
+      "properties": {
+        "apk": {
+          "description": "Information about the APK associated with the entry",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "hash"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the APK inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value encompassing the entire signed APK",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the APK",
+                  "type": "string"
+                }
+              }
+            },
+            "packageName": {
+              "description": "The package name from the manifest of the APK",
+              "type": "string",
+              "readOnly": true
+            }
+          }
+        },
+        "signature": {
+          "description": "Information about the signer in the APK Signing Block of the APK",
+          "type": "object",
+          "required": [
+            "scheme",
+            "publicKey",
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the signer block of the scheme, which holds the signed data and the signatures over it",
+              "type": "string",
+              "format": "byte"
+            },
+            "publicKey": {
+              "description": "The X509 certificate of the signer of the APK",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+                  "type": "string",
+                  "format": "byte"
+                }
+              }
+            },
+            "scheme": {
+              "description": "The most recent APK Signature Scheme the APK is signed with",
+              "type": "string",
+              "enum": [
+                "v2",
+                "v3"
+              ]
+            }
+          },
+          "readOnly": true
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/apkandroid/apk_android_v0_0_1_schema.json"
+    },
+    "cose": {
+      "description": "COSE object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/coseSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "coseSchema": {
+      "description": "COSE for Rekord objects",
+      "type": "object",
+      "title": "COSE Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/coseV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/cose/cose_schema.json"
+    },
+    "coseV001Schema": {
+      "description": "Schema for cose object",
+      "type": "object",
+      "title": "cose v0.0.1 Schema",
+      "required": [
+        "publicKey",
+        "data"
+      ],
+      "properties": {
+        "data": {
+          "description": "Information about the content associated with the entry",
+          "type": "object",
+          "properties": {
+            "aad": {
+              "description": "Specifies the additional authenticated data required to verify the signature",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "envelopeHash": {
+              "description": "Specifies the hash algorithm and value for the COSE envelope",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the envelope",
+                  "type": "string"
+                }
+              },
+              "readOnly": true
+            },
+            "payloadHash": {
+              "description": "Specifies the hash algorithm and value for the content",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the content",
+                  "type": "string"
+                }
+              },
+              "readOnly": true
+            }
+          }
+        },
+        "message": {
+          "description": "The COSE Sign1 Message",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "string",
+          "format": "byte"
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/cose/cose_v0_0_1_schema.json"
+    },
+    "deb": {
+      "description": "Debian package or APT repository metadata",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/debSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "debSchema": {
+      "description": "Schema for Debian package and APT repository metadata objects",
+      "type": "object",
+      "title": "Debian Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/debV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/deb/deb_schema.json"
+    },
+    "debV001Schema": {
+      "description": "Schema for Debian package and APT repository metadata entries",
+      "type": "object",
+      "title": "Debian v0.0.1 Schema",
+      "required": [
+        "publicKey"
+      ],
+      "oneOf": [
+        {
+          "required": [
+            "package"
+          ]
+        },
+        {
+          "required": [
+            "release"
+          ]
+        }
+      ],
+      "properties": {
+        "package": {
+          "description": "Information about the Debian package associated with the entry",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "hash"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the package inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the package",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the package",
+                  "type": "string"
+                }
+              }
+            },
+            "headers": {
+              "description": "Values of the Package, Version and Architecture fields of the package's control file",
+              "type": "object",
+              "additionalProperties": {
+                "type": "string"
+              },
+              "readOnly": true
+            }
+          }
+        },
+        "publicKey": {
+          "description": "The PGP public key that can verify the package or repository metadata signature",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        },
+        "release": {
+          "description": "Information about the clearsigned InRelease file of an APT repository associated with the entry",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "hash"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the InRelease file inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the InRelease file",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the InRelease file",
+                  "type": "string"
+                }
+              }
+            },
+            "packagesIndices": {
+              "description": "SHA256 hashes of the Packages indices listed in the InRelease file, keyed by their path",
+              "type": "object",
+              "additionalProperties": {
+                "type": "string"
+              },
+              "readOnly": true
+            }
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/deb/deb_v0_0_1_schema.json"
+    },
+    "dsse": {
+      "description": "DSSE envelope",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/dsseSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "dsseSchema": {
+      "description": "log entry schema for dsse envelopes",
+      "type": "object",
+      "title": "DSSE Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/dsseV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/dsse/dsse_schema.json"
+    },
+    "dsseV001Schema": {
+      "description": "Schema for DSSE envelopes",
+      "type": "object",
+      "title": "DSSE v0.0.1 Schema",
+      "oneOf": [
+        {
+          "required": [
+            "proposedContent"
+          ]
+        },
+        {
+          "required": [
+            "signatures",
+            "envelopeHash",
+            "payloadHash"
+          ]
+        }
+      ],
+      "properties": {
+        "envelopeHash": {
+          "description": "Specifies the hash algorithm and value encompassing the entire envelope sent to Rekor",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The value of the computed digest over the entire envelope",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        },
+        "payloadHash": {
+          "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The value of the computed digest over the payload within the envelope",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        },
+        "payloadType": {
+          "description": "type describing the payload of the envelope",
+          "type": "string",
+          "readOnly": true
+        },
+        "proposedContent": {
+          "type": "object",
+          "required": [
+            "envelope",
+            "verifiers"
+          ],
+          "properties": {
+            "envelope": {
+              "description": "DSSE envelope specified as a stringified JSON object",
+              "type": "string",
+              "writeOnly": true
+            },
+            "verifiers": {
+              "description": "collection of all verification material (e.g. public keys or certificates) used to verify signatures over envelope's payload, specified as base64-encoded strings",
+              "type": "array",
+              "minItems": 1,
+              "items": {
+                "type": "string",
+                "format": "byte"
+              },
+              "writeOnly": true
+            }
+          },
+          "writeOnly": true
+        },
+        "signatures": {
+          "description": "extracted collection of all signatures of the envelope's payload; elements will be sorted by lexicographical order of the base64 encoded signature strings",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/DSSEV001SchemaSignaturesItems0"
+          },
+          "readOnly": true
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/dsse/dsse_v0_0_1_schema.json"
+    },
+    "git": {
+      "description": "Signed git commit or tag",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/gitSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "gitSchema": {
+      "description": "Schema for signed git objects",
+      "type": "object",
+      "title": "Git Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/gitV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/git/git_schema.json"
+    },
+    "gitV001Schema": {
+      "description": "Schema for signed git commit and tag entries",
+      "type": "object",
+      "title": "Git v0.0.1 Schema",
+      "required": [
+        "object"
+      ],
+      "properties": {
+        "object": {
+          "description": "Information about the signed commit or tag",
+          "type": "object",
+          "properties": {
+            "committer": {
+              "description": "The email address of the committer of the commit, or the tagger of the tag",
+              "type": "string",
+              "readOnly": true
+            },
+            "content": {
+              "description": "Specifies the raw commit or tag object, including its signature, inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "id": {
+              "description": "The SHA1 object ID of the commit or tag",
+              "type": "string",
+              "pattern": "^[0-9a-f]{40}$",
+              "readOnly": true
+            },
+            "treeId": {
+              "description": "The SHA1 object ID of the tree of the commit",
+              "type": "string",
+              "pattern": "^[0-9a-f]{40}$",
+              "readOnly": true
+            },
+            "type": {
+              "description": "The type of the git object",
+              "type": "string",
+              "enum": [
+                "commit",
+                "tag"
+              ],
+              "readOnly": true
+            }
+          }
+        },
+        "signature": {
+          "description": "Information about the signature embedded in the object",
+          "type": "object",
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature",
+              "type": "string",
+              "format": "byte",
+              "readOnly": true
+            },
+            "format": {
+              "description": "Specifies the type of signature",
+              "type": "string",
+              "enum": [
+                "pgp",
+                "ssh",
+                "x509"
+              ],
+              "readOnly": true
+            },
+            "publicKey": {
+              "description": "The public key or certificate that can verify the signature; required for pgp and ssh signatures",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key or certificate inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                }
+              }
+            }
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/git/git_v0_0_1_schema.json"
+    },
+    "gomod": {
+      "description": "Signed Go module checksums",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/gomodSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "gomodSchema": {
+      "description": "Schema for signed Go module checksums",
       "type": "object",
-      "required": [
-        "content"
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the content of the public key inline within the document",
-          "type": "string",
-          "format": "byte"
+      "title": "Go Module Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/gomodV001Schema"
         }
-      }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/gomod/gomod_schema.json"
     },
-    "SearchIndex": {
+    "gomodV001Schema": {
+      "description": "Schema for signed Go module checksum entries",
       "type": "object",
+      "title": "Go Module v0.0.1 Schema",
+      "required": [
+        "signature",
+        "module"
+      ],
       "properties": {
-        "email": {
-          "type": "string",
-          "format": "email"
-        },
-        "hash": {
-          "type": "string",
-          "pattern": "^(sha256:)?[0-9a-fA-F]{64}$|^(sha1:)?[0-9a-fA-F]{40}$"
-        },
-        "operator": {
-          "type": "string",
-          "enum": [
-            "and",
-            "or"
-          ]
+        "module": {
+          "description": "Information about the version of the Go module associated with the entry",
+          "type": "object",
+          "required": [
+            "path",
+            "version",
+            "zipHash",
+            "modHash"
+          ],
+          "properties": {
+            "modHash": {
+              "description": "The h1: hash of the go.mod file of the module, as found in go.sum",
+              "type": "string",
+              "pattern": "^h1:[A-Za-z0-9+/]{43}=$"
+            },
+            "path": {
+              "description": "The module path",
+              "type": "string"
+            },
+            "version": {
+              "description": "The canonical semantic version of the module",
+              "type": "string"
+            },
+            "zipHash": {
+              "description": "The h1: hash of the module zip file, as found in go.sum",
+              "type": "string",
+              "pattern": "^h1:[A-Za-z0-9+/]{43}=$"
+            }
+          }
         },
-        "publicKey": {
+        "signature": {
+          "description": "Information about the detached signature over the go.sum lines of the module",
           "type": "object",
           "required": [
-            "format"
+            "format",
+            "content",
+            "publicKey"
           ],
           "properties": {
             "content": {
+              "description": "Specifies the content of the signature inline within the document",
               "type": "string",
               "format": "byte"
             },
             "format": {
+              "description": "Specifies the type of signature",
               "type": "string",
               "enum": [
                 "pgp",
-                "x509",
                 "minisign",
-                "ssh",
-                "tuf"
+                "x509",
+                "ssh"
               ]
             },
-            "url": {
-              "type": "string",
-              "format": "uri"
+            "publicKey": {
+              "description": "The public key that can verify the signature",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                }
+              }
             }
           }
         }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/gomod/gomod_v0_0_1_schema.json"
     },
-    "SearchIndexPublicKey": {
+    "hashedrekord": {
+      "description": "Hashed Rekord object",
       "type": "object",
-      "required": [
-        "format"
-      ],
-      "properties": {
-        "content": {
-          "type": "string",
-          "format": "byte"
-        },
-        "format": {
-          "type": "string",
-          "enum": [
-            "pgp",
-            "x509",
-            "minisign",
-            "ssh",
-            "tuf"
-          ]
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
         },
-        "url": {
-          "type": "string",
-          "format": "uri"
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/hashedrekordSchema"
+            }
+          },
+          "additionalProperties": false
         }
-      }
+      ]
     },
-    "SearchLogQuery": {
+    "hashedrekordSchema": {
+      "description": "Schema for Rekord objects",
       "type": "object",
-      "properties": {
-        "entries": {
-          "type": "array",
-          "maxItems": 10,
-          "minItems": 1,
-          "items": {
-            "$ref": "#/definitions/ProposedEntry"
-          }
-        },
-        "entryUUIDs": {
-          "type": "array",
-          "maxItems": 10,
-          "minItems": 1,
-          "items": {
-            "type": "string",
-            "pattern": "^([0-9a-fA-F]{64}|[0-9a-fA-F]{80})$"
-          }
-        },
-        "logIndexes": {
-          "type": "array",
-          "maxItems": 10,
-          "minItems": 1,
-          "items": {
-            "type": "integer",
-            "minimum": 0
-          }
+      "title": "Rekor Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/hashedrekordV001Schema"
         }
-      }
-    },
-    "TUFV001SchemaMetadata": {
-      "description": "TUF metadata",
-      "type": "object",
-      "required": [
-        "metadata"
       ],
-      "properties": {
-        "content": {
-          "description": "Specifies the metadata inline within the document",
-          "type": "object",
-          "additionalProperties": true
-        }
-      }
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/hashedrekord/hasehedrekord_schema.json"
     },
-    "TUFV001SchemaRoot": {
-      "description": "root metadata containing about the public keys used to sign the manifest",
+    "hashedrekordV001Schema": {
+      "description": "Schema for Hashed Rekord object",
       "type": "object",
+      "title": "Hashed Rekor v0.0.1 Schema",
       "required": [
-        "content"
+        "signature",
+        "data"
       ],
       "properties": {
-        "content": {
-          "description": "Specifies the metadata inline within the document",
+        "data": {
+          "description": "Information about the content associated with the entry",
           "type": "object",
-          "additionalProperties": true
+          "properties": {
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the content",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the content",
+                  "type": "string"
+                }
+              }
+            }
+          }
+        },
+        "signature": {
+          "description": "Information about the detached signature associated with the entry",
+          "type": "object",
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "publicKey": {
+              "description": "The public key that can verify the signature; this can also be an X509 code signing certificate that contains the raw public key information",
+              "type": "object",
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key or code signing certificate inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                }
+              }
+            }
+          }
         }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/rekord/rekord_v0_0_1_schema.json"
     },
-    "alpine": {
-      "description": "Alpine package",
+    "helm": {
+      "description": "Helm chart",
       "type": "object",
       "allOf": [
         {
@@ -2762,58 +5701,42 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/alpineSchema"
+              "$ref": "#/definitions/helmSchema"
             }
-          },
-          "additionalProperties": false
+          }
         }
       ]
     },
-    "alpineSchema": {
-      "description": "Schema for Alpine package objects",
+    "helmSchema": {
+      "description": "Schema for Helm objects",
       "type": "object",
-      "title": "Alpine Package Schema",
+      "title": "Helm Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/alpineV001Schema"
+          "$ref": "#/definitions/helmV001Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/alpine/alpine_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/helm/helm_schema.json"
     },
-    "alpineV001Schema": {
-      "description": "Schema for Alpine Package entries",
+    "helmV001Schema": {
+      "description": "Schema for Helm object",
       "type": "object",
-      "title": "Alpine v0.0.1 Schema",
+      "title": "Helm v0.0.1 Schema",
       "required": [
         "publicKey",
-        "package"
+        "chart"
       ],
       "properties": {
-        "package": {
-          "description": "Information about the package associated with the entry",
+        "chart": {
+          "description": "Information about the Helm chart associated with the entry",
           "type": "object",
-          "oneOf": [
-            {
-              "required": [
-                "hash"
-              ]
-            },
-            {
-              "required": [
-                "content"
-              ]
-            }
+          "required": [
+            "provenance"
           ],
           "properties": {
-            "content": {
-              "description": "Specifies the package inline within the document",
-              "type": "string",
-              "format": "byte",
-              "writeOnly": true
-            },
             "hash": {
-              "description": "Specifies the hash algorithm and value for the package",
+              "description": "Specifies the hash algorithm and value for the chart",
               "type": "object",
               "required": [
                 "algorithm",
@@ -2828,19 +5751,51 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the package",
+                  "description": "The hash value for the chart",
                   "type": "string"
                 }
               },
               "readOnly": true
             },
-            "pkginfo": {
-              "description": "Values of the .PKGINFO key / value pairs",
+            "provenance": {
+              "description": "The provenance entry associated with the signed Helm Chart",
               "type": "object",
-              "additionalProperties": {
-                "type": "string"
-              },
-              "readOnly": true
+              "oneOf": [
+                {
+                  "required": [
+                    "signature"
+                  ]
+                },
+                {
+                  "required": [
+                    "content"
+                  ]
+                }
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the provenance file inline within the document",
+                  "type": "string",
+                  "format": "byte",
+                  "writeOnly": true
+                },
+                "signature": {
+                  "description": "Information about the included signature in the provenance file",
+                  "type": "object",
+                  "required": [
+                    "content"
+                  ],
+                  "properties": {
+                    "content": {
+                      "description": "Specifies the signature embedded within the provenance file ",
+                      "type": "string",
+                      "format": "byte",
+                      "readOnly": true
+                    }
+                  },
+                  "readOnly": true
+                }
+              }
             }
           }
         },
@@ -2860,10 +5815,10 @@ func init() {
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/alpine/alpine_v0_0_1_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/helm/helm_v0_0_1_schema.json"
     },
-    "cose": {
-      "description": "COSE object",
+    "intoto": {
+      "description": "Intoto object",
       "type": "object",
       "allOf": [
         {
@@ -2880,46 +5835,47 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/coseSchema"
+              "$ref": "#/definitions/intotoSchema"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "coseSchema": {
-      "description": "COSE for Rekord objects",
+    "intotoSchema": {
+      "description": "Intoto for Rekord objects",
       "type": "object",
-      "title": "COSE Schema",
+      "title": "Intoto Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/coseV001Schema"
+          "$ref": "#/definitions/intotoV001Schema"
+        },
+        {
+          "$ref": "#/definitions/intotoV002Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/cose/cose_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/intoto/intoto_schema.json"
     },
-    "coseV001Schema": {
-      "description": "Schema for cose object",
+    "intotoV001Schema": {
+      "description": "Schema for intoto object",
       "type": "object",
-      "title": "cose v0.0.1 Schema",
+      "title": "intoto v0.0.1 Schema",
       "required": [
         "publicKey",
-        "data"
+        "content"
       ],
       "properties": {
-        "data": {
-          "description": "Information about the content associated with the entry",
+        "content": {
           "type": "object",
           "properties": {
-            "aad": {
-              "description": "Specifies the additional authenticated data required to verify the signature",
+            "envelope": {
+              "description": "envelope",
               "type": "string",
-              "format": "byte",
               "writeOnly": true
             },
-            "envelopeHash": {
-              "description": "Specifies the hash algorithm and value for the COSE envelope",
+            "hash": {
+              "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
               "type": "object",
               "required": [
                 "algorithm",
@@ -2934,14 +5890,14 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the envelope",
+                  "description": "The hash value for the archive",
                   "type": "string"
                 }
               },
               "readOnly": true
             },
             "payloadHash": {
-              "description": "Specifies the hash algorithm and value for the content",
+              "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
               "type": "object",
               "required": [
                 "algorithm",
@@ -2956,7 +5912,7 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the content",
+                  "description": "The hash value for the envelope's payload",
                   "type": "string"
                 }
               },
@@ -2964,12 +5920,6 @@ func init() {
             }
           }
         },
-        "message": {
-          "description": "The COSE Sign1 Message",
-          "type": "string",
-          "format": "byte",
-          "writeOnly": true
-        },
         "publicKey": {
           "description": "The public key that can verify the signature",
           "type": "string",
@@ -2977,10 +5927,99 @@ func init() {
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/cose/cose_v0_0_1_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/intoto/intoto_v0_0_1_schema.json"
     },
-    "hashedrekord": {
-      "description": "Hashed Rekord object",
+    "intotoV002Schema": {
+      "description": "Schema for intoto object",
+      "type": "object",
+      "title": "intoto v0.0.2 Schema",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "type": "object",
+          "properties": {
+            "envelope": {
+              "description": "dsse envelope",
+              "type": "object",
+              "required": [
+                "payloadType",
+                "signatures"
+              ],
+              "properties": {
+                "payload": {
+                  "description": "payload of the envelope",
+                  "type": "string",
+                  "format": "byte",
+                  "writeOnly": true
+                },
+                "payloadType": {
+                  "description": "type describing the payload",
+                  "type": "string"
+                },
+                "signatures": {
+                  "description": "collection of all signatures of the envelope's payload",
+                  "type": "array",
+                  "minItems": 1,
+                  "items": {
+                    "$ref": "#/definitions/IntotoV002SchemaContentEnvelopeSignaturesItems0"
+                  }
+                }
+              }
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the archive",
+                  "type": "string"
+                }
+              },
+              "readOnly": true
+            },
+            "payloadHash": {
+              "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value of the payload",
+                  "type": "string"
+                }
+              },
+              "readOnly": true
+            }
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/intoto/intoto_v0_0_2_schema.json"
+    },
+    "jar": {
+      "description": "Java Archive (JAR)",
       "type": "object",
       "allOf": [
         {
@@ -2997,40 +6036,57 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/hashedrekordSchema"
+              "$ref": "#/definitions/jarSchema"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "hashedrekordSchema": {
-      "description": "Schema for Rekord objects",
+    "jarSchema": {
+      "description": "Schema for JAR objects",
       "type": "object",
-      "title": "Rekor Schema",
+      "title": "JAR Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/hashedrekordV001Schema"
+          "$ref": "#/definitions/jarV001Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/hashedrekord/hasehedrekord_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/jar/jar_schema.json"
     },
-    "hashedrekordV001Schema": {
-      "description": "Schema for Hashed Rekord object",
+    "jarV001Schema": {
+      "description": "Schema for JAR entries",
       "type": "object",
-      "title": "Hashed Rekor v0.0.1 Schema",
+      "title": "JAR v0.0.1 Schema",
       "required": [
-        "signature",
-        "data"
+        "archive"
       ],
       "properties": {
-        "data": {
-          "description": "Information about the content associated with the entry",
+        "archive": {
+          "description": "Information about the archive associated with the entry",
           "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "hash"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
           "properties": {
+            "content": {
+              "description": "Specifies the archive inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
             "hash": {
-              "description": "Specifies the hash algorithm and value for the content",
+              "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
               "type": "object",
               "required": [
                 "algorithm",
@@ -3045,7 +6101,7 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the content",
+                  "description": "The hash value for the archive",
                   "type": "string"
                 }
               }
@@ -3053,33 +6109,42 @@ func init() {
           }
         },
         "signature": {
-          "description": "Information about the detached signature associated with the entry",
+          "description": "Information about the included signature in the JAR file",
           "type": "object",
+          "required": [
+            "publicKey",
+            "content"
+          ],
           "properties": {
             "content": {
-              "description": "Specifies the content of the signature inline within the document",
+              "description": "Specifies the PKCS7 signature embedded within the JAR file ",
               "type": "string",
-              "format": "byte"
+              "format": "byte",
+              "readOnly": true
             },
             "publicKey": {
-              "description": "The public key that can verify the signature; this can also be an X509 code signing certificate that contains the raw public key information",
+              "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
               "type": "object",
+              "required": [
+                "content"
+              ],
               "properties": {
                 "content": {
-                  "description": "Specifies the content of the public key or code signing certificate inline within the document",
+                  "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
                   "type": "string",
                   "format": "byte"
                 }
-              }
+              },
+              "readOnly": true
             }
           }
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/rekord/rekord_v0_0_1_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/jar/jar_v0_0_1_schema.json"
     },
-    "helm": {
-      "description": "Helm chart",
+    "npm": {
+      "description": "npm package registry signature or provenance",
       "type": "object",
       "allOf": [
         {
@@ -3096,42 +6161,79 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/helmSchema"
+              "$ref": "#/definitions/npmSchema"
             }
-          }
+          },
+          "additionalProperties": false
         }
       ]
     },
-    "helmSchema": {
-      "description": "Schema for Helm objects",
+    "npmSchema": {
+      "description": "Schema for npm package objects",
       "type": "object",
-      "title": "Helm Schema",
+      "title": "npm Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/helmV001Schema"
+          "$ref": "#/definitions/npmV001Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/helm/helm_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/npm/npm_schema.json"
     },
-    "helmV001Schema": {
-      "description": "Schema for Helm object",
+    "npmV001Schema": {
+      "description": "Schema for npm registry signature and provenance entries",
       "type": "object",
-      "title": "Helm v0.0.1 Schema",
-      "required": [
-        "publicKey",
-        "chart"
+      "title": "npm v0.0.1 Schema",
+      "anyOf": [
+        {
+          "required": [
+            "package",
+            "signature"
+          ]
+        },
+        {
+          "required": [
+            "provenance"
+          ]
+        }
       ],
       "properties": {
-        "chart": {
-          "description": "Information about the Helm chart associated with the entry",
+        "package": {
+          "description": "The npm package version associated with the entry",
           "type": "object",
           "required": [
-            "provenance"
+            "name",
+            "version",
+            "integrity"
           ],
           "properties": {
+            "integrity": {
+              "description": "The subresource integrity string of the SHA512 hash of the package tarball",
+              "type": "string",
+              "pattern": "^sha512-[A-Za-z0-9+/]{86}==$"
+            },
+            "name": {
+              "description": "The name of the package, including its scope",
+              "type": "string"
+            },
+            "version": {
+              "description": "The version of the package",
+              "type": "string"
+            }
+          }
+        },
+        "provenance": {
+          "description": "The Sigstore bundle holding the provenance of the package",
+          "type": "object",
+          "properties": {
+            "content": {
+              "description": "Specifies the bundle inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
             "hash": {
-              "description": "Specifies the hash algorithm and value for the chart",
+              "description": "Specifies the hash algorithm and value for the bundle",
               "type": "object",
               "required": [
                 "algorithm",
@@ -3146,74 +6248,49 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the chart",
+                  "description": "The hash value for the bundle",
                   "type": "string"
                 }
               },
               "readOnly": true
-            },
-            "provenance": {
-              "description": "The provenance entry associated with the signed Helm Chart",
-              "type": "object",
-              "oneOf": [
-                {
-                  "required": [
-                    "signature"
-                  ]
-                },
-                {
-                  "required": [
-                    "content"
-                  ]
-                }
-              ],
-              "properties": {
-                "content": {
-                  "description": "Specifies the content of the provenance file inline within the document",
-                  "type": "string",
-                  "format": "byte",
-                  "writeOnly": true
-                },
-                "signature": {
-                  "description": "Information about the included signature in the provenance file",
-                  "type": "object",
-                  "required": [
-                    "content"
-                  ],
-                  "properties": {
-                    "content": {
-                      "description": "Specifies the signature embedded within the provenance file ",
-                      "type": "string",
-                      "format": "byte",
-                      "readOnly": true
-                    }
-                  },
-                  "readOnly": true
-                }
-              }
             }
           }
         },
-        "publicKey": {
-          "description": "The public key that can verify the package signature",
+        "signature": {
+          "description": "The signature over the package, and the public key or certificate that verifies it",
           "type": "object",
           "required": [
-            "content"
+            "content",
+            "publicKey"
           ],
           "properties": {
             "content": {
-              "description": "Specifies the content of the public key inline within the document",
+              "description": "Specifies the content of the signature inline within the document",
               "type": "string",
               "format": "byte"
+            },
+            "publicKey": {
+              "description": "The public key or certificate that can verify the signature",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key or certificate inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                }
+              }
             }
           }
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/helm/helm_v0_0_1_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/npm/npm_v0_0_1_schema.json"
     },
-    "intoto": {
-      "description": "Intoto object",
+    "oci": {
+      "description": "OCI image signature",
       "type": "object",
       "allOf": [
         {
@@ -3230,47 +6307,61 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/intotoSchema"
+              "$ref": "#/definitions/ociSchema"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "intotoSchema": {
-      "description": "Intoto for Rekord objects",
+    "ociSchema": {
+      "description": "Schema for OCI image signature objects",
       "type": "object",
-      "title": "Intoto Schema",
+      "title": "OCI Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/intotoV001Schema"
-        },
-        {
-          "$ref": "#/definitions/intotoV002Schema"
+          "$ref": "#/definitions/ociV001Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/intoto/intoto_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/oci/oci_schema.json"
     },
-    "intotoV001Schema": {
-      "description": "Schema for intoto object",
+    "ociV001Schema": {
+      "description": "Schema for OCI image signature objects",
       "type": "object",
-      "title": "intoto v0.0.1 Schema",
+      "title": "OCI v0.0.1 Schema",
       "required": [
-        "publicKey",
-        "content"
+        "signature",
+        "payload"
       ],
       "properties": {
-        "content": {
+        "image": {
+          "description": "The image the payload was signed for",
           "type": "object",
           "properties": {
-            "envelope": {
-              "description": "envelope",
+            "digest": {
+              "description": "The digest of the image manifest",
+              "type": "string",
+              "pattern": "^sha256:[0-9a-f]{64}$"
+            },
+            "reference": {
+              "description": "The repository reference of the image, which is taken from simple signing payloads; it cannot be provided for image manifests, as their signature does not cover it",
+              "type": "string"
+            }
+          }
+        },
+        "payload": {
+          "description": "Information about the signed payload, which is either a simple signing payload or an image manifest",
+          "type": "object",
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the payload inline within the document",
               "type": "string",
+              "format": "byte",
               "writeOnly": true
             },
             "hash": {
-              "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+              "description": "Specifies the hash algorithm and value for the payload",
               "type": "object",
               "required": [
                 "algorithm",
@@ -3285,86 +6376,115 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the archive",
+                  "description": "The hash value for the payload",
                   "type": "string"
                 }
-              },
-              "readOnly": true
+              }
+            }
+          }
+        },
+        "signature": {
+          "description": "Information about the signature over the payload",
+          "type": "object",
+          "required": [
+            "content",
+            "publicKey"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
             },
-            "payloadHash": {
-              "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
+            "publicKey": {
+              "description": "The public key that can verify the signature; this can also be an X509 code signing certificate that contains the raw public key information",
               "type": "object",
               "required": [
-                "algorithm",
-                "value"
+                "content"
               ],
               "properties": {
-                "algorithm": {
-                  "description": "The hashing function used to compute the hash value",
+                "content": {
+                  "description": "Specifies the content of the public key or code signing certificate inline within the document",
                   "type": "string",
-                  "enum": [
-                    "sha256"
-                  ]
-                },
-                "value": {
-                  "description": "The hash value for the envelope's payload",
-                  "type": "string"
+                  "format": "byte"
                 }
-              },
-              "readOnly": true
+              }
             }
           }
-        },
-        "publicKey": {
-          "description": "The public key that can verify the signature",
-          "type": "string",
-          "format": "byte"
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/intoto/intoto_v0_0_1_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/oci/oci_v0_0_1_schema.json"
     },
-    "intotoV002Schema": {
-      "description": "Schema for intoto object",
+    "pe": {
+      "description": "Authenticode-signed PE binary",
       "type": "object",
-      "title": "intoto v0.0.2 Schema",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/peSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "peSchema": {
+      "description": "Schema for Authenticode-signed PE binaries",
+      "type": "object",
+      "title": "PE Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/peV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/pe/pe_schema.json"
+    },
+    "peV001Schema": {
+      "description": "Schema for PE entries",
+      "type": "object",
+      "title": "PE v0.0.1 Schema",
       "required": [
-        "content"
+        "image"
       ],
       "properties": {
-        "content": {
+        "image": {
+          "description": "Information about the PE file associated with the entry",
           "type": "object",
-          "properties": {
-            "envelope": {
-              "description": "dsse envelope",
-              "type": "object",
+          "oneOf": [
+            {
               "required": [
-                "payloadType",
-                "signatures"
-              ],
-              "properties": {
-                "payload": {
-                  "description": "payload of the envelope",
-                  "type": "string",
-                  "format": "byte",
-                  "writeOnly": true
-                },
-                "payloadType": {
-                  "description": "type describing the payload",
-                  "type": "string"
-                },
-                "signatures": {
-                  "description": "collection of all signatures of the envelope's payload",
-                  "type": "array",
-                  "minItems": 1,
-                  "items": {
-                    "$ref": "#/definitions/IntotoV002SchemaContentEnvelopeSignaturesItems0"
-                  }
-                }
-              }
+                "hash"
+              ]
             },
-            "hash": {
-              "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the PE file inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "digest": {
+              "description": "Specifies the Authenticode digest of the PE file that is signed by the signature",
               "type": "object",
               "required": [
                 "algorithm",
@@ -3372,21 +6492,24 @@ func init() {
               ],
               "properties": {
                 "algorithm": {
-                  "description": "The hashing function used to compute the hash value",
+                  "description": "The hashing function used to compute the Authenticode digest",
                   "type": "string",
                   "enum": [
-                    "sha256"
+                    "sha1",
+                    "sha256",
+                    "sha384",
+                    "sha512"
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the archive",
+                  "description": "The Authenticode digest of the PE file",
                   "type": "string"
                 }
               },
               "readOnly": true
             },
-            "payloadHash": {
-              "description": "Specifies the hash algorithm and value covering the payload within the DSSE envelope",
+            "hash": {
+              "description": "Specifies the hash algorithm and value encompassing the entire signed PE file",
               "type": "object",
               "required": [
                 "algorithm",
@@ -3401,9 +6524,39 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value of the payload",
+                  "description": "The hash value for the PE file",
                   "type": "string"
                 }
+              }
+            }
+          }
+        },
+        "signature": {
+          "description": "Information about the Authenticode signature embedded in the PE file",
+          "type": "object",
+          "required": [
+            "publicKey",
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the PKCS7 signature extracted from the certificate table of the PE file",
+              "type": "string",
+              "format": "byte",
+              "readOnly": true
+            },
+            "publicKey": {
+              "description": "The X509 certificate of the signer of the PE file",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+                  "type": "string",
+                  "format": "byte"
+                }
               },
               "readOnly": true
             }
@@ -3411,10 +6564,10 @@ func init() {
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/intoto/intoto_v0_0_2_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/pe/pe_v0_0_1_schema.json"
     },
-    "jar": {
-      "description": "Java Archive (JAR)",
+    "pypi": {
+      "description": "Python wheel or sdist signature",
       "type": "object",
       "allOf": [
         {
@@ -3431,36 +6584,40 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/jarSchema"
+              "$ref": "#/definitions/pypiSchema"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "jarSchema": {
-      "description": "Schema for JAR objects",
+    "pypiSchema": {
+      "description": "Schema for Python distribution objects",
       "type": "object",
-      "title": "JAR Schema",
+      "title": "PyPI Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/jarV001Schema"
+          "$ref": "#/definitions/pypiV001Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/jar/jar_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/pypi/pypi_schema.json"
     },
-    "jarV001Schema": {
-      "description": "Schema for JAR entries",
+    "pypiV001Schema": {
+      "description": "Schema for signed Python wheel and sdist entries",
       "type": "object",
-      "title": "JAR v0.0.1 Schema",
+      "title": "PyPI v0.0.1 Schema",
       "required": [
-        "archive"
+        "signature",
+        "package"
       ],
       "properties": {
-        "archive": {
-          "description": "Information about the archive associated with the entry",
+        "package": {
+          "description": "Information about the wheel or sdist associated with the entry",
           "type": "object",
+          "required": [
+            "filename"
+          ],
           "oneOf": [
             {
               "required": [
@@ -3475,13 +6632,17 @@ func init() {
           ],
           "properties": {
             "content": {
-              "description": "Specifies the archive inline within the document",
+              "description": "Specifies the distribution inline within the document",
               "type": "string",
               "format": "byte",
               "writeOnly": true
             },
+            "filename": {
+              "description": "The filename of the wheel or sdist",
+              "type": "string"
+            },
             "hash": {
-              "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
+              "description": "Specifies the hash algorithm and value for the distribution",
               "type": "object",
               "required": [
                 "algorithm",
@@ -3496,47 +6657,66 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the archive",
+                  "description": "The hash value for the distribution",
                   "type": "string"
                 }
               }
+            },
+            "name": {
+              "description": "The normalized name of the project",
+              "type": "string",
+              "readOnly": true
+            },
+            "version": {
+              "description": "The version of the project",
+              "type": "string",
+              "readOnly": true
             }
           }
         },
         "signature": {
-          "description": "Information about the included signature in the JAR file",
+          "description": "Information about the detached signature over the distribution",
           "type": "object",
           "required": [
-            "publicKey",
-            "content"
+            "format",
+            "content",
+            "publicKey"
           ],
           "properties": {
             "content": {
-              "description": "Specifies the PKCS7 signature embedded within the JAR file ",
+              "description": "Specifies the content of the signature inline within the document",
               "type": "string",
-              "format": "byte",
-              "readOnly": true
+              "format": "byte"
+            },
+            "format": {
+              "description": "Specifies the type of signature",
+              "type": "string",
+              "enum": [
+                "pgp",
+                "minisign",
+                "x509",
+                "ssh"
+              ]
             },
             "publicKey": {
-              "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
+              "description": "The public key that can verify the signature",
               "type": "object",
               "required": [
                 "content"
               ],
               "properties": {
                 "content": {
-                  "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+                  "description": "Specifies the content of the public key inline within the document",
                   "type": "string",
                   "format": "byte"
                 }
-              },
-              "readOnly": true
+              }
             }
           }
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/jar/jar_v0_0_1_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/pypi/pypi_v0_0_1_schema.json"
     },
     "rekord": {
       "description": "Rekord object",
@@ -3854,6 +7034,150 @@ func init() {
       "$schema": "http://json-schema.org/draft-07/schema",
       "$id": "http://rekor.sigstore.dev/types/rpm/rpm_v0_0_1_schema.json"
     },
+    "sbom": {
+      "description": "Signed SPDX or CycloneDX software bill of materials",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/sbomSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "sbomSchema": {
+      "description": "Schema for signed software bill of materials objects",
+      "type": "object",
+      "title": "SBOM Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/sbomV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/sbom/sbom_schema.json"
+    },
+    "sbomV001Schema": {
+      "description": "Schema for signed SPDX and CycloneDX documents",
+      "type": "object",
+      "title": "SBOM v0.0.1 Schema",
+      "required": [
+        "signature",
+        "document"
+      ],
+      "properties": {
+        "document": {
+          "description": "Information about the SBOM document associated with the entry",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "hash"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the document inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "format": {
+              "description": "The format of the document, which is only known when the document is provided",
+              "type": "string",
+              "enum": [
+                "spdx",
+                "cyclonedx"
+              ],
+              "readOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the document",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the document",
+                  "type": "string"
+                }
+              }
+            }
+          }
+        },
+        "signature": {
+          "description": "Information about the detached signature over the document",
+          "type": "object",
+          "required": [
+            "format",
+            "content",
+            "publicKey"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "description": "Specifies the type of signature",
+              "type": "string",
+              "enum": [
+                "pgp",
+                "minisign",
+                "x509",
+                "ssh"
+              ]
+            },
+            "publicKey": {
+              "description": "The public key that can verify the signature",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                }
+              }
+            }
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/sbom/sbom_v0_0_1_schema.json"
+    },
     "tuf": {
       "description": "TUF metadata",
       "type": "object",
@@ -40,6 +40,7 @@ import (
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/index"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/pubkey"
 	serverops "github.com/sigstore/rekor/pkg/generated/restapi/operations/server"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/timestamp"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/tlog"
 )
 
@@ -61,8 +62,17 @@ func NewRekorServerAPI(spec *loads.Document) *RekorServerAPI {
 		APIKeyAuthenticator: security.APIKeyAuth,
 		BearerAuthenticator: security.BearerAuth,
 
+		ApplicationTimestampQueryConsumer: runtime.ConsumerFunc(func(r io.Reader, target interface{}) error {
+			return errors.NotImplemented("applicationTimestampQuery consumer has not yet been implemented")
+		}),
 		JSONConsumer: runtime.JSONConsumer(),
 
+		ApplicationPemCertificateChainProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("applicationPemCertificateChain producer has not yet been implemented")
+		}),
+		ApplicationTimestampReplyProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("applicationTimestampReply producer has not yet been implemented")
+		}),
 		ApplicationXPemFileProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
 			return errors.NotImplemented("applicationXPemFile producer has not yet been implemented")
 		}),
@@ -89,6 +99,15 @@ func NewRekorServerAPI(spec *loads.Document) *RekorServerAPI {
 		ServerGetRekorVersionHandler: serverops.GetRekorVersionHandlerFunc(func(params serverops.GetRekorVersionParams) middleware.Responder {
 			return middleware.NotImplemented("operation server.GetRekorVersion has not yet been implemented")
 		}),
+		TimestampGetTimestampCertChainHandler: timestamp.GetTimestampCertChainHandlerFunc(func(params timestamp.GetTimestampCertChainParams) middleware.Responder {
+			return middleware.NotImplemented("operation timestamp.GetTimestampCertChain has not yet been implemented")
+		}),
+		TimestampGetTimestampNoteHandler: timestamp.GetTimestampNoteHandlerFunc(func(params timestamp.GetTimestampNoteParams) middleware.Responder {
+			return middleware.NotImplemented("operation timestamp.GetTimestampNote has not yet been implemented")
+		}),
+		TimestampGetTimestampResponseHandler: timestamp.GetTimestampResponseHandlerFunc(func(params timestamp.GetTimestampResponseParams) middleware.Responder {
+			return middleware.NotImplemented("operation timestamp.GetTimestampResponse has not yet been implemented")
+		}),
 		IndexSearchIndexHandler: index.SearchIndexHandlerFunc(func(params index.SearchIndexParams) middleware.Responder {
 			return middleware.NotImplemented("operation index.SearchIndex has not yet been implemented")
 		}),
@@ -123,10 +142,19 @@ type RekorServerAPI struct {
 	// It has a default implementation in the security package, however you can replace it for your particular usage.
 	BearerAuthenticator func(string, security.ScopedTokenAuthentication) runtime.Authenticator
 
+	// ApplicationTimestampQueryConsumer registers a consumer for the following mime types:
+	//   - application/timestamp-query
+	ApplicationTimestampQueryConsumer runtime.Consumer
 	// JSONConsumer registers a consumer for the following mime types:
 	//   - application/json
 	JSONConsumer runtime.Consumer
 
+	// ApplicationPemCertificateChainProducer registers a producer for the following mime types:
+	//   - application/pem-certificate-chain
+	ApplicationPemCertificateChainProducer runtime.Producer
+	// ApplicationTimestampReplyProducer registers a producer for the following mime types:
+	//   - application/timestamp-reply
+	ApplicationTimestampReplyProducer runtime.Producer
 	// ApplicationXPemFileProducer registers a producer for the following mime types:
 	//   - application/x-pem-file
 	ApplicationXPemFileProducer runtime.Producer
@@ -148,6 +176,12 @@ type RekorServerAPI struct {
 	PubkeyGetPublicKeyHandler pubkey.GetPublicKeyHandler
 	// ServerGetRekorVersionHandler sets the operation handler for the get rekor version operation
 	ServerGetRekorVersionHandler serverops.GetRekorVersionHandler
+	// TimestampGetTimestampCertChainHandler sets the operation handler for the get timestamp cert chain operation
+	TimestampGetTimestampCertChainHandler timestamp.GetTimestampCertChainHandler
+	// TimestampGetTimestampNoteHandler sets the operation handler for the get timestamp note operation
+	TimestampGetTimestampNoteHandler timestamp.GetTimestampNoteHandler
+	// TimestampGetTimestampResponseHandler sets the operation handler for the get timestamp response operation
+	TimestampGetTimestampResponseHandler timestamp.GetTimestampResponseHandler
 	// IndexSearchIndexHandler sets the operation handler for the search index operation
 	IndexSearchIndexHandler index.SearchIndexHandler
 	// EntriesSearchLogQueryHandler sets the operation handler for the search log query operation
@@ -221,10 +255,19 @@ func (o *RekorServerAPI) RegisterFormat(name string, format strfmt.Format, valid
 func (o *RekorServerAPI) Validate() error {
 	var unregistered []string
 
+	if o.ApplicationTimestampQueryConsumer == nil {
+		unregistered = append(unregistered, "ApplicationTimestampQueryConsumer")
+	}
 	if o.JSONConsumer == nil {
 		unregistered = append(unregistered, "JSONConsumer")
 	}
 
+	if o.ApplicationPemCertificateChainProducer == nil {
+		unregistered = append(unregistered, "ApplicationPemCertificateChainProducer")
+	}
+	if o.ApplicationTimestampReplyProducer == nil {
+		unregistered = append(unregistered, "ApplicationTimestampReplyProducer")
+	}
 	if o.ApplicationXPemFileProducer == nil {
 		unregistered = append(unregistered, "ApplicationXPemFileProducer")
 	}
@@ -253,6 +296,15 @@ func (o *RekorServerAPI) Validate() error {
 	if o.ServerGetRekorVersionHandler == nil {
 		unregistered = append(unregistered, "server.GetRekorVersionHandler")
 	}
+	if o.TimestampGetTimestampCertChainHandler == nil {
+		unregistered = append(unregistered, "timestamp.GetTimestampCertChainHandler")
+	}
+	if o.TimestampGetTimestampNoteHandler == nil {
+		unregistered = append(unregistered, "timestamp.GetTimestampNoteHandler")
+	}
+	if o.TimestampGetTimestampResponseHandler == nil {
+		unregistered = append(unregistered, "timestamp.GetTimestampResponseHandler")
+	}
 	if o.IndexSearchIndexHandler == nil {
 		unregistered = append(unregistered, "index.SearchIndexHandler")
 	}
@@ -288,6 +340,8 @@ func (o *RekorServerAPI) ConsumersFor(mediaTypes []string) map[string]runtime.Co
 	result := make(map[string]runtime.Consumer, len(mediaTypes))
 	for _, mt := range mediaTypes {
 		switch mt {
+		case "application/timestamp-query":
+			result["application/timestamp-query"] = o.ApplicationTimestampQueryConsumer
 		case "application/json":
 			result["application/json"] = o.JSONConsumer
 		}
@@ -305,6 +359,10 @@ func (o *RekorServerAPI) ProducersFor(mediaTypes []string) map[string]runtime.Pr
 	result := make(map[string]runtime.Producer, len(mediaTypes))
 	for _, mt := range mediaTypes {
 		switch mt {
+		case "application/pem-certificate-chain":
+			result["application/pem-certificate-chain"] = o.ApplicationPemCertificateChainProducer
+		case "application/timestamp-reply":
+			result["application/timestamp-reply"] = o.ApplicationTimestampReplyProducer
 		case "application/x-pem-file":
 			result["application/x-pem-file"] = o.ApplicationXPemFileProducer
 		case "application/json":
@@ -377,6 +435,18 @@ func (o *RekorServerAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/api/v1/version"] = serverops.NewGetRekorVersion(o.context, o.ServerGetRekorVersionHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/v1/log/timestamp/certchain"] = timestamp.NewGetTimestampCertChain(o.context, o.TimestampGetTimestampCertChainHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/api/v1/timestamp/note"] = timestamp.NewGetTimestampNote(o.context, o.TimestampGetTimestampNoteHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/api/v1/timestamp"] = timestamp.NewGetTimestampResponse(o.context, o.TimestampGetTimestampResponseHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
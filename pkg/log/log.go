@@ -27,6 +27,12 @@ import (
 // Logger set the default logger to development mode
 var Logger *zap.SugaredLogger
 
+var (
+	// level is the minimum level of Logger, which can be changed at runtime
+	level        = zap.NewAtomicLevel()
+	defaultLevel zapcore.Level
+)
+
 func init() {
 	ConfigureLogger("dev")
 }
@@ -41,6 +47,9 @@ func ConfigureLogger(logType string) {
 		cfg = zap.NewDevelopmentConfig()
 		cfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	}
+	defaultLevel = cfg.Level.Level()
+	level.SetLevel(defaultLevel)
+	cfg.Level = level
 	logger, err := cfg.Build()
 	if err != nil {
 		log.Fatalln("createLogger", err)
@@ -48,6 +57,21 @@ func ConfigureLogger(logType string) {
 	Logger = logger.Sugar()
 }
 
+// SetLevel changes the minimum level of Logger, e.g. to "debug" or "warn". The
+// empty string restores the default level for the logger type.
+func SetLevel(l string) error {
+	if l == "" {
+		level.SetLevel(defaultLevel)
+		return nil
+	}
+	parsed, err := zapcore.ParseLevel(l)
+	if err != nil {
+		return err
+	}
+	level.SetLevel(parsed)
+	return nil
+}
+
 var CliLogger = createCliLogger()
 
 func createCliLogger() *zap.SugaredLogger {
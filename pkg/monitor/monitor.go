@@ -0,0 +1,173 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/verify"
+)
+
+// maxEntriesPerQuery matches the maximum number of log indexes accepted in a
+// single search query
+const maxEntriesPerQuery = 10
+
+// Progress is the resumable state of a monitor
+type Progress struct {
+	// NextIndex is the virtual log index of the next entry to be checked
+	NextIndex int64 `json:"nextIndex"`
+}
+
+// Monitor checks entries against a watch list and notifies sinks of matches
+type Monitor struct {
+	Client *client.Rekor
+	// Verifiers holds the key of each shard, as the SET of an entry is signed
+	// with the key of the tree it is in
+	Verifiers *verify.TreeVerifiers
+	WatchList *WatchList
+	Sinks     []Sink
+}
+
+// CheckEntries fetches and verifies the entries with virtual log indexes in
+// [start, end), and notifies the sinks of every entry that matches the watch
+// list. It returns the index of the first entry that was not checked, which
+// is end unless an error is returned. Notifications are delivered at least
+// once: if a sink fails, the entry is checked again by the next call.
+func (m *Monitor) CheckEntries(ctx context.Context, start, end int64) (int64, error) {
+	for next := start; next < end; {
+		batch := end - next
+		if batch > maxEntriesPerQuery {
+			batch = maxEntriesPerQuery
+		}
+		logEntries, err := m.fetch(ctx, next, batch)
+		if err != nil {
+			return next, err
+		}
+		for _, e := range logEntries {
+			if err := m.checkEntry(ctx, e.uuid, e.entry); err != nil {
+				return next, fmt.Errorf("checking entry %d: %w", next, err)
+			}
+			next++
+		}
+	}
+	return end, nil
+}
+
+type indexedEntry struct {
+	uuid  string
+	entry models.LogEntryAnon
+}
+
+// fetch returns the count entries starting at the virtual log index start,
+// in order of their log index
+func (m *Monitor) fetch(ctx context.Context, start, count int64) ([]indexedEntry, error) {
+	query := &models.SearchLogQuery{}
+	for i := start; i < start+count; i++ {
+		query.LogIndexes = append(query.LogIndexes, swag.Int64(i))
+	}
+	params := entries.NewSearchLogQueryParamsWithContext(ctx)
+	params.SetEntry(query)
+	resp, err := m.Client.Entries.SearchLogQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("searching log indexes %d to %d: %w", start, start+count-1, err)
+	}
+
+	var result []indexedEntry
+	for _, logEntry := range resp.Payload {
+		for uuid, e := range logEntry {
+			result = append(result, indexedEntry{uuid: uuid, entry: e})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return swag.Int64Value(result[i].entry.LogIndex) < swag.Int64Value(result[j].entry.LogIndex)
+	})
+	if int64(len(result)) != count {
+		return nil, fmt.Errorf("expected %d entries from log index %d but got %d", count, start, len(result))
+	}
+	for i, e := range result {
+		if swag.Int64Value(e.entry.LogIndex) != start+int64(i) {
+			return nil, fmt.Errorf("expected entry at log index %d but got %d", start+int64(i), swag.Int64Value(e.entry.LogIndex))
+		}
+	}
+	return result, nil
+}
+
+// checkEntry verifies the entry is included in the log, and notifies the
+// sinks if it matches the watch list
+func (m *Monitor) checkEntry(ctx context.Context, uuid string, e models.LogEntryAnon) error {
+	verifier, err := m.Verifiers.ForLogID(ctx, swag.StringValue(e.LogID))
+	if err != nil {
+		return fmt.Errorf("getting key of entry: %w", err)
+	}
+	if err := verify.VerifyLogEntry(ctx, &e, verifier); err != nil {
+		return fmt.Errorf("verifying entry: %w", err)
+	}
+
+	body, ok := e.Body.(string)
+	if !ok {
+		return fmt.Errorf("unexpected body type %T", e.Body)
+	}
+	b, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return fmt.Errorf("decoding body: %w", err)
+	}
+	pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+	if err != nil {
+		return fmt.Errorf("unmarshalling body: %w", err)
+	}
+	eimpl, err := types.UnmarshalEntry(pe)
+	if err != nil {
+		// entries of types unknown to this binary cannot hold watched identities
+		// that could be extracted, so they are skipped rather than blocking progress
+		log.Logger.Warnf("skipping entry %d of kind %s: %v", swag.Int64Value(e.LogIndex), pe.Kind(), err)
+		return nil
+	}
+	matches, err := m.WatchList.Matches(eimpl)
+	if err != nil {
+		log.Logger.Warnf("skipping entry %d: getting index keys: %v", swag.Int64Value(e.LogIndex), err)
+		return nil
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	n := Notification{
+		LogIndex:       swag.Int64Value(e.LogIndex),
+		UUID:           uuid,
+		Kind:           pe.Kind(),
+		APIVersion:     eimpl.APIVersion(),
+		IntegratedTime: swag.Int64Value(e.IntegratedTime),
+		Matches:        matches,
+	}
+	for _, s := range m.Sinks {
+		if err := s.Notify(ctx, n); err != nil {
+			return fmt.Errorf("notifying: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Notification is sent to the sinks when an entry matches the watch list
+type Notification struct {
+	LogIndex       int64   `json:"logIndex"`
+	UUID           string  `json:"uuid"`
+	Kind           string  `json:"kind"`
+	APIVersion     string  `json:"apiVersion"`
+	IntegratedTime int64   `json:"integratedTime"`
+	Matches        []Match `json:"matches"`
+}
+
+// Sink delivers notifications
+type Sink interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// NewSink returns the sink for the URI, which is one of "stdout",
+// "file:///path/to/file" or an http(s) webhook URL
+func NewSink(uri string) (Sink, error) {
+	if uri == "stdout" {
+		return &writerSink{w: os.Stdout}, nil
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parsing sink %q: %w", uri, err)
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, fmt.Errorf("file sink %q has no path", uri)
+		}
+		return &fileSink{path: filepath.Clean(u.Path)}, nil
+	case "http", "https":
+		return &webhookSink{url: uri, client: &http.Client{Timeout: 30 * time.Second}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported sink %q", uri)
+	}
+}
+
+// writerSink writes each notification as a line of JSON
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *writerSink) Notify(_ context.Context, n Notification) error {
+	b, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(b, '\n'))
+	return err
+}
+
+// fileSink appends each notification to a file as a line of JSON
+type fileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func (s *fileSink) Notify(_ context.Context, n Notification) error {
+	b, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// webhookSink POSTs each notification as JSON
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *webhookSink) Notify(ctx context.Context, n Notification) error {
+	b, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned unexpected status %s", resp.Status)
+	}
+	return nil
+}
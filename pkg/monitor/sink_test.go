@@ -0,0 +1,127 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var testNotification = Notification{
+	LogIndex:   42,
+	UUID:       "abcd",
+	Kind:       "hashedrekord",
+	APIVersion: "0.0.1",
+	Matches:    []Match{{Type: MatchEmail, Value: "user@example.com"}},
+}
+
+func TestNewSink(t *testing.T) {
+	for _, uri := range []string{"stdout", "file:///tmp/notifications", "https://example.com/hook"} {
+		if _, err := NewSink(uri); err != nil {
+			t.Errorf("NewSink(%q) = %v", uri, err)
+		}
+	}
+	for _, uri := range []string{"stderr", "ftp://example.com", "file://"} {
+		if _, err := NewSink(uri); err == nil {
+			t.Errorf("expected error from NewSink(%q)", uri)
+		}
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	s := &writerSink{w: &buf}
+	if err := s.Notify(context.Background(), testNotification); err != nil {
+		t.Fatal(err)
+	}
+	var got Notification
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, testNotification) {
+		t.Errorf("got %v, want %v", got, testNotification)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications")
+	s, err := NewSink("file://" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := s.Notify(context.Background(), testNotification); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lines := 0
+	for scanner := bufio.NewScanner(f); scanner.Scan(); lines++ {
+		var got Notification
+		if err := json.Unmarshal(scanner.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if lines != 2 {
+		t.Errorf("expected 2 notifications to be appended, got %d", lines)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var got Notification
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer hook.Close()
+
+	s, err := NewSink(hook.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Notify(context.Background(), testNotification); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, testNotification) {
+		t.Errorf("got %v, want %v", got, testNotification)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	s, err = NewSink(failing.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Notify(context.Background(), testNotification); err == nil {
+		t.Error("expected error from failing webhook")
+	}
+}
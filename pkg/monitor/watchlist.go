@@ -0,0 +1,109 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+// WatchList is the set of identities that the monitor notifies about
+type WatchList struct {
+	// Emails are matched case insensitively against the email addresses in
+	// public keys and certificates
+	Emails []string `json:"emails"`
+	// Subjects are matched against the subject URIs in certificates
+	Subjects []string `json:"subjects"`
+	// PublicKeyHashes are hex encoded SHA256 hashes of public keys or
+	// certificates, optionally prefixed with "sha256:"
+	PublicKeyHashes []string `json:"publicKeyHashes"`
+}
+
+// Match is an identity from the watch list that was found in an entry
+type Match struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+const (
+	MatchEmail         = "email"
+	MatchSubject       = "subject"
+	MatchPublicKeyHash = "publicKeyHash"
+)
+
+// LoadWatchList reads a watch list from a file, in JSON or YAML
+func LoadWatchList(path string) (*WatchList, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	wl := &WatchList{}
+	// YAML is a superset of JSON
+	if err := yaml.Unmarshal(contents, wl); err != nil {
+		return nil, err
+	}
+	if len(wl.Emails)+len(wl.Subjects)+len(wl.PublicKeyHashes) == 0 {
+		return nil, errors.New("watch list is empty")
+	}
+	return wl, nil
+}
+
+// Matches returns the identities in the watch list found in the index keys of
+// the entry, which include the email addresses and subject URIs in its public
+// keys and certificates as well as the hashes of the keys themselves
+func (wl *WatchList) Matches(entry types.EntryImpl) ([]Match, error) {
+	keys, err := entry.IndexKeys()
+	if err != nil {
+		return nil, err
+	}
+	return wl.MatchKeys(keys), nil
+}
+
+// MatchKeys returns the identities in the watch list found in keys
+func (wl *WatchList) MatchKeys(keys []string) []Match {
+	present := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		present[k] = struct{}{}
+		present[strings.ToLower(k)] = struct{}{}
+	}
+	has := func(v string) bool {
+		_, ok := present[v]
+		return ok
+	}
+
+	var matches []Match
+	for _, email := range wl.Emails {
+		if has(strings.ToLower(email)) {
+			matches = append(matches, Match{Type: MatchEmail, Value: email})
+		}
+	}
+	for _, subject := range wl.Subjects {
+		if has(subject) {
+			matches = append(matches, Match{Type: MatchSubject, Value: subject})
+		}
+	}
+	for _, hash := range wl.PublicKeyHashes {
+		if has(strings.ToLower(strings.TrimPrefix(hash, "sha256:"))) {
+			matches = append(matches, Match{Type: MatchPublicKeyHash, Value: hash})
+		}
+	}
+	return matches
+}
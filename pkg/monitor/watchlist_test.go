@@ -0,0 +1,122 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadWatchList(t *testing.T) {
+	td := t.TempDir()
+	tests := []struct {
+		name     string
+		contents string
+		want     *WatchList
+		wantErr  bool
+	}{
+		{
+			name: "yaml",
+			contents: `emails:
+- user@example.com
+subjects:
+- https://github.com/example/repo/.github/workflows/release.yml@refs/heads/main
+publicKeyHashes:
+- sha256:abcd
+`,
+			want: &WatchList{
+				Emails:          []string{"user@example.com"},
+				Subjects:        []string{"https://github.com/example/repo/.github/workflows/release.yml@refs/heads/main"},
+				PublicKeyHashes: []string{"sha256:abcd"},
+			},
+		},
+		{
+			name:     "json",
+			contents: `{"emails": ["user@example.com"]}`,
+			want:     &WatchList{Emails: []string{"user@example.com"}},
+		},
+		{
+			name:     "empty",
+			contents: `emails: []`,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid",
+			contents: `emails: [`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(td, tt.name)
+			if err := ioutil.WriteFile(path, []byte(tt.contents), 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadWatchList(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadWatchList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadWatchList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchKeys(t *testing.T) {
+	wl := &WatchList{
+		Emails:          []string{"User@Example.com", "other@example.com"},
+		Subjects:        []string{"https://example.com/workflow"},
+		PublicKeyHashes: []string{"sha256:ABCD", "ef01"},
+	}
+	tests := []struct {
+		name string
+		keys []string
+		want []Match
+	}{
+		{
+			name: "no matches",
+			keys: []string{"nobody@example.com", "sha256:abcd"},
+		},
+		{
+			name: "email is case insensitive",
+			keys: []string{"user@example.com"},
+			want: []Match{{Type: MatchEmail, Value: "User@Example.com"}},
+		},
+		{
+			name: "subject",
+			keys: []string{"https://example.com/workflow"},
+			want: []Match{{Type: MatchSubject, Value: "https://example.com/workflow"}},
+		},
+		{
+			name: "key hashes with and without prefix",
+			keys: []string{"abcd", "ef01"},
+			want: []Match{
+				{Type: MatchPublicKeyHash, Value: "sha256:ABCD"},
+				{Type: MatchPublicKeyHash, Value: "ef01"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wl.MatchKeys(tt.keys); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -29,10 +29,14 @@ const (
 )
 
 func Armor(s *ssh.Signature, p ssh.PublicKey) []byte {
+	return armor(s, p, namespace)
+}
+
+func armor(s *ssh.Signature, p ssh.PublicKey, ns string) []byte {
 	sig := WrappedSig{
 		Version:       1,
 		PublicKey:     string(p.Marshal()),
-		Namespace:     namespace,
+		Namespace:     ns,
 		HashAlgorithm: defaultHashAlgorithm,
 		Signature:     string(ssh.Marshal(s)),
 	}
@@ -47,6 +51,12 @@ func Armor(s *ssh.Signature, p ssh.PublicKey) []byte {
 }
 
 func Decode(b []byte) (*Signature, error) {
+	return decode(b, namespace)
+}
+
+// decode parses an armored signature, which must have been created for the
+// given namespace
+func decode(b []byte, ns string) (*Signature, error) {
 	pemBlock, _ := pem.Decode(b)
 	if pemBlock == nil {
 		return nil, errors.New("unable to decode pem file")
@@ -68,7 +78,7 @@ func Decode(b []byte) (*Signature, error) {
 	if string(sig.MagicHeader[:]) != magicHeader {
 		return nil, fmt.Errorf("invalid magic header: %s", sig.MagicHeader[:])
 	}
-	if sig.Namespace != "file" {
+	if sig.Namespace != ns {
 		return nil, fmt.Errorf("invalid signature namespace: %s", sig.Namespace)
 	}
 	if _, ok := supportedHashAlgorithms[sig.HashAlgorithm]; !ok {
@@ -91,5 +101,6 @@ func Decode(b []byte) (*Signature, error) {
 		signature: &sshSig,
 		pk:        pk,
 		hashAlg:   sig.HashAlgorithm,
+		namespace: ns,
 	}, nil
 }
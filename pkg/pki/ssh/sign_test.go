@@ -299,6 +299,57 @@ func TestRoundTrip(t *testing.T) {
 
 }
 
+func TestNamespace(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/hello_world.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Generated with "ssh-keygen -Y sign -n git -f testdata/id_rsa testdata/hello_world.txt"
+	gitSig, err := ioutil.ReadFile("testdata/hello_world.txt.git.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := ioutil.ReadFile("testdata/id_rsa.pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := NewPublicKey(bytes.NewReader(pub))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := NewSignatureWithNamespace(bytes.NewReader(gitSig), "git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sig.Verify(bytes.NewReader(data), key); err != nil {
+		t.Error(err)
+	}
+	// The canonical value should keep the namespace
+	cv, err := sig.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verify(bytes.NewReader(data), cv, pub, "git"); err != nil {
+		t.Error(err)
+	}
+
+	// A signature for another namespace must not be accepted
+	if _, err := NewSignature(bytes.NewReader(gitSig)); err == nil {
+		t.Error("expected error for git signature in file namespace")
+	}
+	if err := Verify(bytes.NewReader(data), gitSig, pub); err == nil {
+		t.Error("expected error for git signature in file namespace")
+	}
+	fileSig, err := ioutil.ReadFile("testdata/hello_world.txt.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSignatureWithNamespace(bytes.NewReader(fileSig), "git"); err == nil {
+		t.Error("expected error for file signature in git namespace")
+	}
+}
+
 func write(t *testing.T, d []byte, fp ...string) string {
 	p := filepath.Join(fp...)
 	if err := ioutil.WriteFile(p, d, 0600); err != nil {
@@ -28,15 +28,23 @@ type Signature struct {
 	signature *ssh.Signature
 	pk        ssh.PublicKey
 	hashAlg   string
+	namespace string
 }
 
 // NewSignature creates and Validates an ssh signature object
 func NewSignature(r io.Reader) (*Signature, error) {
+	return NewSignatureWithNamespace(r, namespace)
+}
+
+// NewSignatureWithNamespace creates and validates an ssh signature object
+// that was created for the given namespace, such as "git" for signatures
+// made by git
+func NewSignatureWithNamespace(r io.Reader, ns string) (*Signature, error) {
 	b, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, err
 	}
-	sig, err := Decode(b)
+	sig, err := decode(b, ns)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +53,7 @@ func NewSignature(r io.Reader) (*Signature, error) {
 
 // CanonicalValue implements the pki.Signature interface
 func (s Signature) CanonicalValue() ([]byte, error) {
-	return []byte(Armor(s.signature, s.pk)), nil
+	return armor(s.signature, s.pk, s.namespace), nil
 }
 
 // Verify implements the pki.Signature interface
@@ -67,7 +75,7 @@ func (s Signature) Verify(r io.Reader, k interface{}, opts ...sigsig.VerifyOptio
 	if err != nil {
 		return err
 	}
-	return Verify(r, cs, ck)
+	return verify(r, cs, ck, s.namespace)
 }
 
 // PublicKey contains an ssh PublicKey
@@ -22,7 +22,11 @@ import (
 )
 
 func Verify(message io.Reader, armoredSignature []byte, publicKey []byte) error {
-	decodedSignature, err := Decode(armoredSignature)
+	return verify(message, armoredSignature, publicKey, namespace)
+}
+
+func verify(message io.Reader, armoredSignature []byte, publicKey []byte, ns string) error {
+	decodedSignature, err := decode(armoredSignature, ns)
 	if err != nil {
 		return err
 	}
@@ -40,7 +44,7 @@ func Verify(message io.Reader, armoredSignature []byte, publicKey []byte) error
 	hm := h.Sum(nil)
 
 	toVerify := MessageWrapper{
-		Namespace:     "file",
+		Namespace:     ns,
 		HashAlgorithm: decodedSignature.hashAlg,
 		Hash:          string(hm),
 	}
@@ -16,12 +16,16 @@
 package sharding
 
 import (
+	"bytes"
 	"context"
+	"crypto"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -29,6 +33,9 @@ import (
 	"github.com/google/trillian"
 	"github.com/google/trillian/types"
 	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
 )
 
 type LogRanges struct {
@@ -41,23 +48,51 @@ type Ranges []LogRange
 type LogRange struct {
 	TreeID           int64  `json:"treeID"`
 	TreeLength       int64  `json:"treeLength"`
-	EncodedPublicKey string `json:"encodedPublicKey"`
+	EncodedPublicKey string `json:"encodedPublicKey,omitempty"`
+	// SignedCheckpoint is the final checkpoint of the tree when it was frozen
+	SignedCheckpoint string `json:"signedCheckpoint,omitempty"`
+	// Signer is the URI of the signer of the tree's checkpoints and entries,
+	// in the format of the rekor_server.signer flag. Without one, the tree is
+	// signed by the signer of the active tree.
+	Signer           string `json:"signer,omitempty"`
 	decodedPublicKey string
 }
 
+// Config is the contents of the sharding config file. The file may either be
+// a list of inactive ranges, with the active tree set by flag, or a document
+// that also records the active tree, as written when rotating to a new tree.
+type Config struct {
+	ActiveTreeID int64  `json:"activeTreeID,omitempty"`
+	Inactive     Ranges `json:"inactive"`
+}
+
 func NewLogRanges(ctx context.Context, logClient trillian.TrillianLogClient, path string, treeID uint) (LogRanges, error) {
 	if path == "" {
 		log.Logger.Info("No config file specified, skipping init of logRange map")
 		return LogRanges{}, nil
 	}
-	if treeID == 0 {
-		return LogRanges{}, errors.New("non-zero tlog_id required when passing in shard config filepath; please set the active tree ID via the `--trillian_log_server.tlog_id` flag")
-	}
-	// otherwise, try to read contents of the sharding config
-	ranges, err := logRangesFromPath(path)
+	return NewLogRangesFromStore(ctx, logClient, FileStore(path), treeID)
+}
+
+// NewLogRangesFromStore reads the sharding config from the store. The length
+// of each inactive shard is checked against its tree, as a wrong length would
+// change the virtual index of every later entry.
+func NewLogRangesFromStore(ctx context.Context, logClient trillian.TrillianLogClient, store Store, treeID uint) (LogRanges, error) {
+	config, err := store.ReadConfig(ctx)
 	if err != nil {
-		return LogRanges{}, fmt.Errorf("log ranges from path: %w", err)
+		return LogRanges{}, fmt.Errorf("log ranges from %v: %w", store, err)
+	}
+	active := int64(treeID)
+	if config.ActiveTreeID != 0 {
+		if treeID != 0 && config.ActiveTreeID != active {
+			log.Logger.Warnf("Using active tree %d from sharding config instead of tlog_id %d", config.ActiveTreeID, treeID)
+		}
+		active = config.ActiveTreeID
+	}
+	if active == 0 {
+		return LogRanges{}, errors.New("non-zero tlog_id required when passing in shard config filepath; please set the active tree ID via the `--trillian_log_server.tlog_id` flag")
 	}
+	ranges := config.Inactive
 	for i, r := range ranges {
 		r, err := updateRange(ctx, logClient, r)
 		if err != nil {
@@ -68,44 +103,91 @@ func NewLogRanges(ctx context.Context, logClient trillian.TrillianLogClient, pat
 	log.Logger.Info("Ranges: %v", ranges)
 	return LogRanges{
 		inactive: ranges,
-		active:   int64(treeID),
+		active:   active,
 	}, nil
 }
 
 func logRangesFromPath(path string) (Ranges, error) {
+	config, err := ReadConfig(path)
+	if err != nil {
+		return Ranges{}, err
+	}
+	return config.Inactive, nil
+}
+
+// ReadConfig reads a sharding config file in either of its formats
+func ReadConfig(path string) (Config, error) {
 	var ranges Ranges
 	contents, err := ioutil.ReadFile(path)
 	if err != nil {
-		return Ranges{}, err
+		return Config{}, err
 	}
 	if string(contents) == "" {
 		log.Logger.Info("Sharding config file contents empty, skipping init of logRange map")
-		return Ranges{}, nil
+		return Config{Inactive: Ranges{}}, nil
 	}
 	if err := yaml.Unmarshal(contents, &ranges); err != nil {
 		// Try to use JSON
 		if jerr := json.Unmarshal(contents, &ranges); jerr == nil {
-			return ranges, nil
+			return Config{Inactive: ranges}, nil
 		}
-		return Ranges{}, err
+		// Otherwise the file may be a document including the active tree
+		var config Config
+		if cerr := yaml.Unmarshal(contents, &config); cerr == nil {
+			return config, nil
+		}
+		return Config{}, err
+	}
+	return Config{Inactive: ranges}, nil
+}
+
+// WriteConfig replaces the sharding config file with the given config. The
+// file is replaced atomically, so that readers never see a partial config.
+func WriteConfig(path string, config Config) error {
+	contents, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(contents); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
 	}
-	return ranges, nil
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
 }
 
-// updateRange fills in any missing information about the range
+// updateRange fills in any missing information about the range, and checks
+// the range against the tree
 func updateRange(ctx context.Context, logClient trillian.TrillianLogClient, r LogRange) (LogRange, error) {
+	resp, err := logClient.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: r.TreeID})
+	if err != nil {
+		return LogRange{}, fmt.Errorf("getting signed log root for tree %d: %w", r.TreeID, err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
+		return LogRange{}, err
+	}
 	// If a tree length wasn't passed in, get it ourselves
 	if r.TreeLength == 0 {
-		resp, err := logClient.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: r.TreeID})
-		if err != nil {
-			return LogRange{}, fmt.Errorf("getting signed log root for tree %d: %w", r.TreeID, err)
-		}
-		var root types.LogRootV1
-		if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
-			return LogRange{}, err
-		}
 		r.TreeLength = int64(root.TreeSize)
 	}
+	if uint64(r.TreeLength) != root.TreeSize {
+		return LogRange{}, fmt.Errorf("tree %d has length %d in the sharding config but size %d", r.TreeID, r.TreeLength, root.TreeSize)
+	}
 	// If a public key was provided, decode it
 	if r.EncodedPublicKey != "" {
 		decoded, err := base64.StdEncoding.DecodeString(r.EncodedPublicKey)
@@ -114,9 +196,51 @@ func updateRange(ctx context.Context, logClient trillian.TrillianLogClient, r Lo
 		}
 		r.decodedPublicKey = string(decoded)
 	}
+	if r.SignedCheckpoint != "" {
+		var checkpoint util.SignedCheckpoint
+		if err := checkpoint.UnmarshalText([]byte(r.SignedCheckpoint)); err != nil {
+			return LogRange{}, fmt.Errorf("parsing final checkpoint of tree %d: %w", r.TreeID, err)
+		}
+		if checkpoint.Size != root.TreeSize || !bytes.Equal(checkpoint.Hash, root.RootHash) {
+			return LogRange{}, fmt.Errorf("final checkpoint of tree %d doesn't match its root", r.TreeID)
+		}
+		switch {
+		case r.decodedPublicKey != "":
+			pub, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(r.decodedPublicKey))
+			if err != nil {
+				return LogRange{}, fmt.Errorf("parsing public key of tree %d: %w", r.TreeID, err)
+			}
+			verifier, err := signature.LoadVerifier(pub, crypto.SHA256)
+			if err != nil {
+				return LogRange{}, fmt.Errorf("loading public key of tree %d: %w", r.TreeID, err)
+			}
+			if err := r.VerifyCheckpoint(verifier); err != nil {
+				return LogRange{}, err
+			}
+		case r.Signer == "":
+			return LogRange{}, fmt.Errorf("final checkpoint of tree %d has no public key or signer to verify it with", r.TreeID)
+		}
+		// otherwise it's verified once the signer of the tree is loaded
+	}
 	return r, nil
 }
 
+// VerifyCheckpoint verifies the final checkpoint of the tree, if there is
+// one, is signed by the verifier
+func (r LogRange) VerifyCheckpoint(verifier signature.Verifier) error {
+	if r.SignedCheckpoint == "" {
+		return nil
+	}
+	var checkpoint util.SignedCheckpoint
+	if err := checkpoint.UnmarshalText([]byte(r.SignedCheckpoint)); err != nil {
+		return fmt.Errorf("parsing final checkpoint of tree %d: %w", r.TreeID, err)
+	}
+	if !checkpoint.Verify(verifier) {
+		return fmt.Errorf("final checkpoint of tree %d isn't signed by the key of the tree", r.TreeID)
+	}
+	return nil
+}
+
 func (l *LogRanges) ResolveVirtualIndex(index int) (int64, int64) {
 	indexLeft := index
 	for _, l := range l.inactive {
@@ -168,6 +292,29 @@ func (l *LogRanges) GetActive() int64 {
 	return l.active
 }
 
+// Extends returns an error unless l keeps the virtual index mapping of prev.
+// The inactive ranges of prev must be unchanged, and if the active tree has
+// changed, prev's active tree must now be the last inactive range.
+func (l *LogRanges) Extends(prev LogRanges) error {
+	n := len(prev.inactive)
+	if len(l.inactive) < n {
+		return fmt.Errorf("%d inactive ranges were removed", n-len(l.inactive))
+	}
+	for i, r := range prev.inactive {
+		if l.inactive[i].TreeID != r.TreeID || l.inactive[i].TreeLength != r.TreeLength {
+			return fmt.Errorf("inactive range %d changed from %d=%d to %d=%d", i, r.TreeID, r.TreeLength, l.inactive[i].TreeID, l.inactive[i].TreeLength)
+		}
+	}
+	switch {
+	case l.active == prev.active && len(l.inactive) == n:
+		return nil
+	case l.active != prev.active && len(l.inactive) == n+1 && l.inactive[n].TreeID == prev.active:
+		return nil
+	default:
+		return fmt.Errorf("active tree %d must become the last inactive range when switching to tree %d", prev.active, l.active)
+	}
+}
+
 func (l *LogRanges) String() string {
 	ranges := []string{}
 	for _, r := range l.inactive {
@@ -17,22 +17,76 @@ package sharding
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/google/trillian"
-	"google.golang.org/grpc"
+	"github.com/google/trillian/types"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+
+	"github.com/sigstore/rekor/pkg/faketrillian"
+	"github.com/sigstore/rekor/pkg/util"
 )
 
+func latestRoot(t *testing.T, f *faketrillian.Trillian, treeID int64) types.LogRootV1 {
+	t.Helper()
+	resp, err := f.GetLatestSignedLogRoot(context.Background(), &trillian.GetLatestSignedLogRootRequest{LogId: treeID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func mustKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv
+}
+
+// fakeLog returns a tree of the fake Trillian with the given number of leaves
+func fakeLog(t *testing.T, f *faketrillian.Trillian, leaves int) int64 {
+	t.Helper()
+	ctx := context.Background()
+	treeID, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < leaves; i++ {
+		if _, err := f.QueueLeaf(ctx, &trillian.QueueLeafRequest{
+			LogId: treeID,
+			Leaf:  &trillian.LogLeaf{LeafValue: []byte(fmt.Sprintf("leaf %d", i))},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return treeID
+}
+
 func TestNewLogRanges(t *testing.T) {
-	contents := `
-- treeID: 0001
+	f := faketrillian.New(0)
+	first, second := fakeLog(t, f, 3), fakeLog(t, f, 4)
+	contents := fmt.Sprintf(`
+- treeID: %d
   treeLength: 3
   encodedPublicKey: c2hhcmRpbmcK
-- treeID: 0002
-  treeLength: 4`
+- treeID: %d`, first, second)
 	file := filepath.Join(t.TempDir(), "sharding-config")
 	if err := ioutil.WriteFile(file, []byte(contents), 0644); err != nil {
 		t.Fatal(err)
@@ -41,19 +95,18 @@ func TestNewLogRanges(t *testing.T) {
 	expected := LogRanges{
 		inactive: []LogRange{
 			{
-				TreeID:           1,
+				TreeID:           first,
 				TreeLength:       3,
 				EncodedPublicKey: "c2hhcmRpbmcK",
 				decodedPublicKey: "sharding\n",
 			}, {
-				TreeID:     2,
+				TreeID:     second,
 				TreeLength: 4,
 			}},
 		active: int64(45),
 	}
 	ctx := context.Background()
-	tc := trillian.NewTrillianLogClient(&grpc.ClientConn{})
-	got, err := NewLogRanges(ctx, tc, file, treeID)
+	got, err := NewLogRanges(ctx, f, file, treeID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -65,6 +118,79 @@ func TestNewLogRanges(t *testing.T) {
 	}
 }
 
+func TestNewLogRangesFromStore_Mismatch(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	treeID := fakeLog(t, f, 3)
+	root := latestRoot(t, f, treeID)
+	s, err := signature.LoadECDSASignerVerifier(mustKey(t), crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := signature.LoadECDSASignerVerifier(mustKey(t), crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedKey := func(sv signature.SignerVerifier) string {
+		pub, err := sv.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pemKey, err := cryptoutils.MarshalPublicKeyToPEM(pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.StdEncoding.EncodeToString(pemKey)
+	}
+	key := encodedKey(s)
+	checkpoint := func(size uint64, hash []byte) string {
+		sc, err := util.CreateSignedCheckpoint(util.Checkpoint{Origin: "rekor.test", Size: size, Hash: hash})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sc.Sign("rekor.test", s, options.WithContext(ctx)); err != nil {
+			t.Fatal(err)
+		}
+		b, err := sc.SignedNote.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	tests := []struct {
+		name    string
+		r       LogRange
+		wantErr bool
+	}{
+		{name: "length from tree", r: LogRange{TreeID: treeID}},
+		{name: "matching length", r: LogRange{TreeID: treeID, TreeLength: 3}},
+		{name: "wrong length", r: LogRange{TreeID: treeID, TreeLength: 2}, wantErr: true},
+		{name: "matching checkpoint", r: LogRange{TreeID: treeID, TreeLength: 3, EncodedPublicKey: key, SignedCheckpoint: checkpoint(3, root.RootHash)}},
+		{name: "checkpoint of another size", r: LogRange{TreeID: treeID, TreeLength: 3, EncodedPublicKey: key, SignedCheckpoint: checkpoint(2, root.RootHash)}, wantErr: true},
+		{name: "checkpoint of another root", r: LogRange{TreeID: treeID, TreeLength: 3, EncodedPublicKey: key, SignedCheckpoint: checkpoint(3, make([]byte, 32))}, wantErr: true},
+		{name: "invalid checkpoint", r: LogRange{TreeID: treeID, TreeLength: 3, EncodedPublicKey: key, SignedCheckpoint: "checkpoint"}, wantErr: true},
+		{name: "checkpoint signed with another key", r: LogRange{TreeID: treeID, TreeLength: 3, EncodedPublicKey: encodedKey(other), SignedCheckpoint: checkpoint(3, root.RootHash)}, wantErr: true},
+		{name: "checkpoint without a key", r: LogRange{TreeID: treeID, TreeLength: 3, SignedCheckpoint: checkpoint(3, root.RootHash)}, wantErr: true},
+		{name: "checkpoint verified with the key of its signer", r: LogRange{TreeID: treeID, TreeLength: 3, Signer: "memory", SignedCheckpoint: checkpoint(3, root.RootHash)}},
+		{name: "unknown tree", r: LogRange{TreeID: 1234, TreeLength: 3}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := FileStore(filepath.Join(t.TempDir(), "sharding-config"))
+			if err := store.WriteConfig(ctx, Config{}, Config{Inactive: Ranges{tt.r}}); err != nil {
+				t.Fatal(err)
+			}
+			got, err := NewLogRangesFromStore(ctx, f, store, 45)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewLogRangesFromStore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.TotalInactiveLength() != 3 {
+				t.Errorf("expected length 3, got %d", got.TotalInactiveLength())
+			}
+		})
+	}
+}
+
 func TestLogRangesFromPath(t *testing.T) {
 	contents := `
 - treeID: 0001
@@ -122,6 +248,84 @@ func TestLogRangesFromPathJSON(t *testing.T) {
 	}
 }
 
+func TestConfig(t *testing.T) {
+	f := faketrillian.New(0)
+	first, second := fakeLog(t, f, 3), fakeLog(t, f, 4)
+	file := filepath.Join(t.TempDir(), "sharding-config")
+	expected := Config{
+		ActiveTreeID: 3,
+		Inactive: Ranges{
+			{TreeID: first, TreeLength: 3, EncodedPublicKey: "c2hhcmRpbmcK"},
+			{TreeID: second, TreeLength: 4},
+		},
+	}
+	if err := WriteConfig(file, expected); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadConfig(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v got %v", expected, got)
+	}
+	// the active tree in the config takes precedence over the flag
+	ranges, err := NewLogRanges(context.Background(), f, file, 45)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ranges.ActiveTreeID() != 3 || ranges.TotalInactiveLength() != 7 {
+		t.Fatalf("unexpected ranges %v", ranges.String())
+	}
+	// the list of inactive ranges is still the active tree's config
+	if got, err := logRangesFromPath(file); err != nil || !reflect.DeepEqual(expected.Inactive, got) {
+		t.Fatalf("expected %v got %v, %v", expected.Inactive, got, err)
+	}
+}
+
+func TestLogRanges_Extends(t *testing.T) {
+	prev := LogRanges{
+		inactive: []LogRange{{TreeID: 1, TreeLength: 17}},
+		active:   2,
+	}
+	for _, tt := range []struct {
+		description string
+		next        LogRanges
+		shouldErr   bool
+	}{
+		{
+			description: "unchanged",
+			next:        LogRanges{inactive: []LogRange{{TreeID: 1, TreeLength: 17, EncodedPublicKey: "a2V5Cg=="}}, active: 2},
+		}, {
+			description: "rotated",
+			next:        LogRanges{inactive: []LogRange{{TreeID: 1, TreeLength: 17}, {TreeID: 2, TreeLength: 5}}, active: 3},
+		}, {
+			description: "changed length",
+			next:        LogRanges{inactive: []LogRange{{TreeID: 1, TreeLength: 16}}, active: 2},
+			shouldErr:   true,
+		}, {
+			description: "removed range",
+			next:        LogRanges{active: 2},
+			shouldErr:   true,
+		}, {
+			description: "new active tree without the previous one",
+			next:        LogRanges{inactive: []LogRange{{TreeID: 1, TreeLength: 17}}, active: 3},
+			shouldErr:   true,
+		}, {
+			description: "range added to the active tree",
+			next:        LogRanges{inactive: []LogRange{{TreeID: 1, TreeLength: 17}, {TreeID: 4, TreeLength: 5}}, active: 2},
+			shouldErr:   true,
+		},
+	} {
+		t.Run(tt.description, func(t *testing.T) {
+			err := tt.next.Extends(prev)
+			if (err != nil) != tt.shouldErr {
+				t.Errorf("Extends() = %v, shouldErr %v", err, tt.shouldErr)
+			}
+		})
+	}
+}
+
 func TestLogRanges_ResolveVirtualIndex(t *testing.T) {
 	lrs := LogRanges{
 		inactive: []LogRange{
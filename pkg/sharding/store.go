@@ -0,0 +1,164 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	radix "github.com/mediocregopher/radix/v4"
+)
+
+// A Store holds the sharding config of the log
+type Store interface {
+	ReadConfig(ctx context.Context) (Config, error)
+	// WriteConfig replaces the config prev, as read from the store, with
+	// config. It fails with ErrConfigChanged if the stored config is no
+	// longer prev, such as when another rotation has written it since.
+	WriteConfig(ctx context.Context, prev, config Config) error
+}
+
+// ErrConfigChanged is returned when writing a sharding config that was
+// changed since it was read
+var ErrConfigChanged = errors.New("sharding config changed since it was read")
+
+// sameConfig returns whether two configs are the same, treating a missing
+// list of inactive ranges as empty
+func sameConfig(a, b Config) bool {
+	if a.ActiveTreeID != b.ActiveTreeID || len(a.Inactive) != len(b.Inactive) {
+		return false
+	}
+	for i := range a.Inactive {
+		if a.Inactive[i] != b.Inactive[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// FileStore is the path of a sharding config file, which must be copied to
+// every replica
+type FileStore string
+
+func (f FileStore) ReadConfig(_ context.Context) (Config, error) {
+	return ReadConfig(string(f))
+}
+
+// WriteConfig checks the file still holds prev before replacing it. The file
+// could still be changed between the check and the write, so the Redis store
+// should be used if more than one process writes the config.
+func (f FileStore) WriteConfig(_ context.Context, prev, config Config) error {
+	current, err := ReadConfig(string(f))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		current = Config{}
+	case err != nil:
+		return err
+	}
+	if !sameConfig(current, prev) {
+		return ErrConfigChanged
+	}
+	return WriteConfig(string(f), config)
+}
+
+func (f FileStore) String() string {
+	return string(f)
+}
+
+// RedisConfigKey is the key of the sharding config in Redis
+const RedisConfigKey = "rekor:sharding_config"
+
+// RedisStore holds the sharding config in Redis, where it is shared by every
+// replica using the same index
+type RedisStore struct {
+	Client radix.Client
+}
+
+func (r RedisStore) ReadConfig(ctx context.Context) (Config, error) {
+	return readRedisConfig(ctx, r.Client)
+}
+
+func readRedisConfig(ctx context.Context, c radix.Client) (Config, error) {
+	var contents []byte
+	mb := radix.Maybe{Rcv: &contents}
+	if err := c.Do(ctx, radix.Cmd(&mb, "GET", RedisConfigKey)); err != nil {
+		return Config{}, fmt.Errorf("reading sharding config from redis: %w", err)
+	}
+	// until the log is first rotated, there are no inactive shards
+	if mb.Null {
+		return Config{Inactive: Ranges{}}, nil
+	}
+	var config Config
+	if err := json.Unmarshal(contents, &config); err != nil {
+		return Config{}, fmt.Errorf("parsing sharding config from redis: %w", err)
+	}
+	return config, nil
+}
+
+// WriteConfig watches the key of the config while checking it still holds
+// prev, so that the write is discarded if it's changed before it's written
+func (r RedisStore) WriteConfig(ctx context.Context, prev, config Config) error {
+	contents, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	err = r.Client.Do(ctx, radix.WithConn(RedisConfigKey, func(ctx context.Context, c radix.Conn) error {
+		if err := c.Do(ctx, radix.Cmd(nil, "WATCH", RedisConfigKey)); err != nil {
+			return err
+		}
+		current, err := readRedisConfig(ctx, c)
+		if err == nil && !sameConfig(current, prev) {
+			err = ErrConfigChanged
+		}
+		if err != nil {
+			_ = c.Do(ctx, radix.Cmd(nil, "UNWATCH"))
+			return err
+		}
+		if err := c.Do(ctx, radix.Cmd(nil, "MULTI")); err != nil {
+			return err
+		}
+		if err := c.Do(ctx, radix.Cmd(nil, "SET", RedisConfigKey, string(contents))); err != nil {
+			_ = c.Do(ctx, radix.Cmd(nil, "DISCARD"))
+			return err
+		}
+		// EXEC replies with null if the watched key was changed
+		var replies []string
+		mb := radix.Maybe{Rcv: &replies}
+		if err := c.Do(ctx, radix.Cmd(&mb, "EXEC")); err != nil {
+			return err
+		}
+		if mb.Null {
+			return ErrConfigChanged
+		}
+		return nil
+	}))
+	if err != nil {
+		return fmt.Errorf("writing sharding config to redis: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connections to Redis
+func (r RedisStore) Close() error {
+	return r.Client.Close()
+}
+
+func (r RedisStore) String() string {
+	return fmt.Sprintf("redis://%s/%s", r.Client.Addr(), RedisConfigKey)
+}
@@ -0,0 +1,154 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharding
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	radix "github.com/mediocregopher/radix/v4"
+)
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+	values := map[string]string{}
+	// the stub runs transactions, and interfere is called before one is
+	// executed to write the watched key as another client would
+	var (
+		watched, dirty bool
+		queued         []string
+		interfere      func()
+	)
+	store := RedisStore{Client: radix.NewStubConn("tcp", "redis.test:6379", func(_ context.Context, args []string) interface{} {
+		switch args[0] {
+		case "GET":
+			if v, ok := values[args[1]]; ok {
+				return v
+			}
+			return nil
+		case "WATCH":
+			watched, dirty = true, false
+			return "OK"
+		case "UNWATCH":
+			watched = false
+			return "OK"
+		case "MULTI":
+			queued = nil
+			return "OK"
+		case "SET":
+			if watched {
+				queued = args
+				return "QUEUED"
+			}
+			values[args[1]] = args[2]
+			return "OK"
+		case "EXEC":
+			if interfere != nil {
+				interfere()
+				dirty = true
+			}
+			watched = false
+			if dirty {
+				return nil
+			}
+			values[queued[1]] = queued[2]
+			return []string{"OK"}
+		}
+		t.Fatalf("unexpected command %v", args)
+		return nil
+	})}
+
+	got, err := store.ReadConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, Config{Inactive: Ranges{}}) {
+		t.Errorf("expected empty config before the first write, got %+v", got)
+	}
+
+	expected := Config{
+		ActiveTreeID: 3,
+		Inactive: Ranges{
+			{TreeID: 1, TreeLength: 3, EncodedPublicKey: "c2hhcmRpbmcK", SignedCheckpoint: "rekor.test\n3\n"},
+			{TreeID: 2, TreeLength: 4},
+		},
+	}
+	if err := store.WriteConfig(ctx, got, expected); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := values[RedisConfigKey]; !ok {
+		t.Fatalf("expected config to be stored at %s, got %v", RedisConfigKey, values)
+	}
+	got, err = store.ReadConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v got %+v", expected, got)
+	}
+
+	// a config read before the last write is not written
+	next := Config{ActiveTreeID: 4, Inactive: append(Ranges{}, expected.Inactive...)}
+	if err := store.WriteConfig(ctx, Config{Inactive: Ranges{}}, next); !errors.Is(err, ErrConfigChanged) {
+		t.Errorf("expected stale config to fail with %v, got %v", ErrConfigChanged, err)
+	}
+	// nor is a config written while the write is in progress
+	interfere = func() {
+		values[RedisConfigKey] = `{"activeTreeID":5,"inactive":[]}`
+	}
+	if err := store.WriteConfig(ctx, expected, next); !errors.Is(err, ErrConfigChanged) {
+		t.Errorf("expected concurrent write to fail with %v, got %v", ErrConfigChanged, err)
+	}
+	interfere = nil
+	if got, err := store.ReadConfig(ctx); err != nil || got.ActiveTreeID != 5 {
+		t.Errorf("expected concurrent write to be kept, got %+v, %v", got, err)
+	}
+
+	if s := store.String(); s != "redis://redis.test:6379/"+RedisConfigKey {
+		t.Errorf("unexpected store %s", s)
+	}
+
+	values[RedisConfigKey] = "not json"
+	if _, err := store.ReadConfig(ctx); err == nil {
+		t.Error("expected invalid config to fail")
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store := FileStore(filepath.Join(t.TempDir(), "sharding-config"))
+	first := Config{ActiveTreeID: 2, Inactive: Ranges{{TreeID: 1, TreeLength: 3}}}
+	if err := store.WriteConfig(ctx, Config{}, first); err != nil {
+		t.Fatal(err)
+	}
+	second := Config{ActiveTreeID: 3, Inactive: Ranges{{TreeID: 1, TreeLength: 3}, {TreeID: 2, TreeLength: 4}}}
+	if err := store.WriteConfig(ctx, Config{}, second); !errors.Is(err, ErrConfigChanged) {
+		t.Errorf("expected stale config to fail with %v, got %v", ErrConfigChanged, err)
+	}
+	if err := store.WriteConfig(ctx, first, second); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.ReadConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, second) {
+		t.Errorf("expected %+v got %+v", second, got)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+var (
+	oidExtKeyUsage            = asn1.ObjectIdentifier{2, 5, 29, 37}
+	oidKeyPurposeTimestamping = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 8}
+)
+
+// NewTimestampingCertWithChain generates an in-memory root CA and issues a
+// timestamping certificate for the public key of the signer. It is intended
+// for use with the memory signer; production deployments provide a chain
+// issued by a real CA.
+func NewTimestampingCertWithChain(ctx context.Context, signer signature.Signer) ([]*x509.Certificate, error) {
+	pub, err := signer.PublicKey(options.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("getting public key: %w", err)
+	}
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	rootSerial, err := cryptoutils.GenerateSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          rootSerial,
+		Subject:               pkix.Name{CommonName: "Test TSA Root", Organization: []string{"local"}},
+		NotBefore:             time.Now().Add(-5 * time.Minute),
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating root certificate: %w", err)
+	}
+	root, err := x509.ParseCertificate(rootDER)
+	if err != nil {
+		return nil, err
+	}
+
+	// RFC 3161 requires the extended key usage extension to be critical, which
+	// the x509 package does not do for ExtKeyUsage, so it is set explicitly
+	eku, err := asn1.Marshal([]asn1.ObjectIdentifier{oidKeyPurposeTimestamping})
+	if err != nil {
+		return nil, err
+	}
+	leafSerial, err := cryptoutils.GenerateSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber:    leafSerial,
+		Subject:         pkix.Name{CommonName: "Test TSA Timestamping", Organization: []string{"local"}},
+		NotBefore:       time.Now().Add(-5 * time.Minute),
+		NotAfter:        time.Now().AddDate(10, 0, 0),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtraExtensions: []pkix.Extension{{Id: oidExtKeyUsage, Critical: true, Value: eku}},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, root, pub, rootKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating timestamping certificate: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(leafDER)
+	if err != nil {
+		return nil, err
+	}
+	return []*x509.Certificate{leaf, root}, nil
+}
+
+// VerifyTimestampingCertChain checks that the chain starts with a
+// timestamping certificate for the signer's public key and that it chains up
+// to the last certificate, which is taken as the root.
+func VerifyTimestampingCertChain(ctx context.Context, certs []*x509.Certificate, signer signature.Signer) error {
+	if len(certs) < 2 {
+		return errors.New("certificate chain must contain the timestamping certificate and its root")
+	}
+	leaf := certs[0]
+	pub, err := signer.PublicKey(options.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("getting public key: %w", err)
+	}
+	if err := cryptoutils.EqualKeys(pub, leaf.PublicKey); err != nil {
+		return fmt.Errorf("timestamping certificate does not match signer: %w", err)
+	}
+
+	// the extended key usage must be critical and only allow timestamping
+	var ekuCritical bool
+	for _, ext := range leaf.Extensions {
+		if ext.Id.Equal(oidExtKeyUsage) {
+			ekuCritical = ext.Critical
+		}
+	}
+	if !ekuCritical || len(leaf.ExtKeyUsage) != 1 || leaf.ExtKeyUsage[0] != x509.ExtKeyUsageTimeStamping || len(leaf.UnknownExtKeyUsage) != 0 {
+		return errors.New("timestamping certificate must have a critical extended key usage of only timestamping")
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(certs[len(certs)-1])
+	intermediates := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}); err != nil {
+		return fmt.Errorf("verifying certificate chain: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTimestampingCertWithChain(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	certChain, err := NewTimestampingCertWithChain(ctx, m)
+	if err != nil {
+		t.Fatalf("generating chain: %v", err)
+	}
+	if err := VerifyTimestampingCertChain(ctx, certChain, m); err != nil {
+		t.Errorf("unexpected error verifying chain: %v", err)
+	}
+
+	other, err := NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyTimestampingCertChain(ctx, certChain, other); err == nil {
+		t.Error("expected error verifying chain for a different signer")
+	}
+	if err := VerifyTimestampingCertChain(ctx, certChain[:1], m); err == nil {
+		t.Error("expected error verifying chain without its root")
+	}
+	if err := VerifyTimestampingCertChain(ctx, certChain[1:], m); err == nil {
+		t.Error("expected error verifying chain without the timestamping certificate")
+	}
+}
@@ -0,0 +1,99 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+)
+
+// ErrNotFound is returned by a Fetcher when the requested object does not exist
+var ErrNotFound = errors.New("not found")
+
+// Fetcher retrieves published objects by their path in the tlog-tiles layout
+type Fetcher interface {
+	Fetch(ctx context.Context, path string) ([]byte, error)
+}
+
+// BucketFetcher reads a published log directly from a blob bucket
+type BucketFetcher struct {
+	Bucket *blob.Bucket
+}
+
+func (b *BucketFetcher) Fetch(ctx context.Context, path string) ([]byte, error) {
+	data, err := b.Bucket.ReadAll(ctx, path)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil, fmt.Errorf("%s: %w", path, ErrNotFound)
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// HTTPFetcher reads a published log over HTTP(S), e.g. from a CDN in front of the bucket
+type HTTPFetcher struct {
+	// BaseURL is the URL prefix the log is served under
+	BaseURL string
+	// Client is the HTTP client to use; if nil, http.DefaultClient is used
+	Client *http.Client
+}
+
+func (h *HTTPFetcher) Fetch(ctx context.Context, path string) ([]byte, error) {
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	url := strings.TrimSuffix(h.BaseURL, "/") + "/" + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return ioutil.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", url, ErrNotFound)
+	default:
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", url, resp.Status)
+	}
+}
+
+// overlayFetcher serves objects that are about to be written before falling
+// back to the objects already published
+type overlayFetcher struct {
+	pending map[string][]byte
+	Fetcher
+}
+
+func (o *overlayFetcher) Fetch(ctx context.Context, path string) ([]byte, error) {
+	if b, ok := o.pending[path]; ok {
+		return b, nil
+	}
+	return o.Fetcher.Fetch(ctx, path)
+}
@@ -0,0 +1,139 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiles
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The layout of a published log follows the tlog-tiles specification
+// (https://c2sp.org/tlog-tiles):
+//
+//	checkpoint                  the latest signed checkpoint
+//	tile/<L>/<N>[.p/<W>]        Merkle tree hashes at level L
+//	tile/entries/<N>[.p/<W>]    the leaf values of the log entries
+//
+// A tile holds up to TileWidth hashes (or entries); a tile with fewer than
+// TileWidth elements is a partial tile and carries its width W in the path.
+// Full tiles never change once written, while partial tiles and the
+// checkpoint are superseded as the log grows.
+
+const (
+	// TileHeight is the number of Merkle tree levels covered by a single tile
+	TileHeight = 8
+	// TileWidth is the maximum number of hashes or entries stored in a tile
+	TileWidth = 1 << TileHeight
+	// HashSize is the size of a RFC 6962 SHA-256 node hash
+	HashSize = 32
+
+	// CheckpointPath is the location of the latest signed checkpoint
+	CheckpointPath = "checkpoint"
+
+	// maxEntrySize is the largest entry that can be length-prefixed in an entry bundle
+	maxEntrySize = 1<<16 - 1
+)
+
+// TilePath returns the location of the tile at the given level and index,
+// containing width hashes.
+func TilePath(level, index, width uint64) string {
+	return fmt.Sprintf("tile/%d/%s", level, encodeIndex(index, width))
+}
+
+// EntriesPath returns the location of the entry bundle at the given index,
+// containing width entries.
+func EntriesPath(index, width uint64) string {
+	return fmt.Sprintf("tile/entries/%s", encodeIndex(index, width))
+}
+
+// encodeIndex splits the index into path elements of three digits, prefixing
+// all but the last element with an "x", so that no directory grows beyond 1000
+// children. Partial tiles are suffixed with their width.
+func encodeIndex(index, width uint64) string {
+	elements := []string{fmt.Sprintf("%03d", index%1000)}
+	for index >= 1000 {
+		index /= 1000
+		elements = append([]string{fmt.Sprintf("x%03d", index%1000)}, elements...)
+	}
+	p := strings.Join(elements, "/")
+	if width < TileWidth {
+		p += fmt.Sprintf(".p/%d", width)
+	}
+	return p
+}
+
+// tileWidth returns the number of elements in tile index of a level holding
+// count elements in total.
+func tileWidth(index, count uint64) uint64 {
+	if w := count - index*TileWidth; w < TileWidth {
+		return w
+	}
+	return TileWidth
+}
+
+// levelSize returns the number of hashes stored in tiles at the given tile
+// level, for a tree of the given size.
+func levelSize(level, size uint64) uint64 {
+	return size >> (level * TileHeight)
+}
+
+// MarshalEntryBundle encodes the entries as a sequence of big-endian uint16
+// length prefixed values.
+func MarshalEntryBundle(entries [][]byte) ([]byte, error) {
+	var b []byte
+	for _, e := range entries {
+		if len(e) > maxEntrySize {
+			return nil, fmt.Errorf("entry of %d bytes is too large for an entry bundle", len(e))
+		}
+		var l [2]byte
+		binary.BigEndian.PutUint16(l[:], uint16(len(e)))
+		b = append(b, l[:]...)
+		b = append(b, e...)
+	}
+	return b, nil
+}
+
+// UnmarshalEntryBundle decodes an entry bundle into its entries
+func UnmarshalEntryBundle(b []byte) ([][]byte, error) {
+	var entries [][]byte
+	for len(b) > 0 {
+		if len(b) < 2 {
+			return nil, errors.New("truncated entry length")
+		}
+		l := int(binary.BigEndian.Uint16(b))
+		b = b[2:]
+		if len(b) < l {
+			return nil, errors.New("truncated entry")
+		}
+		entries = append(entries, b[:l])
+		b = b[l:]
+	}
+	return entries, nil
+}
+
+// unmarshalTile splits a tile into its node hashes
+func unmarshalTile(b []byte, width uint64) ([][]byte, error) {
+	if uint64(len(b)) != width*HashSize {
+		return nil, fmt.Errorf("tile has %d bytes, expected %d", len(b), width*HashSize)
+	}
+	hashes := make([][]byte, width)
+	for i := range hashes {
+		hashes[i] = b[i*HashSize : (i+1)*HashSize]
+	}
+	return hashes, nil
+}
@@ -0,0 +1,70 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiles
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTilePath(t *testing.T) {
+	tests := []struct {
+		level, index, width uint64
+		want                string
+	}{
+		{level: 0, index: 0, width: 256, want: "tile/0/000"},
+		{level: 0, index: 7, width: 3, want: "tile/0/007.p/3"},
+		{level: 1, index: 1000, width: 256, want: "tile/1/x001/000"},
+		{level: 2, index: 1234067, width: 256, want: "tile/2/x001/x234/067"},
+		{level: 0, index: 1234067, width: 255, want: "tile/0/x001/x234/067.p/255"},
+	}
+	for _, tt := range tests {
+		if got := TilePath(tt.level, tt.index, tt.width); got != tt.want {
+			t.Errorf("TilePath(%d, %d, %d) = %s, want %s", tt.level, tt.index, tt.width, got, tt.want)
+		}
+	}
+
+	if got, want := EntriesPath(1234, 1), "tile/entries/x001/234.p/1"; got != want {
+		t.Errorf("EntriesPath() = %s, want %s", got, want)
+	}
+}
+
+func TestEntryBundle(t *testing.T) {
+	entries := [][]byte{[]byte("first"), {}, bytes.Repeat([]byte("a"), 300)}
+	b, err := MarshalEntryBundle(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalEntryBundle(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(entries) {
+		t.Fatalf("got %d entries, want %d", len(got), len(entries))
+	}
+	for i := range entries {
+		if !bytes.Equal(got[i], entries[i]) {
+			t.Errorf("entry %d = %q, want %q", i, got[i], entries[i])
+		}
+	}
+
+	if _, err := UnmarshalEntryBundle(b[:len(b)-1]); err == nil {
+		t.Error("expected error for truncated bundle")
+	}
+	if _, err := MarshalEntryBundle([][]byte{make([]byte, maxEntrySize+1)}); err == nil {
+		t.Error("expected error for oversized entry")
+	}
+}
@@ -0,0 +1,158 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiles
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+// ReadCheckpoint fetches and parses the latest published checkpoint. Callers
+// MUST verify the signature on the checkpoint.
+func ReadCheckpoint(ctx context.Context, f Fetcher) (*util.SignedCheckpoint, error) {
+	b, err := f.Fetch(ctx, CheckpointPath)
+	if err != nil {
+		return nil, err
+	}
+	sth := util.SignedCheckpoint{}
+	if err := sth.UnmarshalText(b); err != nil {
+		return nil, fmt.Errorf("unmarshalling checkpoint: %w", err)
+	}
+	return &sth, nil
+}
+
+// ReadEntry fetches the leaf value at index from the entry bundles of a tree of the given size
+func ReadEntry(ctx context.Context, f Fetcher, index, size uint64) ([]byte, error) {
+	if index >= size {
+		return nil, fmt.Errorf("index %d out of bounds for tree size %d", index, size)
+	}
+	tile := index / TileWidth
+	b, err := f.Fetch(ctx, EntriesPath(tile, tileWidth(tile, size)))
+	if err != nil {
+		return nil, err
+	}
+	entries, err := UnmarshalEntryBundle(b)
+	if err != nil {
+		return nil, err
+	}
+	offset := index % TileWidth
+	if offset >= uint64(len(entries)) {
+		return nil, fmt.Errorf("entry bundle %d has %d entries, expected more than %d", tile, len(entries), offset)
+	}
+	return entries[offset], nil
+}
+
+// NodeHash computes the hash of the Merkle tree node at the given level and
+// index from the tiles of a tree of the given size. The node must be the root
+// of a perfect subtree contained in the tree.
+func NodeHash(ctx context.Context, f Fetcher, level uint, index, size uint64) ([]byte, error) {
+	if (index+1)<<level > size {
+		return nil, fmt.Errorf("node (%d, %d) is not contained in tree of size %d", level, index, size)
+	}
+	tileLevel := uint64(level / TileHeight)
+	// the node covers 2^span consecutive hashes stored in a single tile
+	span := level % TileHeight
+	first := index << span
+	tile := first / TileWidth
+	width := tileWidth(tile, levelSize(tileLevel, size))
+
+	b, err := f.Fetch(ctx, TilePath(tileLevel, tile, width))
+	if err != nil {
+		return nil, err
+	}
+	hashes, err := unmarshalTile(b, width)
+	if err != nil {
+		return nil, fmt.Errorf("tile %d/%d: %w", tileLevel, tile, err)
+	}
+	offset := first % TileWidth
+	return subtreeHash(hashes[offset : offset+1<<span]), nil
+}
+
+// RootHash computes the root hash of a tree of the given size from its tiles
+func RootHash(ctx context.Context, f Fetcher, size uint64) ([]byte, error) {
+	if size == 0 {
+		return rfc6962.DefaultHasher.EmptyRoot(), nil
+	}
+	ids := compact.RangeNodes(0, size, nil)
+	hashes, err := nodeHashes(ctx, f, ids, size)
+	if err != nil {
+		return nil, err
+	}
+	rng, err := (&compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren}).NewRange(0, size, hashes)
+	if err != nil {
+		return nil, err
+	}
+	return rng.GetRootHash(nil)
+}
+
+// InclusionProof builds the RFC 6962 inclusion proof for the leaf at index in
+// a tree of the given size from its tiles.
+func InclusionProof(ctx context.Context, f Fetcher, index, size uint64) ([][]byte, error) {
+	nodes, err := proof.Inclusion(index, size)
+	if err != nil {
+		return nil, err
+	}
+	return rehash(ctx, f, nodes, size)
+}
+
+// ConsistencyProof builds the RFC 6962 consistency proof between two tree
+// sizes from the tiles of a tree of size2.
+func ConsistencyProof(ctx context.Context, f Fetcher, size1, size2 uint64) ([][]byte, error) {
+	nodes, err := proof.Consistency(size1, size2)
+	if err != nil {
+		return nil, err
+	}
+	return rehash(ctx, f, nodes, size2)
+}
+
+func rehash(ctx context.Context, f Fetcher, nodes proof.Nodes, size uint64) ([][]byte, error) {
+	hashes, err := nodeHashes(ctx, f, nodes.IDs, size)
+	if err != nil {
+		return nil, err
+	}
+	return nodes.Rehash(hashes, rfc6962.DefaultHasher.HashChildren)
+}
+
+func nodeHashes(ctx context.Context, f Fetcher, ids []compact.NodeID, size uint64) ([][]byte, error) {
+	hashes := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		h, err := NodeHash(ctx, f, id.Level, id.Index, size)
+		if err != nil {
+			return nil, fmt.Errorf("fetching node (%d, %d): %w", id.Level, id.Index, err)
+		}
+		hashes = append(hashes, h)
+	}
+	return hashes, nil
+}
+
+// subtreeHash computes the root of a perfect subtree from its leaf-level hashes
+func subtreeHash(hashes [][]byte) []byte {
+	level := make([][]byte, len(hashes))
+	copy(level, hashes)
+	for len(level) > 1 {
+		for i := 0; i < len(level)/2; i++ {
+			level[i] = rfc6962.DefaultHasher.HashChildren(level[2*i], level[2*i+1])
+		}
+		level = level[:len(level)/2]
+	}
+	return level[0]
+}
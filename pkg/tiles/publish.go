@@ -0,0 +1,286 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiles
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"gocloud.dev/blob"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+const (
+	// full tiles are immutable and can be cached forever
+	cacheImmutable = "public, s-maxage=31536000, max-age=31536000, immutable"
+	// partial tiles and the checkpoint are replaced as the log grows
+	cacheMutable = "no-cache"
+
+	// maxLeavesPerRequest bounds the number of leaves requested from Trillian at a time
+	maxLeavesPerRequest = 1000
+)
+
+// Publisher publishes a Trillian tree as static tiles, entry bundles and a
+// signed checkpoint to a blob bucket
+type Publisher struct {
+	logClient trillian.TrillianLogClient
+	treeID    int64
+	bucket    *blob.Bucket
+	signer    signature.Signer
+	hostname  string
+}
+
+// NewPublisher returns a Publisher for the given tree. The hostname and signer
+// must match those of the rekor-server instance serving the tree, so that the
+// published checkpoints match the ones returned by the API.
+func NewPublisher(logClient trillian.TrillianLogClient, treeID int64, bucket *blob.Bucket, signer signature.Signer, hostname string) *Publisher {
+	return &Publisher{
+		logClient: logClient,
+		treeID:    treeID,
+		bucket:    bucket,
+		signer:    signer,
+		hostname:  hostname,
+	}
+}
+
+// Publish extends the published log up to the latest tree head in Trillian
+// and returns the newly published checkpoint. If the log has not grown since
+// the last publication, the previously published checkpoint is returned. If
+// nothing was published yet and the log is empty, nil is returned.
+func (p *Publisher) Publish(ctx context.Context) (*util.SignedCheckpoint, error) {
+	published := &BucketFetcher{Bucket: p.bucket}
+
+	var from uint64
+	var fromHash []byte
+	last, err := ReadCheckpoint(ctx, published)
+	switch {
+	case err == nil:
+		from, fromHash = last.Size, last.Hash
+	case errors.Is(err, ErrNotFound):
+		log.ContextLogger(ctx).Infof("no checkpoint published for tree %d, publishing from the start", p.treeID)
+	default:
+		return nil, fmt.Errorf("reading published checkpoint: %w", err)
+	}
+
+	resp, err := p.logClient.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: p.treeID})
+	if err != nil {
+		return nil, fmt.Errorf("getting latest log root: %w", err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case root.TreeSize < from:
+		return nil, fmt.Errorf("tree %d shrank from published size %d to %d", p.treeID, from, root.TreeSize)
+	case root.TreeSize == from:
+		if last == nil {
+			// nothing was published and the log is still empty
+			return nil, nil
+		}
+		if !bytes.Equal(root.RootHash, fromHash) {
+			return nil, fmt.Errorf("root hash of tree %d at size %d does not match published checkpoint", p.treeID, from)
+		}
+		return last, nil
+	}
+
+	start := from - from%TileWidth
+	leaves, err := p.fetchLeaves(ctx, start, root.TreeSize)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := extend(ctx, published, from, root.TreeSize, leaves)
+	if err != nil {
+		return nil, err
+	}
+
+	// check the tiles against Trillian's root before publishing anything, so
+	// that immutable tiles are never written with unexpected contents
+	computed, err := RootHash(ctx, &overlayFetcher{pending: pending, Fetcher: published}, root.TreeSize)
+	if err != nil {
+		return nil, fmt.Errorf("computing root hash from tiles: %w", err)
+	}
+	if !bytes.Equal(computed, root.RootHash) {
+		return nil, fmt.Errorf("root hash computed from tiles for tree %d at size %d does not match log root", p.treeID, root.TreeSize)
+	}
+
+	paths := make([]string, 0, len(pending))
+	for path := range pending {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := p.write(ctx, path, pending[path]); err != nil {
+			return nil, err
+		}
+	}
+
+	sth, err := p.signCheckpoint(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	scBytes, err := sth.SignedNote.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	// the checkpoint is written last, so readers never see a checkpoint
+	// before all tiles it commits to are available
+	if err := p.write(ctx, CheckpointPath, scBytes); err != nil {
+		return nil, err
+	}
+	return sth, nil
+}
+
+func (p *Publisher) signCheckpoint(ctx context.Context, root types.LogRootV1) (*util.SignedCheckpoint, error) {
+	sth, err := util.CreateSignedCheckpoint(util.Checkpoint{
+		Origin: fmt.Sprintf("%s - %d", p.hostname, p.treeID),
+		Size:   root.TreeSize,
+		Hash:   root.RootHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sth.SetTimestamp(uint64(time.Now().UnixNano()))
+	if _, err := sth.Sign(p.hostname, p.signer, options.WithContext(ctx)); err != nil {
+		return nil, fmt.Errorf("signing checkpoint: %w", err)
+	}
+	return sth, nil
+}
+
+func (p *Publisher) fetchLeaves(ctx context.Context, start, end uint64) ([][]byte, error) {
+	leaves := make([][]byte, 0, end-start)
+	for next := start; next < end; {
+		count := end - next
+		if count > maxLeavesPerRequest {
+			count = maxLeavesPerRequest
+		}
+		resp, err := p.logClient.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{
+			LogId:      p.treeID,
+			StartIndex: int64(next),
+			Count:      int64(count),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting leaves [%d, %d): %w", next, next+count, err)
+		}
+		if len(resp.Leaves) == 0 {
+			return nil, fmt.Errorf("no leaves returned from index %d", next)
+		}
+		for _, leaf := range resp.Leaves {
+			if uint64(leaf.LeafIndex) != next {
+				return nil, fmt.Errorf("expected leaf at index %d, got %d", next, leaf.LeafIndex)
+			}
+			leaves = append(leaves, leaf.LeafValue)
+			next++
+			if next == end {
+				break
+			}
+		}
+	}
+	return leaves, nil
+}
+
+func (p *Publisher) write(ctx context.Context, path string, data []byte) error {
+	cacheControl := cacheMutable
+	if path != CheckpointPath && !isPartial(path) {
+		cacheControl = cacheImmutable
+	}
+	w, err := p.bucket.NewWriter(ctx, path, &blob.WriterOptions{CacheControl: cacheControl})
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return w.Close()
+}
+
+func isPartial(path string) bool {
+	return strings.Contains(path, ".p/")
+}
+
+// extend returns the tiles and entry bundles, keyed by path, that must be
+// written to grow a published log from size from to size to. leaves must hold
+// the leaf values from the start of the tile containing index from, up to to;
+// tile hashes above level 0 are read from f.
+func extend(ctx context.Context, f Fetcher, from, to uint64, leaves [][]byte) (map[string][]byte, error) {
+	start := from - from%TileWidth
+	if uint64(len(leaves)) != to-start {
+		return nil, fmt.Errorf("expected %d leaves, got %d", to-start, len(leaves))
+	}
+	pending := map[string][]byte{}
+
+	hashes := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		hashes[i] = rfc6962.DefaultHasher.HashLeaf(leaf)
+	}
+	for tile := start / TileWidth; tile*TileWidth < to; tile++ {
+		width := tileWidth(tile, to)
+		offset := tile*TileWidth - start
+		bundle, err := MarshalEntryBundle(leaves[offset : offset+width])
+		if err != nil {
+			return nil, fmt.Errorf("entry bundle %d: %w", tile, err)
+		}
+		pending[EntriesPath(tile, width)] = bundle
+	}
+
+	// hashes holds the level's hashes from the start of the tile containing
+	// index levelFrom, up to levelTo
+	levelFrom, levelTo := from, to
+	for level := uint64(0); ; level++ {
+		firstTile := levelFrom / TileWidth
+		for tile := firstTile; tile*TileWidth < levelTo; tile++ {
+			width := tileWidth(tile, levelTo)
+			offset := (tile - firstTile) * TileWidth
+			pending[TilePath(level, tile, width)] = bytes.Join(hashes[offset:offset+width], nil)
+		}
+
+		// each full tile contributes its subtree root to the level above
+		nextFrom, nextTo := levelFrom/TileWidth, levelTo/TileWidth
+		if nextFrom == nextTo {
+			return pending, nil
+		}
+		var next [][]byte
+		if width := nextFrom % TileWidth; width > 0 {
+			b, err := f.Fetch(ctx, TilePath(level+1, nextFrom/TileWidth, width))
+			if err != nil {
+				return nil, fmt.Errorf("reading partial tile at level %d: %w", level+1, err)
+			}
+			if next, err = unmarshalTile(b, width); err != nil {
+				return nil, err
+			}
+		}
+		for tile := nextFrom; tile < nextTo; tile++ {
+			offset := (tile - firstTile) * TileWidth
+			next = append(next, subtreeHash(hashes[offset:offset+TileWidth]))
+		}
+		hashes = next
+		levelFrom, levelTo = nextFrom, nextTo
+	}
+}
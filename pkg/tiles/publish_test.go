@@ -0,0 +1,186 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tiles
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"gocloud.dev/blob/memblob"
+
+	"github.com/sigstore/rekor/pkg/faketrillian"
+	"github.com/sigstore/rekor/pkg/signer"
+)
+
+type mapFetcher map[string][]byte
+
+func (m mapFetcher) Fetch(_ context.Context, path string) ([]byte, error) {
+	if b, ok := m[path]; ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("%s: %w", path, ErrNotFound)
+}
+
+func leafValue(i uint64) []byte {
+	return []byte(fmt.Sprintf("leaf %d", i))
+}
+
+// referenceRoot computes the root hash of the first size leaves without tiles
+func referenceRoot(t *testing.T, size uint64) []byte {
+	t.Helper()
+	rng := (&compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren}).NewEmptyRange(0)
+	for i := uint64(0); i < size; i++ {
+		if err := rng.Append(rfc6962.DefaultHasher.HashLeaf(leafValue(i)), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root, err := rng.GetRootHash(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestExtend(t *testing.T) {
+	ctx := context.Background()
+	published := mapFetcher{}
+
+	var from uint64
+	var lastRoot []byte
+	for _, to := range []uint64{1, 5, 255, 256, 257, 600, 65535, 65536, 65800} {
+		start := from - from%TileWidth
+		var leaves [][]byte
+		for i := start; i < to; i++ {
+			leaves = append(leaves, leafValue(i))
+		}
+		pending, err := extend(ctx, published, from, to, leaves)
+		if err != nil {
+			t.Fatalf("extend(%d, %d): %v", from, to, err)
+		}
+		for path, b := range pending {
+			if prev, ok := published[path]; ok && !isPartial(path) && !bytes.Equal(prev, b) {
+				t.Fatalf("full tile %s was rewritten with different contents", path)
+			}
+			published[path] = b
+		}
+
+		root, err := RootHash(ctx, published, to)
+		if err != nil {
+			t.Fatalf("RootHash(%d): %v", to, err)
+		}
+		if want := referenceRoot(t, to); !bytes.Equal(root, want) {
+			t.Fatalf("RootHash(%d) = %x, want %x", to, root, want)
+		}
+
+		for _, index := range []uint64{0, from, to / 2, to - 1} {
+			if index >= to {
+				continue
+			}
+			p, err := InclusionProof(ctx, published, index, to)
+			if err != nil {
+				t.Fatalf("InclusionProof(%d, %d): %v", index, to, err)
+			}
+			entry, err := ReadEntry(ctx, published, index, to)
+			if err != nil {
+				t.Fatalf("ReadEntry(%d, %d): %v", index, to, err)
+			}
+			if !bytes.Equal(entry, leafValue(index)) {
+				t.Fatalf("ReadEntry(%d, %d) = %q", index, to, entry)
+			}
+			if err := proof.VerifyInclusion(rfc6962.DefaultHasher, index, to, rfc6962.DefaultHasher.HashLeaf(entry), p, root); err != nil {
+				t.Fatalf("inclusion proof for %d in %d: %v", index, to, err)
+			}
+		}
+
+		if from > 0 {
+			p, err := ConsistencyProof(ctx, published, from, to)
+			if err != nil {
+				t.Fatalf("ConsistencyProof(%d, %d): %v", from, to, err)
+			}
+			if err := proof.VerifyConsistency(rfc6962.DefaultHasher, from, to, p, lastRoot, root); err != nil {
+				t.Fatalf("consistency proof from %d to %d: %v", from, to, err)
+			}
+		}
+		from, lastRoot = to, root
+	}
+}
+
+func TestExtendMissingPartialTile(t *testing.T) {
+	ctx := context.Background()
+	// growing past a level 1 boundary requires the previously published partial level 1 tile
+	var leaves [][]byte
+	for i := uint64(512); i < 768; i++ {
+		leaves = append(leaves, leafValue(i))
+	}
+	if _, err := extend(ctx, mapFetcher{}, 512, 768, leaves); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := extend(ctx, mapFetcher{}, 512, 768, leaves[1:]); err == nil {
+		t.Error("expected error for missing leaves")
+	}
+}
+
+func TestPublishEmptyLog(t *testing.T) {
+	ctx := context.Background()
+	f := faketrillian.New(0)
+	treeID, err := f.CreateLog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bucket := memblob.OpenBucket(nil)
+	defer bucket.Close()
+	p := NewPublisher(f, treeID, bucket, s, "rekor.test")
+
+	// a new log has nothing to publish
+	for i := 0; i < 2; i++ {
+		sth, err := p.Publish(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sth != nil {
+			t.Fatalf("unexpected checkpoint published for empty log: %+v", sth)
+		}
+	}
+	if _, err := ReadCheckpoint(ctx, &BucketFetcher{Bucket: bucket}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected no checkpoint to be written, got %v", err)
+	}
+
+	// once the log grows, it is published
+	if _, err := f.QueueLeaf(ctx, &trillian.QueueLeafRequest{
+		LogId: treeID,
+		Leaf:  &trillian.LogLeaf{LeafValue: leafValue(0)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sth, err := p.Publish(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sth == nil || sth.Size != 1 || !bytes.Equal(sth.Hash, referenceRoot(t, 1)) {
+		t.Fatalf("unexpected checkpoint %+v", sth)
+	}
+}
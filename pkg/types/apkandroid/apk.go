@@ -0,0 +1,405 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apkandroid
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	SchemeV2 = "v2"
+	SchemeV3 = "v3"
+)
+
+// https://source.android.com/docs/security/features/apksigning/v2
+const (
+	sigBlockMagic = "APK Sig Block 42"
+	blockIDV2     = 0x7109871a
+	blockIDV3     = 0xf05368c0
+
+	eocdSignature = 0x06054b50
+	eocdSize      = 22
+
+	chunkSize = 1024 * 1024
+
+	manifestName = "AndroidManifest.xml"
+)
+
+// signatureAlgorithm is a signature algorithm of APK Signature Scheme v2
+// and v3, which also determines how the contents of the APK are digested
+type signatureAlgorithm struct {
+	hash crypto.Hash
+	pss  bool
+}
+
+// signatureAlgorithms are the supported signature algorithms; signatures
+// using other algorithms, such as DSA or those with verity digests, are
+// ignored
+var signatureAlgorithms = map[uint32]signatureAlgorithm{
+	0x0101: {hash: crypto.SHA256, pss: true}, // RSASSA-PSS with SHA2-256
+	0x0102: {hash: crypto.SHA512, pss: true}, // RSASSA-PSS with SHA2-512
+	0x0103: {hash: crypto.SHA256},            // RSASSA-PKCS1-v1_5 with SHA2-256
+	0x0104: {hash: crypto.SHA512},            // RSASSA-PKCS1-v1_5 with SHA2-512
+	0x0201: {hash: crypto.SHA256},            // ECDSA with SHA2-256
+	0x0202: {hash: crypto.SHA512},            // ECDSA with SHA2-512
+}
+
+// APK is an Android application package signed with APK Signature Scheme
+// v2 or v3
+type APK struct {
+	// PackageName is the package name from the manifest
+	PackageName string
+	// Scheme is the most recent signature scheme the APK is signed with
+	Scheme string
+	// Signer is the signer block of that scheme, which holds the signed
+	// data and the signatures over it
+	Signer []byte
+	// Certificate is the certificate of the signer
+	Certificate *x509.Certificate
+}
+
+// Unmarshal parses an APK and verifies its v2 and v3 signatures over the
+// contents of the APK. Signatures from the JAR-based v1 scheme are not
+// considered.
+func (a *APK) Unmarshal(content []byte) error {
+	blocks, sections, err := signingBlock(content)
+	if err != nil {
+		return err
+	}
+
+	apk := APK{}
+	digests := map[crypto.Hash][]byte{}
+	for _, scheme := range []struct {
+		id   uint32
+		name string
+	}{
+		{blockIDV2, SchemeV2},
+		// v3 is verified last so that it is the scheme recorded
+		{blockIDV3, SchemeV3},
+	} {
+		block, ok := blocks[scheme.id]
+		if !ok {
+			continue
+		}
+		signer, cert, err := verifySigner(block, scheme.id == blockIDV3, sections, digests)
+		if err != nil {
+			return fmt.Errorf("verifying APK Signature Scheme %s: %w", scheme.name, err)
+		}
+		apk.Scheme = scheme.name
+		apk.Signer = signer
+		apk.Certificate = cert
+	}
+	if apk.Scheme == "" {
+		return errors.New("APK is not signed with APK Signature Scheme v2 or v3")
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return err
+	}
+	f, err := zr.Open(manifestName)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", manifestName, err)
+	}
+	defer f.Close()
+	manifest, err := ioutil.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", manifestName, err)
+	}
+	if apk.PackageName, err = packageName(manifest); err != nil {
+		return err
+	}
+
+	*a = apk
+	return nil
+}
+
+// signingBlock returns the ID-value pairs of the APK Signing Block, and the
+// sections of the APK that are digested
+func signingBlock(content []byte) (map[uint32][]byte, [][]byte, error) {
+	// the end of central directory record is followed by a comment of at
+	// most 65535 bytes
+	eocd := -1
+	for i := len(content) - eocdSize; i >= 0 && i >= len(content)-eocdSize-0xffff; i-- {
+		if binary.LittleEndian.Uint32(content[i:]) == eocdSignature &&
+			int(binary.LittleEndian.Uint16(content[i+20:])) == len(content)-i-eocdSize {
+			eocd = i
+			break
+		}
+	}
+	if eocd < 0 {
+		return nil, nil, errors.New("APK is not a ZIP archive")
+	}
+	cdSize := uint64(binary.LittleEndian.Uint32(content[eocd+12:]))
+	cdOffset := uint64(binary.LittleEndian.Uint32(content[eocd+16:]))
+	if cdOffset+cdSize != uint64(eocd) {
+		return nil, nil, errors.New("ZIP central directory is not followed by the end of central directory record")
+	}
+
+	// the block ends with its size and magic, and starts with its size
+	if cdOffset < 32 || string(content[cdOffset-16:cdOffset]) != sigBlockMagic {
+		return nil, nil, errors.New("APK Signing Block not found; APKs signed only with the v1 scheme are supported by the jar type")
+	}
+	size := binary.LittleEndian.Uint64(content[cdOffset-24:])
+	if size < 24 || size > cdOffset-8 {
+		return nil, nil, errors.New("invalid APK Signing Block size")
+	}
+	start := cdOffset - size - 8
+	if binary.LittleEndian.Uint64(content[start:]) != size {
+		return nil, nil, errors.New("invalid APK Signing Block size")
+	}
+
+	blocks := map[uint32][]byte{}
+	for pairs := content[start+8 : cdOffset-24]; len(pairs) > 0; {
+		if len(pairs) < 12 {
+			return nil, nil, errors.New("invalid APK Signing Block")
+		}
+		n := binary.LittleEndian.Uint64(pairs)
+		pairs = pairs[8:]
+		if n < 4 || n > uint64(len(pairs)) {
+			return nil, nil, errors.New("invalid APK Signing Block")
+		}
+		blocks[binary.LittleEndian.Uint32(pairs)] = pairs[4:n]
+		pairs = pairs[n:]
+	}
+
+	// the offset of the central directory is digested as if there were no
+	// signing block
+	eocdRecord := append([]byte{}, content[eocd:]...)
+	binary.LittleEndian.PutUint32(eocdRecord[16:], uint32(start))
+	sections := [][]byte{content[:start], content[cdOffset:eocd], eocdRecord}
+	return blocks, sections, nil
+}
+
+// contentDigest computes the digest of the sections of an APK, which is
+// the digest of the digests of each 1MiB chunk of the sections
+func contentDigest(hash crypto.Hash, sections [][]byte) []byte {
+	var digests []byte
+	var count uint32
+	for _, section := range sections {
+		for len(section) > 0 {
+			n := len(section)
+			if n > chunkSize {
+				n = chunkSize
+			}
+			h := hash.New()
+			var prefix [5]byte
+			prefix[0] = 0xa5
+			binary.LittleEndian.PutUint32(prefix[1:], uint32(n))
+			h.Write(prefix[:])
+			h.Write(section[:n])
+			digests = h.Sum(digests)
+			section = section[n:]
+			count++
+		}
+	}
+	h := hash.New()
+	var prefix [5]byte
+	prefix[0] = 0x5a
+	binary.LittleEndian.PutUint32(prefix[1:], count)
+	h.Write(prefix[:])
+	h.Write(digests)
+	return h.Sum(nil)
+}
+
+// lengthPrefixed splits a value prefixed with its 32-bit length from b
+func lengthPrefixed(b []byte) ([]byte, []byte, error) {
+	if len(b) < 4 {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+	n := binary.LittleEndian.Uint32(b)
+	if uint64(n) > uint64(len(b)-4) {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+	return b[4 : 4+n], b[4+n:], nil
+}
+
+// sequence splits a length-prefixed sequence of length-prefixed values
+// from b
+func sequence(b []byte) ([][]byte, []byte, error) {
+	seq, rest, err := lengthPrefixed(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	var values [][]byte
+	for len(seq) > 0 {
+		var value []byte
+		if value, seq, err = lengthPrefixed(seq); err != nil {
+			return nil, nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rest, nil
+}
+
+// algorithmValue splits a 32-bit algorithm ID and a length-prefixed value
+// from b
+func algorithmValue(b []byte) (uint32, []byte, error) {
+	if len(b) < 4 {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	value, rest, err := lengthPrefixed(b[4:])
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(rest) != 0 {
+		return 0, nil, errors.New("unexpected data after value")
+	}
+	return binary.LittleEndian.Uint32(b), value, nil
+}
+
+// verifySigner verifies the signer in a v2 or v3 block, and returns the
+// signer and its certificate. Content digests are cached in digests.
+func verifySigner(block []byte, v3 bool, sections [][]byte, digests map[crypto.Hash][]byte) ([]byte, *x509.Certificate, error) {
+	signers, _, err := sequence(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch len(signers) {
+	case 0:
+		return nil, nil, errors.New("no signers found")
+	case 1:
+	default:
+		return nil, nil, errors.New("multiple signers detected in APK; unable to process")
+	}
+	signer := signers[0]
+
+	signedData, rest, err := lengthPrefixed(signer)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sdkVersions []byte
+	if v3 {
+		if len(rest) < 8 {
+			return nil, nil, io.ErrUnexpectedEOF
+		}
+		sdkVersions, rest = rest[:8], rest[8:]
+	}
+	signatures, rest, err := sequence(rest)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKeyBytes, _, err := lengthPrefixed(rest)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(publicKeyBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var signatureIDs []uint32
+	verified := false
+	for _, s := range signatures {
+		id, sig, err := algorithmValue(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		signatureIDs = append(signatureIDs, id)
+		alg, ok := signatureAlgorithms[id]
+		if !ok {
+			continue
+		}
+		if err := verifySignature(alg, publicKey, signedData, sig); err != nil {
+			return nil, nil, fmt.Errorf("verifying signature with algorithm 0x%04x: %w", id, err)
+		}
+		verified = true
+	}
+	if !verified {
+		return nil, nil, errors.New("no signatures with supported algorithms found")
+	}
+
+	// only the signed data is trusted from here on
+	digestValues, rest, err := sequence(signedData)
+	if err != nil {
+		return nil, nil, err
+	}
+	certs, rest, err := sequence(rest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v3 && (len(rest) < 8 || !bytes.Equal(rest[:8], sdkVersions)) {
+		return nil, nil, errors.New("SDK versions of signer do not match signed data")
+	}
+
+	if len(digestValues) != len(signatureIDs) {
+		return nil, nil, errors.New("digest algorithms do not match signature algorithms")
+	}
+	for i, d := range digestValues {
+		id, digest, err := algorithmValue(d)
+		if err != nil {
+			return nil, nil, err
+		}
+		if id != signatureIDs[i] {
+			return nil, nil, errors.New("digest algorithms do not match signature algorithms")
+		}
+		alg, ok := signatureAlgorithms[id]
+		if !ok {
+			continue
+		}
+		computed, ok := digests[alg.hash]
+		if !ok {
+			computed = contentDigest(alg.hash, sections)
+			digests[alg.hash] = computed
+		}
+		if !bytes.Equal(digest, computed) {
+			return nil, nil, fmt.Errorf("digest mismatch for algorithm 0x%04x", id)
+		}
+	}
+
+	if len(certs) == 0 {
+		return nil, nil, errors.New("no certificates found")
+	}
+	cert, err := x509.ParseCertificate(certs[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.Equal(cert.RawSubjectPublicKeyInfo, publicKeyBytes) {
+		return nil, nil, errors.New("public key does not match certificate of signer")
+	}
+	return signer, cert, nil
+}
+
+func verifySignature(alg signatureAlgorithm, publicKey crypto.PublicKey, signedData, sig []byte) error {
+	h := alg.hash.New()
+	h.Write(signedData)
+	digest := h.Sum(nil)
+
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		if alg.pss {
+			return rsa.VerifyPSS(pub, alg.hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		return rsa.VerifyPKCS1v15(pub, alg.hash, digest, sig)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pub, digest, sig) {
+			return errors.New("ECDSA verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
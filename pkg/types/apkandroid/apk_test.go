@@ -0,0 +1,148 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apkandroid
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/pem"
+	"os"
+	"testing"
+)
+
+func readTestFile(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile("../../../tests/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestAPK(t *testing.T) {
+	content := readTestFile(t, "test_apk_android.apk")
+	block, _ := pem.Decode(readTestFile(t, "test_apk_android.pem"))
+
+	a := APK{}
+	if err := a.Unmarshal(content); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if a.PackageName != "dev.sigstore.rekor" {
+		t.Errorf("unexpected package name %q", a.PackageName)
+	}
+	if a.Scheme != SchemeV3 {
+		t.Errorf("unexpected scheme %q", a.Scheme)
+	}
+	if !bytes.Equal(a.Certificate.Raw, block.Bytes) {
+		t.Errorf("unexpected signer certificate %v", a.Certificate.Subject)
+	}
+	if !bytes.Contains(content, a.Signer) {
+		t.Error("signer was not extracted from the APK Signing Block")
+	}
+}
+
+func TestAPKInvalid(t *testing.T) {
+	content := readTestFile(t, "test_apk_android.apk")
+	eocd := len(content) - eocdSize
+	cdOffset := binary.LittleEndian.Uint32(content[eocd+16:])
+	blockStart := cdOffset - uint32(binary.LittleEndian.Uint64(content[cdOffset-24:])) - 8
+
+	modified := func(f func(b []byte) []byte) []byte {
+		return f(append([]byte{}, content...))
+	}
+
+	// an APK signed only with the JAR-based v1 scheme has no signing block
+	var v1 bytes.Buffer
+	zw := zip.NewWriter(&v1)
+	w, err := zw.Create(manifestName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(testManifest{root: "manifest", attr: "package", value: "dev.sigstore.rekor"}.bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		content []byte
+	}{
+		{name: "empty", content: []byte{}},
+		{name: "not a ZIP archive", content: []byte("hello world")},
+		{name: "v1 only", content: v1.Bytes()},
+		{
+			name: "signing block removed",
+			content: modified(func(b []byte) []byte {
+				b = append(b[:blockStart], b[cdOffset:]...)
+				binary.LittleEndian.PutUint32(b[len(b)-eocdSize+16:], blockStart)
+				return b
+			}),
+		},
+		{
+			name: "trailing data",
+			content: modified(func(b []byte) []byte {
+				return append(b, 0)
+			}),
+		},
+		{
+			name: "modified entry",
+			content: modified(func(b []byte) []byte {
+				b[blockStart-1]++
+				return b
+			}),
+		},
+		{
+			name: "modified central directory",
+			content: modified(func(b []byte) []byte {
+				b[cdOffset+10]++
+				return b
+			}),
+		},
+		{
+			name: "corrupt magic",
+			content: modified(func(b []byte) []byte {
+				b[cdOffset-1]++
+				return b
+			}),
+		},
+		{
+			name: "corrupt block size",
+			content: modified(func(b []byte) []byte {
+				b[blockStart]++
+				return b
+			}),
+		},
+		{
+			name: "modified signed data",
+			content: modified(func(b []byte) []byte {
+				// the SHA2-256 content digest is the first value in the signed
+				// data of the v3 signer, which follows the v2 signer
+				b[bytes.LastIndex(b, []byte{0x01, 0x02, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00})+8]++
+				return b
+			}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a := APK{}
+			if err := a.Unmarshal(tc.content); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
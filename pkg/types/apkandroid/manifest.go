@@ -0,0 +1,224 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apkandroid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode/utf16"
+)
+
+// chunk types of the binary XML format used for AndroidManifest.xml
+const (
+	chunkStringPool   = 0x0001
+	chunkXML          = 0x0003
+	chunkStartElement = 0x0102
+
+	stringPoolUTF8 = 1 << 8
+	noEntry        = 0xffffffff
+	typeString     = 0x03
+)
+
+// packageNameRegexp matches Java-style package names, which Android
+// requires to have at least two segments
+var packageNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z][A-Za-z0-9_]*)+$`)
+
+var errInvalidManifest = errors.New("invalid binary AndroidManifest.xml")
+
+// chunk returns the type of the chunk at the start of b, its header and the
+// whole chunk
+func chunk(b []byte) (uint16, []byte, []byte, error) {
+	if len(b) < 8 {
+		return 0, nil, nil, errInvalidManifest
+	}
+	chunkType := binary.LittleEndian.Uint16(b[0:2])
+	headerSize := binary.LittleEndian.Uint16(b[2:4])
+	size := binary.LittleEndian.Uint32(b[4:8])
+	if headerSize < 8 || uint32(headerSize) > size || uint64(size) > uint64(len(b)) {
+		return 0, nil, nil, errInvalidManifest
+	}
+	return chunkType, b[:headerSize], b[:size], nil
+}
+
+// stringPool is a string pool chunk of a binary XML document
+type stringPool struct {
+	chunk   []byte
+	offsets []uint32
+	start   uint32
+	utf8    bool
+}
+
+func newStringPool(header, chunk []byte) (*stringPool, error) {
+	if len(header) < 28 {
+		return nil, errInvalidManifest
+	}
+	count := binary.LittleEndian.Uint32(header[8:12])
+	flags := binary.LittleEndian.Uint32(header[16:20])
+	start := binary.LittleEndian.Uint32(header[20:24])
+	if uint64(len(header))+4*uint64(count) > uint64(len(chunk)) || uint64(start) > uint64(len(chunk)) {
+		return nil, errInvalidManifest
+	}
+	offsets := make([]uint32, count)
+	for i := range offsets {
+		offsets[i] = binary.LittleEndian.Uint32(chunk[len(header)+4*i:])
+	}
+	return &stringPool{chunk: chunk, offsets: offsets, start: start, utf8: flags&stringPoolUTF8 != 0}, nil
+}
+
+// get returns the string at index i of the pool
+func (p *stringPool) get(i uint32) (string, error) {
+	if uint64(i) >= uint64(len(p.offsets)) {
+		return "", errInvalidManifest
+	}
+	offset := uint64(p.start) + uint64(p.offsets[i])
+	if offset > uint64(len(p.chunk)) {
+		return "", errInvalidManifest
+	}
+	b := p.chunk[offset:]
+
+	if p.utf8 {
+		// the length in UTF-16 code units is followed by the length in bytes,
+		// each of which takes two bytes if the high bit is set
+		var n int
+		for j := 0; j < 2; j++ {
+			if len(b) < 1 {
+				return "", errInvalidManifest
+			}
+			n = int(b[0])
+			b = b[1:]
+			if n&0x80 != 0 {
+				if len(b) < 1 {
+					return "", errInvalidManifest
+				}
+				n = (n&0x7f)<<8 | int(b[0])
+				b = b[1:]
+			}
+		}
+		if n > len(b) {
+			return "", errInvalidManifest
+		}
+		return string(b[:n]), nil
+	}
+
+	if len(b) < 2 {
+		return "", errInvalidManifest
+	}
+	n := int(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if n&0x8000 != 0 {
+		if len(b) < 2 {
+			return "", errInvalidManifest
+		}
+		n = (n&0x7fff)<<16 | int(binary.LittleEndian.Uint16(b))
+		b = b[2:]
+	}
+	if 2*n > len(b) {
+		return "", errInvalidManifest
+	}
+	units := make([]uint16, n)
+	for j := range units {
+		units[j] = binary.LittleEndian.Uint16(b[2*j:])
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// packageName returns the package attribute of the manifest element of a
+// binary AndroidManifest.xml
+func packageName(manifest []byte) (string, error) {
+	chunkType, header, doc, err := chunk(manifest)
+	if err != nil {
+		return "", err
+	}
+	if chunkType != chunkXML {
+		return "", errInvalidManifest
+	}
+
+	var pool *stringPool
+	for b := doc[len(header):]; len(b) > 0; {
+		chunkType, header, c, err := chunk(b)
+		if err != nil {
+			return "", err
+		}
+		b = b[len(c):]
+
+		switch chunkType {
+		case chunkStringPool:
+			if pool, err = newStringPool(header, c); err != nil {
+				return "", err
+			}
+		case chunkStartElement:
+			if pool == nil {
+				return "", errInvalidManifest
+			}
+			return manifestPackage(pool, header, c)
+		}
+	}
+	return "", errors.New("manifest element not found in AndroidManifest.xml")
+}
+
+// manifestPackage returns the package attribute of the start element in c,
+// which must be the manifest element
+func manifestPackage(pool *stringPool, header, c []byte) (string, error) {
+	ext := c[len(header):]
+	if len(ext) < 20 {
+		return "", errInvalidManifest
+	}
+	name, err := pool.get(binary.LittleEndian.Uint32(ext[4:8]))
+	if err != nil {
+		return "", err
+	}
+	if name != "manifest" {
+		return "", fmt.Errorf("unexpected root element %q in AndroidManifest.xml", name)
+	}
+
+	attrStart := int(binary.LittleEndian.Uint16(ext[8:10]))
+	attrSize := int(binary.LittleEndian.Uint16(ext[10:12]))
+	attrCount := int(binary.LittleEndian.Uint16(ext[12:14]))
+	if attrSize < 20 || attrStart+attrSize*attrCount > len(ext) {
+		return "", errInvalidManifest
+	}
+	for i := 0; i < attrCount; i++ {
+		attr := ext[attrStart+i*attrSize:]
+		name, err := pool.get(binary.LittleEndian.Uint32(attr[4:8]))
+		if err != nil {
+			return "", err
+		}
+		if name != "package" {
+			continue
+		}
+
+		// the value is the raw string, or a typed string value when the
+		// raw value is not retained
+		value := binary.LittleEndian.Uint32(attr[8:12])
+		if value == noEntry {
+			if attr[15] != typeString {
+				return "", errors.New("package attribute in AndroidManifest.xml is not a string")
+			}
+			value = binary.LittleEndian.Uint32(attr[16:20])
+		}
+		pkg, err := pool.get(value)
+		if err != nil {
+			return "", err
+		}
+		if !packageNameRegexp.MatchString(pkg) {
+			return "", fmt.Errorf("invalid package name %q in AndroidManifest.xml", pkg)
+		}
+		return pkg, nil
+	}
+	return "", errors.New("manifest element has no package attribute")
+}
@@ -0,0 +1,168 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apkandroid
+
+import (
+	"testing"
+	"unicode/utf16"
+)
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+// testManifest is a binary AndroidManifest.xml with a single root element
+type testManifest struct {
+	root    string
+	attr    string
+	value   string
+	utf16   bool
+	typed   bool
+	noStart bool
+}
+
+func (m testManifest) bytes() []byte {
+	strs := []string{m.root, m.attr, m.value}
+
+	// string pool
+	var offsets, data []byte
+	for _, s := range strs {
+		offsets = appendUint32(offsets, uint32(len(data)))
+		if m.utf16 {
+			units := utf16.Encode([]rune(s))
+			data = appendUint16(data, uint16(len(units)))
+			for _, u := range units {
+				data = appendUint16(data, u)
+			}
+			data = append(data, 0, 0)
+		} else {
+			data = append(data, byte(len(s)), byte(len(s)))
+			data = append(data, s...)
+			data = append(data, 0)
+		}
+	}
+	for len(data)%4 != 0 {
+		data = append(data, 0)
+	}
+	var flags uint32 = stringPoolUTF8
+	if m.utf16 {
+		flags = 0
+	}
+	pool := appendUint16(nil, chunkStringPool)
+	pool = appendUint16(pool, 28)
+	pool = appendUint32(pool, uint32(28+len(offsets)+len(data)))
+	pool = appendUint32(pool, uint32(len(strs)))
+	pool = appendUint32(pool, 0)
+	pool = appendUint32(pool, flags)
+	pool = appendUint32(pool, uint32(28+len(offsets)))
+	pool = appendUint32(pool, 0)
+	pool = append(pool, offsets...)
+	pool = append(pool, data...)
+
+	// start element with a single attribute
+	attr := appendUint32(nil, noEntry)
+	attr = appendUint32(attr, 1)
+	if m.typed {
+		attr = appendUint32(attr, noEntry)
+	} else {
+		attr = appendUint32(attr, 2)
+	}
+	attr = appendUint16(attr, 8)
+	attr = append(attr, 0, typeString)
+	attr = appendUint32(attr, 2)
+	ext := appendUint32(nil, noEntry)
+	ext = appendUint32(ext, 0)
+	ext = appendUint16(ext, 20)
+	ext = appendUint16(ext, 20)
+	ext = appendUint16(ext, 1)
+	ext = append(ext, make([]byte, 6)...)
+	ext = append(ext, attr...)
+	elem := appendUint16(nil, chunkStartElement)
+	elem = appendUint16(elem, 16)
+	elem = appendUint32(elem, uint32(16+len(ext)))
+	elem = appendUint32(elem, 1)
+	elem = appendUint32(elem, noEntry)
+	elem = append(elem, ext...)
+
+	body := pool
+	if !m.noStart {
+		body = append(body, elem...)
+	}
+	doc := appendUint16(nil, chunkXML)
+	doc = appendUint16(doc, 8)
+	doc = appendUint32(doc, uint32(8+len(body)))
+	return append(doc, body...)
+}
+
+func TestPackageName(t *testing.T) {
+	valid := testManifest{root: "manifest", attr: "package", value: "dev.sigstore.rekor"}
+
+	for _, tc := range []struct {
+		name     string
+		manifest []byte
+		want     string
+		wantErr  bool
+	}{
+		{name: "utf-8", manifest: valid.bytes(), want: "dev.sigstore.rekor"},
+		{
+			name:     "utf-16",
+			manifest: testManifest{root: "manifest", attr: "package", value: "dev.sigstore.rekor", utf16: true}.bytes(),
+			want:     "dev.sigstore.rekor",
+		},
+		{
+			name:     "typed value",
+			manifest: testManifest{root: "manifest", attr: "package", value: "dev.sigstore.rekor", typed: true}.bytes(),
+			want:     "dev.sigstore.rekor",
+		},
+		{name: "empty", manifest: []byte{}, wantErr: true},
+		{name: "text manifest", manifest: []byte(`<manifest package="dev.sigstore.rekor"/>`), wantErr: true},
+		{name: "truncated", manifest: valid.bytes()[:len(valid.bytes())-4], wantErr: true},
+		{
+			name:     "no elements",
+			manifest: testManifest{root: "manifest", attr: "package", value: "dev.sigstore.rekor", noStart: true}.bytes(),
+			wantErr:  true,
+		},
+		{
+			name:     "wrong root element",
+			manifest: testManifest{root: "application", attr: "package", value: "dev.sigstore.rekor"}.bytes(),
+			wantErr:  true,
+		},
+		{
+			name:     "missing package",
+			manifest: testManifest{root: "manifest", attr: "versionCode", value: "dev.sigstore.rekor"}.bytes(),
+			wantErr:  true,
+		},
+		{
+			name:     "invalid package name",
+			manifest: testManifest{root: "manifest", attr: "package", value: "rekor"}.bytes(),
+			wantErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := packageName(tc.manifest)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("packageName() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("packageName() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apkandroid
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/apkandroid"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := apkandroid.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	APKAndroidObj models.APKAndroidV001Schema
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() ([]string, error) {
+	var result []string
+
+	sig := v.APKAndroidObj.Signature
+	if sig != nil && sig.PublicKey != nil && sig.PublicKey.Content != nil {
+		keyObj, err := x509.NewPublicKey(bytes.NewReader(*sig.PublicKey.Content))
+		if err != nil {
+			return nil, err
+		}
+		key, err := keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
+		result = append(result, keyObj.Subjects()...)
+	}
+
+	apk := v.APKAndroidObj.APK
+	if apk.Hash != nil {
+		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *apk.Hash.Algorithm, *apk.Hash.Value))
+		result = append(result, hashKey)
+	}
+	if apk.PackageName != "" {
+		result = append(result, apk.PackageName)
+	}
+
+	return result, nil
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	apkObj, ok := pe.(*models.APKAndroid)
+	if !ok {
+		return errors.New("cannot unmarshal non APK v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(apkObj.Spec, &v.APKAndroidObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.APKAndroidObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// cross field validation
+	return v.validate()
+}
+
+// fetchExternalEntities parses the APK, verifies the signers in its APK
+// Signing Block and fills in the fields derived from the APK
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) error {
+	if err := v.validate(); err != nil {
+		return types.ValidationError(err)
+	}
+
+	apk := v.APKAndroidObj.APK
+	if len(apk.Content) == 0 {
+		return types.ValidationError(errors.New("'content' must be specified for apk"))
+	}
+
+	hasher := sha256.New()
+	if _, err := hasher.Write(apk.Content); err != nil {
+		return err
+	}
+	computedSHA := hex.EncodeToString(hasher.Sum(nil))
+	if apk.Hash != nil && swag.StringValue(apk.Hash.Value) != computedSHA {
+		return types.ValidationError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, swag.StringValue(apk.Hash.Value)))
+	}
+
+	// this verifies the v2 and v3 signatures over the contents of the APK
+	a := apkandroid.APK{}
+	if err := a.Unmarshal(apk.Content); err != nil {
+		return types.ValidationError(err)
+	}
+
+	certPEM, err := cryptoutils.MarshalCertificateToPEM(a.Certificate)
+	if err != nil {
+		return err
+	}
+	keyObj, err := x509.NewPublicKey(bytes.NewReader(certPEM))
+	if err != nil {
+		return types.ValidationError(err)
+	}
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		return err
+	}
+
+	apk.Hash = &models.APKAndroidV001SchemaAPKHash{
+		Algorithm: swag.String(models.APKAndroidV001SchemaAPKHashAlgorithmSha256),
+		Value:     swag.String(computedSHA),
+	}
+	apk.PackageName = a.PackageName
+	v.APKAndroidObj.Signature = &models.APKAndroidV001SchemaSignature{
+		Scheme:  swag.String(a.Scheme),
+		Content: (*strfmt.Base64)(&a.Signer),
+		PublicKey: &models.APKAndroidV001SchemaSignaturePublicKey{
+			Content: (*strfmt.Base64)(&key),
+		},
+	}
+
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.fetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+
+	canonicalEntry := models.APKAndroidV001Schema{
+		Signature: v.APKAndroidObj.Signature,
+		APK: &models.APKAndroidV001SchemaAPK{
+			Hash:        v.APKAndroidObj.APK.Hash,
+			PackageName: v.APKAndroidObj.APK.PackageName,
+		},
+	}
+	// apk content is not set deliberately
+
+	v.APKAndroidObj = canonicalEntry
+	// wrap in valid object with kind and apiVersion set
+	apkObj := models.APKAndroid{}
+	apkObj.APIVersion = swag.String(APIVERSION)
+	apkObj.Spec = &canonicalEntry
+
+	return json.Marshal(&apkObj)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	apk := v.APKAndroidObj.APK
+	if apk == nil {
+		return errors.New("missing apk")
+	}
+
+	if len(apk.Content) == 0 && apk.Hash == nil {
+		return errors.New("'content' must be specified for apk")
+	}
+
+	hash := apk.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	}
+
+	return nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.APKAndroid{}
+	re := V001Entry{}
+
+	// we will need only the artifact; the signature and certificate are
+	// embedded in the APK Signing Block
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		var artifactReader io.ReadCloser
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to artifact file must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			artifactReader, err = util.FileOrURLReadCloser(ctx, props.ArtifactPath.String(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("error reading APK file: %w", err)
+			}
+		} else {
+			artifactReader, err = os.Open(filepath.Clean(props.ArtifactPath.Path))
+			if err != nil {
+				return nil, fmt.Errorf("error opening APK file: %w", err)
+			}
+		}
+		defer artifactReader.Close()
+		artifactBytes, err = ioutil.ReadAll(artifactReader)
+		if err != nil {
+			return nil, fmt.Errorf("error reading APK file: %w", err)
+		}
+	}
+	re.APKAndroidObj.APK = &models.APKAndroidV001SchemaAPK{
+		Content: strfmt.Base64(artifactBytes),
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := re.fetchExternalEntities(ctx); err != nil {
+		return nil, fmt.Errorf("error retrieving external entities: %v", err)
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.APKAndroidObj
+
+	return &returnVal, nil
+}
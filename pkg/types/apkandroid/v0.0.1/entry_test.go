@@ -0,0 +1,265 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apkandroid
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/apkandroid"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	apkBytes, err := os.ReadFile("../../../../tests/test_apk_android.apk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	certBytes, err := os.ReadFile("../../../../tests/test_apk_android.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := apkandroid.APK{}
+	if err := a.Unmarshal(apkBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	// the signer ends with the signature value, followed by the
+	// length-prefixed public key
+	signerEnd := bytes.Index(apkBytes, a.Signer) + len(a.Signer)
+	badSig := append([]byte{}, apkBytes...)
+	badSig[signerEnd-len(a.Certificate.RawSubjectPublicKeyInfo)-5]++
+
+	modified := append([]byte{}, apkBytes...)
+	modified[40]++
+
+	hash := func(b []byte) *models.APKAndroidV001SchemaAPKHash {
+		return &models.APKAndroidV001SchemaAPKHash{
+			Algorithm: swag.String(models.APKAndroidV001SchemaAPKHashAlgorithmSha256),
+			Value:     swag.String(sha256Hex(b)),
+		}
+	}
+
+	wantKeys := []string{
+		sha256Hex(certBytes),
+		"test@rekor.dev",
+		"sha256:" + sha256Hex(apkBytes),
+		"dev.sigstore.rekor",
+	}
+
+	tests := []struct {
+		name         string
+		model        models.APKAndroidV001Schema
+		wantKeys     []string
+		unmarshalErr bool
+		canonicalErr bool
+	}{
+		{
+			name:         "missing apk",
+			model:        models.APKAndroidV001Schema{},
+			unmarshalErr: true,
+		},
+		{
+			name:         "empty apk",
+			model:        models.APKAndroidV001Schema{APK: &models.APKAndroidV001SchemaAPK{}},
+			unmarshalErr: true,
+		},
+		{
+			name:     "signed apk",
+			model:    models.APKAndroidV001Schema{APK: &models.APKAndroidV001SchemaAPK{Content: apkBytes}},
+			wantKeys: wantKeys,
+		},
+		{
+			name:     "signed apk with hash",
+			model:    models.APKAndroidV001Schema{APK: &models.APKAndroidV001SchemaAPK{Content: apkBytes, Hash: hash(apkBytes)}},
+			wantKeys: wantKeys,
+		},
+		{
+			name:         "hash without apk",
+			model:        models.APKAndroidV001Schema{APK: &models.APKAndroidV001SchemaAPK{Hash: hash(apkBytes)}},
+			canonicalErr: true,
+		},
+		{
+			name:         "mismatched hash",
+			model:        models.APKAndroidV001Schema{APK: &models.APKAndroidV001SchemaAPK{Content: apkBytes, Hash: hash(modified)}},
+			canonicalErr: true,
+		},
+		{
+			name: "invalid hash",
+			model: models.APKAndroidV001Schema{APK: &models.APKAndroidV001SchemaAPK{
+				Content: apkBytes,
+				Hash: &models.APKAndroidV001SchemaAPKHash{
+					Algorithm: swag.String(models.APKAndroidV001SchemaAPKHashAlgorithmSha256),
+					Value:     swag.String("not a hash"),
+				},
+			}},
+			unmarshalErr: true,
+		},
+		{
+			name:         "modified apk",
+			model:        models.APKAndroidV001Schema{APK: &models.APKAndroidV001SchemaAPK{Content: modified}},
+			canonicalErr: true,
+		},
+		{
+			name:         "invalid signature",
+			model:        models.APKAndroidV001Schema{APK: &models.APKAndroidV001SchemaAPK{Content: badSig}},
+			canonicalErr: true,
+		},
+		{
+			name:         "not an apk",
+			model:        models.APKAndroidV001Schema{APK: &models.APKAndroidV001SchemaAPK{Content: certBytes}},
+			canonicalErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &V001Entry{}
+			err := v.Unmarshal(&models.APKAndroid{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       tc.model,
+			})
+			if (err != nil) != tc.unmarshalErr {
+				t.Fatalf("unexpected result from Unmarshal: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			canonicalBytes, err := v.Canonicalize(context.Background())
+			if (err != nil) != tc.canonicalErr {
+				t.Fatalf("unexpected result from Canonicalize: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := v.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tc.wantKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("V001Entry.IndexKeys() = %v, want %v", got, tc.wantKeys)
+			}
+
+			sig := v.APKAndroidObj.Signature
+			if swag.StringValue(sig.Scheme) != models.APKAndroidV001SchemaSignatureSchemeV3 {
+				t.Errorf("unexpected scheme %v", swag.StringValue(sig.Scheme))
+			}
+			if !bytes.Equal(*sig.Content, a.Signer) {
+				t.Error("unexpected signer")
+			}
+
+			proposed, err := models.UnmarshalProposedEntry(bytes.NewReader(canonicalBytes), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			canonicalEntry, err := types.UnmarshalEntry(proposed)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			if len(canonicalEntry.(*V001Entry).APKAndroidObj.APK.Content) != 0 {
+				t.Error("canonicalized entry contains the apk")
+			}
+			canonicalKeys, err := canonicalEntry.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, canonicalKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("index keys from hydrated object do not match those generated from canonicalized (and re-hydrated) object: %v %v", got, canonicalKeys)
+			}
+		})
+	}
+}
+
+func TestCreateFromArtifactProperties(t *testing.T) {
+	apkBytes, err := os.ReadFile("../../../../tests/test_apk_android.apk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		props   types.ArtifactProperties
+		wantErr bool
+	}{
+		{
+			name:  "signed apk",
+			props: types.ArtifactProperties{ArtifactBytes: apkBytes},
+		},
+		{
+			name:    "unsigned file",
+			props:   types.ArtifactProperties{ArtifactBytes: []byte("hello world")},
+			wantErr: true,
+		},
+		{
+			name:    "missing artifact",
+			props:   types.ArtifactProperties{},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			proposed, err := V001Entry{}.CreateFromArtifactProperties(context.Background(), tc.props)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected result from CreateFromArtifactProperties: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			spec := proposed.(*models.APKAndroid).Spec.(models.APKAndroidV001Schema)
+			if swag.StringValue(spec.APK.Hash.Value) != sha256Hex(apkBytes) {
+				t.Errorf("unexpected hash %v", swag.StringValue(spec.APK.Hash.Value))
+			}
+			if spec.APK.PackageName != "dev.sigstore.rekor" {
+				t.Errorf("unexpected package name %q", spec.APK.PackageName)
+			}
+			if spec.Signature == nil || spec.Signature.PublicKey == nil {
+				t.Error("signer certificate was not extracted")
+			}
+			if _, err := types.UnmarshalEntry(proposed); err != nil {
+				t.Errorf("unexpected error unmarshalling created entry: %v", err)
+			}
+		})
+	}
+}
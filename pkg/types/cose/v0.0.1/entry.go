@@ -33,7 +33,6 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	"github.com/in-toto/in-toto-golang/in_toto"
-	"github.com/spf13/viper"
 	gocose "github.com/veraison/go-cose"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
@@ -291,8 +290,8 @@ func (v *V001Entry) AttestationKey() string {
 // into attestation storage
 func (v *V001Entry) AttestationKeyValue() (string, []byte) {
 	storageSize := len(v.CoseObj.Message)
-	if storageSize > viper.GetInt("max_attestation_size") {
-		log.Logger.Infof("Skipping attestation storage, size %d is greater than max %d", storageSize, viper.GetInt("max_attestation_size"))
+	if storageSize > types.MaxAttestationSize() {
+		log.Logger.Infof("Skipping attestation storage, size %d is greater than max %d", storageSize, types.MaxAttestationSize())
 		return "", nil
 	}
 
@@ -0,0 +1,245 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deb
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/ulikunitz/xz"
+
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+)
+
+const (
+	arMagic        = "!<arch>\n"
+	arHeaderSize   = 60
+	debianBinary   = "debian-binary"
+	originSigName  = "_gpgorigin"
+	controlTarName = "control.tar"
+	dataTarName    = "data.tar"
+)
+
+// Package is a Debian binary package
+type Package struct {
+	Control   map[string]string // fields of the control file
+	Signature []byte            // the debsigs origin signature, from the _gpgorigin member
+	// signedContent is the debian-binary, control.tar and data.tar members,
+	// which the origin signature is computed over
+	signedContent [][]byte
+}
+
+// Unmarshal parses the ar archive of a Debian package and its control file
+func (p *Package) Unmarshal(pkgReader io.Reader) error {
+	pkg := Package{}
+	r := bufio.NewReader(pkgReader)
+
+	magic := make([]byte, len(arMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != arMagic {
+		return errors.New("not an ar archive")
+	}
+
+	var control []byte
+	for i := 0; ; i++ {
+		name, content, err := readArMember(r)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		switch {
+		case i == 0:
+			if name != debianBinary || !strings.HasPrefix(string(content), "2.") {
+				return errors.New("debian-binary must be the first member of a Debian package and have format 2.x")
+			}
+			pkg.signedContent = append(pkg.signedContent, content)
+		case name == controlTarName || strings.HasPrefix(name, controlTarName+"."):
+			if control != nil {
+				return errors.New("duplicate control archive")
+			}
+			control, err = readControl(name, content)
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", name, err)
+			}
+			pkg.signedContent = append(pkg.signedContent, content)
+		case name == dataTarName || strings.HasPrefix(name, dataTarName+"."):
+			if len(pkg.signedContent) != 2 {
+				return errors.New("data archive must follow the control archive")
+			}
+			pkg.signedContent = append(pkg.signedContent, content)
+		case name == originSigName:
+			pkg.Signature = content
+		}
+	}
+
+	if control == nil {
+		return errors.New("control file was not located")
+	}
+	if len(pkg.signedContent) != 3 {
+		return errors.New("data archive was not located")
+	}
+
+	fields, err := parseControl(control)
+	if err != nil {
+		return fmt.Errorf("parsing control file: %w", err)
+	}
+	for _, f := range []string{"Package", "Version", "Architecture"} {
+		if fields[f] == "" {
+			return fmt.Errorf("control file is missing the %s field", f)
+		}
+	}
+	pkg.Control = fields
+
+	*p = pkg
+	return nil
+}
+
+// VerifySignature verifies the origin signature of the package with the
+// provided PGP public key
+func (p Package) VerifySignature(key *pgp.PublicKey) error {
+	if p.Signature == nil {
+		return errors.New("no _gpgorigin signature in Debian package")
+	}
+	sig, err := pgp.NewSignature(bytes.NewReader(p.Signature))
+	if err != nil {
+		return err
+	}
+	readers := make([]io.Reader, 0, len(p.signedContent))
+	for _, c := range p.signedContent {
+		readers = append(readers, bytes.NewReader(c))
+	}
+	return sig.Verify(io.MultiReader(readers...), key)
+}
+
+// readArMember reads the name and content of the next member of an ar archive
+func readArMember(r *bufio.Reader) (string, []byte, error) {
+	header := make([]byte, arHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		if err == io.EOF {
+			return "", nil, io.EOF
+		}
+		return "", nil, fmt.Errorf("reading ar header: %w", err)
+	}
+	if string(header[58:60]) != "`\n" {
+		return "", nil, errors.New("invalid ar header")
+	}
+	// GNU ar terminates names with a slash
+	name := strings.TrimSuffix(strings.TrimRight(string(header[0:16]), " "), "/")
+	size, err := strconv.ParseInt(strings.TrimRight(string(header[48:58]), " "), 10, 64)
+	if err != nil || size < 0 {
+		return "", nil, fmt.Errorf("invalid size of ar member %s", name)
+	}
+
+	content, err := ioutil.ReadAll(io.LimitReader(r, size))
+	if err != nil {
+		return "", nil, fmt.Errorf("reading ar member %s: %w", name, err)
+	}
+	if int64(len(content)) != size {
+		return "", nil, fmt.Errorf("ar member %s is truncated", name)
+	}
+	// members are aligned to an even offset
+	if size%2 == 1 {
+		if _, err := r.Discard(1); err != nil && err != io.EOF {
+			return "", nil, err
+		}
+	}
+	return name, content, nil
+}
+
+// readControl returns the control file from the control archive
+func readControl(name string, content []byte) ([]byte, error) {
+	var tarReader io.Reader
+	switch path.Ext(name) {
+	case ".tar":
+		tarReader = bytes.NewReader(content)
+	case ".gz":
+		gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		tarReader = gzipReader
+	case ".xz":
+		xzReader, err := xz.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		tarReader = xzReader
+	default:
+		return nil, fmt.Errorf("unsupported compression %s", path.Ext(name))
+	}
+
+	ctlReader := tar.NewReader(tarReader)
+	for {
+		header, err := ctlReader.Next()
+		if err == io.EOF {
+			return nil, errors.New("control file was not located")
+		} else if err != nil {
+			return nil, fmt.Errorf("getting next entry in tar archive: %w", err)
+		}
+		if path.Clean(header.Name) == "control" {
+			// #nosec G110
+			return ioutil.ReadAll(ctlReader)
+		}
+	}
+}
+
+// parseControl parses the first paragraph of a file in the deb822 format used
+// by control and InRelease files. Continuation lines of multi-line fields are
+// joined with newlines, without their leading space.
+func parseControl(input []byte) (map[string]string, error) {
+	fields := map[string]string{}
+	var last string
+	scanner := bufio.NewScanner(bytes.NewReader(input))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.TrimSpace(line) == "":
+			if len(fields) > 0 {
+				return fields, nil
+			}
+		case line[0] == ' ' || line[0] == '\t':
+			if last == "" {
+				return nil, errors.New("continuation line without a field")
+			}
+			if fields[last] != "" {
+				fields[last] += "\n"
+			}
+			fields[last] += strings.TrimSpace(line)
+		default:
+			i := strings.Index(line, ":")
+			if i <= 0 {
+				return nil, fmt.Errorf("invalid line %q", line)
+			}
+			last = line[:i]
+			fields[last] = strings.TrimSpace(line[i+1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,119 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deb
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+)
+
+func testKey(t *testing.T) *pgp.PublicKey {
+	t.Helper()
+	pubKey, err := os.Open("../../../tests/test_deb_public_key.key")
+	if err != nil {
+		t.Fatalf("could not open public key %v", err)
+	}
+	defer pubKey.Close()
+	key, err := pgp.NewPublicKey(pubKey)
+	if err != nil {
+		t.Fatalf("failed to parse public key: %v", err)
+	}
+	return key
+}
+
+func TestDebianPackage(t *testing.T) {
+	content, err := os.ReadFile("../../../tests/test_deb.deb")
+	if err != nil {
+		t.Fatalf("could not read package %v", err)
+	}
+
+	p := Package{}
+	if err := p.Unmarshal(bytes.NewReader(content)); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	for f, want := range map[string]string{
+		"Package":      "hello-rekor",
+		"Version":      "1.0-1",
+		"Architecture": "amd64",
+		"Description":  "test package\nfor rekor",
+	} {
+		if p.Control[f] != want {
+			t.Errorf("control field %s = %q, want %q", f, p.Control[f], want)
+		}
+	}
+	if err := p.VerifySignature(testKey(t)); err != nil {
+		t.Fatalf("signature verification failed: %v", err)
+	}
+
+	// tampering with the data archive breaks the signature
+	tampered := append([]byte{}, content...)
+	i := bytes.Index(tampered, []byte("data.tar.xz"))
+	tampered[i+arHeaderSize+100] ^= 0xff
+	p = Package{}
+	if err := p.Unmarshal(bytes.NewReader(tampered)); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if err := p.VerifySignature(testKey(t)); err == nil {
+		t.Error("expected signature verification of tampered package to fail")
+	}
+
+	// packages must be signed
+	unsigned := content[:bytes.Index(content, []byte(originSigName))]
+	p = Package{}
+	if err := p.Unmarshal(bytes.NewReader(unsigned)); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if err := p.VerifySignature(testKey(t)); err == nil {
+		t.Error("expected signature verification of unsigned package to fail")
+	}
+
+	for _, invalid := range [][]byte{
+		nil,
+		[]byte("not an archive"),
+		content[:len(arMagic)+arHeaderSize+10],
+	} {
+		p = Package{}
+		if err := p.Unmarshal(bytes.NewReader(invalid)); err == nil {
+			t.Errorf("expected error parsing %q", invalid)
+		}
+	}
+}
+
+func TestParseControl(t *testing.T) {
+	input := []byte("Package: foo\nDescription: short\n long\n .\n more\n\nPackage: bar\n")
+	want := map[string]string{
+		"Package":     "foo",
+		"Description": "short\nlong\n.\nmore",
+	}
+	got, err := parseControl(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseControl() = %v, want %v", got, want)
+	}
+
+	if _, err := parseControl([]byte(" continuation\n")); err == nil {
+		t.Error("expected error for continuation line without a field")
+	}
+	if _, err := parseControl([]byte("no separator\n")); err == nil {
+		t.Error("expected error for line without a separator")
+	}
+}
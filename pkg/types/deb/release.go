@@ -0,0 +1,105 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"golang.org/x/crypto/openpgp/clearsign"
+
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+)
+
+// Release is the clearsigned InRelease file of an APT repository
+type Release struct {
+	Fields map[string]string // fields of the release file
+	// PackagesIndices are the SHA256 hashes of the Packages indices listed
+	// in the release file, keyed by their path
+	PackagesIndices map[string]string
+	signature       []byte
+	signedContent   []byte
+}
+
+// Unmarshal parses a clearsigned InRelease file
+func (r *Release) Unmarshal(releaseReader io.Reader) error {
+	content, err := ioutil.ReadAll(releaseReader)
+	if err != nil {
+		return err
+	}
+	block, _ := clearsign.Decode(content)
+	if block == nil {
+		return errors.New("InRelease file is not clearsigned")
+	}
+	sig, err := ioutil.ReadAll(block.ArmoredSignature.Body)
+	if err != nil {
+		return fmt.Errorf("reading signature of InRelease file: %w", err)
+	}
+
+	fields, err := parseControl(block.Plaintext)
+	if err != nil {
+		return fmt.Errorf("parsing InRelease file: %w", err)
+	}
+
+	indices := map[string]string{}
+	if fields["SHA256"] != "" {
+		for _, line := range strings.Split(fields["SHA256"], "\n") {
+			// each line is the hash, size and path of a file
+			parts := strings.Fields(line)
+			if len(parts) != 3 {
+				return fmt.Errorf("invalid SHA256 line %q", line)
+			}
+			if !strings.HasPrefix(path.Base(parts[2]), "Packages") {
+				continue
+			}
+			hash := strings.ToLower(parts[0])
+			if !govalidator.IsHash(hash, "sha256") {
+				return fmt.Errorf("invalid SHA256 hash of %s", parts[2])
+			}
+			indices[parts[2]] = hash
+		}
+	}
+	if len(indices) == 0 {
+		return errors.New("InRelease file lists no Packages indices with SHA256 hashes")
+	}
+
+	*r = Release{
+		Fields:          fields,
+		PackagesIndices: indices,
+		signature:       sig,
+		signedContent:   block.Bytes,
+	}
+	return nil
+}
+
+// VerifySignature verifies the signature of the release file with the
+// provided PGP public key
+func (r Release) VerifySignature(key *pgp.PublicKey) error {
+	if r.signature == nil {
+		return errors.New("no signature in InRelease file")
+	}
+	sig, err := pgp.NewSignature(bytes.NewReader(r.signature))
+	if err != nil {
+		return err
+	}
+	return sig.Verify(bytes.NewReader(r.signedContent), key)
+}
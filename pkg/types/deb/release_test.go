@@ -0,0 +1,68 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deb
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestRelease(t *testing.T) {
+	content, err := os.ReadFile("../../../tests/test_deb_InRelease")
+	if err != nil {
+		t.Fatalf("could not read InRelease file %v", err)
+	}
+
+	r := Release{}
+	if err := r.Unmarshal(bytes.NewReader(content)); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	want := map[string]string{
+		"main/binary-amd64/Packages":    "1c4a6a1d0a2b05a4e9a17a3b4f9c4b2a8e3e1e4a7d9c6b5a4f3e2d1c0b9a8f7e",
+		"main/binary-amd64/Packages.xz": "8e4f0c1d2b3a4958677a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e",
+	}
+	if !reflect.DeepEqual(r.PackagesIndices, want) {
+		t.Errorf("PackagesIndices = %v, want %v", r.PackagesIndices, want)
+	}
+	if r.Fields["Suite"] != "stable" {
+		t.Errorf("Suite = %q, want stable", r.Fields["Suite"])
+	}
+	if err := r.VerifySignature(testKey(t)); err != nil {
+		t.Fatalf("signature verification failed: %v", err)
+	}
+
+	// tampering with a listed hash breaks the signature
+	tampered := bytes.Replace(content, []byte("1c4a6a1d"), []byte("2c4a6a1d"), 1)
+	r = Release{}
+	if err := r.Unmarshal(bytes.NewReader(tampered)); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if err := r.VerifySignature(testKey(t)); err == nil {
+		t.Error("expected signature verification of tampered InRelease file to fail")
+	}
+
+	for _, invalid := range [][]byte{
+		nil,
+		[]byte("Origin: Rekor\nSHA256:\n 1c4a 12 main/binary-amd64/Packages\n"),
+	} {
+		r = Release{}
+		if err := r.Unmarshal(bytes.NewReader(invalid)); err == nil {
+			t.Errorf("expected error parsing %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deb
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/deb"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := deb.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	DebModel models.DebV001Schema
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() ([]string, error) {
+	var result []string
+
+	keyObj, err := pgp.NewPublicKey(bytes.NewReader(*v.DebModel.PublicKey.Content))
+	if err != nil {
+		return nil, err
+	}
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	keyHash := sha256.Sum256(key)
+	result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+
+	result = append(result, keyObj.Subjects()...)
+
+	if pkg := v.DebModel.Package; pkg != nil {
+		if pkg.Hash != nil {
+			hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *pkg.Hash.Algorithm, *pkg.Hash.Value))
+			result = append(result, hashKey)
+		}
+		// index the package as name, name_version and name_version_arch,
+		// the last of which is how Debian names package files
+		if name := pkg.Headers["Package"]; name != "" {
+			result = append(result, types.PackageIndexKey(deb.KIND, name))
+			if version := pkg.Headers["Version"]; version != "" {
+				result = append(result, types.PackageIndexKey(deb.KIND, name+"_"+version))
+				if arch := pkg.Headers["Architecture"]; arch != "" {
+					result = append(result, types.PackageIndexKey(deb.KIND, name+"_"+version+"_"+arch))
+				}
+			}
+		}
+	}
+
+	if release := v.DebModel.Release; release != nil {
+		if release.Hash != nil {
+			hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *release.Hash.Algorithm, *release.Hash.Value))
+			result = append(result, hashKey)
+		}
+		for _, hash := range release.PackagesIndices {
+			result = append(result, models.DebV001SchemaReleaseHashAlgorithmSha256+":"+strings.ToLower(hash))
+		}
+	}
+
+	return result, nil
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	deb, ok := pe.(*models.Deb)
+	if !ok {
+		return errors.New("cannot unmarshal non Debian v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(deb.Spec, &v.DebModel); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.DebModel.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+// fetchExternalEntities parses and verifies the package or release file,
+// filling in its hash and the values it indexes
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) (*pgp.PublicKey, error) {
+	if err := v.validate(); err != nil {
+		return nil, types.ValidationError(err)
+	}
+
+	keyObj, err := pgp.NewPublicKey(bytes.NewReader(*v.DebModel.PublicKey.Content))
+	if err != nil {
+		return nil, types.ValidationError(err)
+	}
+
+	if pkg := v.DebModel.Package; pkg != nil {
+		if len(pkg.Content) == 0 {
+			return nil, types.ValidationError(errors.New("'content' must be specified for package"))
+		}
+		oldSHA := ""
+		if pkg.Hash != nil {
+			oldSHA = swag.StringValue(pkg.Hash.Value)
+		}
+		hash, err := checkHash(pkg.Content, oldSHA)
+		if err != nil {
+			return nil, types.ValidationError(err)
+		}
+
+		p := deb.Package{}
+		if err := p.Unmarshal(bytes.NewReader(pkg.Content)); err != nil {
+			return nil, types.ValidationError(err)
+		}
+		if err := p.VerifySignature(keyObj); err != nil {
+			return nil, types.ValidationError(err)
+		}
+
+		pkg.Hash = &models.DebV001SchemaPackageHash{
+			Algorithm: swag.String(models.DebV001SchemaPackageHashAlgorithmSha256),
+			Value:     swag.String(hash),
+		}
+		pkg.Headers = map[string]string{
+			"Package":      p.Control["Package"],
+			"Version":      p.Control["Version"],
+			"Architecture": p.Control["Architecture"],
+		}
+		return keyObj, nil
+	}
+
+	release := v.DebModel.Release
+	if len(release.Content) == 0 {
+		return nil, types.ValidationError(errors.New("'content' must be specified for release"))
+	}
+	oldSHA := ""
+	if release.Hash != nil {
+		oldSHA = swag.StringValue(release.Hash.Value)
+	}
+	hash, err := checkHash(release.Content, oldSHA)
+	if err != nil {
+		return nil, types.ValidationError(err)
+	}
+
+	r := deb.Release{}
+	if err := r.Unmarshal(bytes.NewReader(release.Content)); err != nil {
+		return nil, types.ValidationError(err)
+	}
+	if err := r.VerifySignature(keyObj); err != nil {
+		return nil, types.ValidationError(err)
+	}
+
+	release.Hash = &models.DebV001SchemaReleaseHash{
+		Algorithm: swag.String(models.DebV001SchemaReleaseHashAlgorithmSha256),
+		Value:     swag.String(hash),
+	}
+	release.PackagesIndices = r.PackagesIndices
+	return keyObj, nil
+}
+
+// checkHash returns the SHA256 hash of the content, which must match the
+// previously provided hash if there is one
+func checkHash(content []byte, oldSHA string) (string, error) {
+	h := sha256.Sum256(content)
+	computedSHA := hex.EncodeToString(h[:])
+	if oldSHA != "" && computedSHA != oldSHA {
+		return "", fmt.Errorf("SHA mismatch: %s != %s", computedSHA, oldSHA)
+	}
+	return computedSHA, nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	keyObj, err := v.fetchExternalEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry := models.DebV001Schema{}
+
+	// need to canonicalize key content
+	var pubKeyContent []byte
+	canonicalEntry.PublicKey = &models.DebV001SchemaPublicKey{}
+	pubKeyContent, err = keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.PublicKey.Content = (*strfmt.Base64)(&pubKeyContent)
+
+	// package and release content is not set deliberately
+	if pkg := v.DebModel.Package; pkg != nil {
+		canonicalEntry.Package = &models.DebV001SchemaPackage{
+			Hash:    pkg.Hash,
+			Headers: pkg.Headers,
+		}
+	} else {
+		canonicalEntry.Release = &models.DebV001SchemaRelease{
+			Hash:            v.DebModel.Release.Hash,
+			PackagesIndices: v.DebModel.Release.PackagesIndices,
+		}
+	}
+
+	// wrap in valid object with kind and apiVersion set
+	deb := models.Deb{}
+	deb.APIVersion = swag.String(APIVERSION)
+	deb.Spec = &canonicalEntry
+
+	return json.Marshal(&deb)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	key := v.DebModel.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if key.Content == nil || len(*key.Content) == 0 {
+		return errors.New("'content' must be specified for publicKey")
+	}
+
+	pkg, release := v.DebModel.Package, v.DebModel.Release
+	switch {
+	case pkg == nil && release == nil:
+		return errors.New("one of package or release must be specified")
+	case pkg != nil && release != nil:
+		return errors.New("only one of package or release may be specified")
+	case pkg != nil:
+		if pkg.Hash != nil {
+			if !govalidator.IsHash(swag.StringValue(pkg.Hash.Value), swag.StringValue(pkg.Hash.Algorithm)) {
+				return errors.New("invalid value for hash")
+			}
+		} else if len(pkg.Content) == 0 {
+			return errors.New("'content' must be specified for package")
+		}
+	default:
+		if release.Hash != nil {
+			if !govalidator.IsHash(swag.StringValue(release.Hash.Value), swag.StringValue(release.Hash.Algorithm)) {
+				return errors.New("invalid value for hash")
+			}
+		} else if len(release.Content) == 0 {
+			return errors.New("'content' must be specified for release")
+		}
+	}
+
+	return nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Deb{}
+	re := V001Entry{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		var artifactReader io.ReadCloser
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to artifact file must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			artifactReader, err = util.FileOrURLReadCloser(ctx, props.ArtifactPath.String(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+		} else {
+			artifactReader, err = os.Open(filepath.Clean(props.ArtifactPath.Path))
+			if err != nil {
+				return nil, fmt.Errorf("error opening artifact file: %w", err)
+			}
+		}
+		defer artifactReader.Close()
+		artifactBytes, err = ioutil.ReadAll(artifactReader)
+		if err != nil {
+			return nil, fmt.Errorf("error reading artifact file: %w", err)
+		}
+	}
+	// InRelease files are clearsigned text, while packages are ar archives
+	if bytes.HasPrefix(artifactBytes, []byte("-----BEGIN PGP SIGNED MESSAGE-----")) {
+		re.DebModel.Release = &models.DebV001SchemaRelease{
+			Content: strfmt.Base64(artifactBytes),
+		}
+	} else {
+		re.DebModel.Package = &models.DebV001SchemaPackage{
+			Content: strfmt.Base64(artifactBytes),
+		}
+	}
+
+	re.DebModel.PublicKey = &models.DebV001SchemaPublicKey{}
+	publicKeyBytes := props.PublicKeyBytes
+	if len(publicKeyBytes) == 0 {
+		if len(props.PublicKeyPaths) != 1 {
+			return nil, errors.New("only one public key must be provided to verify Debian signature")
+		}
+		keyBytes, err := ioutil.ReadFile(filepath.Clean(props.PublicKeyPaths[0].Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+		publicKeyBytes = append(publicKeyBytes, keyBytes)
+	} else if len(publicKeyBytes) != 1 {
+		return nil, errors.New("only one public key must be provided")
+	}
+
+	re.DebModel.PublicKey.Content = (*strfmt.Base64)(&publicKeyBytes[0])
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := re.fetchExternalEntities(ctx); err != nil {
+		return nil, fmt.Errorf("error retrieving external entities: %v", err)
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.DebModel
+
+	return &returnVal, nil
+}
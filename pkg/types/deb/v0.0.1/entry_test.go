@@ -0,0 +1,292 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deb
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func readFile(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile("../../../../tests/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	keyBytes := readFile(t, "test_deb_public_key.key")
+	debBytes := readFile(t, "test_deb.deb")
+	releaseBytes := readFile(t, "test_deb_InRelease")
+	invalidKeyBytes := readFile(t, "test_rpm_public_key.key")
+
+	keyObj, err := pgp.NewPublicKey(bytes.NewReader(keyBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonicalKey, err := keyObj.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyHash := sha256Hex(canonicalKey)
+
+	key := &models.DebV001SchemaPublicKey{Content: (*strfmt.Base64)(&keyBytes)}
+
+	tests := []struct {
+		name     string
+		model    models.DebV001Schema
+		wantKeys []string
+		// unmarshalErr is set when the entry is rejected before it is verified
+		unmarshalErr bool
+		canonicalErr bool
+	}{
+		{
+			name:         "empty",
+			model:        models.DebV001Schema{},
+			unmarshalErr: true,
+		},
+		{
+			name: "missing package and release",
+			model: models.DebV001Schema{
+				PublicKey: key,
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "package and release",
+			model: models.DebV001Schema{
+				PublicKey: key,
+				Package:   &models.DebV001SchemaPackage{Content: debBytes},
+				Release:   &models.DebV001SchemaRelease{Content: releaseBytes},
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "package",
+			model: models.DebV001Schema{
+				PublicKey: key,
+				Package:   &models.DebV001SchemaPackage{Content: debBytes},
+			},
+			wantKeys: []string{
+				keyHash,
+				"test@rekor.dev",
+				"sha256:" + sha256Hex(debBytes),
+				"deb:hello-rekor",
+				"deb:hello-rekor_1.0-1",
+				"deb:hello-rekor_1.0-1_amd64",
+			},
+		},
+		{
+			name: "package with matching hash",
+			model: models.DebV001Schema{
+				PublicKey: key,
+				Package: &models.DebV001SchemaPackage{
+					Content: debBytes,
+					Hash: &models.DebV001SchemaPackageHash{
+						Algorithm: swag.String(models.DebV001SchemaPackageHashAlgorithmSha256),
+						Value:     swag.String(sha256Hex(debBytes)),
+					},
+				},
+			},
+			wantKeys: []string{
+				keyHash,
+				"test@rekor.dev",
+				"sha256:" + sha256Hex(debBytes),
+				"deb:hello-rekor",
+				"deb:hello-rekor_1.0-1",
+				"deb:hello-rekor_1.0-1_amd64",
+			},
+		},
+		{
+			name: "package with mismatched hash",
+			model: models.DebV001Schema{
+				PublicKey: key,
+				Package: &models.DebV001SchemaPackage{
+					Content: debBytes,
+					Hash: &models.DebV001SchemaPackageHash{
+						Algorithm: swag.String(models.DebV001SchemaPackageHashAlgorithmSha256),
+						Value:     swag.String(sha256Hex(releaseBytes)),
+					},
+				},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "package with wrong key",
+			model: models.DebV001Schema{
+				PublicKey: &models.DebV001SchemaPublicKey{Content: (*strfmt.Base64)(&invalidKeyBytes)},
+				Package:   &models.DebV001SchemaPackage{Content: debBytes},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "not a package",
+			model: models.DebV001Schema{
+				PublicKey: key,
+				Package:   &models.DebV001SchemaPackage{Content: releaseBytes},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "release",
+			model: models.DebV001Schema{
+				PublicKey: key,
+				Release:   &models.DebV001SchemaRelease{Content: releaseBytes},
+			},
+			wantKeys: []string{
+				keyHash,
+				"test@rekor.dev",
+				"sha256:" + sha256Hex(releaseBytes),
+				"sha256:1c4a6a1d0a2b05a4e9a17a3b4f9c4b2a8e3e1e4a7d9c6b5a4f3e2d1c0b9a8f7e",
+				"sha256:8e4f0c1d2b3a4958677a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e",
+			},
+		},
+		{
+			name: "release with wrong key",
+			model: models.DebV001Schema{
+				PublicKey: &models.DebV001SchemaPublicKey{Content: (*strfmt.Base64)(&invalidKeyBytes)},
+				Release:   &models.DebV001SchemaRelease{Content: releaseBytes},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "not a release",
+			model: models.DebV001Schema{
+				PublicKey: key,
+				Release:   &models.DebV001SchemaRelease{Content: debBytes},
+			},
+			canonicalErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &V001Entry{}
+			err := v.Unmarshal(&models.Deb{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       tc.model,
+			})
+			if (err != nil) != tc.unmarshalErr {
+				t.Fatalf("unexpected result from Unmarshal: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			canonicalBytes, err := v.Canonicalize(context.Background())
+			if (err != nil) != tc.canonicalErr {
+				t.Fatalf("unexpected result from Canonicalize: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := v.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tc.wantKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("V001Entry.IndexKeys() = %v, want %v", got, tc.wantKeys)
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(canonicalBytes), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			canonicalEntry, err := types.UnmarshalEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			canonicalV001 := canonicalEntry.(*V001Entry)
+			if pkg := canonicalV001.DebModel.Package; pkg != nil && len(pkg.Content) != 0 {
+				t.Error("canonicalized entry contains the package")
+			}
+			if release := canonicalV001.DebModel.Release; release != nil && len(release.Content) != 0 {
+				t.Error("canonicalized entry contains the InRelease file")
+			}
+			canonicalKeys, err := canonicalEntry.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, canonicalKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("index keys from hydrated object do not match those generated from canonicalized (and re-hydrated) object: %v %v", got, canonicalKeys)
+			}
+		})
+	}
+}
+
+func TestCreateFromArtifactProperties(t *testing.T) {
+	keyBytes := readFile(t, "test_deb_public_key.key")
+
+	for _, tc := range []struct {
+		artifact string
+		release  bool
+	}{
+		{artifact: "test_deb.deb"},
+		{artifact: "test_deb_InRelease", release: true},
+	} {
+		t.Run(tc.artifact, func(t *testing.T) {
+			pe, err := V001Entry{}.CreateFromArtifactProperties(context.Background(), types.ArtifactProperties{
+				ArtifactBytes:  readFile(t, tc.artifact),
+				PublicKeyBytes: [][]byte{keyBytes},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			spec := pe.(*models.Deb).Spec.(models.DebV001Schema)
+			if (spec.Release != nil) != tc.release || (spec.Package != nil) == tc.release {
+				t.Errorf("unexpected entry for %s: %+v", tc.artifact, spec)
+			}
+			if _, err := types.UnmarshalEntry(pe); err != nil {
+				t.Errorf("unexpected error unmarshalling created entry: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsse
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	dsseType "github.com/sigstore/rekor/pkg/types/dsse"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := dsseType.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	DSSEObj models.DSSEV001Schema
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+// IndexKeys computes the list of keys that should map back to this entry.
+// It should *never* reference v.DSSEObj.ProposedContent as those values
+// would only be present at the time of insertion
+func (v V001Entry) IndexKeys() ([]string, error) {
+	var result []string
+
+	for _, sig := range v.DSSEObj.Signatures {
+		if sig == nil || sig.Verifier == nil {
+			return result, errors.New("missing or malformed signature")
+		}
+		keyObj, err := x509.NewPublicKey(bytes.NewReader(*sig.Verifier))
+		if err != nil {
+			return result, err
+		}
+
+		canonKey, err := keyObj.CanonicalValue()
+		if err != nil {
+			return result, fmt.Errorf("could not canonicize key: %w", err)
+		}
+
+		keyHash := sha256.Sum256(canonKey)
+		result = append(result, "sha256:"+hex.EncodeToString(keyHash[:]))
+
+		result = append(result, keyObj.Subjects()...)
+	}
+
+	if v.DSSEObj.PayloadHash != nil {
+		payloadHashKey := strings.ToLower(fmt.Sprintf("%s:%s", *v.DSSEObj.PayloadHash.Algorithm, *v.DSSEObj.PayloadHash.Value))
+		result = append(result, payloadHashKey)
+	}
+
+	if v.DSSEObj.EnvelopeHash != nil {
+		envelopeHashKey := strings.ToLower(fmt.Sprintf("%s:%s", *v.DSSEObj.EnvelopeHash.Algorithm, *v.DSSEObj.EnvelopeHash.Value))
+		result = append(result, envelopeHashKey)
+	}
+
+	return result, nil
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.DSSE)
+	if !ok {
+		return errors.New("cannot unmarshal non DSSE v0.0.1 type")
+	}
+
+	dsseObj := &models.DSSEV001Schema{}
+	if err := types.DecodeEntry(it.Spec, dsseObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := dsseObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// either we have just proposed content or the canonicalized fields
+	if dsseObj.ProposedContent == nil {
+		// then we need canonicalized fields, and all must be present (if present, they would have been validated in the above call to Validate())
+		if dsseObj.EnvelopeHash == nil || dsseObj.PayloadHash == nil || len(dsseObj.Signatures) == 0 {
+			return errors.New("either proposedContent or envelopeHash, payloadHash, and signatures must be present")
+		}
+		v.DSSEObj = *dsseObj
+		return nil
+	}
+	// if we're here, then we're trying to propose a new entry so we check to ensure client's aren't setting server-side computed fields
+	if dsseObj.EnvelopeHash != nil || dsseObj.PayloadHash != nil || len(dsseObj.Signatures) != 0 {
+		return errors.New("either proposedContent or envelopeHash, payloadHash, and signatures must be present but not both")
+	}
+
+	env := &dsse.Envelope{}
+	if err := json.Unmarshal([]byte(*dsseObj.ProposedContent.Envelope), env); err != nil {
+		return fmt.Errorf("envelope must be a valid dsse envelope: %w", err)
+	}
+	if len(env.Signatures) == 0 {
+		return errors.New("envelope must contain at least one signature")
+	}
+
+	allPubKeyBytes := make([][]byte, 0, len(dsseObj.ProposedContent.Verifiers))
+	for _, verifier := range dsseObj.ProposedContent.Verifiers {
+		allPubKeyBytes = append(allPubKeyBytes, verifier)
+	}
+
+	keysBySig, err := verifyEnvelope(allPubKeyBytes, env)
+	if err != nil {
+		return err
+	}
+
+	decodedPayload, err := env.DecodeB64Payload()
+	if err != nil {
+		return fmt.Errorf("could not decode envelope payload: %w", err)
+	}
+
+	for _, sig := range env.Signatures {
+		key := keysBySig[sig.Sig]
+		canonKey, err := key.CanonicalValue()
+		if err != nil {
+			return fmt.Errorf("could not canonicize key: %w", err)
+		}
+		keyBytes := strfmt.Base64(canonKey)
+		v.DSSEObj.Signatures = append(v.DSSEObj.Signatures, &models.DSSEV001SchemaSignaturesItems0{
+			Signature: swag.String(sig.Sig),
+			Verifier:  &keyBytes,
+		})
+	}
+	sort.Slice(v.DSSEObj.Signatures, func(i, j int) bool {
+		return *v.DSSEObj.Signatures[i].Signature < *v.DSSEObj.Signatures[j].Signature
+	})
+
+	payloadHash := sha256.Sum256(decodedPayload)
+	v.DSSEObj.PayloadHash = &models.DSSEV001SchemaPayloadHash{
+		Algorithm: swag.String(models.DSSEV001SchemaPayloadHashAlgorithmSha256),
+		Value:     swag.String(hex.EncodeToString(payloadHash[:])),
+	}
+
+	envelopeHash := sha256.Sum256([]byte(*dsseObj.ProposedContent.Envelope))
+	v.DSSEObj.EnvelopeHash = &models.DSSEV001SchemaEnvelopeHash{
+		Algorithm: swag.String(models.DSSEV001SchemaEnvelopeHashAlgorithmSha256),
+		Value:     swag.String(hex.EncodeToString(envelopeHash[:])),
+	}
+
+	v.DSSEObj.PayloadType = env.PayloadType
+	v.DSSEObj.ProposedContent = dsseObj.ProposedContent
+
+	return nil
+}
+
+// Canonicalize returns the entry without the proposed content, so that neither
+// the envelope nor its payload is persisted in the log
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if len(v.DSSEObj.Signatures) == 0 {
+		return nil, errors.New("cannot canonicalize entry without signatures")
+	}
+	if v.DSSEObj.EnvelopeHash == nil || v.DSSEObj.PayloadHash == nil {
+		return nil, errors.New("cannot canonicalize entry without envelope and payload hashes")
+	}
+
+	canonicalEntry := models.DSSEV001Schema{
+		PayloadType:  v.DSSEObj.PayloadType,
+		Signatures:   v.DSSEObj.Signatures,
+		EnvelopeHash: v.DSSEObj.EnvelopeHash,
+		PayloadHash:  v.DSSEObj.PayloadHash,
+	}
+
+	itObj := models.DSSE{}
+	itObj.APIVersion = swag.String(APIVERSION)
+	itObj.Spec = &canonicalEntry
+
+	return json.Marshal(&itObj)
+}
+
+// verifier wraps a signature.Verifier so it can be used to verify DSSE envelopes
+type verifier struct {
+	v signature.Verifier
+}
+
+func (v *verifier) KeyID() (string, error) {
+	return "", nil
+}
+
+func (v *verifier) Public() crypto.PublicKey {
+	// returning nil keeps the dsse library from skipping this verifier when the key ID
+	// of a signature doesn't match one generated from the public key, as key IDs are arbitrary
+	return nil
+}
+
+func (v *verifier) Verify(data, sig []byte) error {
+	if v.v == nil {
+		return errors.New("nil verifier")
+	}
+	return v.v.VerifySignature(bytes.NewReader(sig), bytes.NewReader(data))
+}
+
+// verifyEnvelope parses each of the key bytes as a public key or certificate and
+// verifies the envelope with it. Every signature on the envelope must be verified
+// by one of the keys. It returns the key that verified each signature.
+func verifyEnvelope(allPubKeyBytes [][]byte, env *dsse.Envelope) (map[string]*x509.PublicKey, error) {
+	keysBySig := make(map[string]*x509.PublicKey)
+	allSigs := make(map[string]struct{})
+	for _, sig := range env.Signatures {
+		allSigs[sig.Sig] = struct{}{}
+	}
+
+	for _, pubKeyBytes := range allPubKeyBytes {
+		key, err := x509.NewPublicKey(bytes.NewReader(pubKeyBytes))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse public key as x509: %w", err)
+		}
+
+		vfr, err := signature.LoadVerifier(key.CryptoPubKey(), crypto.SHA256)
+		if err != nil {
+			return nil, fmt.Errorf("could not load verifier: %w", err)
+		}
+
+		dsseVfr, err := dsse.NewEnvelopeVerifier(&verifier{v: vfr})
+		if err != nil {
+			return nil, fmt.Errorf("could not use public key as a dsse verifier: %w", err)
+		}
+
+		accepted, err := dsseVfr.Verify(env)
+		if err != nil {
+			return nil, fmt.Errorf("could not verify envelope: %w", err)
+		}
+
+		for _, accept := range accepted {
+			delete(allSigs, accept.Sig.Sig)
+			keysBySig[accept.Sig.Sig] = key
+		}
+	}
+
+	if len(allSigs) > 0 {
+		return nil, errors.New("all signatures must have a key that verifies it")
+	}
+
+	return keysBySig, nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(_ context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.DSSE{}
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to artifact file must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			return nil, errors.New("dsse envelopes cannot be fetched over HTTP(S)")
+		}
+		artifactBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	allPubKeyBytes := make([][]byte, 0)
+	allPubKeyBytes = append(allPubKeyBytes, props.PublicKeyBytes...)
+	for _, path := range props.PublicKeyPaths {
+		if path.IsAbs() {
+			return nil, errors.New("dsse public keys cannot be fetched over HTTP(S)")
+		}
+		publicKeyBytes, err := ioutil.ReadFile(filepath.Clean(path.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+		allPubKeyBytes = append(allPubKeyBytes, publicKeyBytes)
+	}
+	if len(allPubKeyBytes) == 0 {
+		return nil, errors.New("at least one public key or certificate must be provided to verify the envelope")
+	}
+
+	env := &dsse.Envelope{}
+	if err := json.Unmarshal(artifactBytes, env); err != nil {
+		return nil, fmt.Errorf("payload must be a valid dsse envelope: %w", err)
+	}
+	if _, err := verifyEnvelope(allPubKeyBytes, env); err != nil {
+		return nil, err
+	}
+
+	re := V001Entry{
+		DSSEObj: models.DSSEV001Schema{
+			ProposedContent: &models.DSSEV001SchemaProposedContent{
+				Envelope: swag.String(string(artifactBytes)),
+			},
+		},
+	}
+	for _, pubKeyBytes := range allPubKeyBytes {
+		re.DSSEObj.ProposedContent.Verifiers = append(re.DSSEObj.ProposedContent.Verifiers, strfmt.Base64(pubKeyBytes))
+	}
+
+	returnVal.Spec = re.DSSEObj
+	returnVal.APIVersion = swag.String(re.APIVersion())
+
+	return &returnVal, nil
+}
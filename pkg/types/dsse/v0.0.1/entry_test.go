@@ -0,0 +1,360 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsse
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+type signer struct {
+	s signature.Signer
+}
+
+func (s *signer) KeyID() (string, error) {
+	return "", nil
+}
+
+func (s *signer) Public() crypto.PublicKey {
+	return nil
+}
+
+func (s *signer) Sign(data []byte) ([]byte, error) {
+	return s.s.SignMessage(bytes.NewReader(data), options.WithCryptoSignerOpts(crypto.SHA256))
+}
+
+func (s *signer) Verify(data, sig []byte) error {
+	return nil
+}
+
+func envelope(t *testing.T, keys []*ecdsa.PrivateKey, payloadType string, payload []byte) string {
+	t.Helper()
+	var signers []dsse.SignVerifier
+	for _, k := range keys {
+		s, err := signature.LoadECDSASigner(k, crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers = append(signers, &signer{s: s})
+	}
+	es, err := dsse.NewMultiEnvelopeSigner(len(signers), signers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := es.SignPayload(payloadType, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func generateKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func generateCert(t *testing.T, email string) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		EmailAddresses: []string{email},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func proposed(env string, verifiers ...[]byte) *models.DSSEV001Schema {
+	pc := &models.DSSEV001SchemaProposedContent{
+		Envelope: swag.String(env),
+	}
+	for _, v := range verifiers {
+		pc.Verifiers = append(pc.Verifiers, strfmt.Base64(v))
+	}
+	return &models.DSSEV001Schema{ProposedContent: pc}
+}
+
+func sha256Key(b []byte) string {
+	h := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(h[:])
+}
+
+func TestV001Entry_Unmarshal(t *testing.T) {
+	key, pub := generateKey(t)
+	certKey, cert := generateCert(t, "signer@example.com")
+	_, otherPub := generateKey(t)
+
+	payload := []byte(`{"bomFormat":"CycloneDX"}`)
+	const payloadType = "application/vnd.cyclonedx+json"
+
+	single := envelope(t, []*ecdsa.PrivateKey{key}, payloadType, payload)
+	multi := envelope(t, []*ecdsa.PrivateKey{key, certKey}, payloadType, payload)
+
+	tests := []struct {
+		name     string
+		obj      *models.DSSEV001Schema
+		wantKeys []string
+		wantErr  bool
+	}{
+		{
+			name:    "empty",
+			obj:     &models.DSSEV001Schema{},
+			wantErr: true,
+		},
+		{
+			name: "key",
+			obj:  proposed(single, pub),
+			wantKeys: []string{
+				sha256Key(pub),
+			},
+		},
+		{
+			name: "certificate",
+			obj:  proposed(envelope(t, []*ecdsa.PrivateKey{certKey}, payloadType, payload), cert),
+			wantKeys: []string{
+				sha256Key(cert),
+				"signer@example.com",
+			},
+		},
+		{
+			name: "multiple signatures",
+			obj:  proposed(multi, cert, pub),
+			wantKeys: []string{
+				sha256Key(pub),
+				sha256Key(cert),
+				"signer@example.com",
+			},
+		},
+		{
+			name:    "unverified signature",
+			obj:     proposed(multi, pub),
+			wantErr: true,
+		},
+		{
+			name:    "wrong key",
+			obj:     proposed(single, otherPub),
+			wantErr: true,
+		},
+		{
+			name:    "invalid key",
+			obj:     proposed(single, []byte("notavalidkey")),
+			wantErr: true,
+		},
+		{
+			name:    "missing verifiers",
+			obj:     proposed(single),
+			wantErr: true,
+		},
+		{
+			name:    "invalid envelope",
+			obj:     proposed("{", pub),
+			wantErr: true,
+		},
+		{
+			name: "unsigned envelope",
+			obj: proposed(`{"payload":"aGVsbG8=","payloadType":"text/plain","signatures":[]}`,
+				pub),
+			wantErr: true,
+		},
+		{
+			name: "proposed content with hashes",
+			obj: func() *models.DSSEV001Schema {
+				o := proposed(single, pub)
+				o.PayloadHash = &models.DSSEV001SchemaPayloadHash{
+					Algorithm: swag.String(models.DSSEV001SchemaPayloadHashAlgorithmSha256),
+					Value:     swag.String("abc"),
+				}
+				return o
+			}(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &V001Entry{}
+			err := v.Unmarshal(&models.DSSE{Spec: tt.obj})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if v.DSSEObj.PayloadType != payloadType {
+				t.Errorf("payload type = %v, want %v", v.DSSEObj.PayloadType, payloadType)
+			}
+			if !sort.SliceIsSorted(v.DSSEObj.Signatures, func(i, j int) bool {
+				return *v.DSSEObj.Signatures[i].Signature < *v.DSSEObj.Signatures[j].Signature
+			}) {
+				t.Error("signatures are not sorted")
+			}
+
+			want := append(tt.wantKeys, sha256Key(payload), sha256Key([]byte(*tt.obj.ProposedContent.Envelope)))
+			got, err := v.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, want, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("V001Entry.IndexKeys() = %v, want %v", got, want)
+			}
+
+			canonicalBytes, err := v.Canonicalize(context.Background())
+			if err != nil {
+				t.Fatalf("error canonicalizing entry: %v", err)
+			}
+			if strings.Contains(string(canonicalBytes), "proposedContent") || strings.Contains(string(canonicalBytes), "payload\"") {
+				t.Errorf("canonicalized entry contains the envelope: %s", canonicalBytes)
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(canonicalBytes), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			canonicalEntry, err := types.UnmarshalEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			canonicalV001 := canonicalEntry.(*V001Entry)
+			if canonicalV001.DSSEObj.ProposedContent != nil {
+				t.Error("canonicalized entry has proposed content")
+			}
+			if *canonicalV001.DSSEObj.PayloadHash.Value != *v.DSSEObj.PayloadHash.Value {
+				t.Errorf("payload hashes do not match post canonicalization")
+			}
+			if *canonicalV001.DSSEObj.EnvelopeHash.Value != *v.DSSEObj.EnvelopeHash.Value {
+				t.Errorf("envelope hashes do not match post canonicalization")
+			}
+			canonicalIndexKeys, err := canonicalV001.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, canonicalIndexKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("index keys from hydrated object do not match those generated from canonicalized (and re-hydrated) object: %v %v", got, canonicalIndexKeys)
+			}
+		})
+	}
+}
+
+func TestV001Entry_CreateFromArtifactProperties(t *testing.T) {
+	key, pub := generateKey(t)
+	_, otherPub := generateKey(t)
+	env := envelope(t, []*ecdsa.PrivateKey{key}, "text/plain", []byte("hello"))
+
+	tests := []struct {
+		name    string
+		props   types.ArtifactProperties
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			props: types.ArtifactProperties{
+				ArtifactBytes:  []byte(env),
+				PublicKeyBytes: [][]byte{pub},
+			},
+		},
+		{
+			name: "missing key",
+			props: types.ArtifactProperties{
+				ArtifactBytes: []byte(env),
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong key",
+			props: types.ArtifactProperties{
+				ArtifactBytes:  []byte(env),
+				PublicKeyBytes: [][]byte{otherPub},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing artifact",
+			props: types.ArtifactProperties{
+				PublicKeyBytes: [][]byte{pub},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pe, err := V001Entry{}.CreateFromArtifactProperties(context.Background(), tt.props)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.CreateFromArtifactProperties() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if _, err := types.UnmarshalEntry(pe); err != nil {
+				t.Errorf("unexpected error unmarshalling created entry: %v", err)
+			}
+		})
+	}
+}
@@ -22,11 +22,13 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"sync/atomic"
 
 	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
 	"github.com/go-openapi/strfmt"
 	"github.com/mitchellh/mapstructure"
 	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/spf13/viper"
 )
 
 // EntryImpl specifies the behavior of a versioned type
@@ -45,6 +47,33 @@ type EntryWithAttestationImpl interface {
 	AttestationKeyValue() (string, []byte) // returns the key to be used when storing the attestation as well as the attestation itself
 }
 
+// maxAttestationSize holds the size set by SetMaxAttestationSize, if any
+var maxAttestationSize atomic.Value
+
+// SetMaxAttestationSize sets the size in bytes above which attestations are
+// not stored. The server sets it when its config is reloaded, as viper is not
+// safe to change while requests are being handled.
+func SetMaxAttestationSize(size int) {
+	maxAttestationSize.Store(size)
+}
+
+// MaxAttestationSize returns the size in bytes above which attestations are
+// not stored, which is the max_attestation_size setting unless it was set by
+// SetMaxAttestationSize
+func MaxAttestationSize() int {
+	if size, ok := maxAttestationSize.Load().(int); ok {
+		return size
+	}
+	return viper.GetInt("max_attestation_size")
+}
+
+// PackageIndexKey returns the key under which entries of the kind are indexed
+// by the name of a package, as searched for with the package query of the
+// search index. The kind keeps the names of different ecosystems apart.
+func PackageIndexKey(kind, name string) string {
+	return kind + ":" + name
+}
+
 // EntryFactory describes a factory function that can generate structs for a specific versioned type
 type EntryFactory func() EntryImpl
 
@@ -0,0 +1,198 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"crypto/sha1" // #nosec G505
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+)
+
+const (
+	TypeCommit = "commit"
+	TypeTag    = "tag"
+
+	FormatPGP  = "pgp"
+	FormatSSH  = "ssh"
+	FormatX509 = "x509"
+
+	// signatureHeader is the commit header that holds the signature of a
+	// commit in a SHA1 repository
+	signatureHeader = "gpgsig"
+)
+
+// signatureFormats maps the first line of each kind of signature that git
+// creates to its format, see gpg-interface.c in git
+var signatureFormats = map[string]string{
+	"-----BEGIN PGP SIGNATURE-----":  FormatPGP,
+	"-----BEGIN PGP MESSAGE-----":    FormatPGP,
+	"-----BEGIN SSH SIGNATURE-----":  FormatSSH,
+	"-----BEGIN SIGNED MESSAGE-----": FormatX509,
+}
+
+// Object is a signed git commit or tag
+type Object struct {
+	Type string
+	// ID is the SHA1 object ID of the commit or tag
+	ID string
+	// TreeID is the object ID of the tree of a commit, and empty for tags
+	TreeID string
+	// Committer is the email address of the committer of a commit, or of
+	// the tagger of a tag
+	Committer string
+	// Signature is the armored signature embedded in the object
+	Signature       []byte
+	SignatureFormat string
+	// SignedContent is the object without its signature, which the
+	// signature is computed over
+	SignedContent []byte
+}
+
+// Unmarshal parses a raw commit or tag object, as printed by
+// "git cat-file commit" or "git cat-file tag", and separates the signature
+// from the content it signs. The signature is not verified.
+func (o *Object) Unmarshal(content []byte) error {
+	i := bytes.Index(content, []byte("\n\n"))
+	if i < 0 {
+		return errors.New("object does not have a message")
+	}
+	headers := strings.Split(string(content[:i]), "\n")
+
+	var objType string
+	switch {
+	case strings.HasPrefix(headers[0], "tree "):
+		objType = TypeCommit
+	case strings.HasPrefix(headers[0], "object "):
+		objType = TypeTag
+	default:
+		return errors.New("object is not a commit or a tag")
+	}
+
+	obj := Object{Type: objType}
+	var signed bytes.Buffer
+	var sig []string
+	for j := 0; j < len(headers); j++ {
+		key, value, _ := strings.Cut(headers[j], " ")
+		switch key {
+		case "tree":
+			if objType == TypeCommit {
+				obj.TreeID = value
+			}
+		case "committer":
+			if objType == TypeCommit {
+				obj.Committer = parseEmail(value)
+			}
+		case "tagger":
+			if objType == TypeTag {
+				obj.Committer = parseEmail(value)
+			}
+		case signatureHeader:
+			if objType != TypeCommit || sig != nil {
+				break
+			}
+			// the value continues on the following lines, which start with
+			// a space
+			sig = append(sig, value)
+			for j+1 < len(headers) && strings.HasPrefix(headers[j+1], " ") {
+				j++
+				sig = append(sig, headers[j][1:])
+			}
+			continue
+		}
+		signed.WriteString(headers[j])
+		signed.WriteByte('\n')
+	}
+	message := content[i+1:]
+
+	if objType == TypeCommit {
+		if !govalidator.IsHash(obj.TreeID, "sha1") {
+			return fmt.Errorf("commit has an invalid tree %q", obj.TreeID)
+		}
+		if sig == nil {
+			return errors.New("commit is not signed")
+		}
+		obj.Signature = []byte(strings.Join(sig, "\n") + "\n")
+		signed.Write(message)
+	} else {
+		// the signature of a tag is appended to its message, starting at
+		// the last line that begins a signature
+		start := -1
+		for _, offset := range lineOffsets(message) {
+			line := message[offset:]
+			if end := bytes.IndexByte(line, '\n'); end >= 0 {
+				line = line[:end]
+			}
+			if _, ok := signatureFormats[string(line)]; ok {
+				start = offset
+			}
+		}
+		if start < 0 {
+			return errors.New("tag is not signed")
+		}
+		obj.Signature = message[start:]
+		signed.Write(message[:start])
+	}
+
+	firstLine, _, _ := strings.Cut(string(obj.Signature), "\n")
+	format, ok := signatureFormats[firstLine]
+	if !ok {
+		return fmt.Errorf("unknown signature type %q", firstLine)
+	}
+	obj.SignatureFormat = format
+	obj.SignedContent = signed.Bytes()
+	obj.ID = ObjectID(objType, content)
+
+	*o = obj
+	return nil
+}
+
+// ObjectID returns the SHA1 object ID of a git object of the given type
+func ObjectID(objType string, content []byte) string {
+	h := sha1.New() // #nosec G401
+	fmt.Fprintf(h, "%s %d\x00", objType, len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// parseEmail returns the email address in an identity such as
+// "Name <email> timestamp timezone"
+func parseEmail(ident string) string {
+	start := strings.Index(ident, "<")
+	end := strings.LastIndex(ident, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return ident[start+1 : end]
+}
+
+// lineOffsets returns the offset of the start of each line in b
+func lineOffsets(b []byte) []int {
+	offsets := []int{}
+	for i := 0; i < len(b); {
+		offsets = append(offsets, i)
+		end := bytes.IndexByte(b[i:], '\n')
+		if end < 0 {
+			break
+		}
+		i += end + 1
+	}
+	return offsets
+}
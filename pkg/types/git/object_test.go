@@ -0,0 +1,136 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestObject(t *testing.T) {
+	for _, tc := range []struct {
+		file          string
+		want          Object
+		signedContent string
+	}{
+		{
+			file: "test_git_commit_ssh",
+			want: Object{
+				Type:            TypeCommit,
+				ID:              "9e586becdbeb924891484b43c89cfa1e16e6eab8",
+				TreeID:          "aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7",
+				Committer:       "test@rekor.dev",
+				SignatureFormat: FormatSSH,
+			},
+			signedContent: "tree aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7\n" +
+				"author Rekor Test <test@rekor.dev> 1660000000 +0000\n" +
+				"committer Rekor Test <test@rekor.dev> 1660000000 +0000\n" +
+				"\nAdd hello\n",
+		},
+		{
+			file: "test_git_commit_pgp",
+			want: Object{
+				Type:            TypeCommit,
+				ID:              "82416c5e638923a4ce5393467f19c3bed523f5bc",
+				TreeID:          "b2ebf159fb11cd612e1c3e62636be14ce60424ad",
+				Committer:       "test@rekor.dev",
+				SignatureFormat: FormatPGP,
+			},
+			signedContent: "tree b2ebf159fb11cd612e1c3e62636be14ce60424ad\n" +
+				"parent 9e586becdbeb924891484b43c89cfa1e16e6eab8\n" +
+				"author Rekor Test <test@rekor.dev> 1660000000 +0000\n" +
+				"committer Rekor Test <test@rekor.dev> 1660000000 +0000\n" +
+				"\nAdd world\n",
+		},
+		{
+			file: "test_git_tag_pgp",
+			want: Object{
+				Type:            TypeTag,
+				ID:              "39ff86c30fb2d41f5d99d4a43fb0a9ddd175bb34",
+				Committer:       "test@rekor.dev",
+				SignatureFormat: FormatPGP,
+			},
+			signedContent: "object 82416c5e638923a4ce5393467f19c3bed523f5bc\n" +
+				"type commit\n" +
+				"tag v1.0.0\n" +
+				"tagger Rekor Test <test@rekor.dev> 1660000000 +0000\n" +
+				"\nRelease v1.0.0\n",
+		},
+		{
+			file: "test_git_tag_ssh",
+			want: Object{
+				Type:            TypeTag,
+				ID:              "150ac0e25922ce021da1f64b0eaec6a14e9c5ce7",
+				Committer:       "test@rekor.dev",
+				SignatureFormat: FormatSSH,
+			},
+			signedContent: "object 82416c5e638923a4ce5393467f19c3bed523f5bc\n" +
+				"type commit\n" +
+				"tag v1.0.1\n" +
+				"tagger Rekor Test <test@rekor.dev> 1660000000 +0000\n" +
+				"\nRelease v1.0.1\n",
+		},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			content, err := os.ReadFile("../../../tests/" + tc.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o := Object{}
+			if err := o.Unmarshal(content); err != nil {
+				t.Fatalf("unmarshal error: %v", err)
+			}
+			if o.Type != tc.want.Type || o.ID != tc.want.ID || o.TreeID != tc.want.TreeID ||
+				o.Committer != tc.want.Committer || o.SignatureFormat != tc.want.SignatureFormat {
+				t.Errorf("Unmarshal() = %+v, want %+v", o, tc.want)
+			}
+			if string(o.SignedContent) != tc.signedContent {
+				t.Errorf("signed content = %q, want %q", o.SignedContent, tc.signedContent)
+			}
+			// the signature is unindented and kept intact
+			if !bytes.HasPrefix(o.Signature, []byte("-----BEGIN ")) || !bytes.HasSuffix(o.Signature, []byte("-----\n")) {
+				t.Errorf("unexpected signature %q", o.Signature)
+			}
+			if bytes.Contains(o.Signature, []byte("\n ")) {
+				t.Errorf("signature is still indented: %q", o.Signature)
+			}
+		})
+	}
+}
+
+func TestObjectInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+	}{
+		{name: "empty", content: ""},
+		{name: "no message", content: "tree aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7\n"},
+		{name: "blob", content: "hello\n\nworld\n"},
+		{name: "unsigned commit", content: "tree aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7\n\nAdd hello\n"},
+		{name: "unsigned tag", content: "object 82416c5e638923a4ce5393467f19c3bed523f5bc\ntype commit\ntag v1\n\nRelease\n"},
+		{name: "SHA256 tree", content: "tree " + strings.Repeat("a", 64) + "\ngpgsig -----BEGIN SSH SIGNATURE-----\n -----END SSH SIGNATURE-----\n\nAdd hello\n"},
+		{name: "unknown signature", content: "tree aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7\ngpgsig -----BEGIN OTHER-----\n -----END OTHER-----\n\nAdd hello\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			o := Object{}
+			if err := o.Unmarshal([]byte(tc.content)); err == nil {
+				t.Errorf("expected error unmarshalling %q", tc.content)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	relicpkcs7 "github.com/sassoftware/relic/lib/pkcs7"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/pki/pkcs7"
+	"github.com/sigstore/rekor/pkg/pki/ssh"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/git"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := git.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	GitObj models.GitV001Schema
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() ([]string, error) {
+	var result []string
+
+	af, err := pki.NewArtifactFactory(pki.Format(v.GitObj.Signature.Format))
+	if err != nil {
+		return nil, err
+	}
+	keyObj, err := af.NewPublicKey(bytes.NewReader(*v.GitObj.Signature.PublicKey.Content))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		log.Logger.Error(err)
+	} else {
+		keyHash := sha256.Sum256(key)
+		result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+	}
+
+	result = append(result, keyObj.Subjects()...)
+
+	obj := v.GitObj.Object
+	result = append(result, "sha1:"+obj.ID)
+	if obj.TreeID != "" {
+		result = append(result, "sha1:"+obj.TreeID)
+	}
+	if obj.Committer != "" {
+		result = append(result, strings.ToLower(obj.Committer))
+	}
+
+	return result, nil
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	gitObj, ok := pe.(*models.Git)
+	if !ok {
+		return errors.New("cannot unmarshal non git v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(gitObj.Spec, &v.GitObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.GitObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// cross field validation
+	return v.validate()
+}
+
+// fetchExternalEntities parses the commit or tag, verifies the signature
+// embedded in it and fills in the fields derived from the object
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) (pki.PublicKey, pki.Signature, error) {
+	if err := v.validate(); err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	obj := git.Object{}
+	if err := obj.Unmarshal(v.GitObj.Object.Content); err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	var userKey []byte
+	if v.GitObj.Signature != nil && v.GitObj.Signature.PublicKey != nil {
+		userKey = *v.GitObj.Signature.PublicKey.Content
+	}
+
+	var keyObj pki.PublicKey
+	var sigObj pki.Signature
+	var err error
+	switch obj.SignatureFormat {
+	case git.FormatPGP, git.FormatSSH:
+		if len(userKey) == 0 {
+			return nil, nil, types.ValidationError(fmt.Errorf("'publicKey' must be specified for %s signatures", obj.SignatureFormat))
+		}
+		af, err := pki.NewArtifactFactory(pki.Format(obj.SignatureFormat))
+		if err != nil {
+			return nil, nil, err
+		}
+		keyObj, err = af.NewPublicKey(bytes.NewReader(userKey))
+		if err != nil {
+			return nil, nil, types.ValidationError(err)
+		}
+		if obj.SignatureFormat == git.FormatSSH {
+			// git signs with its own namespace rather than the one for files
+			sigObj, err = ssh.NewSignatureWithNamespace(bytes.NewReader(obj.Signature), "git")
+		} else {
+			sigObj, err = af.NewSignature(bytes.NewReader(obj.Signature))
+		}
+		if err != nil {
+			return nil, nil, types.ValidationError(err)
+		}
+		if err := sigObj.Verify(bytes.NewReader(obj.SignedContent), keyObj); err != nil {
+			return nil, nil, types.ValidationError(fmt.Errorf("verifying signature: %w", err))
+		}
+	case git.FormatX509:
+		keyObj, sigObj, err = verifyX509(obj.Signature, obj.SignedContent, userKey)
+		if err != nil {
+			return nil, nil, types.ValidationError(err)
+		}
+	}
+
+	v.GitObj.Object.Type = obj.Type
+	v.GitObj.Object.ID = obj.ID
+	v.GitObj.Object.TreeID = obj.TreeID
+	v.GitObj.Object.Committer = obj.Committer
+	if v.GitObj.Signature == nil {
+		v.GitObj.Signature = &models.GitV001SchemaSignature{}
+	}
+	v.GitObj.Signature.Format = obj.SignatureFormat
+	// the key is kept in its canonical form, which for x509 signatures is
+	// the certificate of the signer rather than any public key that was given
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	v.GitObj.Signature.PublicKey = &models.GitV001SchemaSignaturePublicKey{
+		Content: (*strfmt.Base64)(&key),
+	}
+
+	return keyObj, sigObj, nil
+}
+
+// verifyX509 verifies a CMS signature made by gpgsm, and returns the
+// certificate of the signer as the public key. If a public key is given,
+// it must be the key of the signer.
+func verifyX509(signature, signedContent, publicKey []byte) (pki.PublicKey, pki.Signature, error) {
+	block, _ := pem.Decode(signature)
+	if block == nil || block.Type != "SIGNED MESSAGE" {
+		return nil, nil, errors.New("invalid x509 signature")
+	}
+	sigObj, err := pkcs7.NewSignature(bytes.NewReader(block.Bytes))
+	if err != nil {
+		return nil, nil, err
+	}
+	psd, err := relicpkcs7.Unmarshal(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	// this checks the signature against the certificate it names as the
+	// signer, which may not be the first one in the signature
+	signer, err := psd.Content.Verify(signedContent, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("verifying signature: %w", err)
+	}
+	certPEM, err := cryptoutils.MarshalCertificateToPEM(signer.Certificate)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyObj, err := x509.NewPublicKey(bytes.NewReader(certPEM))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(publicKey) > 0 {
+		userKey, err := x509.NewPublicKey(bytes.NewReader(publicKey))
+		if err != nil {
+			return nil, nil, err
+		}
+		pub, ok := userKey.CryptoPubKey().(interface{ Equal(crypto.PublicKey) bool })
+		if !ok || !pub.Equal(keyObj.CryptoPubKey()) {
+			return nil, nil, errors.New("signature was not made by the given public key")
+		}
+	}
+	return keyObj, sigObj, nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	keyObj, sigObj, err := v.fetchExternalEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry := models.GitV001Schema{}
+
+	// need to canonicalize signature & key content
+	canonicalEntry.Signature = &models.GitV001SchemaSignature{}
+	canonicalEntry.Signature.Format = v.GitObj.Signature.Format
+
+	var sigContent []byte
+	sigContent, err = sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.Content = strfmt.Base64(sigContent)
+
+	var pubKeyContent []byte
+	canonicalEntry.Signature.PublicKey = &models.GitV001SchemaSignaturePublicKey{}
+	pubKeyContent, err = keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.PublicKey.Content = (*strfmt.Base64)(&pubKeyContent)
+
+	canonicalEntry.Object = &models.GitV001SchemaObject{
+		Type:      v.GitObj.Object.Type,
+		ID:        v.GitObj.Object.ID,
+		TreeID:    v.GitObj.Object.TreeID,
+		Committer: v.GitObj.Object.Committer,
+	}
+	// object content is not set deliberately
+
+	// wrap in valid object with kind and apiVersion set
+	gitObj := models.Git{}
+	gitObj.APIVersion = swag.String(APIVERSION)
+	gitObj.Spec = &canonicalEntry
+
+	return json.Marshal(&gitObj)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	obj := v.GitObj.Object
+	if obj == nil {
+		return errors.New("missing object")
+	}
+	sig := v.GitObj.Signature
+	if sig != nil && sig.PublicKey != nil && (sig.PublicKey.Content == nil || len(*sig.PublicKey.Content) == 0) {
+		return errors.New("'content' must be specified for publicKey")
+	}
+	if len(obj.Content) > 0 {
+		return nil
+	}
+
+	// without the object, the entry must hold what was derived from it
+	if obj.ID == "" || obj.Type == "" {
+		return errors.New("'content' must be specified for object")
+	}
+	if obj.Type == models.GitV001SchemaObjectTypeCommit && obj.TreeID == "" {
+		return errors.New("missing tree of commit")
+	}
+	if sig == nil || sig.Format == "" || len(sig.Content) == 0 || sig.PublicKey == nil {
+		return errors.New("missing signature")
+	}
+	return nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Git{}
+	re := V001Entry{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		var artifactReader io.ReadCloser
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to commit or tag object must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			artifactReader, err = util.FileOrURLReadCloser(ctx, props.ArtifactPath.String(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("error reading git object: %w", err)
+			}
+		} else {
+			artifactReader, err = os.Open(filepath.Clean(props.ArtifactPath.Path))
+			if err != nil {
+				return nil, fmt.Errorf("error opening git object: %w", err)
+			}
+		}
+		defer artifactReader.Close()
+		artifactBytes, err = ioutil.ReadAll(artifactReader)
+		if err != nil {
+			return nil, fmt.Errorf("error reading git object: %w", err)
+		}
+	}
+	re.GitObj.Object = &models.GitV001SchemaObject{
+		Content: strfmt.Base64(artifactBytes),
+	}
+
+	// the public key is optional for x509 signatures, which carry the
+	// certificate of the signer
+	publicKeyBytes := props.PublicKeyBytes
+	if len(publicKeyBytes) == 0 && len(props.PublicKeyPaths) > 0 {
+		if len(props.PublicKeyPaths) != 1 {
+			return nil, errors.New("only one public key must be provided to verify the signature")
+		}
+		keyBytes, err := ioutil.ReadFile(filepath.Clean(props.PublicKeyPaths[0].Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+		publicKeyBytes = append(publicKeyBytes, keyBytes)
+	} else if len(publicKeyBytes) > 1 {
+		return nil, errors.New("only one public key must be provided")
+	}
+	if len(publicKeyBytes) == 1 {
+		re.GitObj.Signature = &models.GitV001SchemaSignature{
+			PublicKey: &models.GitV001SchemaSignaturePublicKey{
+				Content: (*strfmt.Base64)(&publicKeyBytes[0]),
+			},
+		}
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if _, _, err := re.fetchExternalEntities(ctx); err != nil {
+		return nil, fmt.Errorf("error retrieving external entities: %v", err)
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.GitObj
+
+	return &returnVal, nil
+}
@@ -0,0 +1,377 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	relicpkcs7 "github.com/sassoftware/relic/lib/pkcs7"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/git"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func readFile(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := ioutil.ReadFile("../../../../tests/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// keyIndex returns the index keys derived from a public key
+func keyIndex(t *testing.T, format string, key []byte) []string {
+	t.Helper()
+	af, err := pki.NewArtifactFactory(pki.Format(format))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyObj, err := af.NewPublicKey(bytes.NewReader(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonicalKey, err := keyObj.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.Sum256(canonicalKey)
+	return append([]string{hex.EncodeToString(h[:])}, keyObj.Subjects()...)
+}
+
+// x509Commit returns a commit signed in the way gpgsm does, the public key
+// and the certificate of the signer
+func x509Commit(t *testing.T, message string) ([]byte, []byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: "Rekor Test"},
+		EmailAddresses: []string{"test@rekor.dev"},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := "tree aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7\n" +
+		"author Rekor Test <test@rekor.dev> 1660000000 +0000\n" +
+		"committer Rekor Test <test@rekor.dev> 1660000000 +0000\n"
+	body := "\n" + message + "\n"
+	digest := sha256.Sum256([]byte(headers + body))
+	builder := relicpkcs7.NewBuilder(key, []*x509.Certificate{cert}, crypto.SHA256)
+	if err := builder.SetDetachedContent(relicpkcs7.OidData, digest[:]); err != nil {
+		t.Fatal(err)
+	}
+	psd, err := builder.Sign()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigDER, err := psd.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := strings.TrimSuffix(string(pem.EncodeToMemory(&pem.Block{Type: "SIGNED MESSAGE", Bytes: sigDER})), "\n")
+
+	commit := headers + "gpgsig " + strings.ReplaceAll(sig, "\n", "\n ") + "\n" + body
+	return []byte(commit),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	commitSSH := readFile(t, "test_git_commit_ssh")
+	commitPGP := readFile(t, "test_git_commit_pgp")
+	tagSSH := readFile(t, "test_git_tag_ssh")
+	tagPGP := readFile(t, "test_git_tag_pgp")
+	sshKey := readFile(t, "test_git_ssh.pub")
+	pgpKey := readFile(t, "test_git_pgp_public_key.key")
+	commitX509, x509Key, x509Cert := x509Commit(t, "Add x509")
+	_, otherX509Key, _ := x509Commit(t, "Add x509")
+	otherPGPKey, err := ioutil.ReadFile("../../../pki/pgp/testdata/valid_armored_public.pgp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		commitSSHID   = "sha1:9e586becdbeb924891484b43c89cfa1e16e6eab8"
+		commitSSHTree = "sha1:aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7"
+		commitPGPID   = "sha1:82416c5e638923a4ce5393467f19c3bed523f5bc"
+		commitPGPTree = "sha1:b2ebf159fb11cd612e1c3e62636be14ce60424ad"
+		tagPGPID      = "sha1:39ff86c30fb2d41f5d99d4a43fb0a9ddd175bb34"
+		tagSSHID      = "sha1:150ac0e25922ce021da1f64b0eaec6a14e9c5ce7"
+		email         = "test@rekor.dev"
+	)
+	sshKeys := keyIndex(t, "ssh", sshKey)
+	pgpKeys := keyIndex(t, "pgp", pgpKey)
+	x509Keys := keyIndex(t, "x509", x509Cert)
+
+	object := func(content []byte) *models.GitV001SchemaObject {
+		return &models.GitV001SchemaObject{Content: strfmt.Base64(content)}
+	}
+	withKey := func(key []byte) *models.GitV001SchemaSignature {
+		return &models.GitV001SchemaSignature{
+			PublicKey: &models.GitV001SchemaSignaturePublicKey{Content: (*strfmt.Base64)(&key)},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		model        models.GitV001Schema
+		wantKeys     []string
+		unmarshalErr bool
+		canonicalErr bool
+	}{
+		{
+			name:         "missing object",
+			model:        models.GitV001Schema{Signature: withKey(sshKey)},
+			unmarshalErr: true,
+		},
+		{
+			name:         "object without content",
+			model:        models.GitV001Schema{Object: &models.GitV001SchemaObject{}, Signature: withKey(sshKey)},
+			unmarshalErr: true,
+		},
+		{
+			name:     "ssh signed commit",
+			model:    models.GitV001Schema{Object: object(commitSSH), Signature: withKey(sshKey)},
+			wantKeys: append([]string{commitSSHID, commitSSHTree, email}, sshKeys...),
+		},
+		{
+			name:     "pgp signed commit",
+			model:    models.GitV001Schema{Object: object(commitPGP), Signature: withKey(pgpKey)},
+			wantKeys: append([]string{commitPGPID, commitPGPTree, email}, pgpKeys...),
+		},
+		{
+			name:     "ssh signed tag",
+			model:    models.GitV001Schema{Object: object(tagSSH), Signature: withKey(sshKey)},
+			wantKeys: append([]string{tagSSHID, email}, sshKeys...),
+		},
+		{
+			name:     "pgp signed tag",
+			model:    models.GitV001Schema{Object: object(tagPGP), Signature: withKey(pgpKey)},
+			wantKeys: append([]string{tagPGPID, email}, pgpKeys...),
+		},
+		{
+			name:     "x509 signed commit",
+			model:    models.GitV001Schema{Object: object(commitX509)},
+			wantKeys: append([]string{"sha1:" + git.ObjectID("commit", commitX509), commitSSHTree, email}, x509Keys...),
+		},
+		{
+			name:     "x509 signed commit with public key",
+			model:    models.GitV001Schema{Object: object(commitX509), Signature: withKey(x509Key)},
+			wantKeys: append([]string{"sha1:" + git.ObjectID("commit", commitX509), commitSSHTree, email}, x509Keys...),
+		},
+		{
+			name:         "x509 signed commit with wrong public key",
+			model:        models.GitV001Schema{Object: object(commitX509), Signature: withKey(otherX509Key)},
+			canonicalErr: true,
+		},
+		{
+			name:         "ssh signed commit without public key",
+			model:        models.GitV001Schema{Object: object(commitSSH)},
+			canonicalErr: true,
+		},
+		{
+			name:         "ssh signed commit with pgp key",
+			model:        models.GitV001Schema{Object: object(commitSSH), Signature: withKey(pgpKey)},
+			canonicalErr: true,
+		},
+		{
+			name:         "pgp signed commit with wrong key",
+			model:        models.GitV001Schema{Object: object(commitPGP), Signature: withKey(otherPGPKey)},
+			canonicalErr: true,
+		},
+		{
+			name:         "tampered commit",
+			model:        models.GitV001Schema{Object: object(bytes.Replace(commitSSH, []byte("Add hello"), []byte("Add other"), 1)), Signature: withKey(sshKey)},
+			canonicalErr: true,
+		},
+		{
+			name:         "tampered tag",
+			model:        models.GitV001Schema{Object: object(bytes.Replace(tagPGP, []byte("tag v1.0.0"), []byte("tag v2.0.0"), 1)), Signature: withKey(pgpKey)},
+			canonicalErr: true,
+		},
+		{
+			name:         "unsigned commit",
+			model:        models.GitV001Schema{Object: object([]byte("tree aaa96ced2d9a1c8e72c56b253a0e2fe78393feb7\n\nAdd hello\n")), Signature: withKey(sshKey)},
+			canonicalErr: true,
+		},
+		{
+			name:         "not a git object",
+			model:        models.GitV001Schema{Object: object([]byte("hello\n\nworld\n")), Signature: withKey(sshKey)},
+			canonicalErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &V001Entry{}
+			err := v.Unmarshal(&models.Git{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       tc.model,
+			})
+			if (err != nil) != tc.unmarshalErr {
+				t.Fatalf("unexpected result from Unmarshal: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			canonicalBytes, err := v.Canonicalize(context.Background())
+			if (err != nil) != tc.canonicalErr {
+				t.Fatalf("unexpected result from Canonicalize: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := v.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tc.wantKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("V001Entry.IndexKeys() = %v, want %v", got, tc.wantKeys)
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(canonicalBytes), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			canonicalEntry, err := types.UnmarshalEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			if len(canonicalEntry.(*V001Entry).GitObj.Object.Content) != 0 {
+				t.Error("canonicalized entry contains the git object")
+			}
+			canonicalKeys, err := canonicalEntry.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, canonicalKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("index keys from hydrated object do not match those generated from canonicalized (and re-hydrated) object: %v %v", got, canonicalKeys)
+			}
+		})
+	}
+}
+
+func TestCreateFromArtifactProperties(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		props    types.ArtifactProperties
+		wantType string
+		wantErr  bool
+	}{
+		{
+			name: "commit",
+			props: types.ArtifactProperties{
+				ArtifactPath:   &url.URL{Path: "../../../../tests/test_git_commit_ssh"},
+				PublicKeyPaths: []*url.URL{{Path: "../../../../tests/test_git_ssh.pub"}},
+			},
+			wantType: models.GitV001SchemaObjectTypeCommit,
+		},
+		{
+			name: "tag",
+			props: types.ArtifactProperties{
+				ArtifactBytes:  readFile(t, "test_git_tag_pgp"),
+				PublicKeyBytes: [][]byte{readFile(t, "test_git_pgp_public_key.key")},
+			},
+			wantType: models.GitV001SchemaObjectTypeTag,
+		},
+		{
+			name: "missing public key",
+			props: types.ArtifactProperties{
+				ArtifactBytes: readFile(t, "test_git_tag_pgp"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing object",
+			props: types.ArtifactProperties{
+				PublicKeyBytes: [][]byte{readFile(t, "test_git_pgp_public_key.key")},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pe, err := V001Entry{}.CreateFromArtifactProperties(context.Background(), tc.props)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected result from CreateFromArtifactProperties: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			spec := pe.(*models.Git).Spec.(models.GitV001Schema)
+			if spec.Object.Type != tc.wantType {
+				t.Errorf("unexpected object type %q", spec.Object.Type)
+			}
+			if _, err := types.UnmarshalEntry(pe); err != nil {
+				t.Errorf("unexpected error unmarshalling created entry: %v", err)
+			}
+		})
+	}
+}
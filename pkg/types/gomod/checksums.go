@@ -0,0 +1,136 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomod
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+const (
+	// hashPrefix is the prefix of the hashes computed by dirhash.Hash1,
+	// which are the ones found in go.sum and the checksum database
+	hashPrefix = "h1:"
+	modSuffix  = "/go.mod"
+)
+
+// Module is a version of a Go module, with the hashes of its zip file and
+// of its go.mod file
+type Module struct {
+	Path    string
+	Version string
+	ZipHash string
+	ModHash string
+}
+
+// ValidateHash checks that h is an h1: hash, which is the base64 encoded
+// SHA-256 digest of the summary of the files that are hashed
+func ValidateHash(h string) error {
+	if !strings.HasPrefix(h, hashPrefix) {
+		return fmt.Errorf("invalid hash %q: must start with %q", h, hashPrefix)
+	}
+	digest, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(h, hashPrefix))
+	if err != nil || len(digest) != sha256.Size {
+		return fmt.Errorf("invalid hash %q: must be a base64 encoded SHA-256 digest", h)
+	}
+	return nil
+}
+
+// HashIndexKey returns the key under which an entry is indexed by the h1:
+// hash h, which is its SHA-256 digest as hex, so that it can be searched for
+// like any other hash
+func HashIndexKey(h string) (string, error) {
+	if err := ValidateHash(h); err != nil {
+		return "", err
+	}
+	digest, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(h, hashPrefix))
+	return "sha256:" + hex.EncodeToString(digest), nil
+}
+
+// Validate checks the module path and version, which must be canonical, and
+// the format of the hashes
+func (m Module) Validate() error {
+	if err := module.Check(m.Path, m.Version); err != nil {
+		return err
+	}
+	// otherwise the same version could be indexed under different names
+	if v := module.CanonicalVersion(m.Version); v != m.Version {
+		return fmt.Errorf("version %q is not canonical, expected %q", m.Version, v)
+	}
+	if err := ValidateHash(m.ZipHash); err != nil {
+		return fmt.Errorf("zip: %w", err)
+	}
+	if err := ValidateHash(m.ModHash); err != nil {
+		return fmt.Errorf("go.mod: %w", err)
+	}
+	return nil
+}
+
+// Checksums returns the lines of go.sum for the module, which is the content
+// that is signed
+func (m Module) Checksums() []byte {
+	return []byte(fmt.Sprintf("%s %s %s\n%s %s%s %s\n", m.Path, m.Version, m.ZipHash, m.Path, m.Version, modSuffix, m.ModHash))
+}
+
+// ParseChecksums parses the lines of go.sum for a single version of a
+// module, which must hold the hashes of both its zip file and its go.mod file
+func ParseChecksums(b []byte) (*Module, error) {
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	if len(lines) != 2 {
+		return nil, errors.New("expected the go.sum lines for the zip file and the go.mod file of a module")
+	}
+
+	m := Module{}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid go.sum line %q", line)
+		}
+		path, version, hash := fields[0], fields[1], fields[2]
+		if m.Path != "" && m.Path != path {
+			return nil, fmt.Errorf("go.sum lines are for different modules %q and %q", m.Path, path)
+		}
+		m.Path = path
+
+		if strings.HasSuffix(version, modSuffix) {
+			if m.ModHash != "" {
+				return nil, errors.New("duplicate go.sum line for the go.mod file")
+			}
+			version = strings.TrimSuffix(version, modSuffix)
+			m.ModHash = hash
+		} else {
+			if m.ZipHash != "" {
+				return nil, errors.New("duplicate go.sum line for the zip file")
+			}
+			m.ZipHash = hash
+		}
+		if m.Version != "" && m.Version != version {
+			return nil, fmt.Errorf("go.sum lines are for different versions %q and %q", m.Version, version)
+		}
+		m.Version = version
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,133 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomod
+
+import (
+	"testing"
+)
+
+// hashes in the format of go.sum
+const (
+	testZipHash = "h1:Dt6gXwCsd/3B2bEP5WsBTVUCQBh9z2o4nYhCeuhvd0Q="
+	testModHash = "h1:QXOrdp5ESQEUhLI2hVAWTGlsOCdcvfGqxL/3O0UXw7g="
+)
+
+func TestValidateHash(t *testing.T) {
+	for _, tc := range []struct {
+		hash    string
+		wantErr bool
+	}{
+		{hash: testZipHash},
+		{hash: testModHash},
+		{hash: "", wantErr: true},
+		{hash: "Dt6gXwCsd/3B2bEP5WsBTVUCQBh9z2o4nYhCeuhvd0Q=", wantErr: true},
+		{hash: "h2:Dt6gXwCsd/3B2bEP5WsBTVUCQBh9z2o4nYhCeuhvd0Q=", wantErr: true},
+		{hash: "h1:not base64", wantErr: true},
+		{hash: "h1:aGVsbG8gd29ybGQ=", wantErr: true},
+	} {
+		if err := ValidateHash(tc.hash); (err != nil) != tc.wantErr {
+			t.Errorf("ValidateHash(%q) returned unexpected error %v", tc.hash, err)
+		}
+	}
+}
+
+func TestHashIndexKey(t *testing.T) {
+	key, err := HashIndexKey(testZipHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "sha256:0edea05f00ac77fdc1d9b10fe56b014d550240187dcf6a389d88427ae86f7744"; key != want {
+		t.Errorf("HashIndexKey() = %q, want %q", key, want)
+	}
+	if _, err := HashIndexKey("h1:not base64"); err == nil {
+		t.Error("expected error for invalid hash")
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	want := Module{
+		Path:    "github.com/sigstore/rekor",
+		Version: "v0.12.0",
+		ZipHash: testZipHash,
+		ModHash: testModHash,
+	}
+	checksums := string(want.Checksums())
+	if checksums != "github.com/sigstore/rekor v0.12.0 "+testZipHash+"\ngithub.com/sigstore/rekor v0.12.0/go.mod "+testModHash+"\n" {
+		t.Errorf("unexpected checksums %q", checksums)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		checksums string
+		wantErr   bool
+	}{
+		{name: "go.sum lines", checksums: checksums},
+		{
+			name:      "no trailing newline",
+			checksums: checksums[:len(checksums)-1],
+		},
+		{
+			name:      "reversed",
+			checksums: "github.com/sigstore/rekor v0.12.0/go.mod " + testModHash + "\ngithub.com/sigstore/rekor v0.12.0 " + testZipHash + "\n",
+		},
+		{name: "empty", checksums: "", wantErr: true},
+		{
+			name:      "missing go.mod hash",
+			checksums: "github.com/sigstore/rekor v0.12.0 " + testZipHash + "\n",
+			wantErr:   true,
+		},
+		{
+			name:      "duplicate zip hash",
+			checksums: "github.com/sigstore/rekor v0.12.0 " + testZipHash + "\ngithub.com/sigstore/rekor v0.12.0 " + testZipHash + "\n",
+			wantErr:   true,
+		},
+		{
+			name:      "different modules",
+			checksums: "github.com/sigstore/rekor v0.12.0 " + testZipHash + "\ngithub.com/sigstore/cosign v0.12.0/go.mod " + testModHash + "\n",
+			wantErr:   true,
+		},
+		{
+			name:      "different versions",
+			checksums: "github.com/sigstore/rekor v0.12.0 " + testZipHash + "\ngithub.com/sigstore/rekor v0.12.1/go.mod " + testModHash + "\n",
+			wantErr:   true,
+		},
+		{
+			name:      "non-canonical version",
+			checksums: "github.com/sigstore/rekor v0.12 " + testZipHash + "\ngithub.com/sigstore/rekor v0.12/go.mod " + testModHash + "\n",
+			wantErr:   true,
+		},
+		{
+			name:      "major version mismatch",
+			checksums: "github.com/sigstore/rekor v2.0.0 " + testZipHash + "\ngithub.com/sigstore/rekor v2.0.0/go.mod " + testModHash + "\n",
+			wantErr:   true,
+		},
+		{
+			name:      "invalid hash",
+			checksums: "github.com/sigstore/rekor v0.12.0 h1:abc\ngithub.com/sigstore/rekor v0.12.0/go.mod " + testModHash + "\n",
+			wantErr:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseChecksums([]byte(tc.checksums))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseChecksums() returned unexpected error %v", err)
+			}
+			if err == nil && *got != want {
+				t.Errorf("ParseChecksums() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomod
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/gomod"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := gomod.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	GomodObj models.GomodV001Schema
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() ([]string, error) {
+	var result []string
+
+	af, err := pki.NewArtifactFactory(pki.Format(*v.GomodObj.Signature.Format))
+	if err != nil {
+		return nil, err
+	}
+	keyObj, err := af.NewPublicKey(bytes.NewReader(*v.GomodObj.Signature.PublicKey.Content))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		log.Logger.Error(err)
+	} else {
+		keyHash := sha256.Sum256(key)
+		result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+	}
+
+	result = append(result, keyObj.Subjects()...)
+
+	// entries with different hashes for the same version are found by
+	// searching for the version as a package
+	m := v.module()
+	result = append(result, types.PackageIndexKey(gomod.KIND, m.Path+"@"+m.Version))
+	for _, h := range []string{m.ZipHash, m.ModHash} {
+		hashKey, err := gomod.HashIndexKey(h)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, hashKey)
+	}
+
+	return result, nil
+}
+
+// module returns the version of the module in the entry
+func (v V001Entry) module() gomod.Module {
+	m := v.GomodObj.Module
+	return gomod.Module{
+		Path:    swag.StringValue(m.Path),
+		Version: swag.StringValue(m.Version),
+		ZipHash: swag.StringValue(m.ZipHash),
+		ModHash: swag.StringValue(m.ModHash),
+	}
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	gomodObj, ok := pe.(*models.Gomod)
+	if !ok {
+		return errors.New("cannot unmarshal non Go module v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(gomodObj.Spec, &v.GomodObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.GomodObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// cross field validation
+	return v.validate()
+}
+
+// fetchExternalEntities verifies the signature over the go.sum lines of the
+// module, which are reconstructed from the entry
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) (pki.PublicKey, pki.Signature, error) {
+	if err := v.validate(); err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	af, err := pki.NewArtifactFactory(pki.Format(*v.GomodObj.Signature.Format))
+	if err != nil {
+		return nil, nil, err
+	}
+	sigObj, err := af.NewSignature(bytes.NewReader(*v.GomodObj.Signature.Content))
+	if err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+	keyObj, err := af.NewPublicKey(bytes.NewReader(*v.GomodObj.Signature.PublicKey.Content))
+	if err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	if err := sigObj.Verify(bytes.NewReader(v.module().Checksums()), keyObj); err != nil {
+		return nil, nil, types.ValidationError(fmt.Errorf("verifying signature: %w", err))
+	}
+
+	return keyObj, sigObj, nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	keyObj, sigObj, err := v.fetchExternalEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry := models.GomodV001Schema{}
+
+	// need to canonicalize signature & key content
+	canonicalEntry.Signature = &models.GomodV001SchemaSignature{}
+	canonicalEntry.Signature.Format = v.GomodObj.Signature.Format
+
+	var sigContent []byte
+	sigContent, err = sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.Content = (*strfmt.Base64)(&sigContent)
+
+	var pubKeyContent []byte
+	canonicalEntry.Signature.PublicKey = &models.GomodV001SchemaSignaturePublicKey{}
+	pubKeyContent, err = keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.PublicKey.Content = (*strfmt.Base64)(&pubKeyContent)
+
+	canonicalEntry.Module = v.GomodObj.Module
+
+	// wrap in valid object with kind and apiVersion set
+	gomodObj := models.Gomod{}
+	gomodObj.APIVersion = swag.String(APIVERSION)
+	gomodObj.Spec = &canonicalEntry
+
+	return json.Marshal(&gomodObj)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	sig := v.GomodObj.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if sig.Content == nil || len(*sig.Content) == 0 {
+		return errors.New("'content' must be specified for signature")
+	}
+
+	key := sig.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if key.Content == nil || len(*key.Content) == 0 {
+		return errors.New("'content' must be specified for publicKey")
+	}
+
+	if v.GomodObj.Module == nil {
+		return errors.New("missing module")
+	}
+	return v.module().Validate()
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Gomod{}
+	re := V001Entry{}
+
+	// the artifact is the go.sum lines of the module that were signed
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		var artifactReader io.ReadCloser
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to go.sum lines of the module must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			artifactReader, err = util.FileOrURLReadCloser(ctx, props.ArtifactPath.String(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("error reading go.sum lines: %w", err)
+			}
+		} else {
+			artifactReader, err = os.Open(filepath.Clean(props.ArtifactPath.Path))
+			if err != nil {
+				return nil, fmt.Errorf("error opening go.sum lines: %w", err)
+			}
+		}
+		defer artifactReader.Close()
+		artifactBytes, err = ioutil.ReadAll(artifactReader)
+		if err != nil {
+			return nil, fmt.Errorf("error reading go.sum lines: %w", err)
+		}
+	}
+	m, err := gomod.ParseChecksums(artifactBytes)
+	if err != nil {
+		return nil, err
+	}
+	re.GomodObj.Module = &models.GomodV001SchemaModule{
+		Path:    swag.String(m.Path),
+		Version: swag.String(m.Version),
+		ZipHash: swag.String(m.ZipHash),
+		ModHash: swag.String(m.ModHash),
+	}
+
+	re.GomodObj.Signature = &models.GomodV001SchemaSignature{}
+	switch props.PKIFormat {
+	case "pgp":
+		re.GomodObj.Signature.Format = swag.String(models.GomodV001SchemaSignatureFormatPgp)
+	case "minisign":
+		re.GomodObj.Signature.Format = swag.String(models.GomodV001SchemaSignatureFormatMinisign)
+	case "x509":
+		re.GomodObj.Signature.Format = swag.String(models.GomodV001SchemaSignatureFormatX509)
+	case "ssh":
+		re.GomodObj.Signature.Format = swag.String(models.GomodV001SchemaSignatureFormatSSH)
+	default:
+		return nil, fmt.Errorf("unsupported signature format %q for gomod entries", props.PKIFormat)
+	}
+	sigBytes := props.SignatureBytes
+	if sigBytes == nil {
+		if props.SignaturePath == nil {
+			return nil, errors.New("a detached signature must be provided")
+		}
+		sigBytes, err = ioutil.ReadFile(filepath.Clean(props.SignaturePath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading signature file: %w", err)
+		}
+	}
+	re.GomodObj.Signature.Content = (*strfmt.Base64)(&sigBytes)
+
+	re.GomodObj.Signature.PublicKey = &models.GomodV001SchemaSignaturePublicKey{}
+	publicKeyBytes := props.PublicKeyBytes
+	if len(publicKeyBytes) == 0 {
+		if len(props.PublicKeyPaths) != 1 {
+			return nil, errors.New("only one public key must be provided to verify detached signature")
+		}
+		keyBytes, err := ioutil.ReadFile(filepath.Clean(props.PublicKeyPaths[0].Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+		publicKeyBytes = append(publicKeyBytes, keyBytes)
+	} else if len(publicKeyBytes) != 1 {
+		return nil, errors.New("only one public key must be provided")
+	}
+	re.GomodObj.Signature.PublicKey.Content = (*strfmt.Base64)(&publicKeyBytes[0])
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if _, _, err := re.fetchExternalEntities(ctx); err != nil {
+		return nil, fmt.Errorf("error retrieving external entities: %v", err)
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.GomodObj
+
+	return &returnVal, nil
+}
@@ -0,0 +1,336 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomod
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	x509r "github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/gomod"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+const (
+	testZipHash = "h1:Dt6gXwCsd/3B2bEP5WsBTVUCQBh9z2o4nYhCeuhvd0Q="
+	testModHash = "h1:QXOrdp5ESQEUhLI2hVAWTGlsOCdcvfGqxL/3O0UXw7g="
+)
+
+var testModule = gomod.Module{
+	Path:    "github.com/sigstore/rekor",
+	Version: "v0.12.0",
+	ZipHash: testZipHash,
+	ModHash: testModHash,
+}
+
+func testKey(t *testing.T) (signature.Signer, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.LoadSigner(key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, pem.EncodeToMemory(&pem.Block{Bytes: der, Type: "PUBLIC KEY"})
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	signer, keyBytes := testKey(t)
+	_, otherKeyBytes := testKey(t)
+	keyObj, err := x509r.NewPublicKey(bytes.NewReader(keyBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonicalKey, err := keyObj.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyHash := sha256.Sum256(canonicalKey)
+
+	sign := func(m gomod.Module) *models.GomodV001SchemaSignature {
+		sig, err := signer.SignMessage(bytes.NewReader(m.Checksums()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &models.GomodV001SchemaSignature{
+			Format:    swag.String(models.GomodV001SchemaSignatureFormatX509),
+			Content:   (*strfmt.Base64)(&sig),
+			PublicKey: &models.GomodV001SchemaSignaturePublicKey{Content: (*strfmt.Base64)(&keyBytes)},
+		}
+	}
+	module := func(m gomod.Module) *models.GomodV001SchemaModule {
+		return &models.GomodV001SchemaModule{
+			Path:    swag.String(m.Path),
+			Version: swag.String(m.Version),
+			ZipHash: swag.String(m.ZipHash),
+			ModHash: swag.String(m.ModHash),
+		}
+	}
+
+	// the same version published with a different zip file
+	republished := testModule
+	republished.ZipHash = "h1:vuZ/BQSep8P2Fuwra2PBi8hrRlA6RGS1WiMnO5cuALc="
+
+	hashKey := func(h string) string {
+		key, err := gomod.HashIndexKey(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key
+	}
+
+	wrongKey := sign(testModule)
+	wrongKey.PublicKey.Content = (*strfmt.Base64)(&otherKeyBytes)
+
+	tests := []struct {
+		name     string
+		model    models.GomodV001Schema
+		wantKeys []string
+		// unmarshalErr is set when the entry is rejected before it is verified
+		unmarshalErr bool
+		canonicalErr bool
+	}{
+		{
+			name:         "empty",
+			model:        models.GomodV001Schema{},
+			unmarshalErr: true,
+		},
+		{
+			name: "missing module",
+			model: models.GomodV001Schema{
+				Signature: sign(testModule),
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "missing signature",
+			model: models.GomodV001Schema{
+				Module: module(testModule),
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "valid",
+			model: models.GomodV001Schema{
+				Signature: sign(testModule),
+				Module:    module(testModule),
+			},
+			wantKeys: []string{hex.EncodeToString(keyHash[:]), "gomod:github.com/sigstore/rekor@v0.12.0", hashKey(testZipHash), hashKey(testModHash)},
+		},
+		{
+			name: "republished version",
+			model: models.GomodV001Schema{
+				Signature: sign(republished),
+				Module:    module(republished),
+			},
+			wantKeys: []string{hex.EncodeToString(keyHash[:]), "gomod:github.com/sigstore/rekor@v0.12.0", hashKey(republished.ZipHash), hashKey(testModHash)},
+		},
+		{
+			name: "signature over different hashes",
+			model: models.GomodV001Schema{
+				Signature: sign(republished),
+				Module:    module(testModule),
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "wrong key",
+			model: models.GomodV001Schema{
+				Signature: wrongKey,
+				Module:    module(testModule),
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "invalid hash",
+			model: models.GomodV001Schema{
+				Signature: sign(testModule),
+				Module: &models.GomodV001SchemaModule{
+					Path:    swag.String(testModule.Path),
+					Version: swag.String(testModule.Version),
+					ZipHash: swag.String("sha256:" + hex.EncodeToString(keyHash[:])),
+					ModHash: swag.String(testModHash),
+				},
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "non-canonical version",
+			model: models.GomodV001Schema{
+				Signature: sign(testModule),
+				Module: &models.GomodV001SchemaModule{
+					Path:    swag.String(testModule.Path),
+					Version: swag.String("0.12.0"),
+					ZipHash: swag.String(testZipHash),
+					ModHash: swag.String(testModHash),
+				},
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "invalid module path",
+			model: models.GomodV001Schema{
+				Signature: sign(testModule),
+				Module: &models.GomodV001SchemaModule{
+					Path:    swag.String("github.com/sigstore/rekor/"),
+					Version: swag.String(testModule.Version),
+					ZipHash: swag.String(testZipHash),
+					ModHash: swag.String(testModHash),
+				},
+			},
+			unmarshalErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &V001Entry{}
+			err := v.Unmarshal(&models.Gomod{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       tc.model,
+			})
+			if (err != nil) != tc.unmarshalErr {
+				t.Fatalf("unexpected result from Unmarshal: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			canonicalBytes, err := v.Canonicalize(context.Background())
+			if (err != nil) != tc.canonicalErr {
+				t.Fatalf("unexpected result from Canonicalize: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := v.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tc.wantKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("V001Entry.IndexKeys() = %v, want %v", got, tc.wantKeys)
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(canonicalBytes), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			canonicalEntry, err := types.UnmarshalEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			canonicalKeys, err := canonicalEntry.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, canonicalKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("index keys from hydrated object do not match those generated from canonicalized (and re-hydrated) object: %v %v", got, canonicalKeys)
+			}
+		})
+	}
+}
+
+func TestCreateFromArtifactProperties(t *testing.T) {
+	signer, keyBytes := testKey(t)
+	sig, err := signer.SignMessage(bytes.NewReader(testModule.Checksums()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		checksums []byte
+		pkiFormat string
+		wantErr   bool
+	}{
+		{
+			name:      "go.sum lines",
+			checksums: testModule.Checksums(),
+			pkiFormat: "x509",
+		},
+		{
+			name:      "single go.sum line",
+			checksums: []byte("github.com/sigstore/rekor v0.12.0 " + testZipHash + "\n"),
+			pkiFormat: "x509",
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported format",
+			checksums: testModule.Checksums(),
+			pkiFormat: "tuf",
+			wantErr:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pe, err := V001Entry{}.CreateFromArtifactProperties(context.Background(), types.ArtifactProperties{
+				ArtifactBytes:  tc.checksums,
+				SignatureBytes: sig,
+				PublicKeyBytes: [][]byte{keyBytes},
+				PKIFormat:      tc.pkiFormat,
+			})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected result from CreateFromArtifactProperties: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			spec := pe.(*models.Gomod).Spec.(models.GomodV001Schema)
+			if swag.StringValue(spec.Module.Path) != testModule.Path || swag.StringValue(spec.Module.ZipHash) != testZipHash {
+				t.Errorf("unexpected module %+v", spec.Module)
+			}
+			if _, err := types.UnmarshalEntry(pe); err != nil {
+				t.Errorf("unexpected error unmarshalling created entry: %v", err)
+			}
+		})
+	}
+}
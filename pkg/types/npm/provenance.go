@@ -0,0 +1,200 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const (
+	purlPrefix      = "pkg:npm/"
+	integrityPrefix = "sha512-"
+	// statementInTotoV1 is the statement type of in-toto v1 statements,
+	// which newer npm clients generate
+	statementInTotoV1 = "https://in-toto.io/Statement/v1"
+)
+
+// SignedMessage returns the message that the npm registry signs for a
+// package version
+func SignedMessage(name, version, integrity string) []byte {
+	return []byte(fmt.Sprintf("%s@%s:%s", name, version, integrity))
+}
+
+// IntegrityToHex returns the hex encoded SHA512 hash in a subresource
+// integrity string
+func IntegrityToHex(integrity string) (string, error) {
+	if !strings.HasPrefix(integrity, integrityPrefix) {
+		return "", fmt.Errorf("integrity %q is not a SHA512 hash", integrity)
+	}
+	digest, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(integrity, integrityPrefix))
+	if err != nil || len(digest) != 64 {
+		return "", fmt.Errorf("invalid integrity %q", integrity)
+	}
+	return hex.EncodeToString(digest), nil
+}
+
+// bundle holds the fields of a Sigstore bundle needed to verify the
+// provenance it contains
+type bundle struct {
+	VerificationMaterial struct {
+		X509CertificateChain *struct {
+			Certificates []struct {
+				RawBytes []byte `json:"rawBytes"`
+			} `json:"certificates"`
+		} `json:"x509CertificateChain"`
+		Certificate *struct {
+			RawBytes []byte `json:"rawBytes"`
+		} `json:"certificate"`
+	} `json:"verificationMaterial"`
+	DSSEEnvelope *dsse.Envelope `json:"dsseEnvelope"`
+}
+
+// Provenance is the provenance of an npm package version, as published in
+// a Sigstore bundle
+type Provenance struct {
+	Name      string
+	Version   string
+	Integrity string
+	// Certificate is the PEM encoded signing certificate, whose subject is
+	// the identity that published the package
+	Certificate []byte
+	Signature   []byte
+	// SignedContent is the pre-authentication encoding of the DSSE envelope,
+	// which the signature is computed over
+	SignedContent []byte
+}
+
+// Unmarshal parses a Sigstore bundle and the in-toto statement about an npm
+// package in its DSSE envelope. The signature is not verified.
+func (p *Provenance) Unmarshal(content []byte) error {
+	var b bundle
+	if err := json.Unmarshal(content, &b); err != nil {
+		return fmt.Errorf("parsing bundle: %w", err)
+	}
+
+	var cert []byte
+	switch {
+	case b.VerificationMaterial.Certificate != nil:
+		cert = b.VerificationMaterial.Certificate.RawBytes
+	case b.VerificationMaterial.X509CertificateChain != nil && len(b.VerificationMaterial.X509CertificateChain.Certificates) > 0:
+		// the signing certificate comes first in the chain
+		cert = b.VerificationMaterial.X509CertificateChain.Certificates[0].RawBytes
+	}
+	if len(cert) == 0 {
+		return errors.New("bundle does not contain a signing certificate")
+	}
+
+	env := b.DSSEEnvelope
+	if env == nil {
+		return errors.New("bundle does not contain a DSSE envelope")
+	}
+	if env.PayloadType != in_toto.PayloadType {
+		return fmt.Errorf("unexpected payload type %q", env.PayloadType)
+	}
+	if len(env.Signatures) != 1 {
+		return errors.New("DSSE envelope must contain exactly one signature")
+	}
+	sig, err := base64.StdEncoding.DecodeString(env.Signatures[0].Sig)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return fmt.Errorf("decoding payload: %w", err)
+	}
+
+	var statement in_toto.Statement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return fmt.Errorf("parsing in-toto statement: %w", err)
+	}
+	if statement.Type != in_toto.StatementInTotoV01 && statement.Type != statementInTotoV1 {
+		return fmt.Errorf("unexpected statement type %q", statement.Type)
+	}
+
+	var subject *in_toto.Subject
+	for i := range statement.Subject {
+		if strings.HasPrefix(statement.Subject[i].Name, purlPrefix) {
+			if subject != nil {
+				return errors.New("statement has more than one npm subject")
+			}
+			subject = &statement.Subject[i]
+		}
+	}
+	if subject == nil {
+		return errors.New("statement has no npm subject")
+	}
+	name, version, err := parsePURL(subject.Name)
+	if err != nil {
+		return err
+	}
+	digest, err := hex.DecodeString(subject.Digest["sha512"])
+	if err != nil || len(digest) != 64 {
+		return fmt.Errorf("subject %s does not have a valid SHA512 digest", subject.Name)
+	}
+
+	*p = Provenance{
+		Name:          name,
+		Version:       version,
+		Integrity:     integrityPrefix + base64.StdEncoding.EncodeToString(digest),
+		Certificate:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+		Signature:     sig,
+		SignedContent: dsse.PAE(env.PayloadType, payload),
+	}
+	return nil
+}
+
+// parsePURL returns the name and version of the package in an npm package
+// URL such as pkg:npm/%40scope/name@1.0.0
+func parsePURL(purl string) (string, string, error) {
+	rest := strings.TrimPrefix(purl, purlPrefix)
+	i := strings.LastIndex(rest, "@")
+	if i <= 0 || i == len(rest)-1 {
+		return "", "", fmt.Errorf("package URL %q does not have a name and version", purl)
+	}
+	name, err := url.PathUnescape(rest[:i])
+	if err != nil {
+		return "", "", fmt.Errorf("invalid package URL %q: %w", purl, err)
+	}
+	version, err := url.PathUnescape(rest[i+1:])
+	if err != nil {
+		return "", "", fmt.Errorf("invalid package URL %q: %w", purl, err)
+	}
+	return name, version, nil
+}
+
+// Manifest holds the fields of the registry document of a package version
+// that the registry signature covers
+type Manifest struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Dist    struct {
+		Integrity  string `json:"integrity"`
+		Signatures []struct {
+			KeyID string `json:"keyid"`
+			Sig   string `json:"sig"`
+		} `json:"signatures"`
+	} `json:"dist"`
+}
@@ -0,0 +1,66 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"testing"
+)
+
+func TestParsePURL(t *testing.T) {
+	for _, tc := range []struct {
+		purl        string
+		wantName    string
+		wantVersion string
+		wantErr     bool
+	}{
+		{purl: "pkg:npm/hello-rekor@1.0.0", wantName: "hello-rekor", wantVersion: "1.0.0"},
+		{purl: "pkg:npm/%40sigstore/hello-rekor@1.0.0-rc.1", wantName: "@sigstore/hello-rekor", wantVersion: "1.0.0-rc.1"},
+		{purl: "pkg:npm/hello-rekor", wantErr: true},
+		{purl: "pkg:npm/hello-rekor@", wantErr: true},
+		{purl: "pkg:npm/%zz@1.0.0", wantErr: true},
+	} {
+		name, version, err := parsePURL(tc.purl)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parsePURL(%q) returned unexpected error %v", tc.purl, err)
+			continue
+		}
+		if name != tc.wantName || version != tc.wantVersion {
+			t.Errorf("parsePURL(%q) = %s, %s, want %s, %s", tc.purl, name, version, tc.wantName, tc.wantVersion)
+		}
+	}
+}
+
+func TestIntegrityToHex(t *testing.T) {
+	// the integrity of an empty tarball
+	got, err := IntegrityToHex("sha512-z4PhNX7vuL3xVChQ1m2AB9Yg5AULVxXcg/SpIdNs6c5H0NE8XYXysP+DGNKHfuwvY7kxvUdBeoGlODJ6+SfaPg==")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e"
+	if got != want {
+		t.Errorf("IntegrityToHex() = %s, want %s", got, want)
+	}
+
+	for _, invalid := range []string{
+		"sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=",
+		"sha512-not base64",
+		"sha512-AAAA",
+	} {
+		if _, err := IntegrityToHex(invalid); err == nil {
+			t.Errorf("expected error for integrity %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/npm"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := npm.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	NpmObj models.NpmV001Schema
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() ([]string, error) {
+	var result []string
+
+	if v.NpmObj.Signature == nil || v.NpmObj.Signature.PublicKey == nil {
+		return nil, errors.New("missing public key")
+	}
+	keyObj, err := x509.NewPublicKey(bytes.NewReader(*v.NpmObj.Signature.PublicKey.Content))
+	if err != nil {
+		return nil, err
+	}
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	keyHash := sha256.Sum256(key)
+	result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+
+	// the subjects of a provenance certificate identify who published the package
+	result = append(result, keyObj.Subjects()...)
+
+	if pkg := v.NpmObj.Package; pkg != nil {
+		name, version := swag.StringValue(pkg.Name), swag.StringValue(pkg.Version)
+		result = append(result, types.PackageIndexKey(npm.KIND, name), types.PackageIndexKey(npm.KIND, name+"@"+version))
+
+		integrity, err := npm.IntegrityToHex(swag.StringValue(pkg.Integrity))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, "sha512:"+integrity)
+	}
+
+	if p := v.NpmObj.Provenance; p != nil && p.Hash != nil {
+		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *p.Hash.Algorithm, *p.Hash.Value))
+		result = append(result, hashKey)
+	}
+
+	return result, nil
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.Npm)
+	if !ok {
+		return errors.New("cannot unmarshal non npm v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(it.Spec, &v.NpmObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.NpmObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// cross field validation
+	return v.validate()
+}
+
+// fetchExternalEntities verifies the registry signature over the package,
+// or the provenance bundle, filling in the package, signature and
+// certificate from the bundle
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) (*x509.Signature, *x509.PublicKey, error) {
+	if err := v.validate(); err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	p := v.NpmObj.Provenance
+	if p == nil {
+		pkg, sig := v.NpmObj.Package, v.NpmObj.Signature
+		sigObj, err := x509.NewSignature(bytes.NewReader(*sig.Content))
+		if err != nil {
+			return nil, nil, types.ValidationError(err)
+		}
+		keyObj, err := x509.NewPublicKey(bytes.NewReader(*sig.PublicKey.Content))
+		if err != nil {
+			return nil, nil, types.ValidationError(err)
+		}
+		msg := npm.SignedMessage(swag.StringValue(pkg.Name), swag.StringValue(pkg.Version), swag.StringValue(pkg.Integrity))
+		if err := sigObj.Verify(bytes.NewReader(msg), keyObj); err != nil {
+			return nil, nil, types.ValidationError(fmt.Errorf("verifying registry signature: %w", err))
+		}
+		return sigObj, keyObj, nil
+	}
+
+	if len(p.Content) == 0 {
+		return nil, nil, types.ValidationError(errors.New("'content' must be specified for provenance"))
+	}
+	h := sha256.Sum256(p.Content)
+	computedSHA := hex.EncodeToString(h[:])
+	if p.Hash != nil && swag.StringValue(p.Hash.Value) != computedSHA {
+		return nil, nil, types.ValidationError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, swag.StringValue(p.Hash.Value)))
+	}
+
+	prov := npm.Provenance{}
+	if err := prov.Unmarshal(p.Content); err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+	sigObj, err := x509.NewSignature(bytes.NewReader(prov.Signature))
+	if err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+	keyObj, err := x509.NewPublicKey(bytes.NewReader(prov.Certificate))
+	if err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+	if err := sigObj.Verify(bytes.NewReader(prov.SignedContent), keyObj); err != nil {
+		return nil, nil, types.ValidationError(fmt.Errorf("verifying provenance signature: %w", err))
+	}
+
+	if pkg := v.NpmObj.Package; pkg != nil {
+		if swag.StringValue(pkg.Name) != prov.Name || swag.StringValue(pkg.Version) != prov.Version || swag.StringValue(pkg.Integrity) != prov.Integrity {
+			return nil, nil, types.ValidationError(errors.New("package does not match the provenance"))
+		}
+	}
+	v.NpmObj.Package = &models.NpmV001SchemaPackage{
+		Name:      swag.String(prov.Name),
+		Version:   swag.String(prov.Version),
+		Integrity: swag.String(prov.Integrity),
+	}
+	v.NpmObj.Signature = &models.NpmV001SchemaSignature{
+		Content: (*strfmt.Base64)(&prov.Signature),
+		PublicKey: &models.NpmV001SchemaSignaturePublicKey{
+			Content: (*strfmt.Base64)(&prov.Certificate),
+		},
+	}
+	p.Hash = &models.NpmV001SchemaProvenanceHash{
+		Algorithm: swag.String(models.NpmV001SchemaProvenanceHashAlgorithmSha256),
+		Value:     swag.String(computedSHA),
+	}
+
+	return sigObj, keyObj, nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	sigObj, keyObj, err := v.fetchExternalEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry := models.NpmV001Schema{}
+
+	// need to canonicalize signature & key content
+	canonicalEntry.Signature = &models.NpmV001SchemaSignature{
+		PublicKey: &models.NpmV001SchemaSignaturePublicKey{},
+	}
+	sig, err := sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.Content = (*strfmt.Base64)(&sig)
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.PublicKey.Content = (*strfmt.Base64)(&key)
+
+	canonicalEntry.Package = v.NpmObj.Package
+
+	if p := v.NpmObj.Provenance; p != nil {
+		canonicalEntry.Provenance = &models.NpmV001SchemaProvenance{
+			Hash: p.Hash,
+		}
+		// provenance content is not set deliberately
+	}
+
+	// wrap in valid object with kind and apiVersion set
+	npmObj := models.Npm{}
+	npmObj.APIVersion = swag.String(APIVERSION)
+	npmObj.Spec = &canonicalEntry
+
+	return json.Marshal(&npmObj)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	if p := v.NpmObj.Provenance; p != nil {
+		if p.Hash != nil {
+			if !govalidator.IsHash(swag.StringValue(p.Hash.Value), swag.StringValue(p.Hash.Algorithm)) {
+				return errors.New("invalid value for hash")
+			}
+		} else if len(p.Content) == 0 {
+			return errors.New("'content' must be specified for provenance")
+		}
+		// the package and signature are read from the bundle
+		if len(p.Content) != 0 {
+			return nil
+		}
+	}
+
+	pkg := v.NpmObj.Package
+	if pkg == nil {
+		return errors.New("missing package")
+	}
+	if _, err := npm.IntegrityToHex(swag.StringValue(pkg.Integrity)); err != nil {
+		return err
+	}
+
+	sig := v.NpmObj.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if sig.Content == nil || len(*sig.Content) == 0 {
+		return errors.New("'content' must be specified for signature")
+	}
+	key := sig.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if key.Content == nil || len(*key.Content) == 0 {
+		return errors.New("'content' must be specified for publicKey")
+	}
+
+	return nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Npm{}
+	re := V001Entry{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		var artifactReader io.ReadCloser
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to provenance bundle or registry document must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			artifactReader, err = util.FileOrURLReadCloser(ctx, props.ArtifactPath.String(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+		} else {
+			artifactReader, err = os.Open(filepath.Clean(props.ArtifactPath.Path))
+			if err != nil {
+				return nil, fmt.Errorf("error opening artifact file: %w", err)
+			}
+		}
+		defer artifactReader.Close()
+		artifactBytes, err = ioutil.ReadAll(artifactReader)
+		if err != nil {
+			return nil, fmt.Errorf("error reading artifact file: %w", err)
+		}
+	}
+
+	// the artifact is either a provenance bundle, or the registry document
+	// of a package version holding its registry signature
+	var manifest npm.Manifest
+	if err := json.Unmarshal(artifactBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("artifact must be a provenance bundle or registry document: %w", err)
+	}
+	if manifest.Dist.Integrity == "" {
+		re.NpmObj.Provenance = &models.NpmV001SchemaProvenance{
+			Content: strfmt.Base64(artifactBytes),
+		}
+	} else {
+		re.NpmObj.Package = &models.NpmV001SchemaPackage{
+			Name:      swag.String(manifest.Name),
+			Version:   swag.String(manifest.Version),
+			Integrity: swag.String(manifest.Dist.Integrity),
+		}
+
+		sigBytes := props.SignatureBytes
+		if sigBytes == nil {
+			switch {
+			case props.SignaturePath != nil:
+				sigBytes, err = ioutil.ReadFile(filepath.Clean(props.SignaturePath.Path))
+				if err != nil {
+					return nil, fmt.Errorf("error reading signature file: %w", err)
+				}
+			case len(manifest.Dist.Signatures) == 1:
+				sigBytes, err = base64.StdEncoding.DecodeString(manifest.Dist.Signatures[0].Sig)
+				if err != nil {
+					return nil, fmt.Errorf("error decoding registry signature: %w", err)
+				}
+			default:
+				return nil, errors.New("a registry signature must be provided")
+			}
+		}
+
+		publicKeyBytes := props.PublicKeyBytes
+		if len(publicKeyBytes) == 0 {
+			if len(props.PublicKeyPaths) != 1 {
+				return nil, errors.New("only one public key must be provided to verify registry signature")
+			}
+			keyBytes, err := ioutil.ReadFile(filepath.Clean(props.PublicKeyPaths[0].Path))
+			if err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			publicKeyBytes = append(publicKeyBytes, keyBytes)
+		} else if len(publicKeyBytes) != 1 {
+			return nil, errors.New("only one public key must be provided")
+		}
+
+		re.NpmObj.Signature = &models.NpmV001SchemaSignature{
+			Content: (*strfmt.Base64)(&sigBytes),
+			PublicKey: &models.NpmV001SchemaSignaturePublicKey{
+				Content: (*strfmt.Base64)(&publicKeyBytes[0]),
+			},
+		}
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if _, _, err := re.fetchExternalEntities(ctx); err != nil {
+		return nil, fmt.Errorf("error retrieving external entities: %v", err)
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.NpmObj
+
+	return &returnVal, nil
+}
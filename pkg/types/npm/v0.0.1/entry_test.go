@@ -0,0 +1,434 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	x509r "github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+const testWorkflow = "https://github.com/sigstore/hello-rekor/.github/workflows/publish.yml@refs/tags/v1.0.0"
+
+var testTarball = []byte("package tarball")
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func testIntegrity() (string, string) {
+	h := sha512.Sum512(testTarball)
+	return "sha512-" + base64.StdEncoding.EncodeToString(h[:]), hex.EncodeToString(h[:])
+}
+
+func keyHash(t *testing.T, keyBytes []byte) string {
+	t.Helper()
+	keyObj, err := x509r.NewPublicKey(bytes.NewReader(keyBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonicalKey, err := keyObj.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sha256Hex(canonicalKey)
+}
+
+// registryKey returns a signer standing in for the npm registry and its PEM
+// encoded public key
+func registryKey(t *testing.T) (signature.Signer, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.LoadSigner(key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, pem.EncodeToMemory(&pem.Block{Bytes: der, Type: "PUBLIC KEY"})
+}
+
+// provenanceBundle returns a Sigstore bundle for a statement about the
+// given package URL, signed with a certificate issued to testWorkflow, and
+// the PEM encoded certificate
+func provenanceBundle(t *testing.T, purl string, tamper bool) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workflow, err := url.Parse(testWorkflow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sigstore"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(10 * time.Minute),
+		URIs:         []*url.URL{workflow},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, digest := testIntegrity()
+	statement, err := json.Marshal(map[string]interface{}{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": "https://slsa.dev/provenance/v0.2",
+		"subject": []map[string]interface{}{
+			{"name": purl, "digest": map[string]string{"sha512": digest}},
+		},
+		"predicate": map[string]interface{}{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.LoadSigner(key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := signer.SignMessage(bytes.NewReader(dsse.PAE("application/vnd.in-toto+json", statement)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tamper {
+		statement = bytes.Replace(statement, []byte("v0.2"), []byte("v1.0"), 1)
+	}
+
+	bundle, err := json.Marshal(map[string]interface{}{
+		"mediaType": "application/vnd.dev.sigstore.bundle+json;version=0.1",
+		"verificationMaterial": map[string]interface{}{
+			"x509CertificateChain": map[string]interface{}{
+				"certificates": []map[string][]byte{{"rawBytes": cert}},
+			},
+		},
+		"dsseEnvelope": dsse.Envelope{
+			PayloadType: "application/vnd.in-toto+json",
+			Payload:     base64.StdEncoding.EncodeToString(statement),
+			Signatures:  []dsse.Signature{{Sig: base64.StdEncoding.EncodeToString(sig)}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	signer, keyBytes := registryKey(t)
+	_, otherKeyBytes := registryKey(t)
+	integrity, integrityHex := testIntegrity()
+
+	sign := func(msg string) *strfmt.Base64 {
+		sig, err := signer.SignMessage(bytes.NewReader([]byte(msg)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return (*strfmt.Base64)(&sig)
+	}
+	pkg := func(name, version string) *models.NpmV001SchemaPackage {
+		return &models.NpmV001SchemaPackage{
+			Name:      swag.String(name),
+			Version:   swag.String(version),
+			Integrity: swag.String(integrity),
+		}
+	}
+	registrySig := func(sig *strfmt.Base64, key []byte) *models.NpmV001SchemaSignature {
+		return &models.NpmV001SchemaSignature{
+			Content:   sig,
+			PublicKey: &models.NpmV001SchemaSignaturePublicKey{Content: (*strfmt.Base64)(&key)},
+		}
+	}
+
+	bundle, cert := provenanceBundle(t, "pkg:npm/%40sigstore/hello-rekor@1.0.0", false)
+	tampered, _ := provenanceBundle(t, "pkg:npm/%40sigstore/hello-rekor@1.0.0", true)
+	notNpm, _ := provenanceBundle(t, "pkg:pypi/hello-rekor@1.0.0", false)
+
+	tests := []struct {
+		name     string
+		model    models.NpmV001Schema
+		wantKeys []string
+		// unmarshalErr is set when the entry is rejected before it is verified
+		unmarshalErr bool
+		canonicalErr bool
+	}{
+		{
+			name:         "empty",
+			model:        models.NpmV001Schema{},
+			unmarshalErr: true,
+		},
+		{
+			name: "missing signature",
+			model: models.NpmV001Schema{
+				Package: pkg("hello-rekor", "1.0.0"),
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "invalid integrity",
+			model: models.NpmV001Schema{
+				Package: &models.NpmV001SchemaPackage{
+					Name:      swag.String("hello-rekor"),
+					Version:   swag.String("1.0.0"),
+					Integrity: swag.String("sha1-" + integrityHex),
+				},
+				Signature: registrySig(sign("hello-rekor@1.0.0:"+integrity), keyBytes),
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "registry signature",
+			model: models.NpmV001Schema{
+				Package:   pkg("hello-rekor", "1.0.0"),
+				Signature: registrySig(sign("hello-rekor@1.0.0:"+integrity), keyBytes),
+			},
+			wantKeys: []string{keyHash(t, keyBytes), "npm:hello-rekor", "npm:hello-rekor@1.0.0", "sha512:" + integrityHex},
+		},
+		{
+			name: "registry signature over other version",
+			model: models.NpmV001Schema{
+				Package:   pkg("hello-rekor", "1.0.1"),
+				Signature: registrySig(sign("hello-rekor@1.0.0:"+integrity), keyBytes),
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "registry signature with wrong key",
+			model: models.NpmV001Schema{
+				Package:   pkg("hello-rekor", "1.0.0"),
+				Signature: registrySig(sign("hello-rekor@1.0.0:"+integrity), otherKeyBytes),
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "provenance",
+			model: models.NpmV001Schema{
+				Provenance: &models.NpmV001SchemaProvenance{Content: bundle},
+			},
+			wantKeys: []string{
+				keyHash(t, cert),
+				testWorkflow,
+				"npm:@sigstore/hello-rekor",
+				"npm:@sigstore/hello-rekor@1.0.0",
+				"sha512:" + integrityHex,
+				"sha256:" + sha256Hex(bundle),
+			},
+		},
+		{
+			name: "provenance with matching package",
+			model: models.NpmV001Schema{
+				Package:    pkg("@sigstore/hello-rekor", "1.0.0"),
+				Provenance: &models.NpmV001SchemaProvenance{Content: bundle},
+			},
+			wantKeys: []string{
+				keyHash(t, cert),
+				testWorkflow,
+				"npm:@sigstore/hello-rekor",
+				"npm:@sigstore/hello-rekor@1.0.0",
+				"sha512:" + integrityHex,
+				"sha256:" + sha256Hex(bundle),
+			},
+		},
+		{
+			name: "provenance with other package",
+			model: models.NpmV001Schema{
+				Package:    pkg("hello-rekor", "1.0.0"),
+				Provenance: &models.NpmV001SchemaProvenance{Content: bundle},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "tampered provenance",
+			model: models.NpmV001Schema{
+				Provenance: &models.NpmV001SchemaProvenance{Content: tampered},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "provenance without npm subject",
+			model: models.NpmV001Schema{
+				Provenance: &models.NpmV001SchemaProvenance{Content: notNpm},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "provenance hash without content",
+			model: models.NpmV001Schema{
+				Provenance: &models.NpmV001SchemaProvenance{
+					Hash: &models.NpmV001SchemaProvenanceHash{
+						Algorithm: swag.String(models.NpmV001SchemaProvenanceHashAlgorithmSha256),
+						Value:     swag.String(sha256Hex(bundle)),
+					},
+				},
+			},
+			unmarshalErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &V001Entry{}
+			err := v.Unmarshal(&models.Npm{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       tc.model,
+			})
+			if (err != nil) != tc.unmarshalErr {
+				t.Fatalf("unexpected result from Unmarshal: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			canonicalBytes, err := v.Canonicalize(context.Background())
+			if (err != nil) != tc.canonicalErr {
+				t.Fatalf("unexpected result from Canonicalize: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := v.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tc.wantKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("V001Entry.IndexKeys() = %v, want %v", got, tc.wantKeys)
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(canonicalBytes), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			canonicalEntry, err := types.UnmarshalEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			if p := canonicalEntry.(*V001Entry).NpmObj.Provenance; p != nil && len(p.Content) != 0 {
+				t.Error("canonicalized entry contains the provenance bundle")
+			}
+			canonicalKeys, err := canonicalEntry.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, canonicalKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("index keys from hydrated object do not match those generated from canonicalized (and re-hydrated) object: %v %v", got, canonicalKeys)
+			}
+		})
+	}
+}
+
+func TestCreateFromArtifactProperties(t *testing.T) {
+	signer, keyBytes := registryKey(t)
+	integrity, _ := testIntegrity()
+	sig, err := signer.SignMessage(bytes.NewReader([]byte("hello-rekor@1.0.0:" + integrity)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := json.Marshal(map[string]interface{}{
+		"name":    "hello-rekor",
+		"version": "1.0.0",
+		"dist": map[string]interface{}{
+			"integrity":  integrity,
+			"signatures": []map[string]string{{"keyid": "SHA256:test", "sig": base64.StdEncoding.EncodeToString(sig)}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, _ := provenanceBundle(t, "pkg:npm/hello-rekor@1.0.0", false)
+
+	for _, tc := range []struct {
+		name  string
+		props types.ArtifactProperties
+	}{
+		{
+			name: "registry document",
+			props: types.ArtifactProperties{
+				ArtifactBytes:  manifest,
+				PublicKeyBytes: [][]byte{keyBytes},
+			},
+		},
+		{
+			name: "provenance bundle",
+			props: types.ArtifactProperties{
+				ArtifactBytes: bundle,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pe, err := V001Entry{}.CreateFromArtifactProperties(context.Background(), tc.props)
+			if err != nil {
+				t.Fatal(err)
+			}
+			spec := pe.(*models.Npm).Spec.(models.NpmV001Schema)
+			if spec.Package == nil || swag.StringValue(spec.Package.Name) != "hello-rekor" || swag.StringValue(spec.Package.Integrity) != integrity {
+				t.Errorf("unexpected package %+v", spec.Package)
+			}
+			if _, err := types.UnmarshalEntry(pe); err != nil {
+				t.Errorf("unexpected error unmarshalling created entry: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/oci"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := oci.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+var digestRegexp = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+type V001Entry struct {
+	OCIObj models.OCIV001Schema
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() ([]string, error) {
+	var result []string
+
+	if v.OCIObj.Signature == nil || v.OCIObj.Signature.PublicKey == nil {
+		return nil, errors.New("missing public key")
+	}
+	keyObj, err := x509.NewPublicKey(bytes.NewReader(*v.OCIObj.Signature.PublicKey.Content))
+	if err != nil {
+		return nil, err
+	}
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	keyHash := sha256.Sum256(key)
+	result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+	result = append(result, keyObj.Subjects()...)
+
+	var hashKey string
+	if v.OCIObj.Payload != nil && v.OCIObj.Payload.Hash != nil {
+		hashKey = strings.ToLower(fmt.Sprintf("%s:%s", *v.OCIObj.Payload.Hash.Algorithm, *v.OCIObj.Payload.Hash.Value))
+		result = append(result, hashKey)
+	}
+
+	if v.OCIObj.Image != nil {
+		// the digest of a signed manifest is the payload hash, which is already indexed
+		if v.OCIObj.Image.Digest != "" && strings.ToLower(v.OCIObj.Image.Digest) != hashKey {
+			result = append(result, strings.ToLower(v.OCIObj.Image.Digest))
+		}
+		if v.OCIObj.Image.Reference != "" {
+			result = append(result, types.PackageIndexKey(oci.KIND, v.OCIObj.Image.Reference))
+		}
+	}
+
+	return result, nil
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.OCI)
+	if !ok {
+		return errors.New("cannot unmarshal non OCI v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(it.Spec, &v.OCIObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.OCIObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// cross field validation
+	_, _, err := v.validate()
+	return err
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	// the signature can only be verified over the payload, so unlike an
+	// entry read back from the log, a proposed entry must include it
+	if v.OCIObj.Payload == nil || len(v.OCIObj.Payload.Content) == 0 {
+		return nil, types.ValidationError(errors.New("'content' must be specified for payload"))
+	}
+
+	sigObj, keyObj, err := v.validate()
+	if err != nil {
+		return nil, types.ValidationError(err)
+	}
+
+	canonicalEntry := models.OCIV001Schema{}
+
+	// need to canonicalize signature & key content
+	canonicalEntry.Signature = &models.OCIV001SchemaSignature{
+		PublicKey: &models.OCIV001SchemaSignaturePublicKey{},
+	}
+	sig, err := sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.Content = (*strfmt.Base64)(&sig)
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.PublicKey.Content = (*strfmt.Base64)(&key)
+
+	canonicalEntry.Payload = &models.OCIV001SchemaPayload{
+		Hash: v.OCIObj.Payload.Hash,
+	}
+	// payload content is not set deliberately
+
+	canonicalEntry.Image = v.OCIObj.Image
+
+	// wrap in valid object with kind and apiVersion set
+	ociObj := models.OCI{}
+	ociObj.APIVersion = swag.String(APIVERSION)
+	ociObj.Spec = &canonicalEntry
+
+	return json.Marshal(&ociObj)
+}
+
+// validate performs cross-field validation for fields in object. If the
+// payload content is present, the signature is verified over it and the
+// payload hash and image are filled in from it.
+func (v *V001Entry) validate() (pki.Signature, pki.PublicKey, error) {
+	sig := v.OCIObj.Signature
+	if sig == nil || sig.Content == nil {
+		return nil, nil, types.ValidationError(errors.New("missing signature"))
+	}
+	sigObj, err := x509.NewSignature(bytes.NewReader(*sig.Content))
+	if err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	key := sig.PublicKey
+	if key == nil || key.Content == nil {
+		return nil, nil, types.ValidationError(errors.New("missing public key"))
+	}
+	keyObj, err := x509.NewPublicKey(bytes.NewReader(*key.Content))
+	if err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	p := v.OCIObj.Payload
+	if p == nil {
+		return nil, nil, types.ValidationError(errors.New("missing payload"))
+	}
+
+	// the payload content is only present when the entry is created; an
+	// entry read back from the log was verified when it was canonicalized
+	if len(p.Content) == 0 {
+		if p.Hash == nil {
+			return nil, nil, types.ValidationError(errors.New("missing payload hash"))
+		}
+		if !govalidator.IsHash(swag.StringValue(p.Hash.Value), swag.StringValue(p.Hash.Algorithm)) {
+			return nil, nil, types.ValidationError(errors.New("invalid value for payload hash"))
+		}
+		if v.OCIObj.Image == nil || v.OCIObj.Image.Digest == "" {
+			return nil, nil, types.ValidationError(errors.New("missing image digest"))
+		}
+		return sigObj, keyObj, nil
+	}
+
+	h := sha256.Sum256(p.Content)
+	computedHash := hex.EncodeToString(h[:])
+	if p.Hash != nil && swag.StringValue(p.Hash.Value) != computedHash {
+		return nil, nil, types.ValidationError(errors.New("payload hash does not match the payload"))
+	}
+	p.Hash = &models.OCIV001SchemaPayloadHash{
+		Algorithm: swag.String(models.OCIV001SchemaPayloadHashAlgorithmSha256),
+		Value:     swag.String(computedHash),
+	}
+
+	if err := sigObj.Verify(bytes.NewReader(p.Content), keyObj); err != nil {
+		return nil, nil, types.ValidationError(fmt.Errorf("verifying signature: %w", err))
+	}
+
+	image, err := parsePayload(p.Content)
+	if err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+	if given := v.OCIObj.Image; given != nil {
+		if given.Digest != "" && given.Digest != image.Digest {
+			return nil, nil, types.ValidationError(fmt.Errorf("image digest %s does not match the payload", given.Digest))
+		}
+		// a reference is only stored if the signature covers it, which a
+		// manifest's doesn't, so that a signed manifest can't be attached to
+		// any image name
+		if given.Reference != "" && given.Reference != image.Reference {
+			if image.Reference == "" {
+				return nil, nil, types.ValidationError(errors.New("image reference can only be given by a simple signing payload"))
+			}
+			return nil, nil, types.ValidationError(fmt.Errorf("image reference %s does not match the payload", given.Reference))
+		}
+	}
+	v.OCIObj.Image = image
+
+	return sigObj, keyObj, nil
+}
+
+// manifest holds the fields that identify an OCI image manifest or index
+type manifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Config        json.RawMessage `json:"config"`
+	Manifests     json.RawMessage `json:"manifests"`
+}
+
+// parsePayload returns the image that a simple signing payload or an image
+// manifest identifies. A manifest doesn't name its repository, so only the
+// digest is set for one.
+func parsePayload(content []byte) (*models.OCIV001SchemaImage, error) {
+	var simple payload.SimpleContainerImage
+	if err := json.Unmarshal(content, &simple); err != nil {
+		return nil, fmt.Errorf("payload must be a simple signing payload or image manifest: %w", err)
+	}
+	if simple.Critical.Type != "" {
+		if simple.Critical.Type != payload.CosignSignatureType {
+			return nil, fmt.Errorf("unknown simple signing payload type %q", simple.Critical.Type)
+		}
+		if simple.Critical.Identity.DockerReference == "" {
+			return nil, errors.New("simple signing payload is missing the docker reference")
+		}
+		if !digestRegexp.MatchString(simple.Critical.Image.DockerManifestDigest) {
+			return nil, fmt.Errorf("invalid docker manifest digest %q", simple.Critical.Image.DockerManifestDigest)
+		}
+		return &models.OCIV001SchemaImage{
+			Digest:    simple.Critical.Image.DockerManifestDigest,
+			Reference: simple.Critical.Identity.DockerReference,
+		}, nil
+	}
+
+	var m manifest
+	if err := json.Unmarshal(content, &m); err != nil {
+		return nil, err
+	}
+	if m.SchemaVersion != 2 || (m.Config == nil && m.Manifests == nil) {
+		return nil, errors.New("payload must be a simple signing payload or image manifest")
+	}
+	h := sha256.Sum256(content)
+	return &models.OCIV001SchemaImage{
+		Digest: "sha256:" + hex.EncodeToString(h[:]),
+	}, nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.OCI{}
+	re := V001Entry{}
+
+	var err error
+	payloadBytes := props.ArtifactBytes
+	if payloadBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to payload file must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			return nil, errors.New("oci payloads cannot be fetched over HTTP(S)")
+		}
+		payloadBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading payload file: %w", err)
+		}
+	}
+	re.OCIObj.Payload = &models.OCIV001SchemaPayload{
+		Content: strfmt.Base64(payloadBytes),
+	}
+
+	sigBytes := props.SignatureBytes
+	if sigBytes == nil {
+		if props.SignaturePath == nil {
+			return nil, errors.New("a detached signature must be provided")
+		}
+		sigBytes, err = ioutil.ReadFile(filepath.Clean(props.SignaturePath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading signature file: %w", err)
+		}
+	}
+
+	publicKeyBytes := props.PublicKeyBytes
+	if len(publicKeyBytes) == 0 {
+		if len(props.PublicKeyPaths) != 1 {
+			return nil, errors.New("only one public key must be provided to verify detached signature")
+		}
+		keyBytes, err := ioutil.ReadFile(filepath.Clean(props.PublicKeyPaths[0].Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+		publicKeyBytes = append(publicKeyBytes, keyBytes)
+	} else if len(publicKeyBytes) != 1 {
+		return nil, errors.New("only one public key must be provided")
+	}
+
+	re.OCIObj.Signature = &models.OCIV001SchemaSignature{
+		Content: (*strfmt.Base64)(&sigBytes),
+		PublicKey: &models.OCIV001SchemaSignaturePublicKey{
+			Content: (*strfmt.Base64)(&publicKeyBytes[0]),
+		},
+	}
+
+	if _, _, err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.OCIObj
+
+	return &returnVal, nil
+}
@@ -0,0 +1,348 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+const (
+	manifestDigest = "sha256:6e1f6a9d4b4c2a6f2b7a1b4d7e6b2c3a9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a"
+	reference      = "registry.example.com/team/app"
+)
+
+var (
+	simpleSigning = []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, reference, manifestDigest))
+	imageManifest = []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[]}`)
+)
+
+func generateKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func generateCert(t *testing.T, email string) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		EmailAddresses: []string{email},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, payload []byte) []byte {
+	t.Helper()
+	s, err := signature.LoadECDSASigner(key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := s.SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func entry(payload, sig, pub []byte, image *models.OCIV001SchemaImage) *models.OCIV001Schema {
+	return &models.OCIV001Schema{
+		Signature: &models.OCIV001SchemaSignature{
+			Content: (*strfmt.Base64)(&sig),
+			PublicKey: &models.OCIV001SchemaSignaturePublicKey{
+				Content: (*strfmt.Base64)(&pub),
+			},
+		},
+		Payload: &models.OCIV001SchemaPayload{
+			Content: strfmt.Base64(payload),
+		},
+		Image: image,
+	}
+}
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func TestV001Entry_Unmarshal(t *testing.T) {
+	key, pub := generateKey(t)
+	certKey, cert := generateCert(t, "builder@example.com")
+	_, otherPub := generateKey(t)
+
+	unknownType := []byte(`{"critical":{"identity":{"docker-reference":"r"},"image":{"docker-manifest-digest":"` + manifestDigest + `"},"type":"other"}}`)
+	badDigest := []byte(`{"critical":{"identity":{"docker-reference":"r"},"image":{"docker-manifest-digest":"sha256:abc"},"type":"cosign container image signature"}}`)
+	notManifest := []byte(`{"hello":"world"}`)
+
+	tests := []struct {
+		name      string
+		obj       *models.OCIV001Schema
+		wantImage models.OCIV001SchemaImage
+		wantKeys  []string
+		wantErr   bool
+	}{
+		{
+			name:    "empty",
+			obj:     &models.OCIV001Schema{},
+			wantErr: true,
+		},
+		{
+			name:      "simple signing with key",
+			obj:       entry(simpleSigning, sign(t, key, simpleSigning), pub, nil),
+			wantImage: models.OCIV001SchemaImage{Digest: manifestDigest, Reference: reference},
+			wantKeys:  []string{sha256Hex(pub), "sha256:" + sha256Hex(simpleSigning), manifestDigest, "oci:" + reference},
+		},
+		{
+			name:      "simple signing with certificate",
+			obj:       entry(simpleSigning, sign(t, certKey, simpleSigning), cert, nil),
+			wantImage: models.OCIV001SchemaImage{Digest: manifestDigest, Reference: reference},
+			wantKeys:  []string{sha256Hex(cert), "builder@example.com", "sha256:" + sha256Hex(simpleSigning), manifestDigest, "oci:" + reference},
+		},
+		{
+			name:      "image manifest",
+			obj:       entry(imageManifest, sign(t, key, imageManifest), pub, nil),
+			wantImage: models.OCIV001SchemaImage{Digest: "sha256:" + sha256Hex(imageManifest)},
+			wantKeys:  []string{sha256Hex(pub), "sha256:" + sha256Hex(imageManifest)},
+		},
+		{
+			name:    "image manifest with reference",
+			obj:     entry(imageManifest, sign(t, key, imageManifest), pub, &models.OCIV001SchemaImage{Reference: reference}),
+			wantErr: true,
+		},
+		{
+			name:    "mismatched digest",
+			obj:     entry(simpleSigning, sign(t, key, simpleSigning), pub, &models.OCIV001SchemaImage{Digest: "sha256:" + sha256Hex(imageManifest)}),
+			wantErr: true,
+		},
+		{
+			name:    "mismatched reference",
+			obj:     entry(simpleSigning, sign(t, key, simpleSigning), pub, &models.OCIV001SchemaImage{Reference: "registry.example.com/other"}),
+			wantErr: true,
+		},
+		{
+			name:    "wrong key",
+			obj:     entry(simpleSigning, sign(t, key, simpleSigning), otherPub, nil),
+			wantErr: true,
+		},
+		{
+			name:    "invalid key",
+			obj:     entry(simpleSigning, sign(t, key, simpleSigning), []byte("notavalidkey"), nil),
+			wantErr: true,
+		},
+		{
+			name:    "unknown simple signing type",
+			obj:     entry(unknownType, sign(t, key, unknownType), pub, nil),
+			wantErr: true,
+		},
+		{
+			name:    "invalid manifest digest",
+			obj:     entry(badDigest, sign(t, key, badDigest), pub, nil),
+			wantErr: true,
+		},
+		{
+			name:    "not a manifest",
+			obj:     entry(notManifest, sign(t, key, notManifest), pub, nil),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &V001Entry{}
+			err := v.Unmarshal(&models.OCI{Spec: tt.obj})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(*v.OCIObj.Image, tt.wantImage) {
+				t.Errorf("image = %+v, want %+v", *v.OCIObj.Image, tt.wantImage)
+			}
+			got, err := v.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tt.wantKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("V001Entry.IndexKeys() = %v, want %v", got, tt.wantKeys)
+			}
+
+			canonicalBytes, err := v.Canonicalize(context.Background())
+			if err != nil {
+				t.Fatalf("error canonicalizing entry: %v", err)
+			}
+			if strings.Contains(string(canonicalBytes), `"content":"`+strfmt.Base64(tt.obj.Payload.Content).String()) {
+				t.Errorf("canonicalized entry contains the payload: %s", canonicalBytes)
+			}
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(canonicalBytes), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			canonicalEntry, err := types.UnmarshalEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			canonicalKeys, err := canonicalEntry.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, canonicalKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("index keys from hydrated object do not match those generated from canonicalized (and re-hydrated) object: %v %v", got, canonicalKeys)
+			}
+		})
+	}
+}
+
+func TestV001Entry_CanonicalizeWithoutPayload(t *testing.T) {
+	key, pub := generateKey(t)
+	_, otherPub := generateKey(t)
+
+	// an entry as stored in the log, with only the payload hash
+	stored := entry(nil, sign(t, key, simpleSigning), pub, &models.OCIV001SchemaImage{Digest: manifestDigest, Reference: reference})
+	stored.Payload.Hash = &models.OCIV001SchemaPayloadHash{
+		Algorithm: swag.String(models.OCIV001SchemaPayloadHashAlgorithmSha256),
+		Value:     swag.String(sha256Hex(simpleSigning)),
+	}
+	// a signature that verifies nothing, for any digest and reference
+	forged := entry(nil, []byte("not a signature"), otherPub, &models.OCIV001SchemaImage{Digest: manifestDigest, Reference: "registry.example.com/forged"})
+	forged.Payload.Hash = stored.Payload.Hash
+
+	for name, obj := range map[string]*models.OCIV001Schema{
+		"stored entry": stored,
+		"forged entry": forged,
+	} {
+		t.Run(name, func(t *testing.T) {
+			v := &V001Entry{}
+			if err := v.Unmarshal(&models.OCI{Spec: obj}); err != nil {
+				t.Fatalf("unexpected error unmarshalling entry without payload: %v", err)
+			}
+			if _, err := v.Canonicalize(context.Background()); err == nil {
+				t.Error("expected proposed entry without payload content to be rejected")
+			}
+		})
+	}
+}
+
+func TestV001Entry_CreateFromArtifactProperties(t *testing.T) {
+	key, pub := generateKey(t)
+	_, otherPub := generateKey(t)
+	sig := sign(t, key, simpleSigning)
+
+	tests := []struct {
+		name    string
+		props   types.ArtifactProperties
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			props: types.ArtifactProperties{
+				ArtifactBytes:  simpleSigning,
+				SignatureBytes: sig,
+				PublicKeyBytes: [][]byte{pub},
+			},
+		},
+		{
+			name: "missing signature",
+			props: types.ArtifactProperties{
+				ArtifactBytes:  simpleSigning,
+				PublicKeyBytes: [][]byte{pub},
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong key",
+			props: types.ArtifactProperties{
+				ArtifactBytes:  simpleSigning,
+				SignatureBytes: sig,
+				PublicKeyBytes: [][]byte{otherPub},
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple keys",
+			props: types.ArtifactProperties{
+				ArtifactBytes:  simpleSigning,
+				SignatureBytes: sig,
+				PublicKeyBytes: [][]byte{pub, otherPub},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pe, err := V001Entry{}.CreateFromArtifactProperties(context.Background(), tt.props)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.CreateFromArtifactProperties() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if _, err := types.UnmarshalEntry(pe); err != nil {
+				t.Errorf("unexpected error unmarshalling created entry: %v", err)
+			}
+		})
+	}
+}
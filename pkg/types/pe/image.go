@@ -0,0 +1,179 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pe
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"debug/pe"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/sassoftware/relic/lib/authenticode"
+	"github.com/sassoftware/relic/lib/pkcs7"
+	"github.com/sassoftware/relic/lib/x509tools"
+)
+
+// winCertTypePKCSSignedData is the type of a WIN_CERTIFICATE entry in the
+// certificate table that holds an Authenticode signature
+const winCertTypePKCSSignedData = 0x0002
+
+// Image is a PE file, reduced to its Authenticode signature
+type Image struct {
+	// Signature is the DER encoded PKCS7 signature from the certificate table
+	Signature []byte
+	// SignedContent is the SpcIndirectDataContent covered by the signature,
+	// which holds the Authenticode digest
+	SignedContent []byte
+	// DigestAlgorithm is the hash function of the Authenticode digest
+	DigestAlgorithm crypto.Hash
+	// Digest is the Authenticode digest of the image
+	Digest []byte
+	// Certificate is the certificate of the signer
+	Certificate *x509.Certificate
+}
+
+// Unmarshal parses a PE file, extracts its Authenticode signature and checks
+// that the digest in the signature matches the Authenticode hash of the file.
+// The signature itself is not verified.
+func (i *Image) Unmarshal(content []byte) error {
+	sig, err := certificateTable(content)
+	if err != nil {
+		return err
+	}
+	// the entry in the certificate table may be padded after the signature
+	var raw asn1.RawValue
+	if _, err := asn1.Unmarshal(sig, &raw); err != nil {
+		return fmt.Errorf("parsing Authenticode signature: %w", err)
+	}
+	sig = raw.FullBytes
+
+	psd, err := pkcs7.Unmarshal(sig)
+	if err != nil {
+		return fmt.Errorf("parsing Authenticode signature: %w", err)
+	}
+	if !psd.Content.ContentInfo.ContentType.Equal(authenticode.OidSpcIndirectDataContent) {
+		return errors.New("signature is not an Authenticode signature")
+	}
+	signedContent, err := psd.Content.ContentInfo.Bytes()
+	if err != nil {
+		return err
+	}
+	var indirect authenticode.SpcIndirectDataContentPe
+	if err := psd.Content.ContentInfo.Unmarshal(&indirect); err != nil {
+		return fmt.Errorf("parsing Authenticode signed content: %w", err)
+	}
+	hash, err := x509tools.PkixDigestToHashE(indirect.MessageDigest.DigestAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	digest, err := authenticode.DigestPE(bytes.NewReader(content), hash, false)
+	if err != nil {
+		return fmt.Errorf("computing Authenticode digest: %w", err)
+	}
+	if !bytes.Equal(digest.Imprint, indirect.MessageDigest.Digest) {
+		return fmt.Errorf("Authenticode digest mismatch: %x != %x", digest.Imprint, indirect.MessageDigest.Digest)
+	}
+
+	if len(psd.Content.SignerInfos) != 1 {
+		return fmt.Errorf("expected one signer in Authenticode signature, found %d", len(psd.Content.SignerInfos))
+	}
+	certs, err := psd.Content.Certificates.Parse()
+	if err != nil {
+		return err
+	}
+	cert, err := psd.Content.SignerInfos[0].FindCertificate(certs)
+	if err != nil {
+		return err
+	}
+
+	*i = Image{
+		Signature:       sig,
+		SignedContent:   signedContent,
+		DigestAlgorithm: hash,
+		Digest:          digest.Imprint,
+		Certificate:     cert,
+	}
+	return nil
+}
+
+// certificateTable returns the PKCS7 signature from the certificate table of
+// a PE file
+func certificateTable(content []byte) ([]byte, error) {
+	f, err := pe.NewFile(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("parsing PE file: %w", err)
+	}
+	var dirs []pe.DataDirectory
+	switch h := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		dirs = h.DataDirectory[:]
+		if h.NumberOfRvaAndSizes < uint32(len(dirs)) {
+			dirs = dirs[:h.NumberOfRvaAndSizes]
+		}
+	case *pe.OptionalHeader64:
+		dirs = h.DataDirectory[:]
+		if h.NumberOfRvaAndSizes < uint32(len(dirs)) {
+			dirs = dirs[:h.NumberOfRvaAndSizes]
+		}
+	default:
+		return nil, errors.New("PE file has no optional header")
+	}
+	if len(dirs) <= pe.IMAGE_DIRECTORY_ENTRY_SECURITY || dirs[pe.IMAGE_DIRECTORY_ENTRY_SECURITY].Size == 0 {
+		return nil, errors.New("PE file is not signed")
+	}
+
+	// unlike the other data directories, the address of the certificate
+	// table is a file offset
+	dir := dirs[pe.IMAGE_DIRECTORY_ENTRY_SECURITY]
+	start, end := uint64(dir.VirtualAddress), uint64(dir.VirtualAddress)+uint64(dir.Size)
+	if end > uint64(len(content)) {
+		return nil, errors.New("certificate table extends past the end of the PE file")
+	}
+	table := content[start:end]
+
+	var sig []byte
+	for len(table) > 0 {
+		if len(table) < 8 {
+			return nil, errors.New("invalid certificate table")
+		}
+		length := binary.LittleEndian.Uint32(table[0:4])
+		certType := binary.LittleEndian.Uint16(table[6:8])
+		if length < 8 || uint64(length) > uint64(len(table)) {
+			return nil, errors.New("invalid certificate table")
+		}
+		if certType == winCertTypePKCSSignedData {
+			if sig != nil {
+				return nil, errors.New("multiple signatures detected in PE file; unable to process")
+			}
+			sig = table[8:length]
+		}
+		// entries are aligned to 8 bytes
+		next := (uint64(length) + 7) &^ 7
+		if next > uint64(len(table)) {
+			next = uint64(len(table))
+		}
+		table = table[next:]
+	}
+	if sig == nil {
+		return nil, errors.New("no Authenticode signature found in PE file")
+	}
+	return sig, nil
+}
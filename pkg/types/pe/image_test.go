@@ -0,0 +1,131 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pe
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/binary"
+	"encoding/pem"
+	"os"
+	"testing"
+)
+
+// offset of the certificate table entry in the data directories of the test
+// file, which has a PE32+ header at offset 64
+const securityDirOffset = 64 + 24 + 144
+
+func readTestFile(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile("../../../tests/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestImage(t *testing.T) {
+	content := readTestFile(t, "test_pe.exe")
+	block, _ := pem.Decode(readTestFile(t, "test_pe.pem"))
+
+	i := Image{}
+	if err := i.Unmarshal(content); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if i.DigestAlgorithm != crypto.SHA256 || len(i.Digest) != crypto.SHA256.Size() {
+		t.Errorf("unexpected digest %v %x", i.DigestAlgorithm, i.Digest)
+	}
+	if !bytes.Equal(i.Certificate.Raw, block.Bytes) {
+		t.Errorf("unexpected signer certificate %v", i.Certificate.Subject)
+	}
+	if !bytes.Contains(content, i.Signature) || !bytes.Contains(i.Signature, i.SignedContent[2:]) {
+		t.Error("signature was not extracted from the certificate table")
+	}
+}
+
+func TestImageInvalid(t *testing.T) {
+	content := readTestFile(t, "test_pe.exe")
+	certStart := binary.LittleEndian.Uint32(content[securityDirOffset:])
+
+	modified := func(f func(b []byte) []byte) []byte {
+		return f(append([]byte{}, content...))
+	}
+
+	for _, tc := range []struct {
+		name    string
+		content []byte
+	}{
+		{name: "empty", content: []byte{}},
+		{name: "not a PE file", content: []byte("hello world")},
+		{
+			name: "unsigned",
+			content: modified(func(b []byte) []byte {
+				copy(b[securityDirOffset:], make([]byte, 8))
+				return b[:certStart]
+			}),
+		},
+		{
+			name: "modified code",
+			content: modified(func(b []byte) []byte {
+				b[0x200]++
+				return b
+			}),
+		},
+		{
+			name: "modified header",
+			content: modified(func(b []byte) []byte {
+				// the linker version is covered by the digest
+				b[64+24+2]++
+				return b
+			}),
+		},
+		{
+			name: "certificate table out of bounds",
+			content: modified(func(b []byte) []byte {
+				binary.LittleEndian.PutUint32(b[securityDirOffset+4:], uint32(len(b)))
+				return b
+			}),
+		},
+		{
+			name: "invalid certificate entry",
+			content: modified(func(b []byte) []byte {
+				binary.LittleEndian.PutUint32(b[certStart:], 4)
+				return b
+			}),
+		},
+		{
+			name: "unknown certificate type",
+			content: modified(func(b []byte) []byte {
+				binary.LittleEndian.PutUint16(b[certStart+6:], 0x0001)
+				return b
+			}),
+		},
+		{
+			name: "corrupt signature",
+			content: modified(func(b []byte) []byte {
+				b[certStart+8] = 0
+				return b
+			}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			i := Image{}
+			if err := i.Unmarshal(tc.content); err == nil {
+				t.Error("expected error unmarshalling image")
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pe
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki/pkcs7"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/pe"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := pe.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+// digestAlgorithms maps the hash functions of Authenticode digests to their
+// names in the schema
+var digestAlgorithms = map[crypto.Hash]string{
+	crypto.SHA1:   models.PEV001SchemaImageDigestAlgorithmSha1,
+	crypto.SHA256: models.PEV001SchemaImageDigestAlgorithmSha256,
+	crypto.SHA384: models.PEV001SchemaImageDigestAlgorithmSha384,
+	crypto.SHA512: models.PEV001SchemaImageDigestAlgorithmSha512,
+}
+
+type V001Entry struct {
+	PEObj models.PEV001Schema
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() ([]string, error) {
+	var result []string
+
+	sig := v.PEObj.Signature
+	if sig != nil && sig.PublicKey != nil && sig.PublicKey.Content != nil {
+		keyObj, err := x509.NewPublicKey(bytes.NewReader(*sig.PublicKey.Content))
+		if err != nil {
+			return nil, err
+		}
+		key, err := keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
+		result = append(result, keyObj.Subjects()...)
+	}
+
+	image := v.PEObj.Image
+	if image.Hash != nil {
+		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *image.Hash.Algorithm, *image.Hash.Value))
+		result = append(result, hashKey)
+	}
+	if image.Digest != nil {
+		digestKey := strings.ToLower(fmt.Sprintf("%s:%s", *image.Digest.Algorithm, *image.Digest.Value))
+		result = append(result, digestKey)
+	}
+
+	return result, nil
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	peObj, ok := pe.(*models.PE)
+	if !ok {
+		return errors.New("cannot unmarshal non PE v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(peObj.Spec, &v.PEObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.PEObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// cross field validation
+	return v.validate()
+}
+
+// fetchExternalEntities parses the PE file, verifies the Authenticode
+// signature embedded in it and fills in the fields derived from the file
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) error {
+	if err := v.validate(); err != nil {
+		return types.ValidationError(err)
+	}
+
+	image := v.PEObj.Image
+	if len(image.Content) == 0 {
+		return types.ValidationError(errors.New("'content' must be specified for image"))
+	}
+
+	hasher := sha256.New()
+	if _, err := hasher.Write(image.Content); err != nil {
+		return err
+	}
+	computedSHA := hex.EncodeToString(hasher.Sum(nil))
+	if image.Hash != nil && swag.StringValue(image.Hash.Value) != computedSHA {
+		return types.ValidationError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, swag.StringValue(image.Hash.Value)))
+	}
+
+	// this checks that the digest in the signature matches the file
+	img := pe.Image{}
+	if err := img.Unmarshal(image.Content); err != nil {
+		return types.ValidationError(err)
+	}
+	digestAlgorithm, ok := digestAlgorithms[img.DigestAlgorithm]
+	if !ok {
+		return types.ValidationError(fmt.Errorf("unsupported Authenticode digest algorithm %v", img.DigestAlgorithm))
+	}
+
+	sigObj, err := pkcs7.NewSignature(bytes.NewReader(img.Signature))
+	if err != nil {
+		return types.ValidationError(err)
+	}
+	if err := sigObj.Verify(bytes.NewReader(img.SignedContent), nil); err != nil {
+		return types.ValidationError(fmt.Errorf("verifying signature: %w", err))
+	}
+
+	certPEM, err := cryptoutils.MarshalCertificateToPEM(img.Certificate)
+	if err != nil {
+		return err
+	}
+	keyObj, err := x509.NewPublicKey(bytes.NewReader(certPEM))
+	if err != nil {
+		return types.ValidationError(err)
+	}
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		return err
+	}
+
+	image.Hash = &models.PEV001SchemaImageHash{
+		Algorithm: swag.String(models.PEV001SchemaImageHashAlgorithmSha256),
+		Value:     swag.String(computedSHA),
+	}
+	image.Digest = &models.PEV001SchemaImageDigest{
+		Algorithm: swag.String(digestAlgorithm),
+		Value:     swag.String(hex.EncodeToString(img.Digest)),
+	}
+	// unlike the canonical value of a PKCS7 signature, the signed content is
+	// kept so that the digest can be checked against the signature
+	v.PEObj.Signature = &models.PEV001SchemaSignature{
+		Content: pem.EncodeToMemory(&pem.Block{Type: "PKCS7", Bytes: img.Signature}),
+		PublicKey: &models.PEV001SchemaSignaturePublicKey{
+			Content: (*strfmt.Base64)(&key),
+		},
+	}
+
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.fetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+
+	canonicalEntry := models.PEV001Schema{
+		Signature: v.PEObj.Signature,
+		Image: &models.PEV001SchemaImage{
+			Hash:   v.PEObj.Image.Hash,
+			Digest: v.PEObj.Image.Digest,
+		},
+	}
+	// image content is not set deliberately
+
+	v.PEObj = canonicalEntry
+	// wrap in valid object with kind and apiVersion set
+	peObj := models.PE{}
+	peObj.APIVersion = swag.String(APIVERSION)
+	peObj.Spec = &canonicalEntry
+
+	return json.Marshal(&peObj)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	image := v.PEObj.Image
+	if image == nil {
+		return errors.New("missing image")
+	}
+
+	if len(image.Content) == 0 && image.Hash == nil {
+		return errors.New("'content' must be specified for image")
+	}
+
+	hash := image.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	}
+	digest := image.Digest
+	if digest != nil {
+		if !govalidator.IsHash(swag.StringValue(digest.Value), swag.StringValue(digest.Algorithm)) {
+			return errors.New("invalid value for digest")
+		}
+	}
+
+	return nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.PE{}
+	re := V001Entry{}
+
+	// we will need only the artifact; the signature and certificate are
+	// embedded in the PE file
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		var artifactReader io.ReadCloser
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to artifact file must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			artifactReader, err = util.FileOrURLReadCloser(ctx, props.ArtifactPath.String(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("error reading PE file: %w", err)
+			}
+		} else {
+			artifactReader, err = os.Open(filepath.Clean(props.ArtifactPath.Path))
+			if err != nil {
+				return nil, fmt.Errorf("error opening PE file: %w", err)
+			}
+		}
+		defer artifactReader.Close()
+		artifactBytes, err = ioutil.ReadAll(artifactReader)
+		if err != nil {
+			return nil, fmt.Errorf("error reading PE file: %w", err)
+		}
+	}
+	re.PEObj.Image = &models.PEV001SchemaImage{
+		Content: strfmt.Base64(artifactBytes),
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := re.fetchExternalEntities(ctx); err != nil {
+		return nil, fmt.Errorf("error retrieving external entities: %v", err)
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.PEObj
+
+	return &returnVal, nil
+}
@@ -0,0 +1,272 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pe
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/pe"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	peBytes, err := os.ReadFile("../../../../tests/test_pe.exe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	certBytes, err := os.ReadFile("../../../../tests/test_pe.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := pe.Image{}
+	if err := img.Unmarshal(peBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	// the signature ends with the signature value of the signer, which
+	// can be changed without breaking the structure of the signature
+	certStart := binary.LittleEndian.Uint32(peBytes[64+24+144:])
+	sigEnd := int(certStart) + 8 + len(img.Signature)
+	badSig := append([]byte{}, peBytes...)
+	badSig[sigEnd-1]++
+
+	modified := append([]byte{}, peBytes...)
+	modified[0x200]++
+
+	unsigned := append([]byte{}, peBytes[:certStart]...)
+	copy(unsigned[64+24+144:], make([]byte, 8))
+
+	hash := func(b []byte) *models.PEV001SchemaImageHash {
+		return &models.PEV001SchemaImageHash{
+			Algorithm: swag.String(models.PEV001SchemaImageHashAlgorithmSha256),
+			Value:     swag.String(sha256Hex(b)),
+		}
+	}
+
+	wantKeys := []string{
+		sha256Hex(certBytes),
+		"test@rekor.dev",
+		"sha256:" + sha256Hex(peBytes),
+		"sha256:" + hex.EncodeToString(img.Digest),
+	}
+
+	tests := []struct {
+		name         string
+		model        models.PEV001Schema
+		wantKeys     []string
+		unmarshalErr bool
+		canonicalErr bool
+	}{
+		{
+			name:         "missing image",
+			model:        models.PEV001Schema{},
+			unmarshalErr: true,
+		},
+		{
+			name:         "empty image",
+			model:        models.PEV001Schema{Image: &models.PEV001SchemaImage{}},
+			unmarshalErr: true,
+		},
+		{
+			name:     "signed image",
+			model:    models.PEV001Schema{Image: &models.PEV001SchemaImage{Content: peBytes}},
+			wantKeys: wantKeys,
+		},
+		{
+			name:     "signed image with hash",
+			model:    models.PEV001Schema{Image: &models.PEV001SchemaImage{Content: peBytes, Hash: hash(peBytes)}},
+			wantKeys: wantKeys,
+		},
+		{
+			name:         "hash without image",
+			model:        models.PEV001Schema{Image: &models.PEV001SchemaImage{Hash: hash(peBytes)}},
+			canonicalErr: true,
+		},
+		{
+			name:         "mismatched hash",
+			model:        models.PEV001Schema{Image: &models.PEV001SchemaImage{Content: peBytes, Hash: hash(modified)}},
+			canonicalErr: true,
+		},
+		{
+			name: "invalid hash",
+			model: models.PEV001Schema{Image: &models.PEV001SchemaImage{
+				Content: peBytes,
+				Hash: &models.PEV001SchemaImageHash{
+					Algorithm: swag.String(models.PEV001SchemaImageHashAlgorithmSha256),
+					Value:     swag.String("not a hash"),
+				},
+			}},
+			unmarshalErr: true,
+		},
+		{
+			name:         "modified image",
+			model:        models.PEV001Schema{Image: &models.PEV001SchemaImage{Content: modified}},
+			canonicalErr: true,
+		},
+		{
+			name:         "invalid signature",
+			model:        models.PEV001Schema{Image: &models.PEV001SchemaImage{Content: badSig}},
+			canonicalErr: true,
+		},
+		{
+			name:         "unsigned image",
+			model:        models.PEV001Schema{Image: &models.PEV001SchemaImage{Content: unsigned}},
+			canonicalErr: true,
+		},
+		{
+			name:         "not a PE file",
+			model:        models.PEV001Schema{Image: &models.PEV001SchemaImage{Content: certBytes}},
+			canonicalErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &V001Entry{}
+			err := v.Unmarshal(&models.PE{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       tc.model,
+			})
+			if (err != nil) != tc.unmarshalErr {
+				t.Fatalf("unexpected result from Unmarshal: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			canonicalBytes, err := v.Canonicalize(context.Background())
+			if (err != nil) != tc.canonicalErr {
+				t.Fatalf("unexpected result from Canonicalize: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := v.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tc.wantKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("V001Entry.IndexKeys() = %v, want %v", got, tc.wantKeys)
+			}
+
+			// the signature is kept with the signed content, as found in the
+			// certificate table
+			block, _ := pem.Decode(v.PEObj.Signature.Content)
+			if block == nil || !bytes.Equal(block.Bytes, img.Signature) {
+				t.Errorf("unexpected signature %q", v.PEObj.Signature.Content)
+			}
+
+			proposed, err := models.UnmarshalProposedEntry(bytes.NewReader(canonicalBytes), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			canonicalEntry, err := types.UnmarshalEntry(proposed)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			if len(canonicalEntry.(*V001Entry).PEObj.Image.Content) != 0 {
+				t.Error("canonicalized entry contains the image")
+			}
+			canonicalKeys, err := canonicalEntry.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, canonicalKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("index keys from hydrated object do not match those generated from canonicalized (and re-hydrated) object: %v %v", got, canonicalKeys)
+			}
+		})
+	}
+}
+
+func TestCreateFromArtifactProperties(t *testing.T) {
+	peBytes, err := os.ReadFile("../../../../tests/test_pe.exe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		props   types.ArtifactProperties
+		wantErr bool
+	}{
+		{
+			name:  "signed image",
+			props: types.ArtifactProperties{ArtifactBytes: peBytes},
+		},
+		{
+			name:    "unsigned file",
+			props:   types.ArtifactProperties{ArtifactBytes: []byte("hello world")},
+			wantErr: true,
+		},
+		{
+			name:    "missing artifact",
+			props:   types.ArtifactProperties{},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			proposed, err := V001Entry{}.CreateFromArtifactProperties(context.Background(), tc.props)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected result from CreateFromArtifactProperties: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			spec := proposed.(*models.PE).Spec.(models.PEV001Schema)
+			if swag.StringValue(spec.Image.Hash.Value) != sha256Hex(peBytes) {
+				t.Errorf("unexpected hash %v", swag.StringValue(spec.Image.Hash.Value))
+			}
+			if spec.Signature == nil || spec.Signature.PublicKey == nil {
+				t.Error("signer certificate was not extracted")
+			}
+			if _, err := types.UnmarshalEntry(proposed); err != nil {
+				t.Errorf("unexpected error unmarshalling created entry: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pypi
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/textproto"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	wheelExt = ".whl"
+	sdistExt = ".tar.gz"
+)
+
+var nameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// Distribution is a Python wheel or sdist
+type Distribution struct {
+	Name    string // the normalized name of the project
+	Version string
+}
+
+// NormalizeName returns the normalized form of a project name as defined
+// in PEP 503, which is how package indexes compare names
+func NormalizeName(name string) string {
+	return strings.ToLower(nameSeparators.ReplaceAllString(name, "-"))
+}
+
+// ParseFilename returns the normalized project name and the version
+// contained in the filename of a wheel or sdist
+func ParseFilename(filename string) (*Distribution, error) {
+	if filename != path.Base(filename) {
+		return nil, fmt.Errorf("invalid distribution filename %q", filename)
+	}
+	var name, version string
+	switch {
+	case strings.HasSuffix(filename, wheelExt):
+		// {name}-{version}(-{build tag})?-{python tag}-{abi tag}-{platform tag}.whl
+		parts := strings.Split(strings.TrimSuffix(filename, wheelExt), "-")
+		if len(parts) != 5 && len(parts) != 6 {
+			return nil, fmt.Errorf("invalid wheel filename %q", filename)
+		}
+		name, version = parts[0], parts[1]
+	case strings.HasSuffix(filename, sdistExt):
+		// {name}-{version}.tar.gz, where the name may contain hyphens in older sdists
+		base := strings.TrimSuffix(filename, sdistExt)
+		i := strings.LastIndex(base, "-")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid sdist filename %q", filename)
+		}
+		name, version = base[:i], base[i+1:]
+	default:
+		return nil, fmt.Errorf("%q is not a wheel or a gzipped sdist", filename)
+	}
+	if name == "" || version == "" {
+		return nil, fmt.Errorf("invalid distribution filename %q", filename)
+	}
+	return &Distribution{
+		Name:    NormalizeName(name),
+		Version: version,
+	}, nil
+}
+
+// Unmarshal reads the name and version of the project from the METADATA
+// file of a wheel or the PKG-INFO file of an sdist, which must match those
+// in its filename
+func (d *Distribution) Unmarshal(filename string, content []byte) error {
+	fromFilename, err := ParseFilename(filename)
+	if err != nil {
+		return err
+	}
+
+	var metadata []byte
+	if strings.HasSuffix(filename, wheelExt) {
+		metadata, err = readWheelMetadata(content)
+	} else {
+		metadata, err = readSdistMetadata(content)
+	}
+	if err != nil {
+		return err
+	}
+
+	header, err := textproto.NewReader(bufio.NewReader(bytes.NewReader(metadata))).ReadMIMEHeader()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parsing metadata: %w", err)
+	}
+	name, version := header.Get("Name"), header.Get("Version")
+	if name == "" || version == "" {
+		return errors.New("metadata is missing the name or version")
+	}
+
+	dist := Distribution{
+		Name:    NormalizeName(name),
+		Version: version,
+	}
+	// wheel filenames replace hyphens in the version with underscores
+	if dist.Name != fromFilename.Name || strings.ReplaceAll(dist.Version, "-", "_") != strings.ReplaceAll(fromFilename.Version, "-", "_") {
+		return fmt.Errorf("metadata of %s %s does not match filename %q", dist.Name, dist.Version, filename)
+	}
+
+	*d = dist
+	return nil
+}
+
+// readWheelMetadata returns the METADATA file from the .dist-info directory
+// at the root of a wheel
+func readWheelMetadata(content []byte) ([]byte, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("reading wheel: %w", err)
+	}
+	for _, f := range zipReader.File {
+		dir, file := path.Split(f.Name)
+		if file != "METADATA" || !strings.HasSuffix(dir, ".dist-info/") || strings.Count(dir, "/") != 1 {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		// #nosec G110
+		return ioutil.ReadAll(rc)
+	}
+	return nil, errors.New("METADATA file was not located in wheel")
+}
+
+// readSdistMetadata returns the PKG-INFO file from the top-level directory
+// of an sdist
+func readSdistMetadata(content []byte) ([]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("reading sdist: %w", err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, errors.New("PKG-INFO file was not located in sdist")
+		} else if err != nil {
+			return nil, fmt.Errorf("getting next entry in tar archive: %w", err)
+		}
+		dir, file := path.Split(path.Clean(header.Name))
+		if file == "PKG-INFO" && strings.Count(dir, "/") == 1 {
+			// #nosec G110
+			return ioutil.ReadAll(tarReader)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pypi
+
+import (
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	for name, want := range map[string]string{
+		"requests":         "requests",
+		"Django":           "django",
+		"zope.interface":   "zope-interface",
+		"Hello__Rekor-.py": "hello-rekor-py",
+	} {
+		if got := NormalizeName(name); got != want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestParseFilename(t *testing.T) {
+	for _, tc := range []struct {
+		filename    string
+		wantName    string
+		wantVersion string
+		wantErr     bool
+	}{
+		{filename: "hello_rekor-1.0.0-py3-none-any.whl", wantName: "hello-rekor", wantVersion: "1.0.0"},
+		{filename: "hello_rekor-1.0.0-1-cp310-cp310-manylinux_2_17_x86_64.whl", wantName: "hello-rekor", wantVersion: "1.0.0"},
+		{filename: "hello_rekor-1.0.0.tar.gz", wantName: "hello-rekor", wantVersion: "1.0.0"},
+		{filename: "hello-rekor-1.0.0.tar.gz", wantName: "hello-rekor", wantVersion: "1.0.0"},
+		{filename: "hello_rekor-1.0.0.whl", wantErr: true},
+		{filename: "hello_rekor-1.0.0.zip", wantErr: true},
+		{filename: "1.0.0.tar.gz", wantErr: true},
+		{filename: "dist/hello_rekor-1.0.0.tar.gz", wantErr: true},
+	} {
+		got, err := ParseFilename(tc.filename)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseFilename(%q) returned unexpected error %v", tc.filename, err)
+			continue
+		}
+		if err == nil && (got.Name != tc.wantName || got.Version != tc.wantVersion) {
+			t.Errorf("ParseFilename(%q) = %+v, want %s %s", tc.filename, got, tc.wantName, tc.wantVersion)
+		}
+	}
+}
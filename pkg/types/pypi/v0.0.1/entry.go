@@ -0,0 +1,371 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pypi
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/pypi"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := pypi.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	PypiObj models.PypiV001Schema
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() ([]string, error) {
+	var result []string
+
+	af, err := pki.NewArtifactFactory(pki.Format(*v.PypiObj.Signature.Format))
+	if err != nil {
+		return nil, err
+	}
+	keyObj, err := af.NewPublicKey(bytes.NewReader(*v.PypiObj.Signature.PublicKey.Content))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		log.Logger.Error(err)
+	} else {
+		keyHash := sha256.Sum256(key)
+		result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+	}
+
+	result = append(result, keyObj.Subjects()...)
+
+	pkg := v.PypiObj.Package
+	if pkg.Hash != nil {
+		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *pkg.Hash.Algorithm, *pkg.Hash.Value))
+		result = append(result, hashKey)
+	}
+	result = append(result, types.PackageIndexKey(pypi.KIND, swag.StringValue(pkg.Filename)))
+	// the version is indexed as a requirement specifier, since a version
+	// on its own doesn't identify anything
+	if pkg.Name != "" {
+		result = append(result, types.PackageIndexKey(pypi.KIND, pkg.Name))
+		if pkg.Version != "" {
+			result = append(result, types.PackageIndexKey(pypi.KIND, pkg.Name+"=="+pkg.Version))
+		}
+	}
+
+	return result, nil
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	pypiObj, ok := pe.(*models.Pypi)
+	if !ok {
+		return errors.New("cannot unmarshal non PyPI v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(pypiObj.Spec, &v.PypiObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.PypiObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// cross field validation
+	return v.validate()
+}
+
+// fetchExternalEntities verifies the signature over the distribution, or
+// over its digest if only that was provided, and fills in the hash, name
+// and version of the distribution
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) (pki.PublicKey, pki.Signature, error) {
+	if err := v.validate(); err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	af, err := pki.NewArtifactFactory(pki.Format(*v.PypiObj.Signature.Format))
+	if err != nil {
+		return nil, nil, err
+	}
+	sigObj, err := af.NewSignature(bytes.NewReader(*v.PypiObj.Signature.Content))
+	if err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+	keyObj, err := af.NewPublicKey(bytes.NewReader(*v.PypiObj.Signature.PublicKey.Content))
+	if err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	pkg := v.PypiObj.Package
+	filename := swag.StringValue(pkg.Filename)
+
+	if len(pkg.Content) == 0 {
+		// only x509 signatures can be verified against a digest
+		if *v.PypiObj.Signature.Format != models.PypiV001SchemaSignatureFormatX509 {
+			return nil, nil, types.ValidationError(errors.New("'content' must be specified for package unless the signature is x509"))
+		}
+		digest, err := hex.DecodeString(swag.StringValue(pkg.Hash.Value))
+		if err != nil {
+			return nil, nil, types.ValidationError(err)
+		}
+		if err := sigObj.Verify(nil, keyObj, options.WithDigest(digest)); err != nil {
+			return nil, nil, types.ValidationError(fmt.Errorf("verifying signature: %w", err))
+		}
+		dist, err := pypi.ParseFilename(filename)
+		if err != nil {
+			return nil, nil, types.ValidationError(err)
+		}
+		pkg.Name, pkg.Version = dist.Name, dist.Version
+		return keyObj, sigObj, nil
+	}
+
+	h := sha256.Sum256(pkg.Content)
+	computedSHA := hex.EncodeToString(h[:])
+	if pkg.Hash != nil && swag.StringValue(pkg.Hash.Value) != computedSHA {
+		return nil, nil, types.ValidationError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, swag.StringValue(pkg.Hash.Value)))
+	}
+
+	if err := sigObj.Verify(bytes.NewReader(pkg.Content), keyObj); err != nil {
+		return nil, nil, types.ValidationError(fmt.Errorf("verifying signature: %w", err))
+	}
+
+	dist := pypi.Distribution{}
+	if err := dist.Unmarshal(filename, pkg.Content); err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	pkg.Hash = &models.PypiV001SchemaPackageHash{
+		Algorithm: swag.String(models.PypiV001SchemaPackageHashAlgorithmSha256),
+		Value:     swag.String(computedSHA),
+	}
+	pkg.Name, pkg.Version = dist.Name, dist.Version
+
+	return keyObj, sigObj, nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	keyObj, sigObj, err := v.fetchExternalEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry := models.PypiV001Schema{}
+
+	// need to canonicalize signature & key content
+	canonicalEntry.Signature = &models.PypiV001SchemaSignature{}
+	canonicalEntry.Signature.Format = v.PypiObj.Signature.Format
+
+	var sigContent []byte
+	sigContent, err = sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.Content = (*strfmt.Base64)(&sigContent)
+
+	var pubKeyContent []byte
+	canonicalEntry.Signature.PublicKey = &models.PypiV001SchemaSignaturePublicKey{}
+	pubKeyContent, err = keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.PublicKey.Content = (*strfmt.Base64)(&pubKeyContent)
+
+	canonicalEntry.Package = &models.PypiV001SchemaPackage{
+		Filename: v.PypiObj.Package.Filename,
+		Name:     v.PypiObj.Package.Name,
+		Version:  v.PypiObj.Package.Version,
+		Hash:     v.PypiObj.Package.Hash,
+	}
+	// package content is not set deliberately
+
+	// wrap in valid object with kind and apiVersion set
+	pypiObj := models.Pypi{}
+	pypiObj.APIVersion = swag.String(APIVERSION)
+	pypiObj.Spec = &canonicalEntry
+
+	return json.Marshal(&pypiObj)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	sig := v.PypiObj.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if sig.Content == nil || len(*sig.Content) == 0 {
+		return errors.New("'content' must be specified for signature")
+	}
+
+	key := sig.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if key.Content == nil || len(*key.Content) == 0 {
+		return errors.New("'content' must be specified for publicKey")
+	}
+
+	pkg := v.PypiObj.Package
+	if pkg == nil {
+		return errors.New("missing package")
+	}
+	if _, err := pypi.ParseFilename(swag.StringValue(pkg.Filename)); err != nil {
+		return err
+	}
+
+	hash := pkg.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	} else if len(pkg.Content) == 0 {
+		return errors.New("'content' must be specified for package")
+	}
+
+	return nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Pypi{}
+	re := V001Entry{}
+
+	// the filename of the distribution carries its name and version
+	if props.ArtifactPath == nil {
+		return nil, errors.New("path to distribution file must be specified")
+	}
+	re.PypiObj.Package = &models.PypiV001SchemaPackage{
+		Filename: swag.String(path.Base(props.ArtifactPath.Path)),
+	}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil && props.ArtifactHash != "" {
+		re.PypiObj.Package.Hash = &models.PypiV001SchemaPackageHash{
+			Algorithm: swag.String(models.PypiV001SchemaPackageHashAlgorithmSha256),
+			Value:     swag.String(strings.ToLower(strings.TrimPrefix(props.ArtifactHash, "sha256:"))),
+		}
+	} else {
+		if artifactBytes == nil {
+			var artifactReader io.ReadCloser
+			if props.ArtifactPath.IsAbs() {
+				artifactReader, err = util.FileOrURLReadCloser(ctx, props.ArtifactPath.String(), nil)
+				if err != nil {
+					return nil, fmt.Errorf("error reading distribution file: %w", err)
+				}
+			} else {
+				artifactReader, err = os.Open(filepath.Clean(props.ArtifactPath.Path))
+				if err != nil {
+					return nil, fmt.Errorf("error opening distribution file: %w", err)
+				}
+			}
+			defer artifactReader.Close()
+			artifactBytes, err = ioutil.ReadAll(artifactReader)
+			if err != nil {
+				return nil, fmt.Errorf("error reading distribution file: %w", err)
+			}
+		}
+		re.PypiObj.Package.Content = strfmt.Base64(artifactBytes)
+	}
+
+	re.PypiObj.Signature = &models.PypiV001SchemaSignature{}
+	switch props.PKIFormat {
+	case "pgp":
+		re.PypiObj.Signature.Format = swag.String(models.PypiV001SchemaSignatureFormatPgp)
+	case "minisign":
+		re.PypiObj.Signature.Format = swag.String(models.PypiV001SchemaSignatureFormatMinisign)
+	case "x509":
+		re.PypiObj.Signature.Format = swag.String(models.PypiV001SchemaSignatureFormatX509)
+	case "ssh":
+		re.PypiObj.Signature.Format = swag.String(models.PypiV001SchemaSignatureFormatSSH)
+	default:
+		return nil, fmt.Errorf("unsupported signature format %q for pypi entries", props.PKIFormat)
+	}
+	sigBytes := props.SignatureBytes
+	if sigBytes == nil {
+		if props.SignaturePath == nil {
+			return nil, errors.New("a detached signature must be provided")
+		}
+		sigBytes, err = ioutil.ReadFile(filepath.Clean(props.SignaturePath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading signature file: %w", err)
+		}
+	}
+	re.PypiObj.Signature.Content = (*strfmt.Base64)(&sigBytes)
+
+	re.PypiObj.Signature.PublicKey = &models.PypiV001SchemaSignaturePublicKey{}
+	publicKeyBytes := props.PublicKeyBytes
+	if len(publicKeyBytes) == 0 {
+		if len(props.PublicKeyPaths) != 1 {
+			return nil, errors.New("only one public key must be provided to verify detached signature")
+		}
+		keyBytes, err := ioutil.ReadFile(filepath.Clean(props.PublicKeyPaths[0].Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+		publicKeyBytes = append(publicKeyBytes, keyBytes)
+	} else if len(publicKeyBytes) != 1 {
+		return nil, errors.New("only one public key must be provided")
+	}
+	re.PypiObj.Signature.PublicKey.Content = (*strfmt.Base64)(&publicKeyBytes[0])
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if _, _, err := re.fetchExternalEntities(ctx); err != nil {
+		return nil, fmt.Errorf("error retrieving external entities: %v", err)
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.PypiObj
+
+	return &returnVal, nil
+}
@@ -0,0 +1,392 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pypi
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	x509r "github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+const testMetadata = "Metadata-Version: 2.1\nName: Hello_Rekor\nVersion: 1.0.0\nSummary: A test package\n\nLong description.\n"
+
+func wheel(t *testing.T, metadata string) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"hello_rekor/__init__.py":              "",
+		"hello_rekor-1.0.0.dist-info/METADATA": metadata,
+		"hello_rekor-1.0.0.dist-info/WHEEL":    "Wheel-Version: 1.0\n",
+	} {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func sdist(t *testing.T, metadata string) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	w := tar.NewWriter(gz)
+	for name, content := range map[string]string{
+		"hello_rekor-1.0.0/setup.py": "",
+		"hello_rekor-1.0.0/PKG-INFO": metadata,
+	} {
+		if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func testKey(t *testing.T) (signature.Signer, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.LoadSigner(key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, pem.EncodeToMemory(&pem.Block{Bytes: der, Type: "PUBLIC KEY"})
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	signer, keyBytes := testKey(t)
+	_, otherKeyBytes := testKey(t)
+	keyObj, err := x509r.NewPublicKey(bytes.NewReader(keyBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonicalKey, err := keyObj.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyHash := sha256Hex(canonicalKey)
+
+	sign := func(b []byte) *strfmt.Base64 {
+		sig, err := signer.SignMessage(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return (*strfmt.Base64)(&sig)
+	}
+
+	whl := wheel(t, testMetadata)
+	tgz := sdist(t, testMetadata)
+	mismatched := wheel(t, "Metadata-Version: 2.1\nName: other\nVersion: 1.0.0\n")
+	const whlName = "hello_rekor-1.0.0-py3-none-any.whl"
+	const tgzName = "hello_rekor-1.0.0.tar.gz"
+
+	x509Sig := func(sig *strfmt.Base64, key []byte) *models.PypiV001SchemaSignature {
+		return &models.PypiV001SchemaSignature{
+			Format:    swag.String(models.PypiV001SchemaSignatureFormatX509),
+			Content:   sig,
+			PublicKey: &models.PypiV001SchemaSignaturePublicKey{Content: (*strfmt.Base64)(&key)},
+		}
+	}
+	hash := func(b []byte) *models.PypiV001SchemaPackageHash {
+		return &models.PypiV001SchemaPackageHash{
+			Algorithm: swag.String(models.PypiV001SchemaPackageHashAlgorithmSha256),
+			Value:     swag.String(sha256Hex(b)),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		model    models.PypiV001Schema
+		wantKeys []string
+		// unmarshalErr is set when the entry is rejected before it is verified
+		unmarshalErr bool
+		canonicalErr bool
+	}{
+		{
+			name:         "empty",
+			model:        models.PypiV001Schema{},
+			unmarshalErr: true,
+		},
+		{
+			name: "missing package",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(whl), keyBytes),
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "missing content and hash",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(whl), keyBytes),
+				Package:   &models.PypiV001SchemaPackage{Filename: swag.String(whlName)},
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "invalid filename",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(whl), keyBytes),
+				Package:   &models.PypiV001SchemaPackage{Filename: swag.String("hello_rekor.zip"), Content: whl},
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "wheel",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(whl), keyBytes),
+				Package:   &models.PypiV001SchemaPackage{Filename: swag.String(whlName), Content: whl},
+			},
+			wantKeys: []string{keyHash, "sha256:" + sha256Hex(whl), "pypi:" + whlName, "pypi:hello-rekor", "pypi:hello-rekor==1.0.0"},
+		},
+		{
+			name: "wheel with matching hash",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(whl), keyBytes),
+				Package:   &models.PypiV001SchemaPackage{Filename: swag.String(whlName), Content: whl, Hash: hash(whl)},
+			},
+			wantKeys: []string{keyHash, "sha256:" + sha256Hex(whl), "pypi:" + whlName, "pypi:hello-rekor", "pypi:hello-rekor==1.0.0"},
+		},
+		{
+			name: "wheel with mismatched hash",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(whl), keyBytes),
+				Package:   &models.PypiV001SchemaPackage{Filename: swag.String(whlName), Content: whl, Hash: hash(tgz)},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "wheel with wrong key",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(whl), otherKeyBytes),
+				Package:   &models.PypiV001SchemaPackage{Filename: swag.String(whlName), Content: whl},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "wheel with metadata not matching filename",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(mismatched), keyBytes),
+				Package:   &models.PypiV001SchemaPackage{Filename: swag.String(whlName), Content: mismatched},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "sdist named as wheel",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(tgz), keyBytes),
+				Package:   &models.PypiV001SchemaPackage{Filename: swag.String(whlName), Content: tgz},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "sdist",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(tgz), keyBytes),
+				Package:   &models.PypiV001SchemaPackage{Filename: swag.String(tgzName), Content: tgz},
+			},
+			wantKeys: []string{keyHash, "sha256:" + sha256Hex(tgz), "pypi:" + tgzName, "pypi:hello-rekor", "pypi:hello-rekor==1.0.0"},
+		},
+		{
+			name: "digest",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(whl), keyBytes),
+				Package:   &models.PypiV001SchemaPackage{Filename: swag.String(whlName), Hash: hash(whl)},
+			},
+			wantKeys: []string{keyHash, "sha256:" + sha256Hex(whl), "pypi:" + whlName, "pypi:hello-rekor", "pypi:hello-rekor==1.0.0"},
+		},
+		{
+			name: "digest with wrong signature",
+			model: models.PypiV001Schema{
+				Signature: x509Sig(sign(tgz), keyBytes),
+				Package:   &models.PypiV001SchemaPackage{Filename: swag.String(whlName), Hash: hash(whl)},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "digest with non-x509 signature",
+			model: models.PypiV001Schema{
+				Signature: &models.PypiV001SchemaSignature{
+					Format:    swag.String(models.PypiV001SchemaSignatureFormatSSH),
+					Content:   sign(whl),
+					PublicKey: &models.PypiV001SchemaSignaturePublicKey{Content: (*strfmt.Base64)(&keyBytes)},
+				},
+				Package: &models.PypiV001SchemaPackage{Filename: swag.String(whlName), Hash: hash(whl)},
+			},
+			canonicalErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &V001Entry{}
+			err := v.Unmarshal(&models.Pypi{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       tc.model,
+			})
+			if (err != nil) != tc.unmarshalErr {
+				t.Fatalf("unexpected result from Unmarshal: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			canonicalBytes, err := v.Canonicalize(context.Background())
+			if (err != nil) != tc.canonicalErr {
+				t.Fatalf("unexpected result from Canonicalize: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := v.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tc.wantKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("V001Entry.IndexKeys() = %v, want %v", got, tc.wantKeys)
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(canonicalBytes), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			canonicalEntry, err := types.UnmarshalEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			if len(canonicalEntry.(*V001Entry).PypiObj.Package.Content) != 0 {
+				t.Error("canonicalized entry contains the distribution")
+			}
+			canonicalKeys, err := canonicalEntry.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, canonicalKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("index keys from hydrated object do not match those generated from canonicalized (and re-hydrated) object: %v %v", got, canonicalKeys)
+			}
+		})
+	}
+}
+
+func TestCreateFromArtifactProperties(t *testing.T) {
+	signer, keyBytes := testKey(t)
+	whl := wheel(t, testMetadata)
+	sig, err := signer.SignMessage(bytes.NewReader(whl))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		props types.ArtifactProperties
+	}{
+		{
+			name: "content",
+			props: types.ArtifactProperties{
+				ArtifactBytes: whl,
+			},
+		},
+		{
+			name: "digest",
+			props: types.ArtifactProperties{
+				ArtifactHash: "sha256:" + sha256Hex(whl),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			props := tc.props
+			props.ArtifactPath = &url.URL{Path: "dist/hello_rekor-1.0.0-py3-none-any.whl"}
+			props.SignatureBytes = sig
+			props.PublicKeyBytes = [][]byte{keyBytes}
+			props.PKIFormat = "x509"
+
+			pe, err := V001Entry{}.CreateFromArtifactProperties(context.Background(), props)
+			if err != nil {
+				t.Fatal(err)
+			}
+			spec := pe.(*models.Pypi).Spec.(models.PypiV001Schema)
+			if swag.StringValue(spec.Package.Filename) != "hello_rekor-1.0.0-py3-none-any.whl" || spec.Package.Name != "hello-rekor" {
+				t.Errorf("unexpected package %+v", spec.Package)
+			}
+			if _, err := types.UnmarshalEntry(pe); err != nil {
+				t.Errorf("unexpected error unmarshalling created entry: %v", err)
+			}
+		})
+	}
+}
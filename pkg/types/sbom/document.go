@@ -0,0 +1,182 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+)
+
+const (
+	FormatSPDX      = "spdx"
+	FormatCycloneDX = "cyclonedx"
+)
+
+// spdxDocument holds the fields of an SPDX JSON document that describe its
+// packages
+type spdxDocument struct {
+	SPDXVersion string `json:"spdxVersion"`
+	Packages    []struct {
+		Checksums []struct {
+			Algorithm     string `json:"algorithm"`
+			ChecksumValue string `json:"checksumValue"`
+		} `json:"checksums"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+// cycloneDXComponent holds the fields of a CycloneDX component that
+// identify it
+type cycloneDXComponent struct {
+	PURL   string `json:"purl"`
+	Hashes []struct {
+		Alg     string `json:"alg"`
+		Content string `json:"content"`
+	} `json:"hashes"`
+	// components may be nested
+	Components []cycloneDXComponent `json:"components"`
+}
+
+// cycloneDXDocument holds the fields of a CycloneDX JSON document that
+// describe its components
+type cycloneDXDocument struct {
+	BOMFormat string `json:"bomFormat"`
+	Metadata  struct {
+		Component *cycloneDXComponent `json:"component"`
+	} `json:"metadata"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+// Document is an SBOM document, reduced to the components it describes
+type Document struct {
+	Format string
+	// PackageURLs are the package URLs of the components
+	PackageURLs []string
+	// Checksums are the checksums of the components, such as
+	// sha256:<hex>
+	Checksums []string
+	seen      map[string]struct{}
+}
+
+// Unmarshal parses an SPDX or CycloneDX document in JSON format
+func (d *Document) Unmarshal(content []byte) error {
+	var probe struct {
+		SPDXVersion string `json:"spdxVersion"`
+		BOMFormat   string `json:"bomFormat"`
+	}
+	if err := json.Unmarshal(content, &probe); err != nil {
+		return fmt.Errorf("parsing SBOM: %w", err)
+	}
+
+	doc := Document{seen: map[string]struct{}{}}
+	switch {
+	case strings.HasPrefix(probe.SPDXVersion, "SPDX-"):
+		doc.Format = FormatSPDX
+		var spdx spdxDocument
+		if err := json.Unmarshal(content, &spdx); err != nil {
+			return fmt.Errorf("parsing SPDX document: %w", err)
+		}
+		for _, pkg := range spdx.Packages {
+			for _, ref := range pkg.ExternalRefs {
+				if ref.ReferenceType == "purl" {
+					if err := doc.addPackageURL(ref.ReferenceLocator); err != nil {
+						return err
+					}
+				}
+			}
+			for _, c := range pkg.Checksums {
+				doc.addChecksum(c.Algorithm, c.ChecksumValue)
+			}
+		}
+	case probe.BOMFormat == "CycloneDX":
+		doc.Format = FormatCycloneDX
+		var cdx cycloneDXDocument
+		if err := json.Unmarshal(content, &cdx); err != nil {
+			return fmt.Errorf("parsing CycloneDX document: %w", err)
+		}
+		components := cdx.Components
+		if cdx.Metadata.Component != nil {
+			components = append(components, *cdx.Metadata.Component)
+		}
+		if err := doc.addComponents(components); err != nil {
+			return err
+		}
+	default:
+		return errors.New("document is not an SPDX or CycloneDX document in JSON format")
+	}
+
+	doc.seen = nil
+	*d = doc
+	return nil
+}
+
+func (d *Document) addComponents(components []cycloneDXComponent) error {
+	for _, c := range components {
+		if c.PURL != "" {
+			if err := d.addPackageURL(c.PURL); err != nil {
+				return err
+			}
+		}
+		for _, h := range c.Hashes {
+			d.addChecksum(h.Alg, h.Content)
+		}
+		if err := d.addComponents(c.Components); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Document) addPackageURL(purl string) error {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return fmt.Errorf("invalid package URL %q", purl)
+	}
+	if _, ok := d.seen[purl]; ok {
+		return nil
+	}
+	d.seen[purl] = struct{}{}
+	d.PackageURLs = append(d.PackageURLs, purl)
+	return nil
+}
+
+// addChecksum adds a checksum if it uses an algorithm that entries can be
+// searched by, and ignores it otherwise
+func (d *Document) addChecksum(algorithm, value string) {
+	// SPDX names algorithms like SHA256, and CycloneDX like SHA-256
+	alg := strings.ToLower(strings.ReplaceAll(algorithm, "-", ""))
+	switch alg {
+	case "sha1", "sha256", "sha512":
+	default:
+		return
+	}
+	value = strings.ToLower(value)
+	if !govalidator.IsHash(value, alg) {
+		return
+	}
+	checksum := alg + ":" + value
+	if _, ok := d.seen[checksum]; ok {
+		return
+	}
+	d.seen[checksum] = struct{}{}
+	d.Checksums = append(d.Checksums, checksum)
+}
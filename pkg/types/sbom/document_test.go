@@ -0,0 +1,121 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"reflect"
+	"testing"
+)
+
+const (
+	sha1Hex   = "da39a3ee5e6b4b0d3255bfef95601890afd80709"
+	sha256Hex = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+func TestDocument(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		want    Document
+		wantErr bool
+	}{
+		{
+			name: "spdx",
+			content: `{
+				"spdxVersion": "SPDX-2.3",
+				"packages": [
+					{
+						"name": "rekor",
+						"externalRefs": [
+							{"referenceType": "purl", "referenceLocator": "pkg:golang/github.com/sigstore/rekor@v1.0.0"},
+							{"referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:sigstore:rekor:1.0.0:*:*:*:*:*:*:*"}
+						],
+						"checksums": [
+							{"algorithm": "SHA256", "checksumValue": "` + sha256Hex + `"},
+							{"algorithm": "SHA1", "checksumValue": "` + sha1Hex + `"},
+							{"algorithm": "MD5", "checksumValue": "d41d8cd98f00b204e9800998ecf8427e"}
+						]
+					},
+					{
+						"name": "rekor again",
+						"externalRefs": [
+							{"referenceType": "purl", "referenceLocator": "pkg:golang/github.com/sigstore/rekor@v1.0.0"}
+						]
+					}
+				]
+			}`,
+			want: Document{
+				Format:      FormatSPDX,
+				PackageURLs: []string{"pkg:golang/github.com/sigstore/rekor@v1.0.0"},
+				Checksums:   []string{"sha256:" + sha256Hex, "sha1:" + sha1Hex},
+			},
+		},
+		{
+			name: "cyclonedx",
+			content: `{
+				"bomFormat": "CycloneDX",
+				"specVersion": "1.4",
+				"metadata": {
+					"component": {"name": "app", "purl": "pkg:oci/app@sha256%3A` + sha256Hex + `"}
+				},
+				"components": [
+					{
+						"name": "lib",
+						"purl": "pkg:npm/lib@1.0.0",
+						"hashes": [{"alg": "SHA-256", "content": "` + sha256Hex + `"}],
+						"components": [
+							{"name": "nested", "purl": "pkg:npm/nested@2.0.0", "hashes": [{"alg": "SHA-1", "content": "` + sha1Hex + `"}]}
+						]
+					}
+				]
+			}`,
+			want: Document{
+				Format:      FormatCycloneDX,
+				PackageURLs: []string{"pkg:npm/lib@1.0.0", "pkg:npm/nested@2.0.0", "pkg:oci/app@sha256%3A" + sha256Hex},
+				Checksums:   []string{"sha256:" + sha256Hex, "sha1:" + sha1Hex},
+			},
+		},
+		{
+			name:    "cyclonedx with invalid purl",
+			content: `{"bomFormat": "CycloneDX", "components": [{"purl": "npm/lib@1.0.0"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "not an sbom",
+			content: `{"name": "rekor"}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			content: `SPDXVersion: SPDX-2.3`,
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := Document{}
+			err := d.Unmarshal([]byte(tc.content))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(d, tc.want) {
+				t.Errorf("Unmarshal() = %+v, want %+v", d, tc.want)
+			}
+		})
+	}
+}
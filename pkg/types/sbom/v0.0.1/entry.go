@@ -0,0 +1,377 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/sbom"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := sbom.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	SbomObj models.SbomV001Schema
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() ([]string, error) {
+	var result []string
+
+	af, err := pki.NewArtifactFactory(pki.Format(*v.SbomObj.Signature.Format))
+	if err != nil {
+		return nil, err
+	}
+	keyObj, err := af.NewPublicKey(bytes.NewReader(*v.SbomObj.Signature.PublicKey.Content))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keyObj.CanonicalValue()
+	if err != nil {
+		log.Logger.Error(err)
+	} else {
+		keyHash := sha256.Sum256(key)
+		result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+	}
+
+	result = append(result, keyObj.Subjects()...)
+
+	doc := v.SbomObj.Document
+	if doc.Hash != nil {
+		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *doc.Hash.Algorithm, *doc.Hash.Value))
+		result = append(result, hashKey)
+	}
+
+	// the components are only known when the document was provided, as it is
+	// not stored in the log
+	if len(doc.Content) > 0 {
+		d := sbom.Document{}
+		if err := d.Unmarshal(doc.Content); err != nil {
+			return nil, err
+		}
+		result = append(result, d.PackageURLs...)
+		result = append(result, d.Checksums...)
+	}
+
+	return result, nil
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	sbomObj, ok := pe.(*models.Sbom)
+	if !ok {
+		return errors.New("cannot unmarshal non SBOM v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(sbomObj.Spec, &v.SbomObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.SbomObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// cross field validation
+	return v.validate()
+}
+
+// fetchExternalEntities verifies the signature over the document, or over
+// its digest if only that was provided, and fills in the hash and format
+// of the document
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) (pki.PublicKey, pki.Signature, error) {
+	if err := v.validate(); err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	af, err := pki.NewArtifactFactory(pki.Format(*v.SbomObj.Signature.Format))
+	if err != nil {
+		return nil, nil, err
+	}
+	sigObj, err := af.NewSignature(bytes.NewReader(*v.SbomObj.Signature.Content))
+	if err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+	keyObj, err := af.NewPublicKey(bytes.NewReader(*v.SbomObj.Signature.PublicKey.Content))
+	if err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	doc := v.SbomObj.Document
+
+	if len(doc.Content) == 0 {
+		// only x509 signatures can be verified against a digest
+		if *v.SbomObj.Signature.Format != models.SbomV001SchemaSignatureFormatX509 {
+			return nil, nil, types.ValidationError(errors.New("'content' must be specified for document unless the signature is x509"))
+		}
+		digest, err := hex.DecodeString(swag.StringValue(doc.Hash.Value))
+		if err != nil {
+			return nil, nil, types.ValidationError(err)
+		}
+		if err := sigObj.Verify(nil, keyObj, options.WithDigest(digest)); err != nil {
+			return nil, nil, types.ValidationError(fmt.Errorf("verifying signature: %w", err))
+		}
+		// the format is not known, as nothing about the document but its hash
+		// is covered by the signature
+		return keyObj, sigObj, nil
+	}
+
+	h := sha256.Sum256(doc.Content)
+	computedSHA := hex.EncodeToString(h[:])
+	if doc.Hash != nil && swag.StringValue(doc.Hash.Value) != computedSHA {
+		return nil, nil, types.ValidationError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, swag.StringValue(doc.Hash.Value)))
+	}
+
+	if err := sigObj.Verify(bytes.NewReader(doc.Content), keyObj); err != nil {
+		return nil, nil, types.ValidationError(fmt.Errorf("verifying signature: %w", err))
+	}
+
+	d := sbom.Document{}
+	if err := d.Unmarshal(doc.Content); err != nil {
+		return nil, nil, types.ValidationError(err)
+	}
+
+	doc.Hash = &models.SbomV001SchemaDocumentHash{
+		Algorithm: swag.String(models.SbomV001SchemaDocumentHashAlgorithmSha256),
+		Value:     swag.String(computedSHA),
+	}
+	doc.Format = d.Format
+
+	return keyObj, sigObj, nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	keyObj, sigObj, err := v.fetchExternalEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry := models.SbomV001Schema{}
+
+	// need to canonicalize signature & key content
+	canonicalEntry.Signature = &models.SbomV001SchemaSignature{}
+	canonicalEntry.Signature.Format = v.SbomObj.Signature.Format
+
+	var sigContent []byte
+	sigContent, err = sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.Content = (*strfmt.Base64)(&sigContent)
+
+	var pubKeyContent []byte
+	canonicalEntry.Signature.PublicKey = &models.SbomV001SchemaSignaturePublicKey{}
+	pubKeyContent, err = keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature.PublicKey.Content = (*strfmt.Base64)(&pubKeyContent)
+
+	canonicalEntry.Document = &models.SbomV001SchemaDocument{
+		Format: v.SbomObj.Document.Format,
+		Hash:   v.SbomObj.Document.Hash,
+	}
+	// document content is not set deliberately; the document is kept in
+	// attestation storage
+
+	// wrap in valid object with kind and apiVersion set
+	sbomObj := models.Sbom{}
+	sbomObj.APIVersion = swag.String(APIVERSION)
+	sbomObj.Spec = &canonicalEntry
+
+	return json.Marshal(&sbomObj)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	sig := v.SbomObj.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if sig.Content == nil || len(*sig.Content) == 0 {
+		return errors.New("'content' must be specified for signature")
+	}
+
+	key := sig.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if key.Content == nil || len(*key.Content) == 0 {
+		return errors.New("'content' must be specified for publicKey")
+	}
+
+	doc := v.SbomObj.Document
+	if doc == nil {
+		return errors.New("missing document")
+	}
+
+	hash := doc.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	} else if len(doc.Content) == 0 {
+		return errors.New("'content' must be specified for document")
+	}
+
+	return nil
+}
+
+// AttestationKey returns the digest of the document, to be used to lookup
+// the document from storage
+func (v *V001Entry) AttestationKey() string {
+	if v.SbomObj.Document != nil && v.SbomObj.Document.Hash != nil {
+		return fmt.Sprintf("%s:%s", *v.SbomObj.Document.Hash.Algorithm, *v.SbomObj.Document.Hash.Value)
+	}
+	return ""
+}
+
+// AttestationKeyValue returns both the key and value to be persisted into
+// attestation storage, which is the document when it was provided
+func (v *V001Entry) AttestationKeyValue() (string, []byte) {
+	if v.SbomObj.Document == nil || len(v.SbomObj.Document.Content) == 0 {
+		return "", nil
+	}
+	storageSize := len(v.SbomObj.Document.Content)
+	if storageSize > types.MaxAttestationSize() {
+		log.Logger.Infof("Skipping attestation storage, size %d is greater than max %d", storageSize, types.MaxAttestationSize())
+		return "", nil
+	}
+	return v.AttestationKey(), v.SbomObj.Document.Content
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Sbom{}
+	re := V001Entry{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		var artifactReader io.ReadCloser
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to SBOM document must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			artifactReader, err = util.FileOrURLReadCloser(ctx, props.ArtifactPath.String(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("error reading SBOM document: %w", err)
+			}
+		} else {
+			artifactReader, err = os.Open(filepath.Clean(props.ArtifactPath.Path))
+			if err != nil {
+				return nil, fmt.Errorf("error opening SBOM document: %w", err)
+			}
+		}
+		defer artifactReader.Close()
+		artifactBytes, err = ioutil.ReadAll(artifactReader)
+		if err != nil {
+			return nil, fmt.Errorf("error reading SBOM document: %w", err)
+		}
+	}
+	re.SbomObj.Document = &models.SbomV001SchemaDocument{
+		Content: strfmt.Base64(artifactBytes),
+	}
+
+	re.SbomObj.Signature = &models.SbomV001SchemaSignature{}
+	switch props.PKIFormat {
+	case "pgp":
+		re.SbomObj.Signature.Format = swag.String(models.SbomV001SchemaSignatureFormatPgp)
+	case "minisign":
+		re.SbomObj.Signature.Format = swag.String(models.SbomV001SchemaSignatureFormatMinisign)
+	case "x509":
+		re.SbomObj.Signature.Format = swag.String(models.SbomV001SchemaSignatureFormatX509)
+	case "ssh":
+		re.SbomObj.Signature.Format = swag.String(models.SbomV001SchemaSignatureFormatSSH)
+	default:
+		return nil, fmt.Errorf("unsupported signature format %q for sbom entries", props.PKIFormat)
+	}
+	sigBytes := props.SignatureBytes
+	if sigBytes == nil {
+		if props.SignaturePath == nil {
+			return nil, errors.New("a detached signature must be provided")
+		}
+		sigBytes, err = ioutil.ReadFile(filepath.Clean(props.SignaturePath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading signature file: %w", err)
+		}
+	}
+	re.SbomObj.Signature.Content = (*strfmt.Base64)(&sigBytes)
+
+	re.SbomObj.Signature.PublicKey = &models.SbomV001SchemaSignaturePublicKey{}
+	publicKeyBytes := props.PublicKeyBytes
+	if len(publicKeyBytes) == 0 {
+		if len(props.PublicKeyPaths) != 1 {
+			return nil, errors.New("only one public key must be provided to verify detached signature")
+		}
+		keyBytes, err := ioutil.ReadFile(filepath.Clean(props.PublicKeyPaths[0].Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+		publicKeyBytes = append(publicKeyBytes, keyBytes)
+	} else if len(publicKeyBytes) != 1 {
+		return nil, errors.New("only one public key must be provided")
+	}
+	re.SbomObj.Signature.PublicKey.Content = (*strfmt.Base64)(&publicKeyBytes[0])
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if _, _, err := re.fetchExternalEntities(ctx); err != nil {
+		return nil, fmt.Errorf("error retrieving external entities: %v", err)
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.SbomObj
+
+	return &returnVal, nil
+}
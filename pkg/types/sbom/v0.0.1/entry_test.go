@@ -0,0 +1,415 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/spf13/viper"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+const (
+	componentSHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	spdxDocument    = `{"spdxVersion": "SPDX-2.3", "packages": [{"name": "rekor",` +
+		`"externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:golang/github.com/sigstore/rekor@v1.0.0"}],` +
+		`"checksums": [{"algorithm": "SHA256", "checksumValue": "` + componentSHA256 + `"}]}]}`
+	cycloneDXDocument = `{"bomFormat": "CycloneDX", "specVersion": "1.4", "components": [{"name": "lib", "purl": "pkg:npm/lib@1.0.0"}]}`
+)
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func testKey(t *testing.T) (signature.Signer, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.LoadSigner(key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, pem.EncodeToMemory(&pem.Block{Bytes: der, Type: "PUBLIC KEY"})
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	signer, keyBytes := testKey(t)
+	_, otherKeyBytes := testKey(t)
+	keyHash := func() string {
+		block, _ := pem.Decode(keyBytes)
+		return sha256Hex(pem.EncodeToMemory(block))
+	}()
+
+	sign := func(b []byte) *strfmt.Base64 {
+		sig, err := signer.SignMessage(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return (*strfmt.Base64)(&sig)
+	}
+
+	spdx := []byte(spdxDocument)
+	cdx := []byte(cycloneDXDocument)
+	notSBOM := []byte(`{"name": "rekor"}`)
+
+	x509Sig := func(sig *strfmt.Base64, key []byte) *models.SbomV001SchemaSignature {
+		return &models.SbomV001SchemaSignature{
+			Format:    swag.String(models.SbomV001SchemaSignatureFormatX509),
+			Content:   sig,
+			PublicKey: &models.SbomV001SchemaSignaturePublicKey{Content: (*strfmt.Base64)(&key)},
+		}
+	}
+	hash := func(b []byte) *models.SbomV001SchemaDocumentHash {
+		return &models.SbomV001SchemaDocumentHash{
+			Algorithm: swag.String(models.SbomV001SchemaDocumentHashAlgorithmSha256),
+			Value:     swag.String(sha256Hex(b)),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		model      models.SbomV001Schema
+		wantKeys   []string
+		wantFormat string
+		// the components are not indexed again from the canonical entry
+		wantCanonicalKeys []string
+		unmarshalErr      bool
+		canonicalErr      bool
+	}{
+		{
+			name:         "missing document",
+			model:        models.SbomV001Schema{Signature: x509Sig(sign(spdx), keyBytes)},
+			unmarshalErr: true,
+		},
+		{
+			name:         "missing signature",
+			model:        models.SbomV001Schema{Document: &models.SbomV001SchemaDocument{Content: spdx}},
+			unmarshalErr: true,
+		},
+		{
+			name: "spdx document",
+			model: models.SbomV001Schema{
+				Signature: x509Sig(sign(spdx), keyBytes),
+				Document:  &models.SbomV001SchemaDocument{Content: spdx},
+			},
+			wantKeys:          []string{keyHash, "sha256:" + sha256Hex(spdx), "pkg:golang/github.com/sigstore/rekor@v1.0.0", "sha256:" + componentSHA256},
+			wantFormat:        models.SbomV001SchemaDocumentFormatSpdx,
+			wantCanonicalKeys: []string{keyHash, "sha256:" + sha256Hex(spdx)},
+		},
+		{
+			name: "cyclonedx document with hash",
+			model: models.SbomV001Schema{
+				Signature: x509Sig(sign(cdx), keyBytes),
+				Document:  &models.SbomV001SchemaDocument{Content: cdx, Hash: hash(cdx)},
+			},
+			wantKeys:          []string{keyHash, "sha256:" + sha256Hex(cdx), "pkg:npm/lib@1.0.0"},
+			wantFormat:        models.SbomV001SchemaDocumentFormatCyclonedx,
+			wantCanonicalKeys: []string{keyHash, "sha256:" + sha256Hex(cdx)},
+		},
+		{
+			// the format of the document is not known from its hash
+			name: "hash",
+			model: models.SbomV001Schema{
+				Signature: x509Sig(sign(spdx), keyBytes),
+				Document:  &models.SbomV001SchemaDocument{Hash: hash(spdx)},
+			},
+			wantKeys:          []string{keyHash, "sha256:" + sha256Hex(spdx)},
+			wantCanonicalKeys: []string{keyHash, "sha256:" + sha256Hex(spdx)},
+		},
+		{
+			name: "hash with wrong key",
+			model: models.SbomV001Schema{
+				Signature: x509Sig(sign(spdx), otherKeyBytes),
+				Document:  &models.SbomV001SchemaDocument{Hash: hash(spdx)},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "hash with non-x509 signature",
+			model: models.SbomV001Schema{
+				Signature: &models.SbomV001SchemaSignature{
+					Format:    swag.String(models.SbomV001SchemaSignatureFormatSSH),
+					Content:   sign(spdx),
+					PublicKey: &models.SbomV001SchemaSignaturePublicKey{Content: (*strfmt.Base64)(&keyBytes)},
+				},
+				Document: &models.SbomV001SchemaDocument{Hash: hash(spdx)},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "invalid hash",
+			model: models.SbomV001Schema{
+				Signature: x509Sig(sign(spdx), keyBytes),
+				Document: &models.SbomV001SchemaDocument{
+					Hash: &models.SbomV001SchemaDocumentHash{
+						Algorithm: swag.String(models.SbomV001SchemaDocumentHashAlgorithmSha256),
+						Value:     swag.String("not a hash"),
+					},
+				},
+			},
+			unmarshalErr: true,
+		},
+		{
+			name: "mismatched hash",
+			model: models.SbomV001Schema{
+				Signature: x509Sig(sign(spdx), keyBytes),
+				Document:  &models.SbomV001SchemaDocument{Content: spdx, Hash: hash(cdx)},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "signature over other document",
+			model: models.SbomV001Schema{
+				Signature: x509Sig(sign(cdx), keyBytes),
+				Document:  &models.SbomV001SchemaDocument{Content: spdx},
+			},
+			canonicalErr: true,
+		},
+		{
+			name: "signed document that is not an sbom",
+			model: models.SbomV001Schema{
+				Signature: x509Sig(sign(notSBOM), keyBytes),
+				Document:  &models.SbomV001SchemaDocument{Content: notSBOM},
+			},
+			canonicalErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &V001Entry{}
+			err := v.Unmarshal(&models.Sbom{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       tc.model,
+			})
+			if (err != nil) != tc.unmarshalErr {
+				t.Fatalf("unexpected result from Unmarshal: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			canonicalBytes, err := v.Canonicalize(context.Background())
+			if (err != nil) != tc.canonicalErr {
+				t.Fatalf("unexpected result from Canonicalize: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if v.SbomObj.Document.Format != tc.wantFormat {
+				t.Errorf("unexpected document format %q", v.SbomObj.Document.Format)
+			}
+
+			got, err := v.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(got, tc.wantKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("V001Entry.IndexKeys() = %v, want %v", got, tc.wantKeys)
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(canonicalBytes), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			canonicalEntry, err := types.UnmarshalEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			canonicalDoc := canonicalEntry.(*V001Entry).SbomObj.Document
+			if len(canonicalDoc.Content) != 0 {
+				t.Error("canonicalized entry contains the document")
+			}
+			if canonicalDoc.Format != tc.wantFormat {
+				t.Errorf("unexpected format %q in canonicalized entry", canonicalDoc.Format)
+			}
+			canonicalKeys, err := canonicalEntry.IndexKeys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(canonicalKeys, tc.wantCanonicalKeys, cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+				t.Errorf("index keys from canonicalized (and re-hydrated) object = %v, want %v", canonicalKeys, tc.wantCanonicalKeys)
+			}
+			if canonicalEntry.(*V001Entry).AttestationKey() != v.AttestationKey() {
+				t.Errorf("attestation key from canonicalized object %q does not match %q", canonicalEntry.(*V001Entry).AttestationKey(), v.AttestationKey())
+			}
+		})
+	}
+}
+
+func TestAttestationKeyValue(t *testing.T) {
+	signer, keyBytes := testKey(t)
+	spdx := []byte(spdxDocument)
+	sig, err := signer.SignMessage(bytes.NewReader(spdx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newEntry := func(doc *models.SbomV001SchemaDocument) *V001Entry {
+		v := &V001Entry{}
+		err := v.Unmarshal(&models.Sbom{
+			APIVersion: swag.String(APIVERSION),
+			Spec: models.SbomV001Schema{
+				Signature: &models.SbomV001SchemaSignature{
+					Format:    swag.String(models.SbomV001SchemaSignatureFormatX509),
+					Content:   (*strfmt.Base64)(&sig),
+					PublicKey: &models.SbomV001SchemaSignaturePublicKey{Content: (*strfmt.Base64)(&keyBytes)},
+				},
+				Document: doc,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := v.Canonicalize(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	wantKey := "sha256:" + sha256Hex(spdx)
+
+	defer viper.Set("max_attestation_size", viper.GetInt("max_attestation_size"))
+
+	t.Run("document", func(t *testing.T) {
+		viper.Set("max_attestation_size", 1024*1024)
+		key, att := newEntry(&models.SbomV001SchemaDocument{Content: spdx}).AttestationKeyValue()
+		if key != wantKey {
+			t.Errorf("unexpected attestation key %q, want %q", key, wantKey)
+		}
+		if !bytes.Equal(att, spdx) {
+			t.Errorf("unexpected attestation %q", att)
+		}
+	})
+	t.Run("document too large", func(t *testing.T) {
+		viper.Set("max_attestation_size", len(spdx)-1)
+		if key, att := newEntry(&models.SbomV001SchemaDocument{Content: spdx}).AttestationKeyValue(); key != "" || att != nil {
+			t.Errorf("unexpected attestation %q stored under %q", att, key)
+		}
+	})
+	t.Run("hash", func(t *testing.T) {
+		viper.Set("max_attestation_size", 1024*1024)
+		v := newEntry(&models.SbomV001SchemaDocument{
+			Hash: &models.SbomV001SchemaDocumentHash{
+				Algorithm: swag.String(models.SbomV001SchemaDocumentHashAlgorithmSha256),
+				Value:     swag.String(sha256Hex(spdx)),
+			},
+		})
+		if key, att := v.AttestationKeyValue(); key != "" || att != nil {
+			t.Errorf("unexpected attestation %q stored under %q", att, key)
+		}
+		if v.AttestationKey() != wantKey {
+			t.Errorf("unexpected attestation key %q, want %q", v.AttestationKey(), wantKey)
+		}
+	})
+}
+
+func TestCreateFromArtifactProperties(t *testing.T) {
+	signer, keyBytes := testKey(t)
+	spdx := []byte(spdxDocument)
+	sig, err := signer.SignMessage(bytes.NewReader(spdx))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		props   types.ArtifactProperties
+		wantErr bool
+	}{
+		{
+			name: "x509",
+			props: types.ArtifactProperties{
+				ArtifactBytes:  spdx,
+				SignatureBytes: sig,
+				PublicKeyBytes: [][]byte{keyBytes},
+				PKIFormat:      "x509",
+			},
+		},
+		{
+			name: "unsupported format",
+			props: types.ArtifactProperties{
+				ArtifactBytes:  spdx,
+				SignatureBytes: sig,
+				PublicKeyBytes: [][]byte{keyBytes},
+				PKIFormat:      "tuf",
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing signature",
+			props: types.ArtifactProperties{
+				ArtifactBytes:  spdx,
+				PublicKeyBytes: [][]byte{keyBytes},
+				PKIFormat:      "x509",
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pe, err := V001Entry{}.CreateFromArtifactProperties(context.Background(), tc.props)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected result from CreateFromArtifactProperties: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			spec := pe.(*models.Sbom).Spec.(models.SbomV001Schema)
+			if spec.Document.Format != models.SbomV001SchemaDocumentFormatSpdx || swag.StringValue(spec.Document.Hash.Value) != sha256Hex(spdx) {
+				t.Errorf("unexpected document %+v", spec.Document)
+			}
+			if _, err := types.UnmarshalEntry(pe); err != nil {
+				t.Errorf("unexpected error unmarshalling created entry: %v", err)
+			}
+		})
+	}
+}
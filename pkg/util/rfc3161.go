@@ -0,0 +1,190 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/sassoftware/relic/lib/pkcs7"
+	"github.com/sassoftware/relic/lib/pkcs9"
+	"github.com/sassoftware/relic/lib/x509tools"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+// TimestampPolicy is the policy under which timestamps are issued
+var TimestampPolicy = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 2}
+
+var oidSigningCertificateV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+
+// essCertIDv2 omits the hash algorithm, which defaults to SHA-256 (RFC 5035)
+type essCertIDv2 struct {
+	CertHash []byte
+}
+
+type signingCertificateV2 struct {
+	Certs []essCertIDv2
+}
+
+// ParseTimestampRequest parses and sanity checks a DER encoded RFC 3161 TimeStampReq
+func ParseTimestampRequest(data []byte) (*pkcs9.TimeStampReq, error) {
+	req := new(pkcs9.TimeStampReq)
+	rest, err := asn1.Unmarshal(data, req)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling timestamp request: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after timestamp request")
+	}
+	if req.Version != 1 {
+		return nil, fmt.Errorf("unsupported timestamp request version %d", req.Version)
+	}
+	hash, ok := x509tools.PkixDigestToHash(req.MessageImprint.HashAlgorithm)
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm %v", req.MessageImprint.HashAlgorithm.Algorithm)
+	}
+	if len(req.MessageImprint.HashedMessage) != hash.Size() {
+		return nil, fmt.Errorf("message imprint has length %d, expected %d for %v", len(req.MessageImprint.HashedMessage), hash.Size(), hash)
+	}
+	if len(req.ReqPolicy) != 0 && !req.ReqPolicy.Equal(TimestampPolicy) {
+		return nil, fmt.Errorf("unsupported policy %v", req.ReqPolicy)
+	}
+	for _, ext := range req.Extensions {
+		if ext.Critical {
+			return nil, fmt.Errorf("unsupported critical extension %v", ext.Id)
+		}
+	}
+	return req, nil
+}
+
+// CreateRfc3161Response issues a timestamp for the request, signed by the
+// signer. The first certificate in the chain must be the timestamping
+// certificate for the signer's key. The chain is always included in the
+// token, so that the response can be verified (and logged) on its own.
+func CreateRfc3161Response(ctx context.Context, req pkcs9.TimeStampReq, certChain []*x509.Certificate, signer signature.Signer) (*pkcs9.TimeStampResp, error) {
+	if len(certChain) == 0 {
+		return nil, errors.New("missing timestamping certificate chain")
+	}
+	genTime, err := asn1.MarshalWithParams(time.Now().UTC(), "generalized")
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	info := pkcs9.TSTInfo{
+		Version:        1,
+		Policy:         TimestampPolicy,
+		MessageImprint: req.MessageImprint,
+		SerialNumber:   serial,
+		GenTime:        asn1.RawValue{FullBytes: genTime},
+		Nonce:          req.Nonce,
+	}
+
+	cs, err := newCryptoSigner(ctx, signer)
+	if err != nil {
+		return nil, err
+	}
+	// the TSTInfo is embedded as an OCTET STRING holding its DER encoding
+	infoBytes, err := asn1.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling TSTInfo: %w", err)
+	}
+	builder := pkcs7.NewBuilder(cs, certChain, crypto.SHA256)
+	if err := builder.SetContent(pkcs9.OidTSTInfo, infoBytes); err != nil {
+		return nil, fmt.Errorf("setting TSTInfo: %w", err)
+	}
+	certHash := sha256.Sum256(certChain[0].Raw)
+	if err := builder.AddAuthenticatedAttribute(oidSigningCertificateV2, signingCertificateV2{
+		Certs: []essCertIDv2{{CertHash: certHash[:]}},
+	}); err != nil {
+		return nil, err
+	}
+	token, err := builder.Sign()
+	if err != nil {
+		return nil, fmt.Errorf("signing timestamp: %w", err)
+	}
+	return &pkcs9.TimeStampResp{
+		Status:         pkcs9.PKIStatusInfo{Status: pkcs9.StatusGranted},
+		TimeStampToken: *token,
+	}, nil
+}
+
+// cryptoSigner adapts a signature.Signer to a crypto.Signer over digests
+type cryptoSigner struct {
+	ctx    context.Context
+	signer signature.Signer
+	pub    crypto.PublicKey
+}
+
+func newCryptoSigner(ctx context.Context, signer signature.Signer) (*cryptoSigner, error) {
+	pub, err := signer.PublicKey(options.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("getting public key: %w", err)
+	}
+	return &cryptoSigner{ctx: ctx, signer: signer, pub: pub}, nil
+}
+
+func (c *cryptoSigner) Public() crypto.PublicKey {
+	return c.pub
+}
+
+func (c *cryptoSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return c.signer.SignMessage(nil, options.WithContext(c.ctx), options.WithDigest(digest), options.WithCryptoSignerOpts(opts))
+}
+
+// maxTimestampResponseSize bounds the size of a response read from a TSA
+const maxTimestampResponseSize = 64 * 1024
+
+// RequestTimestamp requests an RFC 3161 timestamp over the SHA-256 digest of
+// data from the TSA at tsaURL. The response is checked against the request,
+// including that it is signed by its embedded certificate, but the certificate
+// chain is not verified. The DER encoded TimeStampResp is returned.
+func RequestTimestamp(ctx context.Context, client *http.Client, tsaURL string, data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+	msg, req, err := pkcs9.NewRequest(tsaURL, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("creating timestamp request: %w", err)
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("error received from timestamping authority: %v", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTimestampResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := msg.ParseResponse(body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,205 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sassoftware/relic/lib/pkcs9"
+	"github.com/sigstore/rekor/pkg/signer"
+)
+
+func TestCreateRfc3161Response(t *testing.T) {
+	ctx := context.Background()
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	certChain, err := signer.NewTimestampingCertWithChain(ctx, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("artifact")
+	digest := sha256.Sum256(data)
+	msg, _, err := pkcs9.NewRequest("http://localhost", crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqBytes, err := asn1.Marshal(*msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := ParseTimestampRequest(reqBytes)
+	if err != nil {
+		t.Fatalf("ParseTimestampRequest: %v", err)
+	}
+
+	resp, err := CreateRfc3161Response(ctx, *req, certChain, s)
+	if err != nil {
+		t.Fatalf("CreateRfc3161Response: %v", err)
+	}
+	respBytes, err := asn1.Marshal(*resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// checks the signature, nonce and imprint against the original request
+	token, err := msg.ParseResponse(respBytes)
+	if err != nil {
+		t.Fatalf("ParseResponse: %v", err)
+	}
+	cs, err := pkcs9.Verify(token, data, nil)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(certChain[len(certChain)-1])
+	if err := cs.VerifyChain(roots, nil); err != nil {
+		t.Errorf("VerifyChain: %v", err)
+	}
+	if _, err := pkcs9.Verify(token, []byte("other artifact"), nil); err == nil {
+		t.Error("expected verification of a different artifact to fail")
+	}
+}
+
+func TestParseTimestampRequest(t *testing.T) {
+	digest := sha256.Sum256([]byte("artifact"))
+	msg, _, err := pkcs9.NewRequest("http://localhost", crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r pkcs9.TimeStampReq) pkcs9.TimeStampReq
+	}{
+		{
+			name: "wrong version",
+			modify: func(r pkcs9.TimeStampReq) pkcs9.TimeStampReq {
+				r.Version = 2
+				return r
+			},
+		},
+		{
+			name: "truncated imprint",
+			modify: func(r pkcs9.TimeStampReq) pkcs9.TimeStampReq {
+				r.MessageImprint.HashedMessage = r.MessageImprint.HashedMessage[1:]
+				return r
+			},
+		},
+		{
+			name: "unknown hash algorithm",
+			modify: func(r pkcs9.TimeStampReq) pkcs9.TimeStampReq {
+				r.MessageImprint.HashAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+				return r
+			},
+		},
+		{
+			name: "unsupported policy",
+			modify: func(r pkcs9.TimeStampReq) pkcs9.TimeStampReq {
+				r.ReqPolicy = asn1.ObjectIdentifier{1, 2, 3}
+				return r
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := asn1.Marshal(tt.modify(*msg))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ParseTimestampRequest(b); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	b, err := asn1.Marshal(*msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseTimestampRequest(append(b, 0)); err == nil {
+		t.Error("expected error for trailing data")
+	}
+}
+
+func TestRequestTimestamp(t *testing.T) {
+	ctx := context.Background()
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	certChain, err := signer.NewTimestampingCertWithChain(ctx, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a local TSA standing in for an external one
+	tsa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		req, err := ParseTimestampRequest(b)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp, err := CreateRfc3161Response(r.Context(), *req, certChain, s)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		body, err := asn1.Marshal(*resp)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/timestamp-reply")
+		_, _ = w.Write(body)
+	}))
+	defer tsa.Close()
+
+	data := []byte("checkpoint")
+	tsr, err := RequestTimestamp(ctx, tsa.Client(), tsa.URL, data)
+	if err != nil {
+		t.Fatalf("RequestTimestamp: %v", err)
+	}
+	var resp pkcs9.TimeStampResp
+	if _, err := asn1.Unmarshal(tsr, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pkcs9.Verify(&resp.TimeStampToken, data, nil); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if _, err := RequestTimestamp(ctx, failing.Client(), failing.URL, data); err == nil {
+		t.Error("expected error from failing TSA")
+	}
+}
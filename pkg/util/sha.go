@@ -22,10 +22,13 @@ import (
 // PrefixSHA sets the prefix of a sha hash to match how it is stored based on the length.
 func PrefixSHA(sha string) string {
 	var prefix string
-	if !strings.HasPrefix(sha, "sha256:") && !strings.HasPrefix(sha, "sha1:") {
-		if len(sha) == 40 {
+	if !strings.HasPrefix(sha, "sha512:") && !strings.HasPrefix(sha, "sha256:") && !strings.HasPrefix(sha, "sha1:") {
+		switch len(sha) {
+		case 40:
 			prefix = "sha1:"
-		} else {
+		case 128:
+			prefix = "sha512:"
+		default:
 			prefix = "sha256:"
 		}
 	}
@@ -49,8 +49,13 @@ type TimestampNote struct {
 func (t TimestampNote) String() string {
 	var b strings.Builder
 	time, _ := t.Time.MarshalText()
+	// the cert chain line is empty if the note has no cert chain
+	certChainRef := ""
+	if t.CertChainRef != nil {
+		certChainRef = t.CertChainRef.String()
+	}
 	fmt.Fprintf(&b, "%s\n%s\n%s\n%s\n%d\n%s\n", t.Origin, t.MessageImprint, base64.StdEncoding.EncodeToString(t.Nonce),
-		time, t.Radius, t.CertChainRef)
+		time, t.Radius, certChainRef)
 	for _, line := range t.OtherContent {
 		fmt.Fprintf(&b, "%s\n", line)
 	}
@@ -72,7 +77,7 @@ func (t TimestampNote) MarshalText() ([]byte, error) {
 // <base64 representation of the nonce>
 // <RFC 3339 representation of the time>
 // <decimal representation of radius>
-// <cert chain URI>
+// <cert chain URI, or an empty line if there is none>
 // <optional non-empty line of other content>...
 // <optional non-empty line of other content>...
 //
@@ -103,10 +108,11 @@ func (t *TimestampNote) UnmarshalText(data []byte) error {
 	if err != nil {
 		return fmt.Errorf("invalid timestamp note - invalid radius: %w", err)
 	}
-	u, err := url.Parse(string(l[5]))
-	if err != nil {
-		return fmt.Errorf("invalid timestamp note - invalid URI: %w", err)
-
+	var u *url.URL
+	if len(l[5]) > 0 {
+		if u, err = url.Parse(string(l[5])); err != nil {
+			return fmt.Errorf("invalid timestamp note - invalid URI: %w", err)
+		}
 	}
 	*t = TimestampNote{
 		Origin:         origin,
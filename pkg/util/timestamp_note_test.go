@@ -84,6 +84,15 @@ func TestMarshalTimestampNote(t *testing.T) {
 				OtherContent: []string{"foo", "bar"},
 			},
 			want: "Timestamp Note v7\nsha256:e4ba5cbd251c98e6cd1c23f126a3b81d8d8328abc95387229850952b3ef9f904\new==\n2021-07-26T00:00:00Z\n123\nhttp://localhost:3000/api/v1/timestamp/certchain\nfoo\nbar\n",
+		}, {
+			msg: []byte("bananas"),
+			t: TimestampNote{
+				Origin: "Timestamp Note v8",
+				Nonce:  big.NewInt(123).Bytes(),
+				Time:   someTime,
+				Radius: 123,
+			},
+			want: "Timestamp Note v8\nsha256:e4ba5cbd251c98e6cd1c23f126a3b81d8d8328abc95387229850952b3ef9f904\new==\n2021-07-26T00:00:00Z\n123\n\n",
 		},
 	} {
 		t.Run(string(test.t.Origin), func(t *testing.T) {
@@ -128,6 +137,17 @@ func TestUnmarshalTimestampNote(t *testing.T) {
 				CertChainRef:   certChainURL,
 			},
 			wantErr: false,
+		}, {
+			desc: "without cert chain",
+			m:    "Timestamp Note v0\nsha256:e4ba5cbd251c98e6cd1c23f126a3b81d8d8328abc95387229850952b3ef9f904\new==\n2021-07-26T00:00:00Z\n123\n\n",
+			want: TimestampNote{
+				Origin:         "Timestamp Note v0",
+				MessageImprint: "sha256:e4ba5cbd251c98e6cd1c23f126a3b81d8d8328abc95387229850952b3ef9f904",
+				Nonce:          big.NewInt(123).Bytes(),
+				Time:           someTime,
+				Radius:         123,
+			},
+			wantErr: false,
 		}, {
 			desc: "valid with different ecosystem",
 			m:    "Timestamp Note v1\nsha256:17fb2e8cbf5f60f881c075b1fd0cad32913f2f08b35053fed1c5a785dff90e8e\nvGFO\n2021-07-26T00:00:00Z\n1\nhttp://localhost:3000/api/v1/timestamp/certchain\n",
@@ -0,0 +1,144 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"sync"
+
+	"github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/pubkey"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// TreeVerifiers loads the public key of each tree of a log from the log on
+// first use, and caches a verifier for it by tree ID and by log ID. Each
+// shard of a log may be signed with a different key.
+type TreeVerifiers struct {
+	client *client.Rekor
+
+	mu      sync.Mutex
+	byTree  map[string]signature.Verifier
+	byLogID map[string]signature.Verifier
+}
+
+// NewTreeVerifiers returns an empty cache of the verifiers of the trees of
+// the log served by c
+func NewTreeVerifiers(c *client.Rekor) *TreeVerifiers {
+	return &TreeVerifiers{
+		client:  c,
+		byTree:  map[string]signature.Verifier{},
+		byLogID: map[string]signature.Verifier{},
+	}
+}
+
+// Add caches v as the verifier of the tree, instead of loading it from the log
+func (t *TreeVerifiers) Add(treeID string, v signature.Verifier) error {
+	pub, err := v.PublicKey()
+	if err != nil {
+		return err
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return err
+	}
+	logID := sha256.Sum256(der)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.byTree[treeID] = v
+	t.byLogID[hex.EncodeToString(logID[:])] = v
+	return nil
+}
+
+// ForTree returns the verifier of the tree, fetching its public key from the
+// log if it is not cached
+func (t *TreeVerifiers) ForTree(ctx context.Context, treeID string) (signature.Verifier, error) {
+	t.mu.Lock()
+	v, ok := t.byTree[treeID]
+	t.mu.Unlock()
+	if ok {
+		return v, nil
+	}
+
+	params := pubkey.NewGetPublicKeyParamsWithContext(ctx)
+	params.SetTreeID(&treeID)
+	keyResp, err := t.client.Pubkey.GetPublicKey(params)
+	if err != nil {
+		return nil, fmt.Errorf("getting public key of tree %s: %w", treeID, err)
+	}
+	block, _ := pem.Decode([]byte(keyResp.Payload))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode public key of tree %s", treeID)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	v, err = signature.LoadVerifier(pub, crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Add(treeID, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// ForLogID returns the verifier of the tree with the log ID, which is the
+// SHA256 hash of the DER-encoded public key the tree is signed with. If no
+// cached verifier matches, the keys of every tree of the log are loaded.
+func (t *TreeVerifiers) ForLogID(ctx context.Context, logID string) (signature.Verifier, error) {
+	if v, ok := t.cachedLogID(logID); ok {
+		return v, nil
+	}
+
+	li, err := t.client.Tlog.GetLogInfo(tlog.NewGetLogInfoParamsWithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("getting log info: %w", err)
+	}
+	treeIDs := []*string{li.Payload.TreeID}
+	for _, shard := range li.Payload.InactiveShards {
+		treeIDs = append(treeIDs, shard.TreeID)
+	}
+	for _, treeID := range treeIDs {
+		if treeID == nil {
+			continue
+		}
+		if _, err := t.ForTree(ctx, *treeID); err != nil {
+			return nil, err
+		}
+	}
+
+	if v, ok := t.cachedLogID(logID); ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("no tree of the log is signed with the key of log ID %s", logID)
+}
+
+func (t *TreeVerifiers) cachedLogID(logID string) (signature.Verifier, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	v, ok := t.byLogID[logID]
+	return v, ok
+}
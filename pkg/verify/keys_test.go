@@ -0,0 +1,148 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/pubkey"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/signer"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// PubkeyClient serves the public key of each tree
+type PubkeyClient struct {
+	Keys     map[string]string
+	Requests int
+}
+
+func (m *PubkeyClient) GetPublicKey(params *pubkey.GetPublicKeyParams, opts ...pubkey.ClientOption) (*pubkey.GetPublicKeyOK, error) {
+	m.Requests++
+	k, ok := m.Keys[swag.StringValue(params.TreeID)]
+	if !ok {
+		return nil, fmt.Errorf("unknown tree %s", swag.StringValue(params.TreeID))
+	}
+	return &pubkey.GetPublicKeyOK{Payload: k}, nil
+}
+
+func (m *PubkeyClient) SetTransport(transport runtime.ClientTransport) {
+}
+
+func publicKey(t *testing.T, s signature.Signer) (pemKey, logID string) {
+	t.Helper()
+	pub, err := s.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.Sum256(der)
+	return string(cryptoutils.PEMEncode(cryptoutils.PublicKeyPEMType, der)), hex.EncodeToString(h[:])
+}
+
+// signedEntry returns an entry with a SET signed by s, as it is by the
+// server for the tree the entry is in
+func signedEntry(t *testing.T, s signature.Signer, logID string) *models.LogEntryAnon {
+	t.Helper()
+	e := &models.LogEntryAnon{
+		Body:           "eyJ9",
+		IntegratedTime: swag.Int64(1),
+		LogIndex:       swag.Int64(2),
+		LogID:          swag.String(logID),
+	}
+	b, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonicalized, err := jsoncanonicalizer.Transform(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := s.SignMessage(bytes.NewReader(canonicalized))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Verification = &models.LogEntryAnonVerification{SignedEntryTimestamp: strfmt.Base64(sig)}
+	return e
+}
+
+func TestTreeVerifiers(t *testing.T) {
+	ctx := context.Background()
+	activeSigner, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	shardSigner, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	activeKey, activeLogID := publicKey(t, activeSigner)
+	shardKey, shardLogID := publicKey(t, shardSigner)
+
+	pubkeyClient := &PubkeyClient{Keys: map[string]string{"1": activeKey, "2": shardKey}}
+	var mClient client.Rekor
+	mClient.Pubkey = pubkeyClient
+	mClient.Tlog = &TlogClient{LogInfo: models.LogInfo{
+		TreeID:         swag.String("1"),
+		InactiveShards: []*models.InactiveShardLogInfo{{TreeID: swag.String("2")}},
+	}}
+	verifiers := NewTreeVerifiers(&mClient)
+
+	activeEntry := signedEntry(t, activeSigner, activeLogID)
+	shardEntry := signedEntry(t, shardSigner, shardLogID)
+
+	// the SET of each entry is verified with the key of its own tree
+	for _, e := range []*models.LogEntryAnon{activeEntry, shardEntry} {
+		v, err := verifiers.ForLogID(ctx, *e.LogID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifySignedEntryTimestamp(ctx, e, v); err != nil {
+			t.Errorf("verifying entry of log ID %s: %v", *e.LogID, err)
+		}
+	}
+	v, err := verifiers.ForTree(ctx, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySignedEntryTimestamp(ctx, shardEntry, v); err == nil {
+		t.Error("entry of the inactive shard verified with the key of the active tree")
+	}
+
+	// the keys are only fetched once
+	if pubkeyClient.Requests != 2 {
+		t.Errorf("fetched public keys %d times, want 2", pubkeyClient.Requests)
+	}
+
+	if _, err := verifiers.ForLogID(ctx, "0000"); err == nil {
+		t.Error("expected error for unknown log ID")
+	}
+}
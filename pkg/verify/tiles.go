@@ -0,0 +1,81 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sigstore/rekor/pkg/tiles"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+// VerifyTileCheckpoint fetches the checkpoint of a log published as tiles,
+// verifies its signature and checks that the published tiles hash to its root.
+func VerifyTileCheckpoint(ctx context.Context, f tiles.Fetcher, verifier signature.Verifier) (*util.SignedCheckpoint, error) {
+	sth, err := tiles.ReadCheckpoint(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	if !sth.Verify(verifier) {
+		return nil, errors.New("signature on tile checkpoint did not verify")
+	}
+	root, err := tiles.RootHash(ctx, f, sth.Size)
+	if err != nil {
+		return nil, fmt.Errorf("computing root hash from tiles: %w", err)
+	}
+	if !bytes.Equal(root, sth.Hash) {
+		return nil, errors.New("root hash computed from tiles does not match checkpoint")
+	}
+	return sth, nil
+}
+
+// ProveConsistencyFromTiles verifies consistency between an initial, trusted
+// STH and a second new STH, computing the proof from the published tiles
+// instead of requesting it from the log. Callers MUST verify signature on the STHs'.
+func ProveConsistencyFromTiles(ctx context.Context, f tiles.Fetcher, oldSTH, newSTH *util.SignedCheckpoint) error {
+	switch {
+	case oldSTH.Size == newSTH.Size:
+		if !bytes.Equal(oldSTH.Hash, newSTH.Hash) {
+			return errors.New("old root hash does not match STH hash")
+		}
+		return nil
+	case oldSTH.Size > newSTH.Size:
+		return errors.New("old tree size is larger than the new tree size")
+	}
+	hashes, err := tiles.ConsistencyProof(ctx, f, oldSTH.Size, newSTH.Size)
+	if err != nil {
+		return err
+	}
+	return proof.VerifyConsistency(rfc6962.DefaultHasher, oldSTH.Size, newSTH.Size, hashes, oldSTH.Hash, newSTH.Hash)
+}
+
+// VerifyInclusionFromTiles verifies that the leaf is included at index in the
+// tree committed to by the STH, computing the inclusion proof from the
+// published tiles. The index is the index within the tree, not the virtual
+// log index. Callers MUST verify signature on the STH.
+func VerifyInclusionFromTiles(ctx context.Context, f tiles.Fetcher, index uint64, leaf []byte, sth *util.SignedCheckpoint) error {
+	hashes, err := tiles.InclusionProof(ctx, f, index, sth.Size)
+	if err != nil {
+		return err
+	}
+	return proof.VerifyInclusion(rfc6962.DefaultHasher, index, sth.Size, rfc6962.DefaultHasher.HashLeaf(leaf), hashes, sth.Hash)
+}
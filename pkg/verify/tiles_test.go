@@ -0,0 +1,133 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sigstore/rekor/pkg/signer"
+	"github.com/sigstore/rekor/pkg/tiles"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+type mapFetcher map[string][]byte
+
+func (m mapFetcher) Fetch(_ context.Context, path string) ([]byte, error) {
+	if b, ok := m[path]; ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("%s: %w", path, tiles.ErrNotFound)
+}
+
+// publishSmallLog lays out a log of fewer than tiles.TileWidth entries, which
+// fits in a single level 0 tile, and returns the checkpoints at each size
+func publishSmallLog(t *testing.T, size int) (mapFetcher, [][]byte, []*util.SignedCheckpoint, *signer.Memory) {
+	t.Helper()
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := mapFetcher{}
+	rng := (&compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren}).NewEmptyRange(0)
+	var leaves, hashes [][]byte
+	var checkpoints []*util.SignedCheckpoint
+	for i := 0; i < size; i++ {
+		leaf := []byte(fmt.Sprintf("entry %d", i))
+		hash := rfc6962.DefaultHasher.HashLeaf(leaf)
+		leaves = append(leaves, leaf)
+		hashes = append(hashes, hash)
+		if err := rng.Append(hash, nil); err != nil {
+			t.Fatal(err)
+		}
+		root, err := rng.GetRootHash(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sth, err := util.CreateSignedCheckpoint(util.Checkpoint{Origin: "rekor.localhost - 1", Size: uint64(i + 1), Hash: root})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sth.Sign("rekor.localhost", s, options.WithContext(context.Background())); err != nil {
+			t.Fatal(err)
+		}
+		checkpoints = append(checkpoints, sth)
+	}
+	bundle, err := tiles.MarshalEntryBundle(leaves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f[tiles.EntriesPath(0, uint64(size))] = bundle
+	f[tiles.TilePath(0, 0, uint64(size))] = bytes.Join(hashes, nil)
+	cp, err := checkpoints[size-1].SignedNote.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f[tiles.CheckpointPath] = cp
+	return f, leaves, checkpoints, s
+}
+
+func TestTiles(t *testing.T) {
+	ctx := context.Background()
+	const size = 10
+	f, leaves, checkpoints, logSigner := publishSmallLog(t, size)
+
+	if _, err := VerifyTileCheckpoint(ctx, f, logSigner); err != nil {
+		t.Errorf("verifying tile checkpoint: %v", err)
+	}
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyTileCheckpoint(ctx, f, s); err == nil {
+		t.Error("expected checkpoint verification to fail with the wrong key")
+	}
+
+	latest := checkpoints[size-1]
+	for i, leaf := range leaves {
+		if err := VerifyInclusionFromTiles(ctx, f, uint64(i), leaf, latest); err != nil {
+			t.Errorf("inclusion of leaf %d: %v", i, err)
+		}
+	}
+	if err := VerifyInclusionFromTiles(ctx, f, 1, leaves[0], latest); err == nil {
+		t.Error("expected inclusion of leaf at the wrong index to fail")
+	}
+
+	for i, sth := range checkpoints {
+		if err := ProveConsistencyFromTiles(ctx, f, sth, latest); err != nil {
+			t.Errorf("consistency from size %d: %v", i+1, err)
+		}
+	}
+	forked := *checkpoints[3]
+	forked.Hash = rfc6962.DefaultHasher.HashLeaf([]byte("fork"))
+	if err := ProveConsistencyFromTiles(ctx, f, &forked, latest); err == nil {
+		t.Error("expected consistency with a forked checkpoint to fail")
+	}
+	if err := ProveConsistencyFromTiles(ctx, f, latest, checkpoints[0]); err == nil {
+		t.Error("expected consistency with a smaller checkpoint to fail")
+	}
+
+	// tampering with a published tile must be detected against the checkpoint
+	f[tiles.TilePath(0, 0, size)][0] ^= 1
+	if err := VerifyInclusionFromTiles(ctx, f, size-1, leaves[size-1], latest); err == nil {
+		t.Error("expected inclusion to fail with a tampered tile")
+	}
+}
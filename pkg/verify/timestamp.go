@@ -0,0 +1,89 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/sassoftware/relic/lib/pkcs9"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// VerifyTimestampNote verifies the signature on a signed timestamp note and
+// checks that it was issued by the log with the origin for the message
+// imprint. The nonce of the request is checked too, unless it is nil, as it is
+// when verifying a stored note.
+func VerifyTimestampNote(stn *util.SignedTimestampNote, verifier signature.Verifier, origin, messageImprint string, nonce []byte) error {
+	if !stn.Verify(verifier) {
+		return errors.New("signature on timestamp note did not verify")
+	}
+	if stn.Origin != origin {
+		return fmt.Errorf("timestamp note is from origin %s, expected %s", stn.Origin, origin)
+	}
+	if stn.MessageImprint != messageImprint {
+		return fmt.Errorf("timestamp note is for message imprint %s, expected %s", stn.MessageImprint, messageImprint)
+	}
+	if nonce != nil && !bytes.Equal(stn.Nonce, nonce) {
+		return errors.New("timestamp note nonce does not match request")
+	}
+	return nil
+}
+
+// VerifyCheckpointTimestamp verifies the log's signature on a timestamped
+// checkpoint and the RFC 3161 timestamp over it, which must chain up to one of
+// the TSA roots. It returns the checkpoint and the timestamped time: every
+// entry below the checkpoint's tree size was integrated before that time.
+func VerifyCheckpointTimestamp(ct *models.CheckpointTimestamp, verifier signature.Verifier, tsaRoots *x509.CertPool) (*util.SignedCheckpoint, time.Time, error) {
+	if ct == nil || ct.SignedCheckpoint == nil || ct.TimestampResponse == nil {
+		return nil, time.Time{}, errors.New("missing checkpoint timestamp")
+	}
+	signedCheckpoint := swag.StringValue(ct.SignedCheckpoint)
+	sth := &util.SignedCheckpoint{}
+	if err := sth.UnmarshalText([]byte(signedCheckpoint)); err != nil {
+		return nil, time.Time{}, err
+	}
+	if !sth.Verify(verifier) {
+		return nil, time.Time{}, errors.New("signature on timestamped checkpoint did not verify")
+	}
+
+	var resp pkcs9.TimeStampResp
+	rest, err := asn1.Unmarshal(*ct.TimestampResponse, &resp)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unmarshalling timestamp response: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, time.Time{}, errors.New("trailing data after timestamp response")
+	}
+	if resp.Status.Status != pkcs9.StatusGranted && resp.Status.Status != pkcs9.StatusGrantedWithMods {
+		return nil, time.Time{}, fmt.Errorf("timestamp response status not granted: %v", resp.Status.Status)
+	}
+	cs, err := pkcs9.Verify(&resp.TimeStampToken, []byte(signedCheckpoint), nil)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("verifying timestamp: %w", err)
+	}
+	if err := cs.VerifyChain(tsaRoots, nil); err != nil {
+		return nil, time.Time{}, fmt.Errorf("verifying timestamp certificate chain: %w", err)
+	}
+	return sth, cs.SigningTime, nil
+}
@@ -0,0 +1,162 @@
+//
+// Copyright 2022 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sassoftware/relic/lib/pkcs9"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/signer"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+func TestVerifyTimestampNote(t *testing.T) {
+	const imprint = "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	nonce := []byte("nonce")
+
+	s, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stn, err := util.CreateSignedTimestampNote(util.TimestampNote{
+		Origin:         "rekor.localhost",
+		MessageImprint: imprint,
+		Nonce:          nonce,
+		Time:           time.Now().UTC(),
+		Radius:         1000000,
+		CertChainRef:   &url.URL{Scheme: "https", Host: "rekor.localhost", Path: "/api/v1/log/timestamp/certchain"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stn.Sign("rekor.localhost", s, options.WithContext(context.Background())); err != nil {
+		t.Fatal(err)
+	}
+	// roundtrip through the wire format
+	b, err := stn.SignedNote.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed := &util.SignedTimestampNote{}
+	if err := parsed.UnmarshalText(b); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyTimestampNote(parsed, s, "rekor.localhost", imprint, nonce); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	other, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyTimestampNote(parsed, other, "rekor.localhost", imprint, nonce); err == nil {
+		t.Error("expected error verifying with the wrong key")
+	}
+	if err := VerifyTimestampNote(parsed, s, "rekor.localhost", "sha256:0000000000000000000000000000000000000000000000000000000000000000", nonce); err == nil {
+		t.Error("expected error verifying a different message imprint")
+	}
+	if err := VerifyTimestampNote(parsed, s, "rekor.localhost", imprint, []byte("other")); err == nil {
+		t.Error("expected error verifying a different nonce")
+	}
+	if err := VerifyTimestampNote(parsed, s, "rekor.example.com", imprint, nonce); err == nil {
+		t.Error("expected error verifying a note from a different origin")
+	}
+	// the nonce of a stored note is not known
+	if err := VerifyTimestampNote(parsed, s, "rekor.localhost", imprint, nil); err != nil {
+		t.Errorf("unexpected error verifying without the nonce: %v", err)
+	}
+}
+
+func TestVerifyCheckpointTimestamp(t *testing.T) {
+	ctx := context.Background()
+	logSigner, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sth, err := util.CreateSignedCheckpoint(util.Checkpoint{Origin: "rekor.localhost - 1", Size: 5, Hash: make([]byte, 32)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sth.Sign("rekor.localhost", logSigner, options.WithContext(ctx)); err != nil {
+		t.Fatal(err)
+	}
+	sc, err := sth.SignedNote.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tsaSigner, err := signer.NewMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	certChain, err := signer.NewTimestampingCertWithChain(ctx, tsaSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	timestamp := func(data []byte) *strfmt.Base64 {
+		digest := sha256.Sum256(data)
+		req, _, err := pkcs9.NewRequest("http://localhost", crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := util.CreateRfc3161Response(ctx, *req, certChain, tsaSigner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := asn1.Marshal(*resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b64 := strfmt.Base64(b)
+		return &b64
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(certChain[len(certChain)-1])
+
+	ct := &models.CheckpointTimestamp{SignedCheckpoint: swag.String(string(sc)), TimestampResponse: timestamp(sc)}
+	verified, ts, err := VerifyCheckpointTimestamp(ct, logSigner, roots)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if verified.Size != 5 {
+		t.Errorf("got tree size %d, want 5", verified.Size)
+	}
+	if time.Since(ts) > time.Minute || time.Until(ts) > time.Minute {
+		t.Errorf("unexpected timestamp %v", ts)
+	}
+
+	if _, _, err := VerifyCheckpointTimestamp(ct, tsaSigner, roots); err == nil {
+		t.Error("expected error verifying checkpoint with the wrong key")
+	}
+	if _, _, err := VerifyCheckpointTimestamp(ct, logSigner, x509.NewCertPool()); err == nil {
+		t.Error("expected error verifying timestamp without its root")
+	}
+	other := &models.CheckpointTimestamp{SignedCheckpoint: ct.SignedCheckpoint, TimestampResponse: timestamp([]byte("other"))}
+	if _, _, err := VerifyCheckpointTimestamp(other, logSigner, roots); err == nil {
+		t.Error("expected error verifying a timestamp over different data")
+	}
+}
@@ -35,6 +35,11 @@ import (
 	"github.com/transparency-dev/merkle/rfc6962"
 )
 
+// ErrInconsistentCheckpoints is wrapped by the errors returned when two
+// checkpoints are proven to be inconsistent, as opposed to when a proof could
+// not be obtained
+var ErrInconsistentCheckpoints = errors.New("inconsistent checkpoints")
+
 // ProveConsistency verifies consistency between an initial, trusted STH
 // and a second new STH. Callers MUST verify signature on the STHs'.
 func ProveConsistency(ctx context.Context, rClient *client.Rekor,
@@ -43,7 +48,7 @@ func ProveConsistency(ctx context.Context, rClient *client.Rekor,
 	switch {
 	case oldTreeSize == int64(newSTH.Size):
 		if !bytes.Equal(oldSTH.Hash, newSTH.Hash) {
-			return errors.New("old root hash does not match STH hash")
+			return fmt.Errorf("%w: old root hash does not match STH hash", ErrInconsistentCheckpoints)
 		}
 	case oldTreeSize < int64(newSTH.Size):
 		consistencyParams := tlog.NewGetLogProofParamsWithContext(ctx)
@@ -64,10 +69,10 @@ func ProveConsistency(ctx context.Context, rClient *client.Rekor,
 		}
 		if err := proof.VerifyConsistency(rfc6962.DefaultHasher,
 			oldSTH.Size, newSTH.Size, hashes, oldSTH.Hash, newSTH.Hash); err != nil {
-			return err
+			return fmt.Errorf("%w: %v", ErrInconsistentCheckpoints, err)
 		}
 	case oldTreeSize > int64(newSTH.Size):
-		return errors.New("inclusion proof returned a tree size larger than the verified tree size")
+		return fmt.Errorf("%w: inclusion proof returned a tree size larger than the verified tree size", ErrInconsistentCheckpoints)
 	}
 	return nil
 
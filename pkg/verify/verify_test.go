@@ -18,6 +18,7 @@ package verify
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"testing"
 
 	"github.com/go-openapi/runtime"
@@ -157,6 +158,9 @@ func TestConsistency(t *testing.T) {
 			if (gotErr != nil) != test.wantErr {
 				t.Fatalf("ProveConsistency = %t, wantErr %t", gotErr, test.wantErr)
 			}
+			if gotErr != nil && !errors.Is(gotErr, ErrInconsistentCheckpoints) {
+				t.Errorf("ProveConsistency = %v, want ErrInconsistentCheckpoints", gotErr)
+			}
 		})
 	}
 }
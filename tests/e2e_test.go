@@ -26,6 +26,7 @@ import (
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
+	"encoding/asn1"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -53,6 +54,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/in-toto/in-toto-golang/in_toto"
 	slsa "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
+	"github.com/sassoftware/relic/lib/pkcs9"
 	"github.com/secure-systems-lab/go-securesystemslib/dsse"
 	"github.com/sigstore/rekor/pkg/client"
 	"github.com/sigstore/rekor/pkg/generated/client/entries"
@@ -63,6 +65,7 @@ import (
 	_ "github.com/sigstore/rekor/pkg/types/intoto/v0.0.1"
 	rekord "github.com/sigstore/rekor/pkg/types/rekord/v0.0.1"
 	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/rekor/pkg/verify"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/sigstore/sigstore/pkg/signature/options"
@@ -823,6 +826,155 @@ func TestTimestampArtifact(t *testing.T) {
 	outputContains(t, out, uuid)
 }
 
+func TestTimestampResponse(t *testing.T) {
+	artifact, err := ioutil.ReadFile("test_file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(artifact)
+	req, _, err := pkcs9.NewRequest("http://localhost:3000/api/v1/timestamp", crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	tsq, err := asn1.Marshal(*req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post("http://localhost:3000/api/v1/timestamp", "application/timestamp-query", bytes.NewReader(tsq))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201 status code but got %d", resp.StatusCode)
+	}
+	tsr, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err = http.Get("http://localhost:3000/api/v1/log/timestamp/certchain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	chainPEM, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certChain, err := cryptoutils.UnmarshalCertificatesFromPEM(chainPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// checks the signature, nonce and imprint against the request
+	token, err := req.ParseResponse(tsr)
+	if err != nil {
+		t.Fatalf("parsing timestamp response: %v", err)
+	}
+	cs, err := pkcs9.Verify(token, artifact, nil)
+	if err != nil {
+		t.Fatalf("verifying timestamp: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(certChain[len(certChain)-1])
+	if err := cs.VerifyChain(roots, nil); err != nil {
+		t.Errorf("verifying timestamp certificate chain: %v", err)
+	}
+}
+
+func TestTimestampNote(t *testing.T) {
+	out := runCli(t, "timestamp", "--artifact", "test_file.txt")
+	outputContains(t, out, "Verification Successful!")
+
+	artifact, err := ioutil.ReadFile("test_file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha := sha256.Sum256(artifact)
+	out = runCli(t, "timestamp", "--sha", hex.EncodeToString(sha[:]), "--format=json")
+	outputContains(t, out, fmt.Sprintf("sha256:%s", hex.EncodeToString(sha[:])))
+
+	// a stored note is verified without requesting a new one
+	var note struct{ SignedTimestampNote string }
+	if err := json.Unmarshal([]byte(out), &note); err != nil {
+		t.Fatal(err)
+	}
+	notePath := filepath.Join(t.TempDir(), "note")
+	if err := ioutil.WriteFile(notePath, []byte(note.SignedTimestampNote), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out = runCli(t, "timestamp", "--artifact", "test_file.txt", "--timestamp_note", notePath)
+	outputContains(t, out, "Verification Successful!")
+	out = runCliErr(t, "timestamp", "--artifact", "test_file.txt", "--timestamp_note", notePath, "--origin", "rekor.example.com")
+	outputContains(t, out, "expected rekor.example.com")
+}
+
+func TestCheckpointTimestamp(t *testing.T) {
+	// make sure the log is not empty, so that there is a checkpoint to timestamp
+	artifactPath := filepath.Join(t.TempDir(), "artifact")
+	sigPath := filepath.Join(t.TempDir(), "signature.asc")
+	createdPGPSignedArtifact(t, artifactPath, sigPath)
+	pubPath := filepath.Join(t.TempDir(), "pubKey.asc")
+	if err := ioutil.WriteFile(pubPath, []byte(publicKey), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runCli(t, "upload", "--artifact", artifactPath, "--signature", sigPath, "--public-key", pubPath)
+
+	rekorClient, err := client.GetRekorClient(rekorServer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ct *models.CheckpointTimestamp
+	for i := 0; i < 30 && ct == nil; i++ {
+		resp, err := rekorClient.Tlog.GetLogInfo(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ct = resp.Payload.CheckpointTimestamp; ct == nil {
+			time.Sleep(time.Second)
+		}
+	}
+	if ct == nil {
+		t.Fatal("checkpoint was not timestamped")
+	}
+
+	resp, err := http.Get("http://localhost:3000/api/v1/log/timestamp/certchain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	chainPEM, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certChain, err := cryptoutils.UnmarshalCertificatesFromPEM(chainPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(certChain[len(certChain)-1])
+
+	rekorPubKey, err := util.PublicKey(context.Background(), rekorClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := signature.LoadVerifier(rekorPubKey, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc, ts, err := verify.VerifyCheckpointTimestamp(ct, verifier, roots)
+	if err != nil {
+		t.Fatalf("verifying checkpoint timestamp: %v", err)
+	}
+	if sc.Size == 0 {
+		t.Error("expected a non-empty checkpoint")
+	}
+	if time.Since(ts) > time.Hour {
+		t.Errorf("unexpected timestamp %v", ts)
+	}
+}
+
 func TestX509(t *testing.T) {
 	td := t.TempDir()
 	artifactPath := filepath.Join(td, "artifact")
@@ -920,6 +1072,76 @@ func TestWatch(t *testing.T) {
 		t.Error("expected files")
 	}
 	fmt.Println(fi[0].Name())
+
+	// The last verified state is persisted, so that restarts continue the chain
+	b, err := ioutil.ReadFile(filepath.Join(td, "watch-state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := struct {
+		Checkpoints map[string]string `json:"checkpoints"`
+	}{}
+	if err := json.Unmarshal(b, &state); err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Checkpoints) == 0 {
+		t.Error("expected verified checkpoints in watch state")
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	rekorClient, err := client.GetRekorClient(rekorServer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := rekorClient.Tlog.GetLogInfo(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	startIndex := *resp.Payload.TreeSize
+	for _, shard := range resp.Payload.InactiveShards {
+		startIndex += *shard.TreeSize
+	}
+
+	td := t.TempDir()
+	watchListPath := filepath.Join(td, "watchlist.yaml")
+	if err := ioutil.WriteFile(watchListPath, []byte("emails:\n- test@rekor.dev\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	notificationsPath := filepath.Join(td, "notifications.json")
+
+	// an entry for the watched email, signed with the certificate
+	artifactPath := filepath.Join(td, "artifact")
+	sigPath := filepath.Join(td, "signature")
+	certPath := filepath.Join(td, "cert.pem")
+	createdX509SignedArtifact(t, artifactPath, sigPath)
+	if err := ioutil.WriteFile(certPath, []byte(rsaCert), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := runCli(t, "upload", "--artifact", artifactPath, "--signature", sigPath,
+		"--public-key", certPath, "--pki-format", "x509")
+	outputContains(t, out, "Created entry at")
+	uuid := getUUIDFromUploadOutput(t, out)
+
+	cmd := exec.Command(server, "monitor", "--interval=1s", "--metrics_port=2114",
+		"--watch_list="+watchListPath, "--sink=file://"+notificationsPath,
+		fmt.Sprintf("--start_index=%d", startIndex))
+	cmd.Env = append(os.Environ(), "REKOR_STH_BUCKET=file://"+td)
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	// Wait 3 intervals
+	time.Sleep(3 * time.Second)
+	cmd.Process.Kill()
+	_ = cmd.Wait()
+
+	b, err := ioutil.ReadFile(notificationsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the UUID may or may not be prefixed with the tree ID
+	outputContains(t, string(b), uuid[len(uuid)-64:])
+	outputContains(t, string(b), "test@rekor.dev")
 }
 
 func TestSignedEntryTimestamp(t *testing.T) {